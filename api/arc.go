@@ -28,20 +28,75 @@ const (
 	BearerAuthScopes    = "BearerAuth.Scopes"
 )
 
+// Defines values for MerklePathVerificationResult.
+const (
+	MerklePathVerificationResultINVALID MerklePathVerificationResult = "INVALID"
+	MerklePathVerificationResultUNKNOWN MerklePathVerificationResult = "UNKNOWN"
+	MerklePathVerificationResultVALID   MerklePathVerificationResult = "VALID"
+)
+
 // Defines values for TransactionDetailsTxStatus.
 const (
-	ANNOUNCEDTONETWORK TransactionDetailsTxStatus = "ANNOUNCED_TO_NETWORK"
-	CONFIRMED          TransactionDetailsTxStatus = "CONFIRMED"
-	MINED              TransactionDetailsTxStatus = "MINED"
-	RECEIVED           TransactionDetailsTxStatus = "RECEIVED"
-	REJECTED           TransactionDetailsTxStatus = "REJECTED"
-	REQUESTEDBYNETWORK TransactionDetailsTxStatus = "REQUESTED_BY_NETWORK"
-	SEENONNETWORK      TransactionDetailsTxStatus = "SEEN_ON_NETWORK"
-	SENTTONETWORK      TransactionDetailsTxStatus = "SENT_TO_NETWORK"
-	STORED             TransactionDetailsTxStatus = "STORED"
-	UNKNOWN            TransactionDetailsTxStatus = "UNKNOWN"
+	TransactionDetailsTxStatusANNOUNCEDTONETWORK TransactionDetailsTxStatus = "ANNOUNCED_TO_NETWORK"
+	TransactionDetailsTxStatusCONFIRMED          TransactionDetailsTxStatus = "CONFIRMED"
+	TransactionDetailsTxStatusMINED              TransactionDetailsTxStatus = "MINED"
+	TransactionDetailsTxStatusRECEIVED           TransactionDetailsTxStatus = "RECEIVED"
+	TransactionDetailsTxStatusREJECTED           TransactionDetailsTxStatus = "REJECTED"
+	TransactionDetailsTxStatusREQUESTEDBYNETWORK TransactionDetailsTxStatus = "REQUESTED_BY_NETWORK"
+	TransactionDetailsTxStatusSEENONNETWORK      TransactionDetailsTxStatus = "SEEN_ON_NETWORK"
+	TransactionDetailsTxStatusSENTTONETWORK      TransactionDetailsTxStatus = "SENT_TO_NETWORK"
+	TransactionDetailsTxStatusSTORED             TransactionDetailsTxStatus = "STORED"
+	TransactionDetailsTxStatusUNKNOWN            TransactionDetailsTxStatus = "UNKNOWN"
 )
 
+// Block defines model for Block.
+type Block struct {
+	// Hash Block hash
+	Hash string `json:"hash"`
+
+	// Height Block height
+	Height uint64 `json:"height"`
+
+	// MerkleRoot Merkle root of the block
+	MerkleRoot string `json:"merkleRoot"`
+
+	// Orphaned Whether the block is not part of the longest chain
+	Orphaned bool `json:"orphaned"`
+
+	// PreviousHash Hash of the previous block
+	PreviousHash string `json:"previousHash"`
+
+	// Processed Whether all transactions of the block have been processed
+	Processed bool `json:"processed"`
+
+	// Size Block size in bytes
+	Size      *uint64   `json:"size,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// TxCount Number of transactions in the block
+	TxCount *uint64 `json:"txCount,omitempty"`
+}
+
+// BlockTransaction defines model for BlockTransaction.
+type BlockTransaction struct {
+	// Pos Position of the transaction in the block
+	Pos uint64 `json:"pos"`
+
+	// Txid Transaction ID in hex
+	Txid string `json:"txid"`
+}
+
+// BlockTransactions defines model for BlockTransactions.
+type BlockTransactions struct {
+	// BlockHash Block hash
+	BlockHash string `json:"blockHash"`
+
+	// NextOffset Offset of the next page, not present if there are no more transactions
+	NextOffset   *uint64            `json:"nextOffset"`
+	Timestamp    time.Time          `json:"timestamp"`
+	Transactions []BlockTransaction `json:"transactions"`
+}
+
 // ChainInfo Chain info
 type ChainInfo struct {
 	// BlockHash Block hash
@@ -259,6 +314,40 @@ type FeeAmount struct {
 	Satoshis uint64 `json:"satoshis"`
 }
 
+// MerklePathVerification defines model for MerklePathVerification.
+type MerklePathVerification struct {
+	// BlockHash Block hash
+	BlockHash *string `json:"blockHash,omitempty"`
+
+	// BlockHeight Block height
+	BlockHeight *uint64 `json:"blockHeight,omitempty"`
+
+	// Confirmations Number of blocks in the longest chain including the block of the transaction if the result is VALID
+	Confirmations uint64 `json:"confirmations"`
+
+	// MerkleRoot Merkle root calculated from the Merkle path
+	MerkleRoot *string `json:"merkleRoot"`
+
+	// Result Result of the verification
+	Result    MerklePathVerificationResult `json:"result"`
+	Timestamp time.Time                    `json:"timestamp"`
+
+	// Txid Transaction ID in hex
+	Txid string `json:"txid"`
+}
+
+// MerklePathVerificationResult Result of the verification
+type MerklePathVerificationResult string
+
+// MerklePathVerificationRequest defines model for MerklePathVerificationRequest.
+type MerklePathVerificationRequest struct {
+	// MerklePath Merkle path in BUMP format (BRC-74) as hex string
+	MerklePath string `json:"merklePath"`
+
+	// Txid Transaction ID in hex
+	Txid string `json:"txid"`
+}
+
 // Policy defines model for Policy.
 type Policy struct {
 	// Maxscriptsizepolicy Maximum script size [bytes]
@@ -278,6 +367,50 @@ type PolicyResponse struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// TransactionCallback defines model for TransactionCallback.
+type TransactionCallback struct {
+	// BlockHash Block hash
+	BlockHash *string `json:"blockHash,omitempty"`
+
+	// BlockHeight Block height
+	BlockHeight *uint64 `json:"blockHeight,omitempty"`
+
+	// CompetingTxs Transaction IDs in hex of transactions which compete with this transaction for the same inputs
+	CompetingTxs *[]string `json:"competingTxs"`
+
+	// ExtraInfo Extra information about the transaction
+	ExtraInfo *string `json:"extraInfo"`
+
+	// MerklePath Transaction Merkle path as a hex string in BUMP format [BRC-74](https://brc.dev/74)
+	MerklePath *string   `json:"merklePath"`
+	Timestamp  time.Time `json:"timestamp"`
+
+	// TxStatus Transaction status
+	TxStatus *string `json:"txStatus,omitempty"`
+
+	// Txid Transaction ID in hex
+	Txid string `json:"txid"`
+
+	// Version Version of the callback payload schema
+	Version int `json:"version"`
+}
+
+// TransactionConfirmations defines model for TransactionConfirmations.
+type TransactionConfirmations struct {
+	// BlockHash Block hash
+	BlockHash *string `json:"blockHash,omitempty"`
+
+	// BlockHeight Block height
+	BlockHeight *uint64 `json:"blockHeight,omitempty"`
+
+	// Confirmations Number of blocks in the longest chain including the block of the transaction
+	Confirmations uint64    `json:"confirmations"`
+	Timestamp     time.Time `json:"timestamp"`
+
+	// Txid Transaction ID in hex
+	Txid string `json:"txid"`
+}
+
 // TransactionDetails defines model for TransactionDetails.
 type TransactionDetails struct {
 	// ExtraInfo Extra information about the transaction
@@ -410,6 +543,15 @@ type SkipTxValidation = bool
 // WaitForStatus defines model for waitForStatus.
 type WaitForStatus = int
 
+// GETBlockTransactionsParams defines parameters for GETBlockTransactions.
+type GETBlockTransactionsParams struct {
+	// Offset Position in the block from which on transactions are returned
+	Offset *uint64 `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Maximum number of transactions to return (max 1000, default 1000)
+	Limit *uint64 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // POSTTransactionTextBody defines parameters for POSTTransaction.
 type POSTTransactionTextBody = string
 
@@ -479,6 +621,9 @@ type POSTTransactionsParams struct {
 	XWaitForStatus *WaitForStatus `json:"X-WaitForStatus,omitempty"`
 }
 
+// POSTMerklePathVerificationJSONRequestBody defines body for POSTMerklePathVerification for application/json ContentType.
+type POSTMerklePathVerificationJSONRequestBody = MerklePathVerificationRequest
+
 // POSTTransactionJSONRequestBody defines body for POSTTransaction for application/json ContentType.
 type POSTTransactionJSONRequestBody = TransactionRequest
 
@@ -766,6 +911,23 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GETBlockByHeight request
+	GETBlockByHeight(ctx context.Context, height uint64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETBlock request
+	GETBlock(ctx context.Context, hash string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETBlockTransactions request
+	GETBlockTransactions(ctx context.Context, hash string, params *GETBlockTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETChainTip request
+	GETChainTip(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTMerklePathVerificationWithBody request with any body
+	POSTMerklePathVerificationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	POSTMerklePathVerification(ctx context.Context, body POSTMerklePathVerificationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GETPolicy request
 	GETPolicy(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -779,6 +941,9 @@ type ClientInterface interface {
 	// GETTransactionStatus request
 	GETTransactionStatus(ctx context.Context, txid string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GETTransactionConfirmations request
+	GETTransactionConfirmations(ctx context.Context, txid string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// POSTTransactionsWithBody request with any body
 	POSTTransactionsWithBody(ctx context.Context, params *POSTTransactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -787,6 +952,78 @@ type ClientInterface interface {
 	POSTTransactionsWithTextBody(ctx context.Context, params *POSTTransactionsParams, body POSTTransactionsTextRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) GETBlockByHeight(ctx context.Context, height uint64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETBlockByHeightRequest(c.Server, height)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GETBlock(ctx context.Context, hash string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETBlockRequest(c.Server, hash)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GETBlockTransactions(ctx context.Context, hash string, params *GETBlockTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETBlockTransactionsRequest(c.Server, hash, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GETChainTip(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETChainTipRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) POSTMerklePathVerificationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTMerklePathVerificationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) POSTMerklePathVerification(ctx context.Context, body POSTMerklePathVerificationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTMerklePathVerificationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GETPolicy(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGETPolicyRequest(c.Server)
 	if err != nil {
@@ -847,6 +1084,18 @@ func (c *Client) GETTransactionStatus(ctx context.Context, txid string, reqEdito
 	return c.Client.Do(req)
 }
 
+func (c *Client) GETTransactionConfirmations(ctx context.Context, txid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETTransactionConfirmationsRequest(c.Server, txid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) POSTTransactionsWithBody(ctx context.Context, params *POSTTransactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPOSTTransactionsRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
@@ -883,16 +1132,23 @@ func (c *Client) POSTTransactionsWithTextBody(ctx context.Context, params *POSTT
 	return c.Client.Do(req)
 }
 
-// NewGETPolicyRequest generates requests for GETPolicy
-func NewGETPolicyRequest(server string) (*http.Request, error) {
+// NewGETBlockByHeightRequest generates requests for GETBlockByHeight
+func NewGETBlockByHeightRequest(server string, height uint64) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "height", runtime.ParamLocationPath, height)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v1/policy")
+	operationPath := fmt.Sprintf("/v1/block/height/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -910,34 +1166,23 @@ func NewGETPolicyRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPOSTTransactionRequest calls the generic POSTTransaction builder with application/json body
-func NewPOSTTransactionRequest(server string, params *POSTTransactionParams, body POSTTransactionJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+// NewGETBlockRequest generates requests for GETBlock
+func NewGETBlockRequest(server string, hash string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hash", runtime.ParamLocationPath, hash)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPOSTTransactionRequestWithBody(server, params, "application/json", bodyReader)
-}
-
-// NewPOSTTransactionRequestWithTextBody calls the generic POSTTransaction builder with text/plain body
-func NewPOSTTransactionRequestWithTextBody(server string, params *POSTTransactionParams, body POSTTransactionTextRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	bodyReader = strings.NewReader(string(body))
-	return NewPOSTTransactionRequestWithBody(server, params, "text/plain", bodyReader)
-}
-
-// NewPOSTTransactionRequestWithBody generates requests for POSTTransaction with any type of body
-func NewPOSTTransactionRequestWithBody(server string, params *POSTTransactionParams, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v1/tx")
+	operationPath := fmt.Sprintf("/v1/block/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -947,72 +1192,283 @@ func NewPOSTTransactionRequestWithBody(server string, params *POSTTransactionPar
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
 
-	if params != nil {
+// NewGETBlockTransactionsRequest generates requests for GETBlockTransactions
+func NewGETBlockTransactionsRequest(server string, hash string, params *GETBlockTransactionsParams) (*http.Request, error) {
+	var err error
 
-		if params.XCallbackUrl != nil {
-			var headerParam0 string
+	var pathParam0 string
 
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-CallbackUrl", runtime.ParamLocationHeader, *params.XCallbackUrl)
-			if err != nil {
-				return nil, err
-			}
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hash", runtime.ParamLocationPath, hash)
+	if err != nil {
+		return nil, err
+	}
 
-			req.Header.Set("X-CallbackUrl", headerParam0)
-		}
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-		if params.XFullStatusUpdates != nil {
-			var headerParam1 string
+	operationPath := fmt.Sprintf("/v1/block/%s/transactions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-FullStatusUpdates", runtime.ParamLocationHeader, *params.XFullStatusUpdates)
-			if err != nil {
-				return nil, err
-			}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-			req.Header.Set("X-FullStatusUpdates", headerParam1)
-		}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-		if params.XMaxTimeout != nil {
-			var headerParam2 string
+		if params.Offset != nil {
 
-			headerParam2, err = runtime.StyleParamWithLocation("simple", false, "X-MaxTimeout", runtime.ParamLocationHeader, *params.XMaxTimeout)
-			if err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
 				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
 			}
 
-			req.Header.Set("X-MaxTimeout", headerParam2)
 		}
 
-		if params.XSkipFeeValidation != nil {
-			var headerParam3 string
+		if params.Limit != nil {
 
-			headerParam3, err = runtime.StyleParamWithLocation("simple", false, "X-SkipFeeValidation", runtime.ParamLocationHeader, *params.XSkipFeeValidation)
-			if err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
 				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
 			}
 
-			req.Header.Set("X-SkipFeeValidation", headerParam3)
 		}
 
-		if params.XSkipScriptValidation != nil {
-			var headerParam4 string
+		queryURL.RawQuery = queryValues.Encode()
+	}
 
-			headerParam4, err = runtime.StyleParamWithLocation("simple", false, "X-SkipScriptValidation", runtime.ParamLocationHeader, *params.XSkipScriptValidation)
-			if err != nil {
-				return nil, err
-			}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-			req.Header.Set("X-SkipScriptValidation", headerParam4)
-		}
+	return req, nil
+}
 
-		if params.XSkipTxValidation != nil {
-			var headerParam5 string
+// NewGETChainTipRequest generates requests for GETChainTip
+func NewGETChainTipRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/chaintip")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPOSTMerklePathVerificationRequest calls the generic POSTMerklePathVerification builder with application/json body
+func NewPOSTMerklePathVerificationRequest(server string, body POSTMerklePathVerificationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTMerklePathVerificationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPOSTMerklePathVerificationRequestWithBody generates requests for POSTMerklePathVerification with any type of body
+func NewPOSTMerklePathVerificationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/merkle/verify")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGETPolicyRequest generates requests for GETPolicy
+func NewGETPolicyRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/policy")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPOSTTransactionRequest calls the generic POSTTransaction builder with application/json body
+func NewPOSTTransactionRequest(server string, params *POSTTransactionParams, body POSTTransactionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTTransactionRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPOSTTransactionRequestWithTextBody calls the generic POSTTransaction builder with text/plain body
+func NewPOSTTransactionRequestWithTextBody(server string, params *POSTTransactionParams, body POSTTransactionTextRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	bodyReader = strings.NewReader(string(body))
+	return NewPOSTTransactionRequestWithBody(server, params, "text/plain", bodyReader)
+}
+
+// NewPOSTTransactionRequestWithBody generates requests for POSTTransaction with any type of body
+func NewPOSTTransactionRequestWithBody(server string, params *POSTTransactionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/tx")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XCallbackUrl != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-CallbackUrl", runtime.ParamLocationHeader, *params.XCallbackUrl)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-CallbackUrl", headerParam0)
+		}
+
+		if params.XFullStatusUpdates != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-FullStatusUpdates", runtime.ParamLocationHeader, *params.XFullStatusUpdates)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-FullStatusUpdates", headerParam1)
+		}
+
+		if params.XMaxTimeout != nil {
+			var headerParam2 string
+
+			headerParam2, err = runtime.StyleParamWithLocation("simple", false, "X-MaxTimeout", runtime.ParamLocationHeader, *params.XMaxTimeout)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-MaxTimeout", headerParam2)
+		}
+
+		if params.XSkipFeeValidation != nil {
+			var headerParam3 string
+
+			headerParam3, err = runtime.StyleParamWithLocation("simple", false, "X-SkipFeeValidation", runtime.ParamLocationHeader, *params.XSkipFeeValidation)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-SkipFeeValidation", headerParam3)
+		}
+
+		if params.XSkipScriptValidation != nil {
+			var headerParam4 string
+
+			headerParam4, err = runtime.StyleParamWithLocation("simple", false, "X-SkipScriptValidation", runtime.ParamLocationHeader, *params.XSkipScriptValidation)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-SkipScriptValidation", headerParam4)
+		}
+
+		if params.XSkipTxValidation != nil {
+			var headerParam5 string
 
 			headerParam5, err = runtime.StyleParamWithLocation("simple", false, "X-SkipTxValidation", runtime.ParamLocationHeader, *params.XSkipTxValidation)
 			if err != nil {
@@ -1094,6 +1550,40 @@ func NewGETTransactionStatusRequest(server string, txid string) (*http.Request,
 	return req, nil
 }
 
+// NewGETTransactionConfirmationsRequest generates requests for GETTransactionConfirmations
+func NewGETTransactionConfirmationsRequest(server string, txid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "txid", runtime.ParamLocationPath, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/tx/%s/confirmations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewPOSTTransactionsRequest calls the generic POSTTransactions builder with application/json body
 func NewPOSTTransactionsRequest(server string, params *POSTTransactionsParams, body POSTTransactionsJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -1287,6 +1777,23 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// GETBlockByHeightWithResponse request
+	GETBlockByHeightWithResponse(ctx context.Context, height uint64, reqEditors ...RequestEditorFn) (*GETBlockByHeightResponse, error)
+
+	// GETBlockWithResponse request
+	GETBlockWithResponse(ctx context.Context, hash string, reqEditors ...RequestEditorFn) (*GETBlockResponse, error)
+
+	// GETBlockTransactionsWithResponse request
+	GETBlockTransactionsWithResponse(ctx context.Context, hash string, params *GETBlockTransactionsParams, reqEditors ...RequestEditorFn) (*GETBlockTransactionsResponse, error)
+
+	// GETChainTipWithResponse request
+	GETChainTipWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETChainTipResponse, error)
+
+	// POSTMerklePathVerificationWithBodyWithResponse request with any body
+	POSTMerklePathVerificationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTMerklePathVerificationResponse, error)
+
+	POSTMerklePathVerificationWithResponse(ctx context.Context, body POSTMerklePathVerificationJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTMerklePathVerificationResponse, error)
+
 	// GETPolicyWithResponse request
 	GETPolicyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETPolicyResponse, error)
 
@@ -1300,6 +1807,9 @@ type ClientWithResponsesInterface interface {
 	// GETTransactionStatusWithResponse request
 	GETTransactionStatusWithResponse(ctx context.Context, txid string, reqEditors ...RequestEditorFn) (*GETTransactionStatusResponse, error)
 
+	// GETTransactionConfirmationsWithResponse request
+	GETTransactionConfirmationsWithResponse(ctx context.Context, txid string, reqEditors ...RequestEditorFn) (*GETTransactionConfirmationsResponse, error)
+
 	// POSTTransactionsWithBodyWithResponse request with any body
 	POSTTransactionsWithBodyWithResponse(ctx context.Context, params *POSTTransactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTTransactionsResponse, error)
 
@@ -1308,14 +1818,16 @@ type ClientWithResponsesInterface interface {
 	POSTTransactionsWithTextBodyWithResponse(ctx context.Context, params *POSTTransactionsParams, body POSTTransactionsTextRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionsResponse, error)
 }
 
-type GETPolicyResponse struct {
+type GETBlockByHeightResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *PolicyResponse
+	JSON200      *Block
+	JSON404      *ErrorNotFound
+	JSON409      *ErrorGeneric
 }
 
 // Status returns HTTPResponse.Status
-func (r GETPolicyResponse) Status() string {
+func (r GETBlockByHeightResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1323,30 +1835,24 @@ func (r GETPolicyResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GETPolicyResponse) StatusCode() int {
+func (r GETBlockByHeightResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type POSTTransactionResponse struct {
+type GETBlockResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *TransactionResponse
+	JSON200      *Block
 	JSON400      *ErrorBadRequest
+	JSON404      *ErrorNotFound
 	JSON409      *ErrorGeneric
-	JSON422      *Error
-	JSON460      *ErrorTxFormat
-	JSON461      *ErrorUnlockingScripts
-	JSON462      *ErrorInputs
-	JSON463      *ErrorMalformed
-	JSON464      *ErrorOutputs
-	JSON465      *ErrorFee
 }
 
 // Status returns HTTPResponse.Status
-func (r POSTTransactionResponse) Status() string {
+func (r GETBlockResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1354,23 +1860,24 @@ func (r POSTTransactionResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r POSTTransactionResponse) StatusCode() int {
+func (r GETBlockResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GETTransactionStatusResponse struct {
+type GETBlockTransactionsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *TransactionStatus
+	JSON200      *BlockTransactions
+	JSON400      *ErrorBadRequest
 	JSON404      *ErrorNotFound
 	JSON409      *ErrorGeneric
 }
 
 // Status returns HTTPResponse.Status
-func (r GETTransactionStatusResponse) Status() string {
+func (r GETBlockTransactionsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1378,110 +1885,536 @@ func (r GETTransactionStatusResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GETTransactionStatusResponse) StatusCode() int {
+func (r GETBlockTransactionsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type POSTTransactionsResponse struct {
+type GETChainTipResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *TransactionResponses
-	JSON400      *ErrorBadRequest
+	JSON200      *Block
+	JSON404      *ErrorNotFound
 	JSON409      *ErrorGeneric
-	JSON422      *Error
-	JSON460      *ErrorTxFormat
-	JSON461      *ErrorUnlockingScripts
-	JSON462      *ErrorInputs
+}
+
+// Status returns HTTPResponse.Status
+func (r GETChainTipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETChainTipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTMerklePathVerificationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MerklePathVerification
+	JSON400      *ErrorBadRequest
+	JSON409      *ErrorGeneric
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTMerklePathVerificationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTMerklePathVerificationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETPolicyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PolicyResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GETPolicyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETPolicyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionResponse
+	JSON400      *ErrorBadRequest
+	JSON409      *ErrorGeneric
+	JSON422      *Error
+	JSON460      *ErrorTxFormat
+	JSON461      *ErrorUnlockingScripts
+	JSON462      *ErrorInputs
 	JSON463      *ErrorMalformed
 	JSON464      *ErrorOutputs
 	JSON465      *ErrorFee
 }
 
-// Status returns HTTPResponse.Status
-func (r POSTTransactionsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// Status returns HTTPResponse.Status
+func (r POSTTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETTransactionStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionStatus
+	JSON404      *ErrorNotFound
+	JSON409      *ErrorGeneric
+}
+
+// Status returns HTTPResponse.Status
+func (r GETTransactionStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETTransactionStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETTransactionConfirmationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionConfirmations
+	JSON400      *ErrorBadRequest
+	JSON404      *ErrorNotFound
+	JSON409      *ErrorGeneric
+}
+
+// Status returns HTTPResponse.Status
+func (r GETTransactionConfirmationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETTransactionConfirmationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionResponses
+	JSON400      *ErrorBadRequest
+	JSON409      *ErrorGeneric
+	JSON422      *Error
+	JSON460      *ErrorTxFormat
+	JSON461      *ErrorUnlockingScripts
+	JSON462      *ErrorInputs
+	JSON463      *ErrorMalformed
+	JSON464      *ErrorOutputs
+	JSON465      *ErrorFee
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GETBlockByHeightWithResponse request returning *GETBlockByHeightResponse
+func (c *ClientWithResponses) GETBlockByHeightWithResponse(ctx context.Context, height uint64, reqEditors ...RequestEditorFn) (*GETBlockByHeightResponse, error) {
+	rsp, err := c.GETBlockByHeight(ctx, height, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETBlockByHeightResponse(rsp)
+}
+
+// GETBlockWithResponse request returning *GETBlockResponse
+func (c *ClientWithResponses) GETBlockWithResponse(ctx context.Context, hash string, reqEditors ...RequestEditorFn) (*GETBlockResponse, error) {
+	rsp, err := c.GETBlock(ctx, hash, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETBlockResponse(rsp)
+}
+
+// GETBlockTransactionsWithResponse request returning *GETBlockTransactionsResponse
+func (c *ClientWithResponses) GETBlockTransactionsWithResponse(ctx context.Context, hash string, params *GETBlockTransactionsParams, reqEditors ...RequestEditorFn) (*GETBlockTransactionsResponse, error) {
+	rsp, err := c.GETBlockTransactions(ctx, hash, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETBlockTransactionsResponse(rsp)
+}
+
+// GETChainTipWithResponse request returning *GETChainTipResponse
+func (c *ClientWithResponses) GETChainTipWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETChainTipResponse, error) {
+	rsp, err := c.GETChainTip(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETChainTipResponse(rsp)
+}
+
+// POSTMerklePathVerificationWithBodyWithResponse request with arbitrary body returning *POSTMerklePathVerificationResponse
+func (c *ClientWithResponses) POSTMerklePathVerificationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTMerklePathVerificationResponse, error) {
+	rsp, err := c.POSTMerklePathVerificationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTMerklePathVerificationResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTMerklePathVerificationWithResponse(ctx context.Context, body POSTMerklePathVerificationJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTMerklePathVerificationResponse, error) {
+	rsp, err := c.POSTMerklePathVerification(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTMerklePathVerificationResponse(rsp)
+}
+
+// GETPolicyWithResponse request returning *GETPolicyResponse
+func (c *ClientWithResponses) GETPolicyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETPolicyResponse, error) {
+	rsp, err := c.GETPolicy(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETPolicyResponse(rsp)
+}
+
+// POSTTransactionWithBodyWithResponse request with arbitrary body returning *POSTTransactionResponse
+func (c *ClientWithResponses) POSTTransactionWithBodyWithResponse(ctx context.Context, params *POSTTransactionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTTransactionResponse, error) {
+	rsp, err := c.POSTTransactionWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTTransactionResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTTransactionWithResponse(ctx context.Context, params *POSTTransactionParams, body POSTTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionResponse, error) {
+	rsp, err := c.POSTTransaction(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTTransactionResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTTransactionWithTextBodyWithResponse(ctx context.Context, params *POSTTransactionParams, body POSTTransactionTextRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionResponse, error) {
+	rsp, err := c.POSTTransactionWithTextBody(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTTransactionResponse(rsp)
+}
+
+// GETTransactionStatusWithResponse request returning *GETTransactionStatusResponse
+func (c *ClientWithResponses) GETTransactionStatusWithResponse(ctx context.Context, txid string, reqEditors ...RequestEditorFn) (*GETTransactionStatusResponse, error) {
+	rsp, err := c.GETTransactionStatus(ctx, txid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETTransactionStatusResponse(rsp)
+}
+
+// GETTransactionConfirmationsWithResponse request returning *GETTransactionConfirmationsResponse
+func (c *ClientWithResponses) GETTransactionConfirmationsWithResponse(ctx context.Context, txid string, reqEditors ...RequestEditorFn) (*GETTransactionConfirmationsResponse, error) {
+	rsp, err := c.GETTransactionConfirmations(ctx, txid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETTransactionConfirmationsResponse(rsp)
+}
+
+// POSTTransactionsWithBodyWithResponse request with arbitrary body returning *POSTTransactionsResponse
+func (c *ClientWithResponses) POSTTransactionsWithBodyWithResponse(ctx context.Context, params *POSTTransactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTTransactionsResponse, error) {
+	rsp, err := c.POSTTransactionsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTTransactionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTTransactionsWithResponse(ctx context.Context, params *POSTTransactionsParams, body POSTTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionsResponse, error) {
+	rsp, err := c.POSTTransactions(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTTransactionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTTransactionsWithTextBodyWithResponse(ctx context.Context, params *POSTTransactionsParams, body POSTTransactionsTextRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionsResponse, error) {
+	rsp, err := c.POSTTransactionsWithTextBody(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTTransactionsResponse(rsp)
+}
+
+// ParseGETBlockByHeightResponse parses an HTTP response from a GETBlockByHeightWithResponse call
+func ParseGETBlockByHeightResponse(rsp *http.Response) (*GETBlockByHeightResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETBlockByHeightResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Block
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorGeneric
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETBlockResponse parses an HTTP response from a GETBlockWithResponse call
+func ParseGETBlockResponse(rsp *http.Response) (*GETBlockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETBlockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Block
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorGeneric
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETBlockTransactionsResponse parses an HTTP response from a GETBlockTransactionsWithResponse call
+func ParseGETBlockTransactionsResponse(rsp *http.Response) (*GETBlockTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETBlockTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BlockTransactions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorGeneric
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r POSTTransactionsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-// GETPolicyWithResponse request returning *GETPolicyResponse
-func (c *ClientWithResponses) GETPolicyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETPolicyResponse, error) {
-	rsp, err := c.GETPolicy(ctx, reqEditors...)
+// ParseGETChainTipResponse parses an HTTP response from a GETChainTipWithResponse call
+func ParseGETChainTipResponse(rsp *http.Response) (*GETChainTipResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGETPolicyResponse(rsp)
-}
 
-// POSTTransactionWithBodyWithResponse request with arbitrary body returning *POSTTransactionResponse
-func (c *ClientWithResponses) POSTTransactionWithBodyWithResponse(ctx context.Context, params *POSTTransactionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTTransactionResponse, error) {
-	rsp, err := c.POSTTransactionWithBody(ctx, params, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GETChainTipResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePOSTTransactionResponse(rsp)
-}
 
-func (c *ClientWithResponses) POSTTransactionWithResponse(ctx context.Context, params *POSTTransactionParams, body POSTTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionResponse, error) {
-	rsp, err := c.POSTTransaction(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePOSTTransactionResponse(rsp)
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Block
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-func (c *ClientWithResponses) POSTTransactionWithTextBodyWithResponse(ctx context.Context, params *POSTTransactionParams, body POSTTransactionTextRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionResponse, error) {
-	rsp, err := c.POSTTransactionWithTextBody(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePOSTTransactionResponse(rsp)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorGeneric
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
 
-// GETTransactionStatusWithResponse request returning *GETTransactionStatusResponse
-func (c *ClientWithResponses) GETTransactionStatusWithResponse(ctx context.Context, txid string, reqEditors ...RequestEditorFn) (*GETTransactionStatusResponse, error) {
-	rsp, err := c.GETTransactionStatus(ctx, txid, reqEditors...)
-	if err != nil {
-		return nil, err
 	}
-	return ParseGETTransactionStatusResponse(rsp)
+
+	return response, nil
 }
 
-// POSTTransactionsWithBodyWithResponse request with arbitrary body returning *POSTTransactionsResponse
-func (c *ClientWithResponses) POSTTransactionsWithBodyWithResponse(ctx context.Context, params *POSTTransactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTTransactionsResponse, error) {
-	rsp, err := c.POSTTransactionsWithBody(ctx, params, contentType, body, reqEditors...)
+// ParsePOSTMerklePathVerificationResponse parses an HTTP response from a POSTMerklePathVerificationWithResponse call
+func ParsePOSTMerklePathVerificationResponse(rsp *http.Response) (*POSTMerklePathVerificationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePOSTTransactionsResponse(rsp)
-}
 
-func (c *ClientWithResponses) POSTTransactionsWithResponse(ctx context.Context, params *POSTTransactionsParams, body POSTTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionsResponse, error) {
-	rsp, err := c.POSTTransactions(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &POSTMerklePathVerificationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePOSTTransactionsResponse(rsp)
-}
 
-func (c *ClientWithResponses) POSTTransactionsWithTextBodyWithResponse(ctx context.Context, params *POSTTransactionsParams, body POSTTransactionsTextRequestBody, reqEditors ...RequestEditorFn) (*POSTTransactionsResponse, error) {
-	rsp, err := c.POSTTransactionsWithTextBody(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MerklePathVerification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorGeneric
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	}
-	return ParsePOSTTransactionsResponse(rsp)
+
+	return response, nil
 }
 
 // ParseGETPolicyResponse parses an HTTP response from a GETPolicyWithResponse call
@@ -1639,6 +2572,53 @@ func ParseGETTransactionStatusResponse(rsp *http.Response) (*GETTransactionStatu
 	return response, nil
 }
 
+// ParseGETTransactionConfirmationsResponse parses an HTTP response from a GETTransactionConfirmationsWithResponse call
+func ParseGETTransactionConfirmationsResponse(rsp *http.Response) (*GETTransactionConfirmationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETTransactionConfirmationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionConfirmations
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorGeneric
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePOSTTransactionsResponse parses an HTTP response from a POSTTransactionsWithResponse call
 func ParsePOSTTransactionsResponse(rsp *http.Response) (*POSTTransactionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1730,6 +2710,21 @@ func ParsePOSTTransactionsResponse(rsp *http.Response) (*POSTTransactionsRespons
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get the block at a given height.
+	// (GET /v1/block/height/{height})
+	GETBlockByHeight(ctx echo.Context, height uint64) error
+	// Get a block.
+	// (GET /v1/block/{hash})
+	GETBlock(ctx echo.Context, hash string) error
+	// Get the transactions of a block.
+	// (GET /v1/block/{hash}/transactions)
+	GETBlockTransactions(ctx echo.Context, hash string, params GETBlockTransactionsParams) error
+	// Get the chain tip.
+	// (GET /v1/chaintip)
+	GETChainTip(ctx echo.Context) error
+	// Verify a Merkle path.
+	// (POST /v1/merkle/verify)
+	POSTMerklePathVerification(ctx echo.Context) error
 	// Get the policy settings
 	// (GET /v1/policy)
 	GETPolicy(ctx echo.Context) error
@@ -1739,6 +2734,9 @@ type ServerInterface interface {
 	// Get transaction status.
 	// (GET /v1/tx/{txid})
 	GETTransactionStatus(ctx echo.Context, txid string) error
+	// Get the number of confirmations of a transaction.
+	// (GET /v1/tx/{txid}/confirmations)
+	GETTransactionConfirmations(ctx echo.Context, txid string) error
 	// Submit multiple transactions.
 	// (POST /v1/txs)
 	POSTTransactions(ctx echo.Context, params POSTTransactionsParams) error
@@ -1749,6 +2747,118 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GETBlockByHeight converts echo context to params.
+func (w *ServerInterfaceWrapper) GETBlockByHeight(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "height" -------------
+	var height uint64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "height", runtime.ParamLocationPath, ctx.Param("height"), &height)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter height: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(Api_KeyScopes, []string{})
+
+	ctx.Set(AuthorizationScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GETBlockByHeight(ctx, height)
+	return err
+}
+
+// GETBlock converts echo context to params.
+func (w *ServerInterfaceWrapper) GETBlock(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "hash" -------------
+	var hash string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "hash", runtime.ParamLocationPath, ctx.Param("hash"), &hash)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter hash: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(Api_KeyScopes, []string{})
+
+	ctx.Set(AuthorizationScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GETBlock(ctx, hash)
+	return err
+}
+
+// GETBlockTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) GETBlockTransactions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "hash" -------------
+	var hash string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "hash", runtime.ParamLocationPath, ctx.Param("hash"), &hash)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter hash: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(Api_KeyScopes, []string{})
+
+	ctx.Set(AuthorizationScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GETBlockTransactionsParams
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GETBlockTransactions(ctx, hash, params)
+	return err
+}
+
+// GETChainTip converts echo context to params.
+func (w *ServerInterfaceWrapper) GETChainTip(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(Api_KeyScopes, []string{})
+
+	ctx.Set(AuthorizationScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GETChainTip(ctx)
+	return err
+}
+
+// POSTMerklePathVerification converts echo context to params.
+func (w *ServerInterfaceWrapper) POSTMerklePathVerification(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(Api_KeyScopes, []string{})
+
+	ctx.Set(AuthorizationScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.POSTMerklePathVerification(ctx)
+	return err
+}
+
 // GETPolicy converts echo context to params.
 func (w *ServerInterfaceWrapper) GETPolicy(ctx echo.Context) error {
 	var err error
@@ -1941,6 +3051,28 @@ func (w *ServerInterfaceWrapper) GETTransactionStatus(ctx echo.Context) error {
 	return err
 }
 
+// GETTransactionConfirmations converts echo context to params.
+func (w *ServerInterfaceWrapper) GETTransactionConfirmations(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "txid" -------------
+	var txid string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "txid", runtime.ParamLocationPath, ctx.Param("txid"), &txid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txid: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(Api_KeyScopes, []string{})
+
+	ctx.Set(AuthorizationScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GETTransactionConfirmations(ctx, txid)
+	return err
+}
+
 // POSTTransactions converts echo context to params.
 func (w *ServerInterfaceWrapper) POSTTransactions(ctx echo.Context) error {
 	var err error
@@ -2124,9 +3256,15 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/v1/block/height/:height", wrapper.GETBlockByHeight)
+	router.GET(baseURL+"/v1/block/:hash", wrapper.GETBlock)
+	router.GET(baseURL+"/v1/block/:hash/transactions", wrapper.GETBlockTransactions)
+	router.GET(baseURL+"/v1/chaintip", wrapper.GETChainTip)
+	router.POST(baseURL+"/v1/merkle/verify", wrapper.POSTMerklePathVerification)
 	router.GET(baseURL+"/v1/policy", wrapper.GETPolicy)
 	router.POST(baseURL+"/v1/tx", wrapper.POSTTransaction)
 	router.GET(baseURL+"/v1/tx/:txid", wrapper.GETTransactionStatus)
+	router.GET(baseURL+"/v1/tx/:txid/confirmations", wrapper.GETTransactionConfirmations)
 	router.POST(baseURL+"/v1/txs", wrapper.POSTTransactions)
 
 }
@@ -2134,75 +3272,100 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+w8a3PbuHZ/BcPbD8mMbIPg2zM7ncSx73V3Y7u2sts268ngcWjhhiJVAnLk3fq/dwCS",
-	"EilRspTI3m03+ZCRhNfBeb/g3x1ejCdFDrlWzvHvzoSWdAwaSvuN0yxjlH8eFp8hNz8IULyUEy2L3Dl2",
-	"3nAOSiFtRlFalCgvtEwlp2YcNYsR5GJSyFwfonONvsgsQwzQVIFAVCGK3kz1qCjlb9WqEVABpd1NjwCN",
-	"tJ7Md3IGjjTnVnOcgZPTMTjHzn8cnHQAHTiKj2BMDcT6YWKmKF3K/M55fBzML/WhzFav9A5SOs00EsWU",
-	"ZYDUBHKBaC7QGMrPGaBJWRTpU/d8Gk5z9mYo02mW3Wiqp+rDRFANahXWX0agR1CiL4DUqJhmAo3oPSCz",
-	"Eim7FE2rtUi2wKzohF7JnGdTIfM7dHN6evHp/OLT5fXVP95cfHp/+v7q8vIne207dHnx6eJ0+Mvl9Y/1",
-	"vqBeb7jk2QroPVdlRZEBze1dx3Q2lGMopnr1kvWAuYECXuTC8Bv6QqWuOA6+IF3SXFFuiVHfm0FalIBK",
-	"+O8pKI1gNpElKPRqTGfIw81OAyRqcgev11/n/QK6nnvIXMMdlNU9LI9cGRZZTy1doArz0OEpSyPD8Q2d",
-	"FHqlyymg/0EpzRRsQvj71rmbuUp9lpMzgJ9pJgWtAHuaq8wilAKg+/mymok2wHSzctITTGBOubFwfAV0",
-	"1ZSdAVw5bwsYh7OvgK+4h5JmGdKznWHsnPcEfEYszoqyEr4+4CQfNSLSlqK0LMaW+RSU91AuxEdPy9xo",
-	"iFcE/YCuT09Oz38+fTdAHvoB3Qwvr81nH/2A3lxcXH64ODl992l42aiKAQrsmn//cHozPH336e1/LkZC",
-	"s/70YtiZHpmNTk5Or5Znx3Z2Rw9tENdfOjjYKLGPA6cENSlyVanXi0I3xgjEKvpugE9LqR+sWpEljI3R",
-	"RCmVGYiKPexRdquTEZX5eZ4Wq9vYISTN2MCZlMUESi0rAFhW8M//oGq0uuqtGUIjMzZwYEbHk8xcBi//",
-	"iwM/8hPmcZcEIiA8THwvjKLA93lMQxrHAfGjxAsYjYUbCWewrCUGNRQg70Z6LRzVaAuSKCaeGw+ctCjH",
-	"VDvHzlTmOvQX+7cVZf1Twf4JXJsjT4rxuMiva2L04MyOo4ZaqF65jD8tx6A0HU/MlzkkxgYdmKHVy1oO",
-	"sMQUzvHH1vrbHiBPy7Ioe7ygHP1jOLxCV2XBMhijd6CpzFQN48A4OQJSmYMwKv78dHiGrs9OUBTjCL0y",
-	"/o06PjrSRZGpQwk6PSzKu6ORHmdHZcrNJKv5ixwuU+f44+/Ov5SQOsfO344WjttRzXhHFsIPuSGRzO8q",
-	"7aacx8EWq87zyXTbue9pZpBruH6b6Wdl8RvkV0Um+cMuK04MqXM1Vc7jbYP+t1RcV0bdEIJm2bZYOZOQ",
-	"iep+XZ4Rllzm00KqhqOF76AAxlZbMkDj5uLWN+I0N44UsxbceMIWIY7MlaY5h+6WDaFpyQ81pdkhL8ZH",
-	"YCBTRy7x/CAIzWI1V93zpT7GRmSkzpa2fEtFA6UzF6q+M5nUvJD5gbo/vJN6NGWHsjCAHP2thuBfpfjh",
-	"k49xn3CulYQzgOemQQqgEC0B6aJAWfHlG9BL1mE3DPqxewadY78Zu2GwG3YrXB2vR1VXC/1U5HdQotaP",
-	"qEiRBaBjMGq0Nt5m23eWqrlwze61n2r1FkXWLBz2GQyY6ZL2G7tL+4FmyM6xVs9oZXMcZcavN0BYKBdO",
-	"yFjmlUmfZhllBmrjCPec22aF7rGvmnNfo59k/tnch3I9NXDYs0ykYF2dbY5Ra9wpSyfECwFtDPuYtKyg",
-	"zHWPCWwx3HL4WX+7B6RhVnlnDRFXANMz2eOmDFskPX+H9Eiq+tZSoRJSKM16pItt7t6w/dIRDxOYs9cA",
-	"fZF6hLIaz2PjObbo/LTRNaMNRubYHjScvl5ClozEM+oiaxxRdSB6xTLKP2dSaTSmOTVSxxsg0HwMxOvn",
-	"0FYR6ddWbQj3oq4ispu6atv4P5ASEwvB85PBfSkyuDuR4e+QQyn5s9rllnrhNsJ9GSco6cd4feNaSe7F",
-	"DUp2QnntOL8QxqVCMrcpBMSA06kCazKlBcK6SnmRH8DMsH6uUVHaFKZ+FsdprSqq4JNNRLEH32k3ZbSI",
-	"T16OKs8ZGqwngddPgjkC2v7dfijh7USJi0KfFdNcvFCwBiYgUsW05NDVTakF4ln0kt9PgotCL079dp3k",
-	"74T2y6n+EyilooKiVyvV859FKPzNeqkGaz/isBtdhrOzOjB4McIYAZC5Cb8gN6Fc5ZcP0FgqZcIAq6Xr",
-	"/Ll6FgkJ8XoJWQJrPzTZLY2xki77/24z3Be3GVv7sGcAb8bFNK8ERAhZBfFXLcTaYthK3vyht0B6MR0z",
-	"KE2gWk3YJjs9cBTVhRrJnv0q2Iw43TRztkx4t+NdtVhbgdWHiVYktQkNc5r87ozprJZi+RtM6uWBrQnY",
-	"CqueKXlXTBQ3d1DNBJ8kfhJGJAnmkxar3bqmMHDGMpf5XZ3xq7Ht2pEFtlxz0S5demFaxup7OpPj6bip",
-	"4Zmp6KM943ZLiq293bqj8jljKHmXUz0tARm4bcJC7Xbq0xfr1Ke/4nZt3G/SRwvpWWa5PjqsR9vq1dpA",
-	"rOfWdvlmOw26VPYxSnQHqV/gfdMZTd1hCSX14tXb3D4O2nq6ruZsf6fW2pspG0tdVyLN5Vri2ir1Oc5S",
-	"zQ13UqpmuO4soHpU1/wWibJjYsSwVflyCCbeAfYOcDJ0yTH2jv340ItJ4uLA9f9rnmo7di5/NF9mTbnY",
-	"WSqyOk2G0eFYhCmHyPXBJyQIXT/FGPOQBlQISqnr+S7ljCU8jlw3cF1f8DT2Uy9iiR9Q6xp0abchZXy6",
-	"IVPcNk2DzU5P5V9ukeJs43ZTLvV93a1B9ahqXBrBDFXbGJPw9sP7q9qNQR/fXp8cRP7tvLzHSn4o4P4o",
-	"8l9vlXWdrSvhD1d6XQwe8unY8PSHix8vLn+5cAZOU6p3Bk5Vp3cGTl+R3k5drdCbZd3yvP1lmTven1/Y",
-	"nU8uL87Or9/bz9en/3Z6Mjx9ZyRrQZ+mrv/VWWyZG3R3aB4ywXhKGQ5IKDwMsQhjEiVplIg0Dd2U+ZiE",
-	"lEPMIuaRKE5oit3Q80II/JSkuDcxveqkrAdL1KphKZ1trtOnJFtLWyXMlkoo6ZfhzDl2fp1i7PG21Vhw",
-	"mh2DVXmq1y5j8Zp+aS1+MhVf7fIk8HtQ8xunz3snnprZo6d3tCFb6aEX1CJ/pBr5Rsl8osozs9p4DlLb",
-	"xD1phK/bXTovwHM7MFBLTO13qWFsP2zXsbGGg58MOysU1VijZUkfetVXF40Lbvhz4XAvzsBfwc4vgvXa",
-	"8u7NmEYJiYjnCRcLykUQ4pADBCzFjMRhGKfcTVyIPIxJQjn4PEq5j0FAkFASkwC2lf/6Lk9KfMd13owd",
-	"O3OBpC5rravd36zglGD36+v2w7p43fJ4hABh6+EwnhRF9iSCFmrY7rWKoceBo+oOxBsjcdUF3wItoXwz",
-	"7ePqagzRqR5Brpt+9W4/2vXZCQqjADedkral1K5bQGy4u2qXlLWQZpJD7QLUjZeXE8jR25uf0U9miBtk",
-	"TMtsNVlElSq4tJAc5qCPignkB0zdH9RbHrWw7Jj93lyfOAPnHkpVXco9xIfYTDIr6UQ6x45nfxo4RmQt",
-	"Uo7u3aNFdHgHfX3lti2i7tivO12VVSt1CVmB1jK/U7b7rskNnJto6O+nw6smLu70jxKM7auJItdQ57Am",
-	"k6zG+9E/VdUwvGhIfTpsXeheg/0lFp7a5xcGFT521+03B/Co291q2Wk6HtPywVZRdd/dDQvQO2XY803J",
-	"nVuzyOBWz6r4Wz2JWKmqlx66QMo+pUAl7Xbs6wLRquXHNrvYniNl47iqQymHma56j5AeUb3oEEK8BKpB",
-	"HSI0HEHzcGTxgAVxms9fmujC9mCXUlS1ibusYDSbX9Qc9lBMS/Sm5EhQNWIFLUXzCkV1bE0PR1xd3gyH",
-	"HWvUfkezxsouphy1n6Sss7Kt6atvQ7ZY1HpkscXs1YcCWy5aaanfcl2nzX2LNd2nSdvcv/VIYovp3VZ6",
-	"EwjWNca3hXjYm5T3xIRGxtobFlyDPlC6BDrubjw3VkzmRox741mY6aNJRuUSUAtDtUW0ubrx46a4uBNw",
-	"Lkyc8YEev0pj1sBWvmLThL1QXsuFvYFzT7MptIsw+ypXdhNztKz7TpAfesdo6WsbpdXeCKMmXd4CwmnX",
-	"eIzLuEjt+aG3MIar16yzcxSLMKFEpFRELo4iDILEhHPw3JAHUULS0MUuDWPsh5SEHnUj6lLAJIxC7LZ9",
-	"t52bAX516odPlY/TIUsH5XnLD5pT5xtyoP/X8p9VHr/qSOlHUTW8BjudJx6U8jhJGQg39ECEGIcuo57H",
-	"OKYsERBDlIqYeT4Vic+J7/pcLGM38kJC4s0oTiHwSeDGGGOCffN/LJIoTYCBECJJE0pjwJAEHvNoFKae",
-	"G5IkpgwHkMSeT2nsupEbQiK8JApCHwLsYhKkoW8XugRISAMexNjjSZr4wuWEx0DDGDikru8G2HXB5WYe",
-	"S3gShiykAhNM3DRIqZeEOOLUY34sAo8nmDARMOYzloY0ojxJeJqkgvoB58RlkQshkDSK4yTEHiY+JYy5",
-	"bghx6JGAJywOXJK6mBHCCYkp80hEUvBSL/KYy4RPExoyz/MZDmPGQkwMKUI3SjxGotjDnpEx10swBwoB",
-	"jVxPAAbKRMIFDb0IkxRinyckTiJMeRpxPwDsYkyDMAJP4DAELw692GyXREGQeJgAZTwOgIUJI5hwAnEo",
-	"fM+LGWUmGItTP3yeUsA8wKwEgIUxw6HPPC9kCfUpE8yNvNQDj6QkYl5MCSGcEReTNHBZzBMShB7Ebshc",
-	"wnxamYyvsIlbur/787uXX5n0nLz07uJrnG+zKtkvzE3rZw/AKz2SPiH7Pbzv1A953cdAWQbIBKD6AR1U",
-	"zeLdV1BVI/0i2WyYeq/gzftuesBc04bih+5+YVh9lrUKy9oeDD/cM8Ga516rMKy2b/qht9/DW+/HdsKB",
-	"v18wmi65DUho9Yr5YbDf48+gV6913h11gvQqJ4ZoGzGH60P0o9+N8n7cMgPSCtTv6mQAn5al8X/rB8NF",
-	"iiialHAvi6nKHurEm+4SqjdbspqCXgmQl0Hrvkw6f4deecR28dgnsK+7MYZ9CTwxXsv8HXCdbuxGH5ve",
-	"xt8+Yy5n9f77T+eYVXuWj3nz7hqtOW9v/QNt2WoWayVxvkFE1NemscbTTMtJBsvZLLWPdNafLJulvqez",
-	"vqezFsI7L3DumtfqKVf+ufJcv+Zflwv79qQWFWJTaqAve/LxL5U+uX0c7JT5+/g99ffcqb+KKLsltT4+",
-	"c1YrdOPwe1bre1brxbJat9+U1lJPVVJU0zr8PcX1PcX1PcX1PcX1F0lxzcPr5UB1KY5vtURZ56LdDPXx",
-	"1oRFbyby4Ed4mH9t/61R++Ot2aK8byLbbs+SphO5eF5HS240/v8GAAD//2TCsdEzVQAA",
+	"H4sIAAAAAAAC/+w9aXPcNpZ/BcWZD3ZVW837UFVqS5bliTax5JXaye56XA6ORzXGbLKHQOuIV/99C+Dd",
+	"ZF9yy3YSzYeJJOJ4eO/h4d3+bNBsNs9SSKUwDj8bFCcJwfST/kXmOBWYSp6llxLLhf7j57/n8O8FCHkw",
+	"BcwgP/jvF8flpHd5cq+GzDMh1X8ZCJrzuZpvHBqXkEokMySngKpt0LuLn9HNFFK4hlx/ae2JcsB0CgJh",
+	"JPT26GbK6RRxgXKYZ7kEhq45rhc7QKcxaqCZZJ8gRTdYoCt+DekIcammCgUGFogAztWeehRP9eZHCznN",
+	"cv471tuX5ytWfQP5pwTe5lkW6zUFyFH3KHGWV3C+OT07eYVolkrMU6GHFfPRHMspyuLlox4YI6NE68uM",
+	"3WlKZKmEVOMRz+cJpxqo8b+EQuZnQ9ApzLD66e85xMah8bdxQ8lx8VWMJ80WFVqM+/v7YjOeAzMOZb4A",
+	"/Qcxz1IBmsa2afbpVy2gj58DBX4NDIkFpSBEvEiSO0OtLBazGc7vjEOjtXmFmMWcYQkKhPuRMcc5noGE",
+	"vMN5mmz93Y/0NiW5FKrTTPK4xEpDBEjZPOOpPECnEt3wJEEE0EIAUyTHgwTWqyl6TKWc1ysZI4OrfYsx",
+	"xshI8QyMQ2OJv4xRixLybq6GCJnz9EojgzY3o3+kVxDjRSIRyxYkASTmkDKEU4ZmJa9oZttwzs1wqr3X",
+	"Q6mIV1zwd5o+og/rr1OQU8jRDSAxzRYJQ1N8DUjN7NJWIN4Cs6ATesZTmiwYT6/Q5cnJ2cfTs4/nF29/",
+	"PDr7+Obkzdvz85/1sfWn87OPZyeTX88vfirXBfF8zSFf90AfOCrJsgRwqs86w7cTPoNsMSChyg/qBAJo",
+	"ljLFb+gGc1lwHNx0xFN5bgJxlgMqry+C2znPQaBnM3yLHLNaaYRYSW7v+erjvGmgGzgHTyVcQV6co5FH",
+	"q6klM1RgHjo8JSp5V0t79EyJAfR/KMaJgHUIb8nBDVwlPvH5a4BfcMIZLgDbzFVqEooB0HU9rWSiNTBd",
+	"9nbawARql0sNxwOgK4bsDGBvvy1gnNw+AL7sGnKcJEje7gzj5HZ7+NS1eJ3ljWKwDJx6qssr0r5FcZ7N",
+	"NPMJyNWjX18fuchTJSGe2egHdHFyfHL6y8mrEXLQD+hycn6hfnbRD+jo7Oz83dnxyauPk/NKVIyQp+f8",
+	"17uTy8nJq48v/6f54qv5J2eTzvBALXR8fPJ2eXSIfliWQ2uu668dHKy9sctP7Fkmq8cIWB99l0AXOZd3",
+	"qHyoZ5BKgWLME2AFe+it9FIvk4x+Uj/gJDmPjcP363WC42w2y9KLEhjjfvTZwIxxtTFO3ubZHHLJFZBa",
+	"FoyMeetPn40pFtM+uBoEpL+NDLjFs3mijm8u/y/03MCNiEMt22OeTf3Idfwg8FyXhtjHYejZbhA5HsEh",
+	"swJmjJblysiYAr+aypUgFF9bQASh7VjhyIizfIalcWgseCp91xj1aFQJ1YssG1i/VODyLJOVAkc04tsH",
+	"drEHFrgEYxJGsYMd27NCHIbUsQgNg9i3wjjw/cABm9IgwMS27IAEOGbAsOOQoQNn+XyKU2CrBUANi9Jv",
+	"1Xs7x3kNZJKlV+pdolPMU2PUu8qKwHDNs4X4cZC06q/VWtXIgZP3SG17oecRixJsgQ0OMI8ExMMhZjZY",
+	"2KcBYT52sIstH/uxPXTyeZ5REGLd0bWga95k0SFNoaMQgBQ1Sw1hQPDfYRVHqW/qvSR3hXZRH9ky3dAL",
+	"/O04S94eZ4t0gK3OFjMCuQa7fQyeNsdob+pYtrfNjh0V/71R3swOpTvsXt+rFr+1CfCh3iIj/wIqjfsP",
+	"96MCQy01Xxt/HXExzwbehreZ4MWj1DOEVh7csrdFNB9gl7YpcvpKbTKF2/byRhDZge04zDIZpszzTZ8C",
+	"eCQ2iR36fhhTK7IgcEzTjjAFlwYxdU1g4EXYDm0P+vy7RAIN2EgjpI/MPirF1xLoGtM/fmupnsKtPI9j",
+	"AQNXpPh7xSxqJJrjKxgVoi4HbdFz/TUHhHNAaYZmWd5hrKWra7tD7JQukgQTNUKpw4PstUQhLmEmNlnh",
+	"vWtyXy+N8xzf9XilocnSjsPX8FiJ9tM0zgaMdvUJcfXt+yR8AcWjven3A1dt6b70caa/o0pdQ+XMZfxJ",
+	"PgMh8WyufqkhUUboC/Vps0Co5w/Jg5M8z/IBN0iKfpxM3qK3eUYSmKFXIDFPRAnjCGGh7EyeAlNC7vRk",
+	"8hpdvD5GQWgG6JlycIjD8VhmWSIOOMj4IMuvxlM5S8Z5TNUgbfplKWwhdjSE71JFIp5eFeaN0NJn46zT",
+	"dL7YduwbnCjkAttu+Os8+x3St1nC6d0uM44VqVOxEMWV0l9eYnZRWPXbC+NiSQ4JK87X5RmmyaV+am7V",
+	"ZNo4DwTATJtLBNCsOrh2jlCcKnFHoKXK3CvDREicUuguWREa5/RAYpwc0Gw2BgWZGFu243qeryaL2nar",
+	"p7qmqa4Ml8nSki8xq6BsxNfQnoRLmvH0hbg+uOJyuiAHPFOAjP9WQvAfnP3w0TXNocu58ia8BnhsGsQA",
+	"Qr8eMstQkt18AXrtVdj1vWHsvobOtl+MXd/bDbsFrg5Xo6orhX5WNkWOWn9Ur7MGwBj10VopdR09T1QH",
+	"Ltm9dFRpuYUL/e9g6MGAW5nj4cfufF7oPEiP0a+ekspqO0yUY08BoaFsvBAznkK++ulv9m2zQnfbZ9W+",
+	"z9HPPP2kzoOpXOCk3CtLS1/HNtuIFf4UTSdEMwZtDLtmWy3mqRzWiSuGW/Y/l79dA5JwW7hnKiL2ANtK",
+	"sZZTLspT6yhNDLmaj2S2zdkrtl/a4m4ONXuN0A2XU5SUeNZaXovOmx9d9bXCSI3tUcXpq2/I0iPxiLJI",
+	"P46o2BA9IwmmnxIuJJrhFKtbRysgUP0N2PPHkFaBPSyt2hDuRVwF9m7iqv3Gf0NKzDUEj08G62uRwdqJ",
+	"DP+AFHJOH/VdbokXql3cX0cJioYxXp64FJJ7UYOinVBeKs5fCeNcIJ7qGAIiQPFCgH4yuQaiNLTTF3Cr",
+	"WD+VKsQg5pDKR1GcVoqiAj5eWRR70J12E0aNffL1qPKYpsFqEjjDJKgR0Nbv9kMJZydKnGXydbZI2Vcy",
+	"1kAZRCJb5BS6sinWQDyKXHKHSXCWyWbXL5dJ7k5oP1/I70AoZQu5UiqV4x/lUrjr5VIJ1n6uw250mdy+",
+	"Lg2Dr0YYdQF4qswvSJUpV+jlIzTjQigzQEvpMoAuHuWG+ObqG7IE1n5ospsbo+cu+7O/GdZXfzO21mFf",
+	"AxzNqqDcLgGTu8EMqSaQV0UKtwlXCSwzMeUD6xWwqet0WY15QOBPNHMLsIYwUeb0YDn9BfI62eyLAlBr",
+	"h9fhih1DVTRLY15a+uspoG5ZHUTtRMBRk4rWxImH4pDFn3IQKmeLC/TL0c+nr9q+F3+/OQUUJ3SRYKVW",
+	"1M6pVtJoe2eDED/2XZNSF8DzSeyEQDxsRX6EqUktn2JsM4fZpk1sm8RO4HjMMgGoSbEPprmNM6Y4eR/m",
+	"iwIjJcqu2wwzMiBdzBTbVcg6Pat+enf209n5r2fGh/Y5qo8PczZ9wyhuiZzREksOh+eGr1c7srD9FZjV",
+	"a63kJp1izFP08t2bt+VTh569vDh+EbjPERYKZ6g8XRt1/1yYpkNnrTWagfob/BEJ1cLXkORr+ZDW0aCG",
+	"+7PKHy1OqvJC5uV0T0dDdXKpvBX8KpsLqqS3qAa4duRGfmBHXj2omW2V0dSRMeMqA66MdZTvjKW/NO+E",
+	"dX/fY4ohmHrcgW/5bDGr0hfVUPRe7/Fhy7dq5elWbZXWAlnwqxTLRQ5IwY2rgPwOu24+WCc19wGna+N+",
+	"3fPV6A3LPDdEh9Vo6x+tDcRqbm0Hrr9GgkiD93V7VBHXJZSUk4cF41B1wtaHmvSKU3bWJmZzkDy9mtyK",
+	"TSJMlDKsl6dVVKQUS0ERm9AxkDYzVvUFAs8q51lbFr43iB8S03eJ4/gkwi4mjFiBEzvg2LEdECfEtm1T",
+	"YlumHXsWCWlke74DoeUTyyYuVuitE1H6qTXDL32ZeTIyriEXg/nFvxQfqqe+Tuqf47skwwwVlOjk1WwM",
+	"Ri2xR7X3hyGNfSlprNy1rgESq+qJshjhDgGW60+06dlmvmW98i+r9j5Av/3eNYBtNLQWtGVCzcPk0ILM",
+	"uGxJo5be0Mq2MoyltCezE9U2umpLkXbVxCoPbaUPtJKPDNu0nRem88KMJpZ9aDqHbnjghHZkmZ7l/m8d",
+	"7Tw0zn9Sv9yWAB4aS4nuRkVMg5rMjykElguubXu+5camaVIfe5gxjLHluBamhEQ0DCzLsyyX0Th0Yycg",
+	"keth3+ipKGui9idrgvUrWHPI71S4+LYwbNap0O112+q0Lh5r9OFl9fp9oV5/qDOsSE4PGFyPA/f5NiA1",
+	"NFkHUB2srqysyqYaGVW5hDEyiloJY2QMFUroof0qCTWtWyJhjAa4Q5c1GiPj+Pzs9enFG/3zxcl/nhxP",
+	"Tl51DbuqtmKfJoNPGKExJqZn+8wxIWR+aAdRHEQsjn0rJq5p+5hCSALi2EEY4di0fMfxwXNjOzYHBcam",
+	"V6cNFitFw4CYGdLWWlNbtl5LJOT4ZnJbW17tB6tnefXuUzm3Z5zjm659t15EFqtsBH4P+ub2D+OWsraS",
+	"0zu+pVvJoa8oRb6lGPnCm7nV61uD1H7iNj7CF+1Kqe9LGVuZAL5d0uwKDt7o+S9Q1EsaX4/Ghhu+Lxzu",
+	"RRn4K7zzTbykfHn/cP638iwbb3xHdV6PHT2yQVKXtValT172cGqb1sNTJyf6zx2Nh6nIoszQDGbzLEs2",
+	"IqgRw3qtPoZUdKisAr1UN6444EvdtkKVjg6US+hvCC/kFFJZ9QzolgSoagA/8MyqWlWHE/S8BmLF3UXJ",
+	"Ki8vacIplCpAWfx6PocUvbz8Bf2sPlGFjEWe9ON1WIiMcg3JQQpynM0hfUHE9YtyyXELy4Za7+ji2Gg5",
+	"JQzrwDww1SA1E8+5cWg4+k8jQ11ZjZTxtTXWFtW4qBUZfy7+q7uRXA3VFE10omrZRKEsPi4adZRNIRiW",
+	"uFtMiAsppJuJlDUpgzWWuoKi8nKeMpU2djLRlSwv736sSlnavS/e94Gr9qxLX3QBchkHKilQf+v28mhX",
+	"IW8RLvww3PpjL91H9KELRlq6jUXTEEVV17RWLVPDNe4WS+tZ7t6g7OYODUDbya5RGXt73bnKohzYeCnd",
+	"sNNc5R8gu7yJO5ypmFDiK8VdZX34BzW/uSifVXXXHu5HmaqPPqXZTark39HF8eobsAPnYzHtmjJDV6Co",
+	"UFt9AZZF8LfndnO/3NMqURrYealo5+muPfyutUtSNt+r8bKZsOMlWy5pr25Z4fy+gRxKPUgWSsfRxfEI",
+	"ZTmDHBgid2oNnqN5VWfdLqo+QJPlDXDdf6PQEVR1rSjG1dW2iOJUpQs1yY8LUXmT67lNFe9qETDp1uR+",
+	"U3EwWlmZ3sZYkY9RoD5LV6OuAurfC8jvGqgyjRBjx3d5tDmo2gFEZiUcReMhHTWuWw6p356vAC/hM74z",
+	"dI8uRztc8iRT/7z6ywpJt0bOaiVb8vkDxGpHkZd8Pqi/b1ZltONjwufGk+78h+a9gtySz9dwW+FFGus8",
+	"t7vVzS27PKdHc925cpukrF7AGl9hngrZzgHs9R2q2LhriS5tt4G50aST4vibzsf7rcp9bGUktoGYYam7",
+	"cq4DboR+Oz1rVuMSsQyKOJ2ejrK8PV+DW48o+2b2UjJxytBvZcxLL3t0cdzMUgdDuI0bLlrGUPcSvz2/",
+	"nKzIen2cRpzrcwC3b8n5iMD8KZ/Z70PgaDTfdaXBGpnTZFzt+L4VE5EAqfKaxODj9bbKNXs09lpKU9v/",
+	"GzYozJfO3sLuUU4b3MrbthDf3O54CLB61rg/pX/YLrm4KNrRygwJ3e8V5bjbVlRmCBdtCXTmmE7cES2b",
+	"RJtkhZiT0/IRKIbTHLBUlhuaNI6axsCqTLhqf9UoMuesqJ+6SjKCkxp9arO7bJGjo5wihsWUZDhnVSqb",
+	"6Optw/J10gnXLNl5Q2hthozbfXPvRxuH9xvYbjGp1Ql2i9H9bqZbTur1/dxy3uR2tznd/snbnL/VyXWL",
+	"4d1+n4UF+KgNq5vHYdRZMKMS5Ashc8Cz7sK14Up4ivO7xnBtJ3zArRzPE8yXgFpOhF+bjtFf+H5d4kjH",
+	"b7GPZ74EVs+AqlFUIxKXiw9HxjVOFtAuFNtXSWU3cw3n5fuHXN85REu/tlFarI1MVCW2t4Aw2nVoKqba",
+	"5L65vtNEi/rHLNPXsMn8CNssxiywzCAwgdmhTSk4lk+9ILJj3zIt7Iem62Pbd7AVYAuDafuBb1rt4ObO",
+	"Bcv/NMruzEUQsEOWSbfNYRMorKnzBUmCf7QEwSLjHthqFBWfV2Cn04YOYxpGMQFm+Q4w3zR9i6huqtTE",
+	"JGIQQhCzkDguZpFLbddyKVvGbuD4th2uR3EMnqv6uarmpqar/j9kURBHQIAxFsURxiGYEHkOcXDgx47l",
+	"21GoEtYgCh0X49CyAsuHiDlR4PkueKZl2l7su3qiZYPtY496oenQKI5cZlGbhoD9ECjElmt5pmWBRdU4",
+	"EtHI94mPmWmbthV7MXYi3wwodogbMs+hkWkT5hHiEhL7OMA0imgcxQy7HqW2RQILfLDjIAwj33RM28U2",
+	"IZblQ+g7tkcjEnqWHVsmsW1q2yFWOXV2DI6qJiMWYS6OsE8cxyWmHxLim7YihW8FkUPsIHRMR90xy4lM",
+	"Chg8HFgOAxMwYRFl2HcC044hdGlkh1FgYhoH1PXAtEwTe34ADjN9H5zQd0K1XBR4XuSYNmBCQw+IHxHb",
+	"tKkNoc9cxwkJJipbIYxVRe5jXIU6A6O4AF+csn/fNHHe6U3cUql+stV2sNVGhmvb+918aNd3aVlrrXKE",
+	"EKRS9fd+URSNdDs16iVQk42pmHqv4NW9AVa47AZK5VVl9V5h6LeO7MOysk5cdWnZKzRVS8o+DP0WM6ox",
+	"yV43b/W43AkHe3bkVp081iCh1c9CNRnc6/aq7G1g605vxI7pXySNdX2nB6sN//FnJby3zXloGepXlb94",
+	"keeQVrlnhdu2aqmd3LUjsl14ej6Yfo7mFoFQ2U3qe+bYutOADo0+3xgbrQuXv4dUicmA2+Qp0PFIgY5e",
+	"ZunmKzLulZw9KNDG0zJqvvma6H9USmv82s2v1mgC3R1oVvzrWevuWLe+7y971bpoeAqq/3kDm2uuzraP",
+	"pfi+3OSzRSL5PIFlb7nYh7v8O/OWiyd3+ZO7vBEWW/0TE0N+84F6oe/Lj/7P9GG+9i93mmNVrbGbd/b9",
+	"X8o9q8pzdoksvH8KLTx2aKEgym5O8/eP7DX3rdB/8po/ec2/mtf8wxe5zcWmSK2oenc8udCfXOhPLvQn",
+	"F/pfxIVem9fLhuqSd6BVk6yVi3Y18vsPyiw6mvMXP8Fd/Wv7H1zXf/wwMop/DaewbLtFwxLPedNiGOdU",
+	"Sfz/HwD3BVERpIAAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -9,15 +9,20 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/bitcoin-sv/arc/api"
 	"github.com/bitcoin-sv/arc/api/transaction_handler"
+	"github.com/bitcoin-sv/arc/blocktx"
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
 	"github.com/bitcoin-sv/arc/validator"
 	defaultValidator "github.com/bitcoin-sv/arc/validator/default"
 	"github.com/labstack/echo/v4"
 	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/opentracing/opentracing-go/log"
@@ -34,14 +39,35 @@ type ArcDefaultHandler struct {
 	NodePolicy         *bitcoin.Settings
 	logger             *slog.Logger
 	now                func() time.Time
+	callbackPolicy     *callbackpolicy.Policy
+	blockTxClient      blocktx.ClientI
+
+	// mu guards the policies, which can be replaced while the handler is serving requests
+	mu sync.RWMutex
 }
 
+var ErrBlockTxNotConfigured = errors.New("block queries are not available, blocktx client is not configured")
+
 func WithNow(nowFunc func() time.Time) func(*ArcDefaultHandler) {
 	return func(p *ArcDefaultHandler) {
 		p.now = nowFunc
 	}
 }
 
+// WithCallbackPolicy rejects transactions with callback URLs which are not allowed by the policy.
+func WithCallbackPolicy(policy *callbackpolicy.Policy) func(*ArcDefaultHandler) {
+	return func(p *ArcDefaultHandler) {
+		p.callbackPolicy = policy
+	}
+}
+
+// WithBlockTxClient enables the block and chain tip endpoints, which query the given blocktx client.
+func WithBlockTxClient(client blocktx.ClientI) func(*ArcDefaultHandler) {
+	return func(p *ArcDefaultHandler) {
+		p.blockTxClient = client
+	}
+}
+
 type Option func(f *ArcDefaultHandler)
 
 func NewDefault(logger *slog.Logger, transactionHandler transaction_handler.TransactionHandler, policy *bitcoin.Settings, opts ...Option) (api.ServerInterface, error) {
@@ -60,17 +86,48 @@ func NewDefault(logger *slog.Logger, transactionHandler transaction_handler.Tran
 	return handler, nil
 }
 
-func (m ArcDefaultHandler) GETPolicy(ctx echo.Context) error {
+// SetNodePolicy replaces the policy of the node which transactions are validated against.
+func (m *ArcDefaultHandler) SetNodePolicy(policy *bitcoin.Settings) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.NodePolicy = policy
+}
+
+// SetCallbackPolicy replaces the policy which callback URLs of transactions have to comply with.
+func (m *ArcDefaultHandler) SetCallbackPolicy(policy *callbackpolicy.Policy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.callbackPolicy = policy
+}
+
+func (m *ArcDefaultHandler) getNodePolicy() *bitcoin.Settings {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.NodePolicy
+}
+
+func (m *ArcDefaultHandler) getCallbackPolicy() *callbackpolicy.Policy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.callbackPolicy
+}
+
+func (m *ArcDefaultHandler) GETPolicy(ctx echo.Context) error {
 	span, _ := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:GETPolicy")
 	defer span.Finish()
 
-	satoshis, bytes := calcFeesFromBSVPerKB(m.NodePolicy.MinMiningTxFee)
+	nodePolicy := m.getNodePolicy()
+	satoshis, bytes := calcFeesFromBSVPerKB(nodePolicy.MinMiningTxFee)
 
 	return ctx.JSON(http.StatusOK, api.PolicyResponse{
 		Policy: api.Policy{
-			Maxscriptsizepolicy:     uint64(m.NodePolicy.MaxScriptSizePolicy),
-			Maxtxsigopscountspolicy: uint64(m.NodePolicy.MaxTxSigopsCountsPolicy),
-			Maxtxsizepolicy:         uint64(m.NodePolicy.MaxTxSizePolicy),
+			Maxscriptsizepolicy:     uint64(nodePolicy.MaxScriptSizePolicy),
+			Maxtxsigopscountspolicy: uint64(nodePolicy.MaxTxSigopsCountsPolicy),
+			Maxtxsizepolicy:         uint64(nodePolicy.MaxTxSizePolicy),
 			MiningFee: api.FeeAmount{
 				Bytes:    bytes,
 				Satoshis: satoshis,
@@ -96,11 +153,11 @@ func calcFeesFromBSVPerKB(feePerKB float64) (uint64, uint64) {
 }
 
 // POSTTransaction ...
-func (m ArcDefaultHandler) POSTTransaction(ctx echo.Context, params api.POSTTransactionParams) error {
+func (m *ArcDefaultHandler) POSTTransaction(ctx echo.Context, params api.POSTTransactionParams) error {
 	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:POSTTransactions")
 	defer span.Finish()
 
-	transactionOptions, err := getTransactionOptions(params)
+	transactionOptions, err := getTransactionOptions(params, m.getCallbackPolicy())
 	if err != nil {
 		e := api.NewErrorFields(api.ErrStatusBadRequest, err.Error())
 		span.SetTag(string(ext.Error), true)
@@ -177,7 +234,7 @@ func (m ArcDefaultHandler) POSTTransaction(ctx echo.Context, params api.POSTTran
 }
 
 // GETTransactionStatus ...
-func (m ArcDefaultHandler) GETTransactionStatus(ctx echo.Context, id string) error {
+func (m *ArcDefaultHandler) GETTransactionStatus(ctx echo.Context, id string) error {
 	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:GETTransactionStatus")
 	defer span.Finish()
 
@@ -215,13 +272,279 @@ func (m ArcDefaultHandler) GETTransactionStatus(ctx echo.Context, id string) err
 	})
 }
 
+// GETTransactionConfirmations ...
+func (m *ArcDefaultHandler) GETTransactionConfirmations(ctx echo.Context, id string) error {
+	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:GETTransactionConfirmations")
+	defer span.Finish()
+
+	span.SetTag("txid", id)
+
+	hash, err := chainhash.NewHashFromStr(id)
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusBadRequest, err)
+	}
+
+	if m.blockTxClient == nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, ErrBlockTxNotConfigured)
+	}
+
+	confirmations, err := m.blockTxClient.GetConfirmations(tracingCtx, hash[:])
+	if err != nil {
+		return m.blockQueryError(ctx, span, blockQueryStatus(err), err)
+	}
+
+	blockHash, err := chainhash.NewHash(confirmations.GetBlockHash())
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, err)
+	}
+
+	return ctx.JSON(http.StatusOK, api.TransactionConfirmations{
+		BlockHash:     PtrTo(blockHash.String()),
+		BlockHeight:   PtrTo(confirmations.GetBlockHeight()),
+		Confirmations: confirmations.GetConfirmations(),
+		Timestamp:     m.now(),
+		Txid:          hash.String(),
+	})
+}
+
+// GETBlock ...
+func (m *ArcDefaultHandler) GETBlock(ctx echo.Context, hashStr string) error {
+	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:GETBlock")
+	defer span.Finish()
+
+	span.SetTag("hash", hashStr)
+
+	hash, err := chainhash.NewHashFromStr(hashStr)
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusBadRequest, err)
+	}
+
+	if m.blockTxClient == nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, ErrBlockTxNotConfigured)
+	}
+
+	block, err := m.blockTxClient.GetBlock(tracingCtx, hash[:])
+	if err != nil {
+		return m.blockQueryError(ctx, span, blockQueryStatus(err), err)
+	}
+
+	return m.blockResponse(ctx, span, block)
+}
+
+// GETBlockByHeight ...
+func (m *ArcDefaultHandler) GETBlockByHeight(ctx echo.Context, height uint64) error {
+	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:GETBlockByHeight")
+	defer span.Finish()
+
+	span.SetTag("height", height)
+
+	if m.blockTxClient == nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, ErrBlockTxNotConfigured)
+	}
+
+	block, err := m.blockTxClient.GetBlockByHeight(tracingCtx, height)
+	if err != nil {
+		return m.blockQueryError(ctx, span, blockQueryStatus(err), err)
+	}
+
+	return m.blockResponse(ctx, span, block)
+}
+
+// GETChainTip ...
+func (m *ArcDefaultHandler) GETChainTip(ctx echo.Context) error {
+	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:GETChainTip")
+	defer span.Finish()
+
+	if m.blockTxClient == nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, ErrBlockTxNotConfigured)
+	}
+
+	block, err := m.blockTxClient.GetChainTip(tracingCtx)
+	if err != nil {
+		return m.blockQueryError(ctx, span, blockQueryStatus(err), err)
+	}
+
+	return m.blockResponse(ctx, span, block)
+}
+
+// GETBlockTransactions ...
+func (m *ArcDefaultHandler) GETBlockTransactions(ctx echo.Context, hashStr string, params api.GETBlockTransactionsParams) error {
+	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:GETBlockTransactions")
+	defer span.Finish()
+
+	span.SetTag("hash", hashStr)
+
+	hash, err := chainhash.NewHashFromStr(hashStr)
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusBadRequest, err)
+	}
+
+	if m.blockTxClient == nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, ErrBlockTxNotConfigured)
+	}
+
+	var offset, limit uint64
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	blockTransactions, err := m.blockTxClient.GetBlockTransactions(tracingCtx, hash[:], offset, limit)
+	if err != nil {
+		return m.blockQueryError(ctx, span, blockQueryStatus(err), err)
+	}
+
+	response := api.BlockTransactions{
+		BlockHash:    hash.String(),
+		Timestamp:    m.now(),
+		Transactions: make([]api.BlockTransaction, 0, len(blockTransactions.GetTransactions())),
+	}
+
+	for _, tx := range blockTransactions.GetTransactions() {
+		txHash, err := chainhash.NewHash(tx.GetHash())
+		if err != nil {
+			return m.blockQueryError(ctx, span, api.ErrStatusGeneric, err)
+		}
+
+		response.Transactions = append(response.Transactions, api.BlockTransaction{
+			Txid: txHash.String(),
+			Pos:  tx.GetPos(),
+		})
+	}
+
+	if blockTransactions.GetNextOffset() > 0 {
+		response.NextOffset = PtrTo(blockTransactions.GetNextOffset())
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// POSTMerklePathVerification ...
+func (m *ArcDefaultHandler) POSTMerklePathVerification(ctx echo.Context) error {
+	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:POSTMerklePathVerification")
+	defer span.Finish()
+
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusBadRequest, err)
+	}
+
+	var request api.MerklePathVerificationRequest
+	if err = json.Unmarshal(body, &request); err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusBadRequest, err)
+	}
+
+	span.SetTag("txid", request.Txid)
+
+	hash, err := chainhash.NewHashFromStr(request.Txid)
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusBadRequest, err)
+	}
+
+	if request.MerklePath == "" {
+		return m.blockQueryError(ctx, span, api.ErrStatusBadRequest, blocktx.ErrInvalidMerklePath)
+	}
+
+	if m.blockTxClient == nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, ErrBlockTxNotConfigured)
+	}
+
+	verification, err := m.blockTxClient.VerifyMerklePath(tracingCtx, request.MerklePath, hash[:])
+	if err != nil {
+		if errors.Is(err, blocktx.ErrInvalidMerklePath) {
+			return m.blockQueryError(ctx, span, api.ErrStatusBadRequest, err)
+		}
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, err)
+	}
+
+	response := api.MerklePathVerification{
+		BlockHeight:   PtrTo(verification.GetBlockHeight()),
+		Confirmations: verification.GetConfirmations(),
+		Timestamp:     m.now(),
+		Txid:          hash.String(),
+	}
+
+	switch verification.GetResult() {
+	case blocktx_api.MerklePathVerificationResult_VALID:
+		response.Result = api.MerklePathVerificationResultVALID
+	case blocktx_api.MerklePathVerificationResult_INVALID:
+		response.Result = api.MerklePathVerificationResultINVALID
+	default:
+		response.Result = api.MerklePathVerificationResultUNKNOWN
+	}
+
+	if len(verification.GetBlockHash()) > 0 {
+		blockHash, err := chainhash.NewHash(verification.GetBlockHash())
+		if err != nil {
+			return m.blockQueryError(ctx, span, api.ErrStatusGeneric, err)
+		}
+		response.BlockHash = PtrTo(blockHash.String())
+	}
+
+	if len(verification.GetMerkleRoot()) > 0 {
+		merkleRoot, err := chainhash.NewHash(verification.GetMerkleRoot())
+		if err != nil {
+			return m.blockQueryError(ctx, span, api.ErrStatusGeneric, err)
+		}
+		response.MerkleRoot = PtrTo(merkleRoot.String())
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (m *ArcDefaultHandler) blockResponse(ctx echo.Context, span opentracing.Span, block *blocktx_api.Block) error {
+	hash, err := chainhash.NewHash(block.GetHash())
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, err)
+	}
+
+	previousHash, err := chainhash.NewHash(block.GetPreviousHash())
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, err)
+	}
+
+	merkleRoot, err := chainhash.NewHash(block.GetMerkleRoot())
+	if err != nil {
+		return m.blockQueryError(ctx, span, api.ErrStatusGeneric, err)
+	}
+
+	return ctx.JSON(http.StatusOK, api.Block{
+		Hash:         hash.String(),
+		PreviousHash: previousHash.String(),
+		MerkleRoot:   merkleRoot.String(),
+		Height:       block.GetHeight(),
+		Orphaned:     block.GetOrphaned(),
+		Processed:    block.GetProcessed(),
+		Size:         PtrTo(block.GetSize()),
+		TxCount:      PtrTo(block.GetTxCount()),
+		Timestamp:    m.now(),
+	})
+}
+
+func (m *ArcDefaultHandler) blockQueryError(ctx echo.Context, span opentracing.Span, status api.StatusCode, err error) error {
+	e := api.NewErrorFields(status, err.Error())
+	span.SetTag(string(ext.Error), true)
+	span.LogFields(log.Error(err))
+	return ctx.JSON(e.Status, e)
+}
+
+func blockQueryStatus(err error) api.StatusCode {
+	if errors.Is(err, blocktx.ErrBlockNotFound) || errors.Is(err, blocktx.ErrTransactionNotFound) {
+		return api.ErrStatusNotFound
+	}
+
+	return api.ErrStatusGeneric
+}
+
 // POSTTransactions ...
-func (m ArcDefaultHandler) POSTTransactions(ctx echo.Context, params api.POSTTransactionsParams) error {
+func (m *ArcDefaultHandler) POSTTransactions(ctx echo.Context, params api.POSTTransactionsParams) error {
 	span, tracingCtx := opentracing.StartSpanFromContext(ctx.Request().Context(), "ArcDefaultHandler:POSTTransactions")
 	defer span.Finish()
 
 	// set the globals for all transactions in this request
-	transactionOptions, err := getTransactionsOptions(params)
+	transactionOptions, err := getTransactionsOptions(params, m.getCallbackPolicy())
 	if err != nil {
 		e := api.NewErrorFields(api.ErrStatusBadRequest, err.Error())
 		span.SetTag(string(ext.Error), true)
@@ -363,11 +686,11 @@ func (m ArcDefaultHandler) POSTTransactions(ctx echo.Context, params api.POSTTra
 	return ctx.JSON(int(status), transactions)
 }
 
-func getTransactionOptions(params api.POSTTransactionParams) (*api.TransactionOptions, error) {
-	return getTransactionsOptions(api.POSTTransactionsParams(params))
+func getTransactionOptions(params api.POSTTransactionParams, callbackPolicy *callbackpolicy.Policy) (*api.TransactionOptions, error) {
+	return getTransactionsOptions(api.POSTTransactionsParams(params), callbackPolicy)
 }
 
-func getTransactionsOptions(params api.POSTTransactionsParams) (*api.TransactionOptions, error) {
+func getTransactionsOptions(params api.POSTTransactionsParams, callbackPolicy *callbackpolicy.Policy) (*api.TransactionOptions, error) {
 	transactionOptions := &api.TransactionOptions{}
 	if params.XCallbackUrl != nil {
 		_, err := url.ParseRequestURI(*params.XCallbackUrl)
@@ -375,6 +698,11 @@ func getTransactionsOptions(params api.POSTTransactionsParams) (*api.Transaction
 			return nil, fmt.Errorf("invalid callback URL [%w]", err)
 		}
 
+		err = callbackPolicy.Validate(*params.XCallbackUrl)
+		if err != nil {
+			return nil, fmt.Errorf("callback URL not allowed [%w]", err)
+		}
+
 		transactionOptions.CallbackURL = *params.XCallbackUrl
 		if params.XCallbackToken != nil {
 			transactionOptions.CallbackToken = *params.XCallbackToken
@@ -414,11 +742,11 @@ func getTransactionsOptions(params api.POSTTransactionsParams) (*api.Transaction
 	return transactionOptions, nil
 }
 
-func (m ArcDefaultHandler) processTransaction(ctx context.Context, transaction *bt.Tx, transactionOptions *api.TransactionOptions) (api.StatusCode, interface{}, error) {
+func (m *ArcDefaultHandler) processTransaction(ctx context.Context, transaction *bt.Tx, transactionOptions *api.TransactionOptions) (api.StatusCode, interface{}, error) {
 	span, tracingCtx := opentracing.StartSpanFromContext(ctx, "ArcDefaultHandler:processTransaction")
 	defer span.Finish()
 
-	txValidator := defaultValidator.New(m.NodePolicy)
+	txValidator := defaultValidator.New(m.getNodePolicy())
 
 	// the validator expects an extended transaction
 	// we must enrich the transaction with the missing data
@@ -473,7 +801,7 @@ func (m ArcDefaultHandler) processTransaction(ctx context.Context, transaction *
 }
 
 // processTransactions validates all the transactions in the array and submits to metamorph for processing.
-func (m ArcDefaultHandler) processTransactions(ctx context.Context, transactions []*bt.Tx, transactionOptions *api.TransactionOptions) (api.StatusCode, []interface{}, error) {
+func (m *ArcDefaultHandler) processTransactions(ctx context.Context, transactions []*bt.Tx, transactionOptions *api.TransactionOptions) (api.StatusCode, []interface{}, error) {
 	span, tracingCtx := opentracing.StartSpanFromContext(ctx, "ArcDefaultHandler:processTransactions")
 	defer span.Finish()
 
@@ -482,7 +810,7 @@ func (m ArcDefaultHandler) processTransactions(ctx context.Context, transactions
 	txErrors := make([]interface{}, 0, len(transactions))
 
 	for _, transaction := range transactions {
-		txValidator := defaultValidator.New(m.NodePolicy)
+		txValidator := defaultValidator.New(m.getNodePolicy())
 
 		// the validator expects an extended transaction
 		// we must enrich the transaction with the missing data
@@ -540,7 +868,7 @@ func (m ArcDefaultHandler) processTransactions(ctx context.Context, transactions
 	return api.StatusOK, transactionOutput, nil
 }
 
-func (m ArcDefaultHandler) extendTransaction(ctx context.Context, transaction *bt.Tx) (err error) {
+func (m *ArcDefaultHandler) extendTransaction(ctx context.Context, transaction *bt.Tx) (err error) {
 	parentTxBytes := make(map[string][]byte)
 	var btParentTx *bt.Tx
 
@@ -573,7 +901,7 @@ func (m ArcDefaultHandler) extendTransaction(ctx context.Context, transaction *b
 	return nil
 }
 
-func (m ArcDefaultHandler) getTransactionStatus(ctx context.Context, id string) (*transaction_handler.TransactionStatus, error) {
+func (m *ArcDefaultHandler) getTransactionStatus(ctx context.Context, id string) (*transaction_handler.TransactionStatus, error) {
 	tx, err := m.TransactionHandler.GetTransactionStatus(ctx, id)
 	if err != nil {
 		return nil, err
@@ -582,7 +910,7 @@ func (m ArcDefaultHandler) getTransactionStatus(ctx context.Context, id string)
 	return tx, nil
 }
 
-func (ArcDefaultHandler) handleError(_ context.Context, transaction *bt.Tx, submitErr error) (api.StatusCode, *api.ErrorFields) {
+func (*ArcDefaultHandler) handleError(_ context.Context, transaction *bt.Tx, submitErr error) (api.StatusCode, *api.ErrorFields) {
 	if submitErr == nil {
 		return api.StatusOK, nil
 	}
@@ -608,7 +936,7 @@ func (ArcDefaultHandler) handleError(_ context.Context, transaction *bt.Tx, subm
 }
 
 // getTransaction returns the transaction with the given id from a store.
-func (m ArcDefaultHandler) getTransaction(ctx context.Context, inputTxID string) ([]byte, error) {
+func (m *ArcDefaultHandler) getTransaction(ctx context.Context, inputTxID string) ([]byte, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "ArcDefaultHandler:getTransaction")
 	defer span.Finish()
 
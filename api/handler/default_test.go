@@ -19,10 +19,14 @@ import (
 	"github.com/bitcoin-sv/arc/api"
 	"github.com/bitcoin-sv/arc/api/handler/mock"
 	"github.com/bitcoin-sv/arc/api/transaction_handler"
+	"github.com/bitcoin-sv/arc/blocktx"
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
 	"github.com/bitcoin-sv/arc/validator"
 	"github.com/labstack/echo/v4"
 	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
 	"github.com/ordishs/go-bitcoin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -77,6 +81,7 @@ var (
 )
 
 //go:generate moq -pkg mock -out ./mock/transaction_handler_mock.go ../transaction_handler/ TransactionHandler
+//go:generate moq -pkg mock -out ./mock/blocktx_client_mock.go ../../blocktx/ ClientI
 
 func TestNewDefault(t *testing.T) {
 	t.Run("simple init", func(t *testing.T) {
@@ -112,6 +117,31 @@ func TestGETPolicy(t *testing.T) {
 		assert.Equal(t, uint64(100000000), policyResponse.Policy.Maxtxsizepolicy)
 		assert.False(t, policyResponse.Timestamp.IsZero())
 	})
+
+	t.Run("replaced policy", func(t *testing.T) {
+		defaultHandler, err := NewDefault(testLogger, nil, defaultPolicy)
+		require.NoError(t, err)
+
+		replacedPolicy := *defaultPolicy
+		replacedPolicy.MaxTxSizePolicy = 1000
+		defaultHandler.(*ArcDefaultHandler).SetNodePolicy(&replacedPolicy)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/v1/policy", strings.NewReader(""))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err = defaultHandler.GETPolicy(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var policyResponse api.PolicyResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &policyResponse))
+
+		assert.Equal(t, uint64(1000), policyResponse.Policy.Maxtxsizepolicy)
+		assert.Equal(t, uint64(100000000), policyResponse.Policy.Maxscriptsizepolicy)
+	})
 }
 
 func TestGETTransactionStatus(t *testing.T) {
@@ -207,6 +237,432 @@ func TestGETTransactionStatus(t *testing.T) {
 	}
 }
 
+func TestGETBlock(t *testing.T) {
+	blockHash := "0000000000000000025855b1cba1e2e3ed5b7b5a8ad2e1a6c7bd6a3a4a16a6f2"
+	hash, _ := chainhash.NewHashFromStr(blockHash)
+	previousHash, _ := chainhash.NewHashFromStr("000000000000000001d29fef90f0f86eb7a99f043b994c7e363e0aa72db05862")
+	merkleRoot, _ := chainhash.NewHashFromStr("4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b")
+	now := time.Date(2023, 5, 3, 10, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name         string
+		hash         string
+		noBlockTx    bool
+		getBlockResp *blocktx_api.Block
+		getBlockErr  error
+
+		expectedStatus   api.StatusCode
+		expectedResponse any
+	}{
+		{
+			name: "success",
+			hash: blockHash,
+			getBlockResp: &blocktx_api.Block{
+				Hash:         hash[:],
+				PreviousHash: previousHash[:],
+				MerkleRoot:   merkleRoot[:],
+				Height:       826481,
+				Processed:    true,
+				Size:         1000,
+				TxCount:      5,
+			},
+
+			expectedStatus: api.StatusOK,
+			expectedResponse: api.Block{
+				Hash:         blockHash,
+				PreviousHash: previousHash.String(),
+				MerkleRoot:   merkleRoot.String(),
+				Height:       826481,
+				Processed:    true,
+				Size:         PtrTo(uint64(1000)),
+				TxCount:      PtrTo(uint64(5)),
+				Timestamp:    now,
+			},
+		},
+		{
+			name: "error - invalid hash",
+			hash: "nothash",
+
+			expectedStatus:   api.ErrStatusBadRequest,
+			expectedResponse: *api.NewErrorFields(api.ErrStatusBadRequest, "encoding/hex: invalid byte: U+006E 'n'"),
+		},
+		{
+			name:        "error - block not found",
+			hash:        blockHash,
+			getBlockErr: blocktx.ErrBlockNotFound,
+
+			expectedStatus:   api.ErrStatusNotFound,
+			expectedResponse: *api.NewErrorFields(api.ErrStatusNotFound, "block not found"),
+		},
+		{
+			name:        "error - generic",
+			hash:        blockHash,
+			getBlockErr: errors.New("some error"),
+
+			expectedStatus:   api.ErrStatusGeneric,
+			expectedResponse: *api.NewErrorFields(api.ErrStatusGeneric, "some error"),
+		},
+		{
+			name:      "error - blocktx not configured",
+			hash:      blockHash,
+			noBlockTx: true,
+
+			expectedStatus:   api.ErrStatusGeneric,
+			expectedResponse: *api.NewErrorFields(api.ErrStatusGeneric, ErrBlockTxNotConfigured.Error()),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, ctx := createEchoGetRequest("/v1/block/" + tc.hash)
+
+			blockTxClient := &mock.ClientIMock{
+				GetBlockFunc: func(ctx context.Context, hash []byte) (*blocktx_api.Block, error) {
+					return tc.getBlockResp, tc.getBlockErr
+				},
+			}
+
+			opts := []Option{WithNow(func() time.Time { return now })}
+			if !tc.noBlockTx {
+				opts = append(opts, WithBlockTxClient(blockTxClient))
+			}
+
+			defaultHandler, err := NewDefault(testLogger, nil, nil, opts...)
+			require.NoError(t, err)
+
+			err = defaultHandler.GETBlock(ctx, tc.hash)
+			require.NoError(t, err)
+
+			assert.Equal(t, int(tc.expectedStatus), rec.Code)
+
+			switch v := tc.expectedResponse.(type) {
+			case api.Block:
+				var block api.Block
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &block))
+				assert.Equal(t, tc.expectedResponse, block)
+			case api.ErrorFields:
+				var blockErr api.ErrorFields
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &blockErr))
+				assert.Equal(t, tc.expectedResponse, blockErr)
+			default:
+				require.Fail(t, fmt.Sprintf("response type %T does not match any valid types", v))
+			}
+		})
+	}
+}
+
+func TestGETChainTip(t *testing.T) {
+	hash, _ := chainhash.NewHashFromStr("0000000000000000025855b1cba1e2e3ed5b7b5a8ad2e1a6c7bd6a3a4a16a6f2")
+
+	tt := []struct {
+		name         string
+		chainTipResp *blocktx_api.Block
+		chainTipErr  error
+
+		expectedStatus api.StatusCode
+		expectedHeight uint64
+	}{
+		{
+			name:         "success",
+			chainTipResp: &blocktx_api.Block{Hash: hash[:], PreviousHash: hash[:], MerkleRoot: hash[:], Height: 826481},
+
+			expectedStatus: api.StatusOK,
+			expectedHeight: 826481,
+		},
+		{
+			name:        "error - no blocks",
+			chainTipErr: blocktx.ErrBlockNotFound,
+
+			expectedStatus: api.ErrStatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, ctx := createEchoGetRequest("/v1/chaintip")
+
+			blockTxClient := &mock.ClientIMock{
+				GetChainTipFunc: func(ctx context.Context) (*blocktx_api.Block, error) {
+					return tc.chainTipResp, tc.chainTipErr
+				},
+			}
+
+			defaultHandler, err := NewDefault(testLogger, nil, nil, WithBlockTxClient(blockTxClient))
+			require.NoError(t, err)
+
+			err = defaultHandler.GETChainTip(ctx)
+			require.NoError(t, err)
+
+			assert.Equal(t, int(tc.expectedStatus), rec.Code)
+
+			if tc.expectedStatus != api.StatusOK {
+				return
+			}
+
+			var block api.Block
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &block))
+			assert.Equal(t, tc.expectedHeight, block.Height)
+			assert.Equal(t, hash.String(), block.Hash)
+		})
+	}
+}
+
+func TestGETBlockTransactions(t *testing.T) {
+	blockHash := "0000000000000000025855b1cba1e2e3ed5b7b5a8ad2e1a6c7bd6a3a4a16a6f2"
+	txHash, _ := chainhash.NewHashFromStr(validTxID)
+
+	tt := []struct {
+		name             string
+		params           api.GETBlockTransactionsParams
+		transactionsResp *blocktx_api.BlockTransactions
+		transactionsErr  error
+
+		expectedStatus     api.StatusCode
+		expectedOffset     uint64
+		expectedLimit      uint64
+		expectedNextOffset *uint64
+		expectedTxs        []api.BlockTransaction
+	}{
+		{
+			name:   "success - next page",
+			params: api.GETBlockTransactionsParams{Offset: PtrTo(uint64(10)), Limit: PtrTo(uint64(1))},
+			transactionsResp: &blocktx_api.BlockTransactions{
+				Transactions: []*blocktx_api.BlockTransaction{{Hash: txHash[:], Pos: 12}},
+				NextOffset:   20,
+			},
+
+			expectedStatus:     api.StatusOK,
+			expectedOffset:     10,
+			expectedLimit:      1,
+			expectedNextOffset: PtrTo(uint64(20)),
+			expectedTxs:        []api.BlockTransaction{{Txid: validTxID, Pos: 12}},
+		},
+		{
+			name:             "success - no transactions",
+			transactionsResp: &blocktx_api.BlockTransactions{},
+
+			expectedStatus: api.StatusOK,
+			expectedTxs:    []api.BlockTransaction{},
+		},
+		{
+			name:            "error - block not found",
+			transactionsErr: blocktx.ErrBlockNotFound,
+
+			expectedStatus: api.ErrStatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, ctx := createEchoGetRequest("/v1/block/" + blockHash + "/transactions")
+
+			blockTxClient := &mock.ClientIMock{
+				GetBlockTransactionsFunc: func(ctx context.Context, hash []byte, offset uint64, limit uint64) (*blocktx_api.BlockTransactions, error) {
+					return tc.transactionsResp, tc.transactionsErr
+				},
+			}
+
+			defaultHandler, err := NewDefault(testLogger, nil, nil, WithBlockTxClient(blockTxClient))
+			require.NoError(t, err)
+
+			err = defaultHandler.GETBlockTransactions(ctx, blockHash, tc.params)
+			require.NoError(t, err)
+
+			assert.Equal(t, int(tc.expectedStatus), rec.Code)
+
+			if tc.expectedStatus != api.StatusOK {
+				return
+			}
+
+			require.Len(t, blockTxClient.GetBlockTransactionsCalls(), 1)
+			assert.Equal(t, tc.expectedOffset, blockTxClient.GetBlockTransactionsCalls()[0].Offset)
+			assert.Equal(t, tc.expectedLimit, blockTxClient.GetBlockTransactionsCalls()[0].Limit)
+
+			var response api.BlockTransactions
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, blockHash, response.BlockHash)
+			assert.Equal(t, tc.expectedNextOffset, response.NextOffset)
+			assert.Equal(t, tc.expectedTxs, response.Transactions)
+		})
+	}
+}
+
+func TestGETTransactionConfirmations(t *testing.T) {
+	blockHash, _ := chainhash.NewHashFromStr("0000000000000000025855b1cba1e2e3ed5b7b5a8ad2e1a6c7bd6a3a4a16a6f2")
+	txHash, _ := chainhash.NewHashFromStr(validTxID)
+
+	tt := []struct {
+		name              string
+		confirmationsResp *blocktx_api.Confirmations
+		confirmationsErr  error
+
+		expectedStatus   api.StatusCode
+		expectedResponse any
+	}{
+		{
+			name: "success",
+			confirmationsResp: &blocktx_api.Confirmations{
+				TransactionHash: txHash[:],
+				BlockHash:       blockHash[:],
+				BlockHeight:     826481,
+				Confirmations:   3,
+			},
+
+			expectedStatus: api.StatusOK,
+			expectedResponse: api.TransactionConfirmations{
+				BlockHash:     PtrTo(blockHash.String()),
+				BlockHeight:   PtrTo(uint64(826481)),
+				Confirmations: 3,
+				Timestamp:     time.Date(2023, 5, 3, 10, 0, 0, 0, time.UTC),
+				Txid:          validTxID,
+			},
+		},
+		{
+			name:             "error - transaction not found",
+			confirmationsErr: blocktx.ErrTransactionNotFound,
+
+			expectedStatus:   api.ErrStatusNotFound,
+			expectedResponse: *api.NewErrorFields(api.ErrStatusNotFound, "transaction not found"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, ctx := createEchoGetRequest("/v1/tx/" + validTxID + "/confirmations")
+
+			blockTxClient := &mock.ClientIMock{
+				GetConfirmationsFunc: func(ctx context.Context, hash []byte) (*blocktx_api.Confirmations, error) {
+					return tc.confirmationsResp, tc.confirmationsErr
+				},
+			}
+
+			defaultHandler, err := NewDefault(testLogger, nil, nil, WithBlockTxClient(blockTxClient), WithNow(func() time.Time { return time.Date(2023, 5, 3, 10, 0, 0, 0, time.UTC) }))
+			require.NoError(t, err)
+
+			err = defaultHandler.GETTransactionConfirmations(ctx, validTxID)
+			require.NoError(t, err)
+
+			assert.Equal(t, int(tc.expectedStatus), rec.Code)
+
+			switch v := tc.expectedResponse.(type) {
+			case api.TransactionConfirmations:
+				var confirmations api.TransactionConfirmations
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &confirmations))
+				assert.Equal(t, tc.expectedResponse, confirmations)
+			case api.ErrorFields:
+				var confirmationsErr api.ErrorFields
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &confirmationsErr))
+				assert.Equal(t, tc.expectedResponse, confirmationsErr)
+			default:
+				require.Fail(t, fmt.Sprintf("response type %T does not match any valid types", v))
+			}
+		})
+	}
+}
+
+func TestPOSTMerklePathVerification(t *testing.T) {
+	blockHash, _ := chainhash.NewHashFromStr("0000000000000000025855b1cba1e2e3ed5b7b5a8ad2e1a6c7bd6a3a4a16a6f2")
+	merkleRoot, _ := chainhash.NewHashFromStr("bb6f640cc4ee56bf38eb5a1969ac0c16caa2d3d202b22bf3735d10eec0ca6e00")
+
+	tt := []struct {
+		name             string
+		body             string
+		verificationResp *blocktx_api.MerklePathVerification
+		verificationErr  error
+
+		expectedStatus   api.StatusCode
+		expectedResponse any
+	}{
+		{
+			name: "valid",
+			body: `{"txid": "` + validTxID + `", "merklePath": "fe716c0c00"}`,
+			verificationResp: &blocktx_api.MerklePathVerification{
+				Result:        blocktx_api.MerklePathVerificationResult_VALID,
+				BlockHeight:   826481,
+				BlockHash:     blockHash[:],
+				MerkleRoot:    merkleRoot[:],
+				Confirmations: 3,
+			},
+
+			expectedStatus: api.StatusOK,
+			expectedResponse: api.MerklePathVerification{
+				BlockHash:     PtrTo(blockHash.String()),
+				BlockHeight:   PtrTo(uint64(826481)),
+				MerkleRoot:    PtrTo(merkleRoot.String()),
+				Confirmations: 3,
+				Result:        api.MerklePathVerificationResultVALID,
+				Timestamp:     time.Date(2023, 5, 3, 10, 0, 0, 0, time.UTC),
+				Txid:          validTxID,
+			},
+		},
+		{
+			name: "unknown",
+			body: `{"txid": "` + validTxID + `", "merklePath": "fe716c0c00"}`,
+			verificationResp: &blocktx_api.MerklePathVerification{
+				Result:      blocktx_api.MerklePathVerificationResult_UNKNOWN,
+				BlockHeight: 826481,
+			},
+
+			expectedStatus: api.StatusOK,
+			expectedResponse: api.MerklePathVerification{
+				BlockHeight: PtrTo(uint64(826481)),
+				Result:      api.MerklePathVerificationResultUNKNOWN,
+				Timestamp:   time.Date(2023, 5, 3, 10, 0, 0, 0, time.UTC),
+				Txid:        validTxID,
+			},
+		},
+		{
+			name: "error - invalid txid",
+			body: `{"txid": "invalid", "merklePath": "fe716c0c00"}`,
+
+			expectedStatus:   api.ErrStatusBadRequest,
+			expectedResponse: *api.NewErrorFields(api.ErrStatusBadRequest, "encoding/hex: invalid byte: U+0069 'i'"),
+		},
+		{
+			name:            "error - invalid merkle path",
+			body:            `{"txid": "` + validTxID + `", "merklePath": "fe716c0c00"}`,
+			verificationErr: blocktx.ErrInvalidMerklePath,
+
+			expectedStatus:   api.ErrStatusBadRequest,
+			expectedResponse: *api.NewErrorFields(api.ErrStatusBadRequest, "invalid Merkle path"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, ctx := createEchoPostRequest(strings.NewReader(tc.body), echo.MIMEApplicationJSON, "/v1/merkle/verify")
+
+			blockTxClient := &mock.ClientIMock{
+				VerifyMerklePathFunc: func(ctx context.Context, merklePath string, hash []byte) (*blocktx_api.MerklePathVerification, error) {
+					require.Equal(t, "fe716c0c00", merklePath)
+					return tc.verificationResp, tc.verificationErr
+				},
+			}
+
+			defaultHandler, err := NewDefault(testLogger, nil, nil, WithBlockTxClient(blockTxClient), WithNow(func() time.Time { return time.Date(2023, 5, 3, 10, 0, 0, 0, time.UTC) }))
+			require.NoError(t, err)
+
+			err = defaultHandler.POSTMerklePathVerification(ctx)
+			require.NoError(t, err)
+
+			assert.Equal(t, int(tc.expectedStatus), rec.Code)
+
+			switch v := tc.expectedResponse.(type) {
+			case api.MerklePathVerification:
+				var verification api.MerklePathVerification
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &verification))
+				assert.Equal(t, tc.expectedResponse, verification)
+			case api.ErrorFields:
+				var verificationErr api.ErrorFields
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &verificationErr))
+				assert.Equal(t, tc.expectedResponse, verificationErr)
+			default:
+				require.Fail(t, fmt.Sprintf("response type %T does not match any valid types", v))
+			}
+		})
+	}
+}
+
 func TestPOSTTransaction(t *testing.T) { //nolint:funlen
 	errFieldMissingInputs := *api.NewErrorFields(api.ErrStatusTxFormat, "parent transaction not found")
 	errFieldMissingInputs.Txid = PtrTo("a147cc3c71cc13b29f18273cf50ffeb59fc9758152e2b33e21a8092f0b049118")
@@ -680,9 +1136,13 @@ func TestArcDefaultHandler_extendTransaction(t *testing.T) {
 }
 
 func TestGetTransactionOptions(t *testing.T) {
+	restrictivePolicy, err := callbackpolicy.New(callbackpolicy.WithBlockPrivateIPs(true))
+	require.NoError(t, err)
+
 	tt := []struct {
-		name   string
-		params api.POSTTransactionParams
+		name           string
+		params         api.POSTTransactionParams
+		callbackPolicy *callbackpolicy.Policy
 
 		expectedErrorStr string
 		expectedOptions  *api.TransactionOptions
@@ -713,6 +1173,26 @@ func TestGetTransactionOptions(t *testing.T) {
 
 			expectedErrorStr: "invalid callback URL",
 		},
+		{
+			name: "callback url allowed by policy",
+			params: api.POSTTransactionParams{
+				XCallbackUrl: PtrTo("https://api.callme.com"),
+			},
+			callbackPolicy: restrictivePolicy,
+
+			expectedOptions: &api.TransactionOptions{
+				CallbackURL: "https://api.callme.com",
+			},
+		},
+		{
+			name: "callback url not allowed by policy",
+			params: api.POSTTransactionParams{
+				XCallbackUrl: PtrTo("http://169.254.169.254/latest/meta-data"),
+			},
+			callbackPolicy: restrictivePolicy,
+
+			expectedErrorStr: "callback URL not allowed",
+		},
 		{
 			name: "merkle proof - true",
 			params: api.POSTTransactionParams{
@@ -777,7 +1257,7 @@ func TestGetTransactionOptions(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			options, err := getTransactionOptions(tc.params)
+			options, err := getTransactionOptions(tc.params, tc.callbackPolicy)
 
 			if tc.expectedErrorStr != "" || err != nil {
 				require.ErrorContains(t, err, tc.expectedErrorStr)
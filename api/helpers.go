@@ -4,6 +4,10 @@ import (
 	"github.com/libsv/go-bt/v2"
 )
 
+// CallbackPayloadVersion is the version of the TransactionCallback payload sent to callback URLs.
+// It has to be increased whenever the payload changes in a way which is not backwards compatible.
+const CallbackPayloadVersion = 1
+
 func FeesToBtFeeQuote(minMiningFee float64) *bt.FeeQuote {
 	satoshisPerKB := int(minMiningFee * 1e8)
 
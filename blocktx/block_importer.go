@@ -0,0 +1,408 @@
+package blocktx
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/headers"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/bitcoin-sv/arc/tracing"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/ordishs/go-utils/expiringmap"
+	"github.com/ordishs/go-utils/safemap"
+)
+
+// ErrNotPrimary is returned by the import if the lease of the primary is held by another instance.
+var ErrNotPrimary = errors.New("lease of the primary is held by another instance")
+
+const (
+	blockFilePattern              = "blk*.dat"
+	importHeadersBatchSizeDefault = 2000
+	importProgressIntervalDefault = 10 * time.Second
+)
+
+// blockLocation is the position of a block in the block files.
+type blockLocation struct {
+	header *wire.BlockHeader
+	file   string
+	offset int64
+	size   uint32
+}
+
+// BlockImporter imports blocks from the raw block files (blk*.dat) of a bitcoind data directory into the blocktx
+// store. Blocks which have already been processed are skipped, therefore an interrupted import can be resumed.
+type BlockImporter struct {
+	logger           *slog.Logger
+	store            store.Interface
+	params           *headers.Params
+	handler          *PeerHandler
+	startHeight      uint64
+	endHeight        uint64
+	headersBatchSize int
+	progressInterval time.Duration
+}
+
+func WithImportStartHeight(height uint64) func(*BlockImporter) {
+	return func(i *BlockImporter) {
+		i.startHeight = height
+	}
+}
+
+// WithImportEndHeight sets the height of the last block which is imported. By default, all blocks up to the tip of
+// the best chain in the block files are imported.
+func WithImportEndHeight(height uint64) func(*BlockImporter) {
+	return func(i *BlockImporter) {
+		i.endHeight = height
+	}
+}
+
+func WithImportTransactionBatchSize(size int) func(*BlockImporter) {
+	return func(i *BlockImporter) {
+		i.handler.transactionStorageBatchSize = size
+	}
+}
+
+// WithImportFullIndex imports all transactions of the blocks instead of only the registered transactions.
+func WithImportFullIndex() func(*BlockImporter) {
+	return func(i *BlockImporter) {
+		i.handler.fullIndex = true
+	}
+}
+
+// WithImportPrimaryElection imports blocks only while the lease of the primary is held and writes them with the epoch
+// of the lease as fencing token, so that the import cannot interfere with a running primary instance.
+func WithImportPrimaryElection(election *PrimaryElection) func(*BlockImporter) {
+	return func(i *BlockImporter) {
+		i.handler.election = election
+	}
+}
+
+func WithImportProgressInterval(interval time.Duration) func(*BlockImporter) {
+	return func(i *BlockImporter) {
+		i.progressInterval = interval
+	}
+}
+
+func NewBlockImporter(logger *slog.Logger, storeI store.Interface, network wire.BitcoinNet, opts ...func(*BlockImporter)) (*BlockImporter, error) {
+	params, err := headers.NetworkParams(network)
+	if err != nil {
+		return nil, err
+	}
+
+	importer := &BlockImporter{
+		logger: logger,
+		store:  storeI,
+		params: params,
+		// the blocks are processed by the same pipeline as blocks received from peers
+		handler: &PeerHandler{
+			store:                       storeI,
+			logger:                      logger,
+			announcedCache:              expiringmap.New[chainhash.Hash, []p2p.PeerI](10 * time.Minute),
+			stats:                       safemap.New[string, *tracing.PeerHandlerStats](),
+			transactionStorageBatchSize: transactionStoringBatchsizeDefault,
+			spoolMemoryLimit:            spoolMemoryLimitDefault,
+			registeredTransactions:      NewRegisteredTransactions(logger, storeI),
+			chainParams:                 params,
+			blockStatsCollector:         newBlockStatsCollector(),
+		},
+		headersBatchSize: importHeadersBatchSizeDefault,
+		progressInterval: importProgressIntervalDefault,
+	}
+
+	for _, opt := range opts {
+		opt(importer)
+	}
+
+	return importer, nil
+}
+
+// Import reads the blocks of the best chain from the block files in the directory and processes the blocks between
+// the start and end height which have not been processed yet. It stops after the current block if the context is
+// cancelled. With a primary election it returns ErrNotPrimary as soon as the lease is not held.
+func (i *BlockImporter) Import(ctx context.Context, dir string) error {
+	if err := i.checkPrimary(); err != nil {
+		return err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, blockFilePattern))
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("no block files found in %s", dir)
+	}
+
+	// blk00000.dat, blk00001.dat, ...
+	sort.Strings(files)
+
+	locations := make(map[chainhash.Hash]*blockLocation)
+	for _, file := range files {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		if err = i.indexBlockFile(file, locations); err != nil {
+			return fmt.Errorf("failed to index block file %s: %v", file, err)
+		}
+	}
+
+	i.logger.Info("indexed block files", slog.Int("files", len(files)), slog.Int("blocks", len(locations)))
+
+	bestChain, err := i.bestChain(locations)
+	if err != nil {
+		return err
+	}
+
+	tip := uint64(len(bestChain) - 1)
+	i.logger.Info("found best chain", slog.Uint64("tip height", tip), slog.String("tip", bestChain[tip].String()))
+
+	if err = i.storeHeaders(ctx, bestChain, locations); err != nil {
+		return err
+	}
+
+	endHeight := tip
+	if i.endHeight != 0 && i.endHeight < endHeight {
+		endHeight = i.endHeight
+	}
+
+	return i.importBlocks(ctx, bestChain, locations, endHeight)
+}
+
+// indexBlockFile reads the headers of the blocks in the block file. Each block is prefixed by the network magic and
+// the size of the block. The remainder of a block file is zero padded.
+func (i *BlockImporter) indexBlockFile(file string, locations map[chainhash.Hash]*blockLocation) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+
+	var offset int64
+	prefix := make([]byte, 8)
+	for {
+		if _, err = io.ReadFull(reader, prefix); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+
+		magic := wire.BitcoinNet(binary.LittleEndian.Uint32(prefix[:4]))
+		if magic == 0 {
+			return nil
+		}
+
+		if magic != i.params.Net {
+			return fmt.Errorf("unexpected network magic %s at offset %d", magic, offset)
+		}
+
+		size := binary.LittleEndian.Uint32(prefix[4:])
+		if size < wire.MaxBlockHeaderPayload {
+			return fmt.Errorf("invalid block size %d at offset %d", size, offset)
+		}
+
+		header := &wire.BlockHeader{}
+		if err = header.Deserialize(reader); err != nil {
+			return fmt.Errorf("failed to read block header at offset %d: %v", offset, err)
+		}
+
+		if _, err = reader.Discard(int(size) - wire.MaxBlockHeaderPayload); err != nil {
+			return fmt.Errorf("failed to read block at offset %d: %v", offset, err)
+		}
+
+		locations[header.BlockHash()] = &blockLocation{
+			header: header,
+			file:   file,
+			offset: offset + int64(len(prefix)),
+			size:   size,
+		}
+
+		offset += int64(len(prefix)) + int64(size)
+	}
+}
+
+// bestChain validates the headers of the indexed blocks and returns the block hashes of the chain with the most
+// accumulated work ordered by height.
+func (i *BlockImporter) bestChain(locations map[chainhash.Hash]*blockLocation) ([]chainhash.Hash, error) {
+	genesisHash := i.params.GenesisHash()
+
+	children := make(map[chainhash.Hash][]chainhash.Hash)
+	for hash, location := range locations {
+		if hash.IsEqual(&genesisHash) {
+			continue
+		}
+		children[location.header.PrevBlock] = append(children[location.header.PrevBlock], hash)
+	}
+
+	chain, err := headers.NewChain(i.params)
+	if err != nil {
+		return nil, err
+	}
+
+	// the headers are added in order of height, therefore the parent of each header is known to the chain
+	queue := children[genesisHash]
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		_, _, err = chain.AddHeader(locations[hash].header)
+		if err != nil {
+			// the descendants of an invalid header are not part of the chain
+			i.logger.Warn("skipping invalid block header", slog.String("hash", hash.String()), slog.String("err", err.Error()))
+			continue
+		}
+
+		queue = append(queue, children[hash]...)
+	}
+
+	tip := chain.Tip()
+	bestChain := make([]chainhash.Hash, tip.Height+1)
+	hash := tip.Hash
+	for height := tip.Height; height > 0; height-- {
+		bestChain[height] = hash
+		hash = locations[hash].header.PrevBlock
+	}
+	bestChain[0] = genesisHash
+
+	return bestChain, nil
+}
+
+// storeHeaders stores the headers of the best chain so that the headers-first sync continues at the tip of the
+// imported headers.
+func (i *BlockImporter) storeHeaders(ctx context.Context, bestChain []chainhash.Hash, locations map[chainhash.Hash]*blockLocation) error {
+	blockHeaders := make([]*store.BlockHeader, 0, i.headersBatchSize)
+	for height := 1; height < len(bestChain); height++ {
+		blockHeaders = append(blockHeaders, &store.BlockHeader{
+			Height: uint64(height),
+			Header: locations[bestChain[height]].header,
+		})
+
+		if len(blockHeaders) == i.headersBatchSize || height == len(bestChain)-1 {
+			if err := i.store.InsertBlockHeaders(ctx, blockHeaders); err != nil {
+				return fmt.Errorf("failed to store block headers: %v", err)
+			}
+			blockHeaders = make([]*store.BlockHeader, 0, i.headersBatchSize)
+		}
+	}
+
+	return nil
+}
+
+func (i *BlockImporter) importBlocks(ctx context.Context, bestChain []chainhash.Hash, locations map[chainhash.Hash]*blockLocation, endHeight uint64) error {
+	var imported, skipped, txs int
+	timeStart := time.Now()
+	lastProgress := timeStart
+
+	for height := i.startHeight; height <= endHeight; height++ {
+		if err := ctx.Err(); err != nil {
+			i.logger.Info("block import interrupted", slog.Uint64("height", height))
+			return err
+		}
+
+		if err := i.checkPrimary(); err != nil {
+			return err
+		}
+
+		hash := bestChain[height]
+
+		block, err := i.store.GetBlock(ctx, &hash)
+		if err != nil && !errors.Is(err, store.ErrBlockNotFound) {
+			return fmt.Errorf("failed to get block %s: %v", hash.String(), err)
+		}
+
+		if block != nil && block.GetProcessed() {
+			skipped++
+			continue
+		}
+
+		location, found := locations[hash]
+		if !found {
+			return fmt.Errorf("block %s at height %d not found in block files", hash.String(), height)
+		}
+
+		txCount, err := i.importBlock(location, height)
+		if err != nil {
+			return err
+		}
+
+		imported++
+		txs += int(txCount)
+
+		if time.Since(lastProgress) >= i.progressInterval {
+			lastProgress = time.Now()
+			i.logProgress(height, endHeight, imported, skipped, txs, timeStart)
+		}
+	}
+
+	i.logProgress(endHeight, endHeight, imported, skipped, txs, timeStart)
+
+	return nil
+}
+
+// checkPrimary returns ErrNotPrimary if the import has a primary election and the lease is not held.
+func (i *BlockImporter) checkPrimary() error {
+	if i.handler.election != nil && !i.handler.election.IsPrimary() {
+		return ErrNotPrimary
+	}
+
+	return nil
+}
+
+func (i *BlockImporter) logProgress(height uint64, endHeight uint64, imported int, skipped int, txs int, timeStart time.Time) {
+	elapsed := time.Since(timeStart)
+
+	progress := 100.0
+	if endHeight > i.startHeight {
+		progress = float64(height-i.startHeight) / float64(endHeight-i.startHeight) * 100
+	}
+
+	i.logger.Info("block import progress",
+		slog.Uint64("height", height),
+		slog.Uint64("end height", endHeight),
+		slog.String("progress", fmt.Sprintf("%.2f%%", progress)),
+		slog.Int("imported", imported),
+		slog.Int("skipped", skipped),
+		slog.Int("txs", txs),
+		slog.String("blocks/s", fmt.Sprintf("%.2f", float64(imported)/elapsed.Seconds())),
+		slog.String("duration", elapsed.String()),
+	)
+}
+
+// importBlock reads the block at the location and processes it at the given height. It returns the number of
+// transactions of the block.
+func (i *BlockImporter) importBlock(location *blockLocation, height uint64) (uint64, error) {
+	f, err := os.Open(location.file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(io.NewSectionReader(f, location.offset, int64(location.size)))
+
+	_, msg, txIDs, err := readBlock(reader, 0, i.handler.spoolDir, i.handler.spoolMemoryLimit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read block %s at height %d: %v", location.header.BlockHash().String(), height, err)
+	}
+	defer func() {
+		_ = txIDs.close()
+	}()
+
+	// the height of the best chain does not depend on the coinbase transaction
+	msg.Height = height
+
+	return i.handler.processBlock(msg, txIDs, nil)
+}
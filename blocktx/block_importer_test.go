@@ -0,0 +1,247 @@
+package blocktx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// the block files in testdata/blocks contain a regtest chain of 8 blocks on top of the genesis block. The blocks are
+// not stored in order of height and the second block at height 5 is a stale block.
+var importedBlockHashes = []string{
+	"0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206",
+	"1d9fe7df4c2de95a1b2245dfd3543e9a787047dd87a2ea44f6ad6457fc795f80",
+	"2c655943f8e7c2e4557e19915d2efaa5853557d1f8147b4314c1c0a3c7a65099",
+	"1eb4b33b34a26e8a2214ba5c4bee7a388daf46058de4821e4a3c654840a2f0b4",
+	"5e18c6ae6a59739ffcd4493f05bfa038601e2e6821b94027f5c5d548b5270f7e",
+	"674dcf8907637cde70ae702da2e08705dfc146f9dd87bd00b5098c89bc6758dd",
+	"2fa2ff44f97df0001c0ad14aaf5dfdc89470e3ff82f6629cd44bf9350af96d0f",
+	"6fab899434b60862a1e3d1e787c6f2b82ad56c85a409e367376972e1fbdd7cec",
+	"6c12b325f04e6938aecf6e282159caa5921dfd6f4fdfed2c41b6a905263de9e1",
+}
+
+var importedBlockTxCounts = []int{1, 1, 4, 5, 2, 3, 4, 5, 2}
+
+func TestBlockImporter(t *testing.T) {
+	tt := []struct {
+		name            string
+		startHeight     uint64
+		endHeight       uint64
+		processedBlocks []int
+		dir             string
+		fullIndex       bool
+		leaseHolder     string
+
+		expectedErr            bool
+		expectedErrIs          error
+		expectedImportedBlocks []int
+		expectedHeaders        int
+		expectedEpoch          int64
+	}{
+		{
+			name:      "import all blocks",
+			dir:       "./testdata/blocks",
+			fullIndex: true,
+
+			expectedImportedBlocks: []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+			expectedHeaders:        8,
+		},
+		{
+			name:        "import range of blocks",
+			dir:         "./testdata/blocks",
+			startHeight: 3,
+			endHeight:   6,
+			fullIndex:   true,
+
+			expectedImportedBlocks: []int{3, 4, 5, 6},
+			expectedHeaders:        8,
+		},
+		{
+			name:            "resume import",
+			dir:             "./testdata/blocks",
+			processedBlocks: []int{0, 1, 2, 3, 4},
+			fullIndex:       true,
+
+			expectedImportedBlocks: []int{5, 6, 7, 8},
+			expectedHeaders:        8,
+		},
+		{
+			name: "import registered transactions only",
+			dir:  "./testdata/blocks",
+
+			expectedImportedBlocks: []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+			expectedHeaders:        8,
+		},
+		{
+			name:        "import with lease",
+			dir:         "./testdata/blocks",
+			leaseHolder: "importer",
+
+			expectedImportedBlocks: []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+			expectedHeaders:        8,
+			expectedEpoch:          3,
+		},
+		{
+			name:        "lease held by another instance",
+			dir:         "./testdata/blocks",
+			leaseHolder: "instance-a",
+
+			expectedErr:   true,
+			expectedErrIs: ErrNotPrimary,
+		},
+		{
+			name: "no block files",
+			dir:  "./testdata",
+
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			processed := make(map[string]bool)
+			for _, height := range tc.processedBlocks {
+				processed[importedBlockHashes[height]] = true
+			}
+
+			var insertedBlocks []*blocktx_api.Block
+			var insertedEpochs []int64
+			blockTransactions := make(map[uint64][]*blocktx_api.BlockTransaction)
+			var doneBlocks []string
+			var storedHeaders []*store.BlockHeader
+
+			storeMock := &store.InterfaceMock{
+				GetBlockFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+					if processed[hash.String()] {
+						return &blocktx_api.Block{Hash: hash[:], Processed: true}, nil
+					}
+					return nil, store.ErrBlockNotFound
+				},
+				InsertBlockHeadersFunc: func(ctx context.Context, blockHeaders []*store.BlockHeader) error {
+					storedHeaders = append(storedHeaders, blockHeaders...)
+					return nil
+				},
+				InsertBlockFunc: func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
+					insertedBlocks = append(insertedBlocks, block)
+					epoch, _ := store.FencingEpoch(ctx)
+					insertedEpochs = append(insertedEpochs, epoch)
+					return uint64(len(insertedBlocks)), nil
+				},
+				InsertBlockMerkleTreeFunc: func(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+					return nil
+				},
+				InsertBlockMerkleSubtreesFunc: func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+					return nil
+				},
+				InsertBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+					blockTransactions[blockId] = append(blockTransactions[blockId], transactions...)
+					return nil
+				},
+				GetBlockRegisteredTxCountFunc: func(ctx context.Context, blockId uint64) (uint64, error) {
+					return 0, nil
+				},
+				GetRegisteredTransactionsFunc: func(ctx context.Context, afterID uint64, limit uint64) ([]*store.RegisteredTransaction, error) {
+					return nil, nil
+				},
+				GetBlockStatsFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+					return nil, store.ErrBlockNotFound
+				},
+				InsertBlockStatsFunc: func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+					return nil
+				},
+				MarkBlockAsDoneFunc: func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
+					doneBlocks = append(doneBlocks, hash.String())
+					return nil
+				},
+				AcquireLeaseFunc: func(ctx context.Context, hostName string, duration time.Duration) (*store.Lease, error) {
+					return &store.Lease{HostName: tc.leaseHolder, Epoch: 3}, nil
+				},
+			}
+
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+			opts := []func(*BlockImporter){
+				WithImportStartHeight(tc.startHeight),
+				WithImportEndHeight(tc.endHeight),
+				WithImportTransactionBatchSize(2),
+			}
+			if tc.fullIndex {
+				opts = append(opts, WithImportFullIndex())
+			}
+			if tc.leaseHolder != "" {
+				election := NewPrimaryElection(logger, storeMock, "importer")
+				election.renew(context.Background())
+				opts = append(opts, WithImportPrimaryElection(election))
+			}
+
+			importer, err := NewBlockImporter(logger, storeMock, wire.TestNet, opts...)
+			require.NoError(t, err)
+
+			err = importer.Import(context.Background(), tc.dir)
+			if tc.expectedErr {
+				require.Error(t, err)
+				if tc.expectedErrIs != nil {
+					require.ErrorIs(t, err, tc.expectedErrIs)
+				}
+				require.Empty(t, insertedBlocks)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, storedHeaders, tc.expectedHeaders)
+			for i, header := range storedHeaders {
+				require.Equal(t, uint64(i+1), header.Height)
+				require.Equal(t, importedBlockHashes[i+1], header.Header.BlockHash().String())
+			}
+
+			require.Len(t, insertedBlocks, len(tc.expectedImportedBlocks))
+			require.Len(t, doneBlocks, len(tc.expectedImportedBlocks))
+			for i, height := range tc.expectedImportedBlocks {
+				hash, err := chainhash.NewHash(insertedBlocks[i].GetHash())
+				require.NoError(t, err)
+				require.Equal(t, importedBlockHashes[height], hash.String())
+				require.Equal(t, uint64(height), insertedBlocks[i].GetHeight())
+				require.Equal(t, importedBlockHashes[height], doneBlocks[i])
+				require.Equal(t, tc.expectedEpoch, insertedEpochs[i])
+
+				// none of the transactions is registered
+				transactions := blockTransactions[uint64(i+1)]
+				if !tc.fullIndex {
+					require.Empty(t, transactions)
+					continue
+				}
+
+				require.Len(t, transactions, importedBlockTxCounts[height])
+				for pos, tx := range transactions {
+					require.Equal(t, uint64(pos), tx.GetPos())
+				}
+			}
+		})
+	}
+}
+
+func TestBlockImporterInterrupted(t *testing.T) {
+	storeMock := &store.InterfaceMock{
+		InsertBlockHeadersFunc: func(ctx context.Context, blockHeaders []*store.BlockHeader) error {
+			return nil
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	importer, err := NewBlockImporter(logger, storeMock, wire.TestNet)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = importer.Import(ctx, "./testdata/blocks")
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, storeMock.InsertBlockCalls())
+}
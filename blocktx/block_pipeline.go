@@ -0,0 +1,209 @@
+package blocktx
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bitcoin-sv/arc/tracing"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/ordishs/go-utils"
+)
+
+const (
+	// blockInFlightTimeout is the time after which a requested block which has not been processed does not count as in
+	// flight anymore. The block is requested again by the announced cache.
+	blockInFlightTimeout = 5 * time.Minute
+	blockPipelineTick    = 10 * time.Second
+)
+
+// receivedBlock is a block which has been read from a peer and waits to be processed.
+type receivedBlock struct {
+	msg       *p2p.BlockMessage
+	txIDs     *blockTxIDs
+	peer      p2p.PeerI
+	stat      *tracing.PeerHandlerStats
+	timeStart time.Time
+}
+
+// blockPipeline bounds the number of blocks in flight, i.e. blocks which have been requested but not processed yet.
+// Received blocks are processed one after another while the peers download the next blocks. A block whose parent is
+// in flight is held back until the parent has been processed, so that blocks are marked as done after their parent.
+type blockPipeline struct {
+	depth    int
+	mu       sync.Mutex
+	inFlight map[chainhash.Hash]time.Time
+	released chan struct{}
+	blocks   chan *receivedBlock
+}
+
+func newBlockPipeline(depth int) *blockPipeline {
+	return &blockPipeline{
+		depth:    depth,
+		inFlight: make(map[chainhash.Hash]time.Time),
+		released: make(chan struct{}, 1),
+		blocks:   make(chan *receivedBlock, depth),
+	}
+}
+
+// tryAcquire marks the block as in flight if fewer than depth blocks are in flight. It returns false if the block
+// cannot be requested yet.
+func (p *blockPipeline) tryAcquire(hash *chainhash.Hash) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// a block which is requested again counts as in flight from the new request on
+	if _, found := p.inFlight[*hash]; found {
+		p.inFlight[*hash] = time.Now()
+		return true
+	}
+
+	for inFlightHash, requestedAt := range p.inFlight {
+		if time.Since(requestedAt) >= blockInFlightTimeout {
+			delete(p.inFlight, inFlightHash)
+		}
+	}
+
+	if len(p.inFlight) >= p.depth {
+		return false
+	}
+
+	p.inFlight[*hash] = time.Now()
+
+	return true
+}
+
+// release removes the block from the blocks in flight and signals that the next block can be requested.
+func (p *blockPipeline) release(hash *chainhash.Hash) {
+	p.mu.Lock()
+	delete(p.inFlight, *hash)
+	p.mu.Unlock()
+
+	select {
+	case p.released <- struct{}{}:
+	default:
+	}
+}
+
+func (p *blockPipeline) isInFlight(hash *chainhash.Hash) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	requestedAt, found := p.inFlight[*hash]
+
+	return found && time.Since(requestedAt) < blockInFlightTimeout
+}
+
+// startPipelinedPeerWorker requests the blocks sent to the worker channel as long as fewer than the pipeline depth
+// blocks are in flight. The other requests wait until a block has been processed.
+func (bs *PeerHandler) startPipelinedPeerWorker() {
+	go func() {
+		ticker := time.NewTicker(blockPipelineTick)
+		defer ticker.Stop()
+
+		pending := make([]utils.Pair[*chainhash.Hash, p2p.PeerI], 0)
+
+		for {
+			select {
+			case pair, ok := <-bs.workerCh:
+				if !ok {
+					return
+				}
+
+				pending = append(pending, pair)
+			case <-bs.pipeline.released:
+			case <-ticker.C:
+			}
+
+			for len(pending) > 0 {
+				hash := pending[0].First
+				peer := pending[0].Second
+
+				if bs.isAnnounced(hash, peer) {
+					pending = pending[1:]
+					continue
+				}
+
+				if !bs.pipeline.tryAcquire(hash) {
+					break
+				}
+
+				pending = pending[1:]
+				bs.requestBlock(hash, peer)
+			}
+		}
+	}()
+}
+
+// startBlockProcessor processes the received blocks in the order in which they are received. A block whose parent is
+// in flight waits until the parent has been processed or is not in flight anymore.
+func (bs *PeerHandler) startBlockProcessor() {
+	go func() {
+		ticker := time.NewTicker(blockPipelineTick)
+		defer ticker.Stop()
+
+		// received blocks by the hash of their parent
+		waiting := make(map[chainhash.Hash][]*receivedBlock)
+
+		var process func(block *receivedBlock)
+		process = func(block *receivedBlock) {
+			blockHash := block.msg.Header.BlockHash()
+
+			if err := bs.processReceivedBlock(block); err != nil {
+				bs.logger.Error("failed to process block", slog.String("hash", blockHash.String()), slog.String("err", err.Error()))
+
+				// the children keep waiting until the block has been received from the other peer
+				if errors.Is(err, errBlockRequestedAgain) {
+					return
+				}
+			}
+
+			bs.pipeline.release(&blockHash)
+
+			children := waiting[blockHash]
+			delete(waiting, blockHash)
+			for _, child := range children {
+				process(child)
+			}
+		}
+
+		for {
+			select {
+			case block, ok := <-bs.pipeline.blocks:
+				if !ok {
+					for _, blocks := range waiting {
+						for _, block := range blocks {
+							if block.txIDs != nil {
+								_ = block.txIDs.close()
+							}
+						}
+					}
+					return
+				}
+
+				parentHash := block.msg.Header.PrevBlock
+				if bs.pipeline.isInFlight(&parentHash) {
+					bs.logger.Info("holding back block until its parent has been processed", slog.String("hash", block.msg.Header.BlockHash().String()), slog.String("parent", parentHash.String()))
+					waiting[parentHash] = append(waiting[parentHash], block)
+					continue
+				}
+
+				process(block)
+			case <-ticker.C:
+				// the parent of a waiting block may not be in flight anymore without having been received
+				for parentHash, children := range waiting {
+					if bs.pipeline.isInFlight(&parentHash) {
+						continue
+					}
+
+					delete(waiting, parentHash)
+					for _, child := range children {
+						process(child)
+					}
+				}
+			}
+		}
+	}()
+}
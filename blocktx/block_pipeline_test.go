@@ -0,0 +1,183 @@
+package blocktx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/ordishs/go-utils"
+	"github.com/stretchr/testify/require"
+)
+
+// requestRecordingPeer records the blocks requested from the peer.
+type requestRecordingPeer struct {
+	MockedPeer
+	name      string
+	mu        sync.Mutex
+	requested []chainhash.Hash
+}
+
+func (peer *requestRecordingPeer) String() string {
+	return peer.name
+}
+
+func (peer *requestRecordingPeer) WriteMsg(msg wire.Message) error {
+	getData, ok := msg.(*wire.MsgGetData)
+	if !ok {
+		return nil
+	}
+
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	for _, invVect := range getData.InvList {
+		peer.requested = append(peer.requested, invVect.Hash)
+	}
+
+	return nil
+}
+
+func (peer *requestRecordingPeer) requestedBlocks() []chainhash.Hash {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	return append([]chainhash.Hash{}, peer.requested...)
+}
+
+func TestBlockPipeline(t *testing.T) {
+	hashes := syntheticTxHashes(0, 3)
+	pipeline := newBlockPipeline(2)
+
+	require.True(t, pipeline.tryAcquire(hashes[0]))
+	require.True(t, pipeline.tryAcquire(hashes[1]))
+	require.False(t, pipeline.tryAcquire(hashes[2]))
+
+	// a block in flight is not counted twice
+	require.True(t, pipeline.tryAcquire(hashes[1]))
+	require.True(t, pipeline.isInFlight(hashes[1]))
+
+	pipeline.release(hashes[1])
+	require.False(t, pipeline.isInFlight(hashes[1]))
+	require.True(t, pipeline.tryAcquire(hashes[2]))
+
+	// blocks which have not been processed within the timeout do not count as in flight
+	pipeline.inFlight[*hashes[0]] = time.Now().Add(-blockInFlightTimeout)
+	require.False(t, pipeline.isInFlight(hashes[0]))
+	require.True(t, pipeline.tryAcquire(hashes[1]))
+}
+
+func TestPipelinedPeerWorker(t *testing.T) {
+	storeMock := &store.InterfaceMock{}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	peerHandler, err := NewPeerHandler(logger, storeMock, 0, []string{}, wire.TestNet, WithBlockPipelineDepth(2))
+	require.NoError(t, err)
+	defer peerHandler.Shutdown()
+
+	peer := &requestRecordingPeer{}
+	hashes := syntheticTxHashes(0, 3)
+	for _, hash := range hashes {
+		utils.SafeSend(peerHandler.workerCh, utils.NewPair(hash, p2p.PeerI(peer)))
+	}
+
+	// only the depth of the pipeline is requested
+	require.Eventually(t, func() bool { return len(peer.requestedBlocks()) == 2 }, time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool { return len(peer.requestedBlocks()) > 2 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	// the next block is requested after a block has been processed
+	peerHandler.pipeline.release(hashes[0])
+	require.Eventually(t, func() bool { return len(peer.requestedBlocks()) == 3 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []chainhash.Hash{*hashes[0], *hashes[1], *hashes[2]}, peer.requestedBlocks())
+}
+
+func TestHandleBlockPipelined(t *testing.T) {
+	// a chain of three blocks
+	blocks := make([]*p2p.BlockMessage, 3)
+	previousHash := chainhash.Hash{}
+	for i := range blocks {
+		txHashes := syntheticTxHashes(i*10, 10)
+		tree, err := store.NewMerkleTree(txHashes, store.DefaultMerkleSubtreeSize)
+		require.NoError(t, err)
+		merkleRoot, err := tree.Root()
+		require.NoError(t, err)
+
+		blocks[i] = &p2p.BlockMessage{
+			Header:            &wire.BlockHeader{PrevBlock: previousHash, MerkleRoot: *merkleRoot},
+			Height:            uint64(100 + i),
+			TransactionHashes: txHashes,
+		}
+		previousHash = blocks[i].Header.BlockHash()
+	}
+
+	var mu sync.Mutex
+	var doneBlocks []chainhash.Hash
+
+	storeMock := &store.InterfaceMock{
+		GetBlockFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+			return &blocktx_api.Block{}, nil
+		},
+		InsertBlockFunc: func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
+			return block.GetHeight(), nil
+		},
+		InsertBlockMerkleTreeFunc: func(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+			return nil
+		},
+		InsertBlockMerkleSubtreesFunc: func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+			return nil
+		},
+		InsertBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+			return nil
+		},
+		GetBlockRegisteredTxCountFunc: func(ctx context.Context, blockId uint64) (uint64, error) {
+			return 0, nil
+		},
+		GetBlockStatsFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+			return nil, store.ErrBlockNotFound
+		},
+		InsertBlockStatsFunc: func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+			return nil
+		},
+		MarkBlockAsDoneFunc: func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			doneBlocks = append(doneBlocks, *hash)
+			return nil
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	peerHandler, err := NewPeerHandler(logger, storeMock, 0, []string{}, wire.TestNet, WithBlockPipelineDepth(3), WithFullIndex())
+	require.NoError(t, err)
+	defer peerHandler.Shutdown()
+
+	for _, block := range blocks {
+		hash := block.Header.BlockHash()
+		require.True(t, peerHandler.pipeline.tryAcquire(&hash))
+	}
+
+	// the blocks are received in reverse order, the children are held back until their parent has been processed
+	for i := len(blocks) - 1; i >= 0; i-- {
+		require.NoError(t, peerHandler.HandleBlock(blocks[i], &MockedPeer{}))
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(doneBlocks) == len(blocks)
+	}, time.Second, 10*time.Millisecond)
+
+	for i, block := range blocks {
+		require.Equal(t, block.Header.BlockHash(), doneBlocks[i])
+		require.False(t, peerHandler.pipeline.isInFlight(&doneBlocks[i]))
+	}
+}
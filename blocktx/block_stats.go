@@ -0,0 +1,170 @@
+package blocktx
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// blockStats calculates the statistics of a processed block. The fees are the value of the coinbase transaction which
+// exceeds the block subsidy. They are only known if the coinbase transaction has been read. The transactions per second
+// are calculated from the time since the previous block, which is taken from the header chain or the statistics of the
+// previous block.
+func (bs *PeerHandler) blockStats(msg *p2p.BlockMessage, txIDs *blockTxIDs, registeredCount uint64) *blocktx_api.BlockStats {
+	blockHash := msg.Header.BlockHash()
+	txCount := txIDs.txCount()
+
+	stats := &blocktx_api.BlockStats{
+		BlockHash:         blockHash[:],
+		Height:            msg.Height,
+		Size:              msg.Size,
+		TxCount:           txCount,
+		Timestamp:         timestamppb.New(msg.Header.Timestamp),
+		RegisteredTxCount: registeredCount,
+	}
+
+	if txCount > 0 {
+		stats.RegisteredTxShare = float64(registeredCount) / float64(txCount)
+	}
+
+	if txIDs.coinbaseParsed {
+		stats.CoinbaseValue = txIDs.coinbaseValue
+
+		if bs.chainParams != nil {
+			stats.Subsidy = bs.chainParams.BlockSubsidy(msg.Height)
+			if stats.CoinbaseValue > stats.Subsidy {
+				stats.TotalFees = stats.CoinbaseValue - stats.Subsidy
+			}
+		}
+	}
+
+	previousBlockTime, found := bs.previousBlockTime(&msg.Header.PrevBlock)
+	if found {
+		interval := msg.Header.Timestamp.Sub(previousBlockTime).Seconds()
+		if interval > 0 {
+			stats.TxPerSecond = float64(txCount) / interval
+		}
+	}
+
+	return stats
+}
+
+func (bs *PeerHandler) previousBlockTime(hash *chainhash.Hash) (time.Time, bool) {
+	if bs.headerChain != nil {
+		if node := bs.headerChain.Lookup(hash); node != nil {
+			return node.Header.Timestamp, true
+		}
+	}
+
+	previousStats, err := bs.store.GetBlockStats(context.Background(), hash)
+	if err != nil || previousStats.GetTimestamp() == nil {
+		return time.Time{}, false
+	}
+
+	return previousStats.GetTimestamp().AsTime(), true
+}
+
+// blockStatsCollector exposes the statistics of the last processed block.
+type blockStatsCollector struct {
+	mu   sync.RWMutex
+	last *blocktx_api.BlockStats
+
+	txCount           atomic.Uint64
+	registeredTxCount atomic.Uint64
+
+	fees              *prometheus.Desc
+	coinbaseValue     *prometheus.Desc
+	txPerSecond       *prometheus.Desc
+	registeredTxShare *prometheus.Desc
+	txCountTotal      *prometheus.Desc
+	registeredTotal   *prometheus.Desc
+}
+
+var (
+	blockStatsCollectorLoaded   = atomic.Bool{}
+	blockStatsCollectorInstance *blockStatsCollector
+)
+
+// newBlockStatsCollector returns the collector of the block statistics. The collector is registered once and shared by
+// all peer handlers.
+func newBlockStatsCollector() *blockStatsCollector {
+	if !blockStatsCollectorLoaded.CompareAndSwap(false, true) {
+		return blockStatsCollectorInstance
+	}
+
+	c := &blockStatsCollector{
+		fees: prometheus.NewDesc("arc_blocktx_block_fees",
+			"Shows the total fees in satoshis of the last processed block",
+			nil, nil,
+		),
+		coinbaseValue: prometheus.NewDesc("arc_blocktx_block_coinbase_value",
+			"Shows the value of the coinbase transaction in satoshis of the last processed block",
+			nil, nil,
+		),
+		txPerSecond: prometheus.NewDesc("arc_blocktx_block_tx_per_second",
+			"Shows the transactions per second of the last processed block since its previous block",
+			nil, nil,
+		),
+		registeredTxShare: prometheus.NewDesc("arc_blocktx_block_registered_tx_share",
+			"Shows the share of registered transactions of the last processed block",
+			nil, nil,
+		),
+		txCountTotal: prometheus.NewDesc("arc_blocktx_block_tx_count",
+			"Shows the number of transactions of all processed blocks",
+			nil, nil,
+		),
+		registeredTotal: prometheus.NewDesc("arc_blocktx_block_registered_tx_count",
+			"Shows the number of registered transactions of all processed blocks",
+			nil, nil,
+		),
+	}
+
+	prometheus.MustRegister(c)
+	blockStatsCollectorInstance = c
+
+	return c
+}
+
+func (c *blockStatsCollector) observe(stats *blocktx_api.BlockStats) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.last = stats
+	c.mu.Unlock()
+
+	c.txCount.Add(stats.GetTxCount())
+	c.registeredTxCount.Add(stats.GetRegisteredTxCount())
+}
+
+// Describe writes all descriptors to the prometheus desc channel.
+func (c *blockStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.fees
+	ch <- c.coinbaseValue
+	ch <- c.txPerSecond
+	ch <- c.registeredTxShare
+	ch <- c.txCountTotal
+	ch <- c.registeredTotal
+}
+
+// Collect implements required collect function for all prometheus collectors
+func (c *blockStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	last := c.last
+	c.mu.RUnlock()
+
+	ch <- prometheus.MustNewConstMetric(c.fees, prometheus.GaugeValue, float64(last.GetTotalFees()))
+	ch <- prometheus.MustNewConstMetric(c.coinbaseValue, prometheus.GaugeValue, float64(last.GetCoinbaseValue()))
+	ch <- prometheus.MustNewConstMetric(c.txPerSecond, prometheus.GaugeValue, last.GetTxPerSecond())
+	ch <- prometheus.MustNewConstMetric(c.registeredTxShare, prometheus.GaugeValue, last.GetRegisteredTxShare())
+	ch <- prometheus.MustNewConstMetric(c.txCountTotal, prometheus.CounterValue, float64(c.txCount.Load()))
+	ch <- prometheus.MustNewConstMetric(c.registeredTotal, prometheus.CounterValue, float64(c.registeredTxCount.Load()))
+}
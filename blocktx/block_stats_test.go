@@ -0,0 +1,220 @@
+package blocktx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBlockStats(t *testing.T) {
+	blockTime := time.Unix(1700000600, 0)
+
+	tt := []struct {
+		name              string
+		height            uint64
+		coinbaseValue     uint64
+		coinbaseParsed    bool
+		previousBlockTime *time.Time
+		registeredCount   uint64
+
+		expectedSubsidy     uint64
+		expectedFees        uint64
+		expectedTxPerSecond float64
+		expectedShare       float64
+	}{
+		{
+			name:              "fees and transactions per second",
+			height:            840000,
+			coinbaseValue:     312_500_000 + 1200,
+			coinbaseParsed:    true,
+			previousBlockTime: ptrTime(blockTime.Add(-10 * time.Second)),
+			registeredCount:   5,
+
+			expectedSubsidy:     312_500_000,
+			expectedFees:        1200,
+			expectedTxPerSecond: 2,
+			expectedShare:       0.25,
+		},
+		{
+			name:              "coinbase value below subsidy",
+			height:            1,
+			coinbaseValue:     1000,
+			coinbaseParsed:    true,
+			previousBlockTime: ptrTime(blockTime.Add(10 * time.Second)),
+
+			expectedSubsidy: 5_000_000_000,
+		},
+		{
+			name:   "coinbase not parsed, previous block unknown",
+			height: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			storeMock := &store.InterfaceMock{
+				GetBlockStatsFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+					if tc.previousBlockTime == nil {
+						return nil, store.ErrBlockNotFound
+					}
+
+					return &blocktx_api.BlockStats{Timestamp: timestamppb.New(*tc.previousBlockTime)}, nil
+				},
+			}
+
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+			peerHandler, err := NewPeerHandler(logger, storeMock, 0, []string{}, wire.MainNet)
+			require.NoError(t, err)
+			defer peerHandler.Shutdown()
+
+			txIDs, err := newBlockTxIDsFromHashes(syntheticTxHashes(0, 20), t.TempDir(), spoolMemoryLimitDefault)
+			require.NoError(t, err)
+			defer func() {
+				_ = txIDs.close()
+			}()
+			txIDs.coinbaseValue = tc.coinbaseValue
+			txIDs.coinbaseParsed = tc.coinbaseParsed
+
+			msg := &p2p.BlockMessage{
+				Header: &wire.BlockHeader{Timestamp: blockTime},
+				Height: tc.height,
+				Size:   1000,
+			}
+
+			stats := peerHandler.blockStats(msg, txIDs, tc.registeredCount)
+
+			require.Equal(t, tc.height, stats.GetHeight())
+			require.Equal(t, uint64(20), stats.GetTxCount())
+			require.Equal(t, uint64(1000), stats.GetSize())
+			require.Equal(t, tc.coinbaseValue, stats.GetCoinbaseValue())
+			require.Equal(t, tc.expectedSubsidy, stats.GetSubsidy())
+			require.Equal(t, tc.expectedFees, stats.GetTotalFees())
+			require.Equal(t, tc.expectedTxPerSecond, stats.GetTxPerSecond())
+			require.Equal(t, tc.registeredCount, stats.GetRegisteredTxCount())
+			require.Equal(t, tc.expectedShare, stats.GetRegisteredTxShare())
+		})
+	}
+}
+
+func TestBlockStatsRegisteredTxCount(t *testing.T) {
+	txHashes := syntheticTxHashes(0, 40)
+	tree, err := store.NewMerkleTree(txHashes, store.DefaultMerkleSubtreeSize)
+	require.NoError(t, err)
+	merkleRoot, err := tree.Root()
+	require.NoError(t, err)
+
+	registeredHashes := []*chainhash.Hash{txHashes[1], txHashes[20], txHashes[39]}
+
+	tt := []struct {
+		name      string
+		fullIndex bool
+	}{
+		{
+			name: "registered transactions only",
+		},
+		{
+			name:      "full index",
+			fullIndex: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			registered := &registeredStore{}
+			registered.register(registeredHashes...)
+
+			isRegistered := make(map[string]bool)
+			for _, hash := range registeredHashes {
+				isRegistered[string(hash[:])] = true
+			}
+
+			// the store maps all transactions of the block with the full index, of which only the registered are counted
+			var mapped []*blocktx_api.BlockTransaction
+			var insertedStats *blocktx_api.BlockStats
+
+			storeMock := registered.mock()
+			storeMock.GetBlockFunc = func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+				return &blocktx_api.Block{}, nil
+			}
+			storeMock.InsertBlockFunc = func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
+				return 1, nil
+			}
+			storeMock.InsertBlockMerkleTreeFunc = func(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+				return nil
+			}
+			storeMock.InsertBlockMerkleSubtreesFunc = func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+				return nil
+			}
+			storeMock.UpdateBlockTransactionsFunc = func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+				mapped = append(mapped, transactions...)
+				return nil
+			}
+			storeMock.InsertBlockTransactionsFunc = func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+				mapped = append(mapped, transactions...)
+				return nil
+			}
+			storeMock.GetBlockRegisteredTxCountFunc = func(ctx context.Context, blockId uint64) (uint64, error) {
+				count := uint64(0)
+				for _, tx := range mapped {
+					if isRegistered[string(tx.GetHash())] {
+						count++
+					}
+				}
+				return count, nil
+			}
+			storeMock.GetBlockStatsFunc = func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+				return nil, store.ErrBlockNotFound
+			}
+			storeMock.InsertBlockStatsFunc = func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+				insertedStats = stats
+				return nil
+			}
+			storeMock.MarkBlockAsDoneFunc = func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
+				return nil
+			}
+
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+			opts := []func(*PeerHandler){WithRegisteredTransactions(NewRegisteredTransactions(logger, storeMock))}
+			if tc.fullIndex {
+				opts = append(opts, WithFullIndex())
+			}
+
+			peerHandler, err := NewPeerHandler(logger, storeMock, 0, []string{}, wire.TestNet, opts...)
+			require.NoError(t, err)
+			defer peerHandler.Shutdown()
+
+			err = peerHandler.HandleBlock(&p2p.BlockMessage{
+				Header:            &wire.BlockHeader{MerkleRoot: *merkleRoot},
+				Height:            100,
+				TransactionHashes: txHashes,
+			}, &MockedPeer{})
+			require.NoError(t, err)
+
+			if tc.fullIndex {
+				require.Len(t, mapped, len(txHashes))
+				require.Len(t, storeMock.GetBlockRegisteredTxCountCalls(), 1)
+			} else {
+				require.Len(t, mapped, len(registeredHashes))
+				require.Empty(t, storeMock.GetBlockRegisteredTxCountCalls())
+			}
+
+			require.NotNil(t, insertedStats)
+			require.Equal(t, uint64(len(registeredHashes)), insertedStats.GetRegisteredTxCount())
+			require.Equal(t, float64(len(registeredHashes))/float64(len(txHashes)), insertedStats.GetRegisteredTxShare())
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
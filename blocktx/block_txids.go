@@ -0,0 +1,227 @@
+package blocktx
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+)
+
+const (
+	spoolMemoryLimitDefault = 32 * 1024 * 1024 // 1M transaction ids
+	spoolFilePattern        = "blocktx-txids-*"
+)
+
+// txIDSpool keeps the transaction ids of a block in the order of the block. Up to the memory limit the ids are kept in
+// memory, beyond the limit they are written to a temporary file so that the memory needed for large blocks is bounded.
+type txIDSpool struct {
+	dir         string
+	memoryLimit int
+	buffer      []byte
+	file        *os.File
+	writer      *bufio.Writer
+}
+
+func newTxIDSpool(dir string, memoryLimit int, txCount uint64) *txIDSpool {
+	// the transaction count is read from the block and is only used as a hint limited by the memory limit
+	capacity := memoryLimit
+	if txCount < uint64(memoryLimit/chainhash.HashSize) {
+		capacity = int(txCount) * chainhash.HashSize
+	}
+
+	return &txIDSpool{
+		dir:         dir,
+		memoryLimit: memoryLimit,
+		buffer:      make([]byte, 0, capacity),
+	}
+}
+
+func (s *txIDSpool) add(hash *chainhash.Hash) error {
+	if s.file == nil && len(s.buffer)+chainhash.HashSize <= s.memoryLimit {
+		s.buffer = append(s.buffer, hash[:]...)
+		return nil
+	}
+
+	if s.file == nil {
+		file, err := os.CreateTemp(s.dir, spoolFilePattern)
+		if err != nil {
+			return fmt.Errorf("failed to create spool file: %v", err)
+		}
+
+		s.file = file
+		s.writer = bufio.NewWriter(file)
+	}
+
+	_, err := s.writer.Write(hash[:])
+	return err
+}
+
+// reader returns a reader of all transaction ids in the order in which they were added.
+func (s *txIDSpool) reader() (io.Reader, error) {
+	if s.file == nil {
+		return bytes.NewReader(s.buffer), nil
+	}
+
+	if err := s.writer.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to flush spool file: %v", err)
+	}
+
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek spool file: %v", err)
+	}
+
+	return io.MultiReader(bytes.NewReader(s.buffer), bufio.NewReader(s.file)), nil
+}
+
+// close releases the memory and removes the temporary file.
+func (s *txIDSpool) close() error {
+	s.buffer = nil
+
+	if s.file == nil {
+		return nil
+	}
+
+	name := s.file.Name()
+	err := errors.Join(s.file.Close(), os.Remove(name))
+	s.file = nil
+
+	return err
+}
+
+// blockTxIDs are the transaction ids of a block together with the Merkle tree which is calculated while the ids are
+// added. The Merkle tree contains the subtree roots only, the subtree leaves are read from the spool.
+type blockTxIDs struct {
+	spool      *txIDSpool
+	builder    *store.MerkleTreeBuilder
+	merkleTree *store.MerkleTree
+	merkleRoot *chainhash.Hash
+
+	// coinbaseValue is the sum of the outputs of the coinbase transaction, only set if the block has been read by
+	// readBlock
+	coinbaseValue  uint64
+	coinbaseParsed bool
+}
+
+func newBlockTxIDs(spoolDir string, spoolMemoryLimit int, txCount uint64) (*blockTxIDs, error) {
+	builder, err := store.NewMerkleTreeBuilder(store.DefaultMerkleSubtreeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockTxIDs{
+		spool:   newTxIDSpool(spoolDir, spoolMemoryLimit, txCount),
+		builder: builder,
+	}, nil
+}
+
+// newBlockTxIDsFromHashes returns the block transaction ids of transaction hashes which are already in memory.
+func newBlockTxIDsFromHashes(hashes []*chainhash.Hash, spoolDir string, spoolMemoryLimit int) (*blockTxIDs, error) {
+	txIDs, err := newBlockTxIDs(spoolDir, spoolMemoryLimit, uint64(len(hashes)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hash := range hashes {
+		if err = txIDs.add(hash); err != nil {
+			_ = txIDs.close()
+			return nil, err
+		}
+	}
+
+	if err = txIDs.finish(); err != nil {
+		_ = txIDs.close()
+		return nil, err
+	}
+
+	return txIDs, nil
+}
+
+func (b *blockTxIDs) add(hash *chainhash.Hash) error {
+	b.builder.Add(hash)
+	return b.spool.add(hash)
+}
+
+func (b *blockTxIDs) finish() error {
+	var err error
+	b.merkleTree, b.merkleRoot, err = b.builder.Finish()
+	b.builder = nil
+
+	return err
+}
+
+func (b *blockTxIDs) txCount() uint64 {
+	return b.merkleTree.TxCount
+}
+
+func (b *blockTxIDs) close() error {
+	return b.spool.close()
+}
+
+// readBlock reads a block from the reader. The transactions are parsed one after another and only their ids are kept,
+// from which the Merkle tree of the block is calculated. The returned block message does not contain the transaction
+// hashes.
+func readBlock(reader io.Reader, bytesRead int, spoolDir string, spoolMemoryLimit int) (int, *p2p.BlockMessage, *blockTxIDs, error) {
+	blockMessage := &p2p.BlockMessage{
+		Header: &wire.BlockHeader{},
+	}
+
+	err := blockMessage.Header.Deserialize(reader)
+	if err != nil {
+		return bytesRead, nil, nil, err
+	}
+	bytesRead += 80 // the bitcoin header is always 80 bytes
+
+	var read int64
+	var txCount bt.VarInt
+	read, err = txCount.ReadFrom(reader)
+	if err != nil {
+		return bytesRead, nil, nil, err
+	}
+	bytesRead += int(read)
+
+	txIDs, err := newBlockTxIDs(spoolDir, spoolMemoryLimit, uint64(txCount))
+	if err != nil {
+		return bytesRead, nil, nil, err
+	}
+
+	var tx *bt.Tx
+	for i := uint64(0); i < uint64(txCount); i++ {
+		tx = bt.NewTx()
+		read, err = tx.ReadFrom(reader)
+		if err != nil {
+			_ = txIDs.close()
+			return bytesRead, nil, nil, err
+		}
+		bytesRead += int(read)
+
+		// the Merkle tree builder keeps the hashes of the current subtree, therefore each hash is a new variable
+		hash := chainhash.DoubleHashH(tx.Bytes())
+		if err = txIDs.add(&hash); err != nil {
+			_ = txIDs.close()
+			return bytesRead, nil, nil, err
+		}
+
+		if i == 0 {
+			blockMessage.Height = extractHeightFromCoinbaseTx(tx)
+			txIDs.coinbaseValue = tx.TotalOutputSatoshis()
+			txIDs.coinbaseParsed = true
+		}
+	}
+
+	if err = txIDs.finish(); err != nil {
+		_ = txIDs.close()
+		return bytesRead, nil, nil, err
+	}
+
+	blockMessage.Size = uint64(bytesRead)
+
+	return bytesRead, blockMessage, txIDs, nil
+}
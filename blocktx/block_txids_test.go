@@ -0,0 +1,300 @@
+package blocktx
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/bitcoin-sv/arc/tracing"
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/ordishs/go-utils/expiringmap"
+	"github.com/ordishs/go-utils/safemap"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticBlockReader generates a block of transactions which differ only in their lock time. The block is generated
+// while it is read so that the memory needed by the reader does not depend on the number of transactions.
+type syntheticBlockReader struct {
+	header  []byte
+	tx      []byte
+	txCount uint64
+	next    uint64
+	buffer  []byte
+}
+
+func newSyntheticBlockReader(txCount uint64) *syntheticBlockReader {
+	unlockingScript := bscript.Script([]byte{0x03, 0x64, 0x00, 0x00}) // height 100
+	lockingScript := bscript.Script([]byte{0x51})
+
+	input := &bt.Input{PreviousTxOutIndex: 0xffffffff, SequenceNumber: 0xffffffff, UnlockingScript: &unlockingScript}
+	if err := input.PreviousTxIDAdd(make([]byte, chainhash.HashSize)); err != nil {
+		panic(err)
+	}
+
+	tx := bt.NewTx()
+	tx.Inputs = append(tx.Inputs, input)
+	tx.Outputs = append(tx.Outputs, &bt.Output{Satoshis: 1000, LockingScript: &lockingScript})
+
+	r := &syntheticBlockReader{tx: tx.Bytes(), txCount: txCount}
+
+	// the merkle root is calculated from the transactions before the block is read
+	builder, err := store.NewMerkleTreeBuilder(store.DefaultMerkleSubtreeSize)
+	if err != nil {
+		panic(err)
+	}
+	for i := uint64(0); i < txCount; i++ {
+		hash := chainhash.DoubleHashH(r.nextTx())
+		builder.Add(&hash)
+	}
+	_, merkleRoot, err := builder.Finish()
+	if err != nil {
+		panic(err)
+	}
+
+	header := wire.BlockHeader{Version: 1, MerkleRoot: *merkleRoot, Timestamp: time.Unix(1700000000, 0)}
+	buffer := &bytes.Buffer{}
+	if err = header.Serialize(buffer); err != nil {
+		panic(err)
+	}
+	buffer.Write(bt.VarInt(txCount).Bytes())
+
+	r.header = buffer.Bytes()
+	r.reset()
+
+	return r
+}
+
+// reset starts reading the block from the beginning.
+func (r *syntheticBlockReader) reset() {
+	r.buffer = r.header
+	r.next = 0
+}
+
+func (r *syntheticBlockReader) nextTx() []byte {
+	binary.LittleEndian.PutUint32(r.tx[len(r.tx)-4:], uint32(r.next))
+	r.next++
+
+	return r.tx
+}
+
+func (r *syntheticBlockReader) Read(p []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		if r.next == r.txCount {
+			return 0, io.EOF
+		}
+
+		r.buffer = r.nextTx()
+	}
+
+	n := copy(p, r.buffer)
+	r.buffer = r.buffer[n:]
+
+	return n, nil
+}
+
+func TestReadBlock(t *testing.T) {
+	tt := []struct {
+		name             string
+		txCount          uint64
+		spoolMemoryLimit int
+
+		expectedSpoolFile bool
+	}{
+		{
+			name:             "single transaction",
+			txCount:          1,
+			spoolMemoryLimit: spoolMemoryLimitDefault,
+		},
+		{
+			name:             "transaction ids in memory",
+			txCount:          2500,
+			spoolMemoryLimit: spoolMemoryLimitDefault,
+		},
+		{
+			name:             "transaction ids exceeding the memory limit",
+			txCount:          2500,
+			spoolMemoryLimit: 100 * chainhash.HashSize,
+
+			expectedSpoolFile: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			spoolDir := t.TempDir()
+			reader := newSyntheticBlockReader(tc.txCount)
+			expectedTxIDs := make([]*chainhash.Hash, tc.txCount)
+			for i := range expectedTxIDs {
+				hash := chainhash.DoubleHashH(reader.nextTx())
+				expectedTxIDs[i] = &hash
+			}
+			reader.reset()
+
+			_, msg, txIDs, err := readBlock(bufio.NewReader(reader), 0, spoolDir, tc.spoolMemoryLimit)
+			require.NoError(t, err)
+
+			require.Equal(t, uint64(100), msg.Height)
+			require.Empty(t, msg.TransactionHashes)
+			require.Equal(t, tc.txCount, txIDs.txCount())
+			require.Equal(t, msg.Header.MerkleRoot, *txIDs.merkleRoot)
+			require.True(t, txIDs.coinbaseParsed)
+			require.Equal(t, uint64(1000), txIDs.coinbaseValue)
+
+			fullTree := bc.BuildMerkleTreeStoreChainHash(expectedTxIDs)
+			require.Equal(t, fullTree[len(fullTree)-1], txIDs.merkleRoot)
+
+			files, err := os.ReadDir(spoolDir)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSpoolFile, len(files) == 1)
+
+			spoolReader, err := txIDs.spool.reader()
+			require.NoError(t, err)
+			spooled, err := io.ReadAll(spoolReader)
+			require.NoError(t, err)
+
+			expected := make([]byte, 0, len(spooled))
+			for _, hash := range expectedTxIDs {
+				expected = append(expected, hash[:]...)
+			}
+			require.Equal(t, expected, spooled)
+
+			require.NoError(t, txIDs.close())
+
+			files, err = os.ReadDir(spoolDir)
+			require.NoError(t, err)
+			require.Empty(t, files)
+		})
+	}
+}
+
+// nopStore is a store which discards all data, in contrast to the mocked store it does not keep the arguments of the
+// calls in memory.
+type nopStore struct {
+	store.Interface
+}
+
+func (nopStore) InsertBlock(context.Context, *blocktx_api.Block) (uint64, error) {
+	return 1, nil
+}
+
+func (nopStore) InsertBlockMerkleTree(context.Context, uint64, *store.MerkleTree) error {
+	return nil
+}
+
+func (nopStore) InsertBlockMerkleSubtrees(context.Context, uint64, uint64, [][]byte) error {
+	return nil
+}
+
+func (nopStore) UpdateBlockTransactions(context.Context, uint64, []*blocktx_api.BlockTransaction) error {
+	return nil
+}
+
+func (nopStore) GetBlockStats(context.Context, *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+	return nil, store.ErrBlockNotFound
+}
+
+func (nopStore) InsertBlockStats(context.Context, uint64, *blocktx_api.BlockStats) error {
+	return nil
+}
+
+func (nopStore) MarkBlockAsDone(context.Context, *chainhash.Hash, uint64, uint64) error {
+	return nil
+}
+
+// peakHeap samples the allocated heap until the returned function is called, which returns the peak.
+func peakHeap() func() uint64 {
+	var peak atomic.Uint64
+	done := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		var mem runtime.MemStats
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			runtime.ReadMemStats(&mem)
+			if mem.HeapAlloc > peak.Load() {
+				peak.Store(mem.HeapAlloc)
+			}
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() uint64 {
+		close(done)
+		wg.Wait()
+		return peak.Load()
+	}
+}
+
+// BenchmarkProcessBlock measures reading and storing blocks of different sizes. The peak heap stays bounded as the
+// transaction ids exceeding the spool memory limit are written to disk.
+func BenchmarkProcessBlock(b *testing.B) {
+	for _, txCount := range []uint64{10_000, 100_000, 1_000_000, 4_000_000} {
+		b.Run(fmt.Sprintf("%d txs", txCount), func(b *testing.B) {
+			peerHandler := &PeerHandler{
+				store:                       nopStore{},
+				logger:                      slog.New(slog.NewTextHandler(io.Discard, nil)),
+				announcedCache:              expiringmap.New[chainhash.Hash, []p2p.PeerI](10 * time.Minute),
+				stats:                       safemap.New[string, *tracing.PeerHandlerStats](),
+				transactionStorageBatchSize: transactionStoringBatchsizeDefault,
+				spoolDir:                    b.TempDir(),
+				spoolMemoryLimit:            spoolMemoryLimitDefault,
+			}
+
+			reader := newSyntheticBlockReader(txCount)
+			var peak uint64
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				reader.reset()
+
+				runtime.GC()
+				var baseline runtime.MemStats
+				runtime.ReadMemStats(&baseline)
+				stop := peakHeap()
+
+				_, msg, txIDs, err := readBlock(bufio.NewReader(reader), 0, peerHandler.spoolDir, peerHandler.spoolMemoryLimit)
+				require.NoError(b, err)
+
+				_, err = peerHandler.processBlock(msg, txIDs, nil)
+				require.NoError(b, err)
+				require.NoError(b, txIDs.close())
+
+				if heap := stop(); heap > baseline.HeapAlloc && heap-baseline.HeapAlloc > peak {
+					peak = heap - baseline.HeapAlloc
+				}
+			}
+
+			b.ReportMetric(float64(peak)/1024/1024, "peak-heap-MiB")
+		})
+	}
+}
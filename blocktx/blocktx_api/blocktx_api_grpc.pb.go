@@ -24,6 +24,13 @@ const (
 	BlockTxAPI_RegisterTransaction_FullMethodName      = "/blocktx_api.BlockTxAPI/RegisterTransaction"
 	BlockTxAPI_GetTransactionMerklePath_FullMethodName = "/blocktx_api.BlockTxAPI/GetTransactionMerklePath"
 	BlockTxAPI_GetTransactionBlocks_FullMethodName     = "/blocktx_api.BlockTxAPI/GetTransactionBlocks"
+	BlockTxAPI_GetBlock_FullMethodName                 = "/blocktx_api.BlockTxAPI/GetBlock"
+	BlockTxAPI_GetBlockByHeight_FullMethodName         = "/blocktx_api.BlockTxAPI/GetBlockByHeight"
+	BlockTxAPI_GetChainTip_FullMethodName              = "/blocktx_api.BlockTxAPI/GetChainTip"
+	BlockTxAPI_GetBlockTransactions_FullMethodName     = "/blocktx_api.BlockTxAPI/GetBlockTransactions"
+	BlockTxAPI_GetConfirmations_FullMethodName         = "/blocktx_api.BlockTxAPI/GetConfirmations"
+	BlockTxAPI_GetBlockStats_FullMethodName            = "/blocktx_api.BlockTxAPI/GetBlockStats"
+	BlockTxAPI_VerifyMerklePath_FullMethodName         = "/blocktx_api.BlockTxAPI/VerifyMerklePath"
 )
 
 // BlockTxAPIClient is the client API for BlockTxAPI service.
@@ -38,6 +45,20 @@ type BlockTxAPIClient interface {
 	GetTransactionMerklePath(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*MerklePath, error)
 	// GetTransactionBlocks returns a list of block hashes (excluding orphaned) for a given transaction hash.
 	GetTransactionBlocks(ctx context.Context, in *Transactions, opts ...grpc.CallOption) (*TransactionBlocks, error)
+	// GetBlock returns the block with the given hash.
+	GetBlock(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*Block, error)
+	// GetBlockByHeight returns the non-orphaned block at the given height.
+	GetBlockByHeight(ctx context.Context, in *Height, opts ...grpc.CallOption) (*Block, error)
+	// GetChainTip returns the non-orphaned block with the greatest height.
+	GetChainTip(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Block, error)
+	// GetBlockTransactions returns a page of the registered transactions of a block ordered by their position in the block.
+	GetBlockTransactions(ctx context.Context, in *BlockTransactionsRequest, opts ...grpc.CallOption) (*BlockTransactions, error)
+	// GetConfirmations returns the number of confirmations of a transaction.
+	GetConfirmations(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*Confirmations, error)
+	// GetBlockStats returns the statistics of the processed block with the given hash.
+	GetBlockStats(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*BlockStats, error)
+	// VerifyMerklePath verifies a merkle path in BUMP format of a transaction against the merkle root of the block at the height of the merkle path.
+	VerifyMerklePath(ctx context.Context, in *MerklePathVerificationRequest, opts ...grpc.CallOption) (*MerklePathVerification, error)
 }
 
 type blockTxAPIClient struct {
@@ -84,6 +105,69 @@ func (c *blockTxAPIClient) GetTransactionBlocks(ctx context.Context, in *Transac
 	return out, nil
 }
 
+func (c *blockTxAPIClient) GetBlock(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, BlockTxAPI_GetBlock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockTxAPIClient) GetBlockByHeight(ctx context.Context, in *Height, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, BlockTxAPI_GetBlockByHeight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockTxAPIClient) GetChainTip(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, BlockTxAPI_GetChainTip_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockTxAPIClient) GetBlockTransactions(ctx context.Context, in *BlockTransactionsRequest, opts ...grpc.CallOption) (*BlockTransactions, error) {
+	out := new(BlockTransactions)
+	err := c.cc.Invoke(ctx, BlockTxAPI_GetBlockTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockTxAPIClient) GetConfirmations(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*Confirmations, error) {
+	out := new(Confirmations)
+	err := c.cc.Invoke(ctx, BlockTxAPI_GetConfirmations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockTxAPIClient) GetBlockStats(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*BlockStats, error) {
+	out := new(BlockStats)
+	err := c.cc.Invoke(ctx, BlockTxAPI_GetBlockStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockTxAPIClient) VerifyMerklePath(ctx context.Context, in *MerklePathVerificationRequest, opts ...grpc.CallOption) (*MerklePathVerification, error) {
+	out := new(MerklePathVerification)
+	err := c.cc.Invoke(ctx, BlockTxAPI_VerifyMerklePath_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlockTxAPIServer is the server API for BlockTxAPI service.
 // All implementations must embed UnimplementedBlockTxAPIServer
 // for forward compatibility
@@ -96,6 +180,20 @@ type BlockTxAPIServer interface {
 	GetTransactionMerklePath(context.Context, *Transaction) (*MerklePath, error)
 	// GetTransactionBlocks returns a list of block hashes (excluding orphaned) for a given transaction hash.
 	GetTransactionBlocks(context.Context, *Transactions) (*TransactionBlocks, error)
+	// GetBlock returns the block with the given hash.
+	GetBlock(context.Context, *Hash) (*Block, error)
+	// GetBlockByHeight returns the non-orphaned block at the given height.
+	GetBlockByHeight(context.Context, *Height) (*Block, error)
+	// GetChainTip returns the non-orphaned block with the greatest height.
+	GetChainTip(context.Context, *emptypb.Empty) (*Block, error)
+	// GetBlockTransactions returns a page of the registered transactions of a block ordered by their position in the block.
+	GetBlockTransactions(context.Context, *BlockTransactionsRequest) (*BlockTransactions, error)
+	// GetConfirmations returns the number of confirmations of a transaction.
+	GetConfirmations(context.Context, *Transaction) (*Confirmations, error)
+	// GetBlockStats returns the statistics of the processed block with the given hash.
+	GetBlockStats(context.Context, *Hash) (*BlockStats, error)
+	// VerifyMerklePath verifies a merkle path in BUMP format of a transaction against the merkle root of the block at the height of the merkle path.
+	VerifyMerklePath(context.Context, *MerklePathVerificationRequest) (*MerklePathVerification, error)
 	mustEmbedUnimplementedBlockTxAPIServer()
 }
 
@@ -115,6 +213,27 @@ func (UnimplementedBlockTxAPIServer) GetTransactionMerklePath(context.Context, *
 func (UnimplementedBlockTxAPIServer) GetTransactionBlocks(context.Context, *Transactions) (*TransactionBlocks, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionBlocks not implemented")
 }
+func (UnimplementedBlockTxAPIServer) GetBlock(context.Context, *Hash) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedBlockTxAPIServer) GetBlockByHeight(context.Context, *Height) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHeight not implemented")
+}
+func (UnimplementedBlockTxAPIServer) GetChainTip(context.Context, *emptypb.Empty) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChainTip not implemented")
+}
+func (UnimplementedBlockTxAPIServer) GetBlockTransactions(context.Context, *BlockTransactionsRequest) (*BlockTransactions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockTransactions not implemented")
+}
+func (UnimplementedBlockTxAPIServer) GetConfirmations(context.Context, *Transaction) (*Confirmations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfirmations not implemented")
+}
+func (UnimplementedBlockTxAPIServer) GetBlockStats(context.Context, *Hash) (*BlockStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockStats not implemented")
+}
+func (UnimplementedBlockTxAPIServer) VerifyMerklePath(context.Context, *MerklePathVerificationRequest) (*MerklePathVerification, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyMerklePath not implemented")
+}
 func (UnimplementedBlockTxAPIServer) mustEmbedUnimplementedBlockTxAPIServer() {}
 
 // UnsafeBlockTxAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -200,6 +319,132 @@ func _BlockTxAPI_GetTransactionBlocks_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockTxAPI_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Hash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockTxAPIServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockTxAPI_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockTxAPIServer).GetBlock(ctx, req.(*Hash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockTxAPI_GetBlockByHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Height)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockTxAPIServer).GetBlockByHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockTxAPI_GetBlockByHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockTxAPIServer).GetBlockByHeight(ctx, req.(*Height))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockTxAPI_GetChainTip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockTxAPIServer).GetChainTip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockTxAPI_GetChainTip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockTxAPIServer).GetChainTip(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockTxAPI_GetBlockTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockTxAPIServer).GetBlockTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockTxAPI_GetBlockTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockTxAPIServer).GetBlockTransactions(ctx, req.(*BlockTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockTxAPI_GetConfirmations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Transaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockTxAPIServer).GetConfirmations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockTxAPI_GetConfirmations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockTxAPIServer).GetConfirmations(ctx, req.(*Transaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockTxAPI_GetBlockStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Hash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockTxAPIServer).GetBlockStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockTxAPI_GetBlockStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockTxAPIServer).GetBlockStats(ctx, req.(*Hash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockTxAPI_VerifyMerklePath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerklePathVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockTxAPIServer).VerifyMerklePath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockTxAPI_VerifyMerklePath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockTxAPIServer).VerifyMerklePath(ctx, req.(*MerklePathVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlockTxAPI_ServiceDesc is the grpc.ServiceDesc for BlockTxAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -223,6 +468,34 @@ var BlockTxAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactionBlocks",
 			Handler:    _BlockTxAPI_GetTransactionBlocks_Handler,
 		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _BlockTxAPI_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBlockByHeight",
+			Handler:    _BlockTxAPI_GetBlockByHeight_Handler,
+		},
+		{
+			MethodName: "GetChainTip",
+			Handler:    _BlockTxAPI_GetChainTip_Handler,
+		},
+		{
+			MethodName: "GetBlockTransactions",
+			Handler:    _BlockTxAPI_GetBlockTransactions_Handler,
+		},
+		{
+			MethodName: "GetConfirmations",
+			Handler:    _BlockTxAPI_GetConfirmations_Handler,
+		},
+		{
+			MethodName: "GetBlockStats",
+			Handler:    _BlockTxAPI_GetBlockStats_Handler,
+		},
+		{
+			MethodName: "VerifyMerklePath",
+			Handler:    _BlockTxAPI_VerifyMerklePath_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "blocktx/blocktx_api/blocktx_api.proto",
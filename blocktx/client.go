@@ -2,12 +2,15 @@ package blocktx
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
 	"github.com/bitcoin-sv/arc/tracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -17,6 +20,13 @@ type ClientI interface {
 	GetTransactionBlocks(ctx context.Context, transaction *blocktx_api.Transactions) (*blocktx_api.TransactionBlocks, error)
 	RegisterTransaction(ctx context.Context, transaction *blocktx_api.TransactionAndSource) error
 	Health(ctx context.Context) error
+	GetBlock(ctx context.Context, hash []byte) (*blocktx_api.Block, error)
+	GetBlockStats(ctx context.Context, hash []byte) (*blocktx_api.BlockStats, error)
+	GetBlockByHeight(ctx context.Context, height uint64) (*blocktx_api.Block, error)
+	GetChainTip(ctx context.Context) (*blocktx_api.Block, error)
+	GetBlockTransactions(ctx context.Context, hash []byte, offset uint64, limit uint64) (*blocktx_api.BlockTransactions, error)
+	GetConfirmations(ctx context.Context, hash []byte) (*blocktx_api.Confirmations, error)
+	VerifyMerklePath(ctx context.Context, merklePath string, hash []byte) (*blocktx_api.MerklePathVerification, error)
 }
 
 type Client struct {
@@ -68,6 +78,88 @@ func (btc *Client) Health(ctx context.Context) error {
 	return nil
 }
 
+func (btc *Client) GetBlock(ctx context.Context, hash []byte) (*blocktx_api.Block, error) {
+	block, err := btc.client.GetBlock(ctx, &blocktx_api.Hash{Hash: hash})
+	if err != nil {
+		return nil, convertError(err, ErrBlockNotFound)
+	}
+
+	return block, nil
+}
+
+func (btc *Client) GetBlockStats(ctx context.Context, hash []byte) (*blocktx_api.BlockStats, error) {
+	stats, err := btc.client.GetBlockStats(ctx, &blocktx_api.Hash{Hash: hash})
+	if err != nil {
+		return nil, convertError(err, ErrBlockNotFound)
+	}
+
+	return stats, nil
+}
+
+func (btc *Client) GetBlockByHeight(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+	block, err := btc.client.GetBlockByHeight(ctx, &blocktx_api.Height{Height: height})
+	if err != nil {
+		return nil, convertError(err, ErrBlockNotFound)
+	}
+
+	return block, nil
+}
+
+func (btc *Client) GetChainTip(ctx context.Context) (*blocktx_api.Block, error) {
+	block, err := btc.client.GetChainTip(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, convertError(err, ErrBlockNotFound)
+	}
+
+	return block, nil
+}
+
+func (btc *Client) GetBlockTransactions(ctx context.Context, hash []byte, offset uint64, limit uint64) (*blocktx_api.BlockTransactions, error) {
+	transactions, err := btc.client.GetBlockTransactions(ctx, &blocktx_api.BlockTransactionsRequest{
+		BlockHash: hash,
+		Offset:    offset,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, convertError(err, ErrBlockNotFound)
+	}
+
+	return transactions, nil
+}
+
+func (btc *Client) GetConfirmations(ctx context.Context, hash []byte) (*blocktx_api.Confirmations, error) {
+	confirmations, err := btc.client.GetConfirmations(ctx, &blocktx_api.Transaction{Hash: hash})
+	if err != nil {
+		return nil, convertError(err, ErrTransactionNotFound)
+	}
+
+	return confirmations, nil
+}
+
+func (btc *Client) VerifyMerklePath(ctx context.Context, merklePath string, hash []byte) (*blocktx_api.MerklePathVerification, error) {
+	verification, err := btc.client.VerifyMerklePath(ctx, &blocktx_api.MerklePathVerificationRequest{
+		MerklePath:      merklePath,
+		TransactionHash: hash,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMerklePath, status.Convert(err).Message())
+		}
+		return nil, err
+	}
+
+	return verification, nil
+}
+
+// convertError returns notFoundErr if the server responded with a not found status.
+func convertError(err error, notFoundErr error) error {
+	if status.Code(err) == codes.NotFound {
+		return notFoundErr
+	}
+
+	return err
+}
+
 func DialGRPC(address string) (*grpc.ClientConn, error) {
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -0,0 +1,61 @@
+package blocktx
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type primaryCollector struct {
+	election *PrimaryElection
+	primary  *prometheus.Desc
+	epoch    *prometheus.Desc
+	changes  *prometheus.Desc
+}
+
+var primaryCollectorLoaded = atomic.Bool{}
+
+func newPrimaryCollector(e *PrimaryElection) *primaryCollector {
+	if !primaryCollectorLoaded.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	c := &primaryCollector{
+		election: e,
+		primary: prometheus.NewDesc("arc_blocktx_primary",
+			"Shows whether this instance is the primary blocktx instance",
+			nil, nil,
+		),
+		epoch: prometheus.NewDesc("arc_blocktx_primary_epoch",
+			"Shows the epoch of the lease held by this instance, 0 if it is not primary",
+			nil, nil,
+		),
+		changes: prometheus.NewDesc("arc_blocktx_primary_changes_count",
+			"Shows how often this instance became or stopped being primary",
+			nil, nil,
+		),
+	}
+
+	prometheus.MustRegister(c)
+
+	return c
+}
+
+// Describe writes all descriptors to the prometheus desc channel.
+func (c *primaryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.primary
+	ch <- c.epoch
+	ch <- c.changes
+}
+
+// Collect implements required collect function for all prometheus collectors
+func (c *primaryCollector) Collect(ch chan<- prometheus.Metric) {
+	var primary float64
+	if c.election.IsPrimary() {
+		primary = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.primary, prometheus.GaugeValue, primary)
+	ch <- prometheus.MustNewConstMetric(c.epoch, prometheus.GaugeValue, float64(c.election.Epoch()))
+	ch <- prometheus.MustNewConstMetric(c.changes, prometheus.CounterValue, float64(c.election.Changes()))
+}
@@ -0,0 +1,530 @@
+package headers
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+)
+
+const (
+	// DefaultRetainedHeaders is the number of headers below the tip which are kept in memory. It covers two legacy
+	// difficulty adjustment intervals, so that all difficulty rules can be validated after the chain has been loaded.
+	DefaultRetainedHeaders = 4032
+
+	medianTimeBlocks      = 11
+	maxFutureBlockTime    = 2 * time.Hour
+	maxInvalidHeaders     = 10000
+	daaWindow             = 144
+	edaMedianTimeBlocks   = 6
+	edaMedianTimeTimespan = 12 * time.Hour
+)
+
+var (
+	ErrUnknownParent = errors.New("previous block header is unknown")
+	ErrInvalidHeader = errors.New("invalid block header")
+
+	ErrTargetOutOfRange = errors.New("target out of range")
+	ErrHashAboveTarget  = errors.New("block hash is higher than target")
+	ErrBadDifficulty    = errors.New("unexpected difficulty")
+	ErrTimeTooOld       = errors.New("block timestamp is not after median time past")
+	ErrTimeTooNew       = errors.New("block timestamp is too far in the future")
+	ErrInvalidAncestor  = errors.New("block header descends from an invalid block header")
+)
+
+// Node is a validated block header in the header chain.
+type Node struct {
+	Hash   chainhash.Hash
+	Header wire.BlockHeader
+	Height uint64
+	// ChainWork is the accumulated work from the root of the chain up to and including this header.
+	ChainWork *big.Int
+
+	parent *Node
+}
+
+// Parent returns the previous header or nil if it is not part of the chain.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Ancestor returns the header of the chain of this node at the given height or nil if it is not part of the chain.
+func (n *Node) Ancestor(height uint64) *Node {
+	if height > n.Height {
+		return nil
+	}
+
+	node := n
+	for node != nil && node.Height > height {
+		node = node.parent
+	}
+
+	return node
+}
+
+// Chain is an in-memory tree of validated block headers. The chain with the most accumulated work is the
+// authoritative chain. Headers which fail validation are quarantined, so that they and their descendants are
+// rejected without validating them again.
+type Chain struct {
+	mu sync.RWMutex
+
+	params          *Params
+	index           map[chainhash.Hash]*Node
+	invalid         map[chainhash.Hash]error
+	root            *Node
+	tip             *Node
+	retainedHeaders uint64
+	now             func() time.Time
+
+	anchorHeight  uint64
+	anchorHeaders []*wire.BlockHeader
+}
+
+// WithAnchor starts the chain with previously validated headers instead of the genesis block. The first header is
+// trusted without validation and placed at the given height, all following headers are validated.
+func WithAnchor(height uint64, headers []*wire.BlockHeader) func(*Chain) {
+	return func(c *Chain) {
+		c.anchorHeight = height
+		c.anchorHeaders = headers
+	}
+}
+
+// WithRetainedHeaders sets the number of headers below the tip which are kept in memory.
+func WithRetainedHeaders(retainedHeaders uint64) func(*Chain) {
+	return func(c *Chain) {
+		c.retainedHeaders = retainedHeaders
+	}
+}
+
+// WithNow sets the function which returns the current time used to validate timestamps.
+func WithNow(now func() time.Time) func(*Chain) {
+	return func(c *Chain) {
+		c.now = now
+	}
+}
+
+// NewChain returns a header chain which starts at the genesis block of the network or at the anchor headers.
+func NewChain(params *Params, opts ...func(*Chain)) (*Chain, error) {
+	c := &Chain{
+		params:          params,
+		index:           make(map[chainhash.Hash]*Node),
+		invalid:         make(map[chainhash.Hash]error),
+		retainedHeaders: DefaultRetainedHeaders,
+		now:             time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	rootHeader := &params.GenesisHeader
+	rootHeight := uint64(0)
+	if len(c.anchorHeaders) > 0 {
+		rootHeader = c.anchorHeaders[0]
+		rootHeight = c.anchorHeight
+	}
+
+	root := &Node{
+		Hash:      rootHeader.BlockHash(),
+		Header:    *rootHeader,
+		Height:    rootHeight,
+		ChainWork: CalcWork(rootHeader.Bits),
+	}
+	c.index[root.Hash] = root
+	c.root = root
+	c.tip = root
+
+	if len(c.anchorHeaders) > 1 {
+		for _, header := range c.anchorHeaders[1:] {
+			_, _, err := c.addHeader(header)
+			// headers of competing chains which fork below the root cannot be connected and are skipped
+			if err != nil && !errors.Is(err, ErrUnknownParent) {
+				return nil, fmt.Errorf("failed to load header %s: %w", header.BlockHash().String(), err)
+			}
+		}
+	}
+
+	c.anchorHeaders = nil
+
+	return c, nil
+}
+
+// Tip returns the last header of the chain with the most accumulated work.
+func (c *Chain) Tip() *Node {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.tip
+}
+
+// Lookup returns the header with the given hash or nil if it is not part of the chain.
+func (c *Chain) Lookup(hash *chainhash.Hash) *Node {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.index[*hash]
+}
+
+// IsInvalid returns whether the header with the given hash has been rejected.
+func (c *Chain) IsInvalid(hash *chainhash.Hash) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, found := c.invalid[*hash]
+	return found
+}
+
+// InBestChain returns whether the given header is part of the chain with the most accumulated work.
+func (c *Chain) InBestChain(node *Node) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.tip.Ancestor(node.Height) == node
+}
+
+// Locator returns block locator hashes for a getheaders message starting at the tip. The first 10 hashes are
+// consecutive, after that the step doubles with each hash. The last hash is the root of the chain.
+func (c *Chain) Locator() []*chainhash.Hash {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	locator := make([]*chainhash.Hash, 0, wire.MaxBlockLocatorsPerMsg)
+	step := uint64(1)
+	node := c.tip
+	for node != nil && len(locator) < wire.MaxBlockLocatorsPerMsg-1 {
+		hash := node.Hash
+		locator = append(locator, &hash)
+
+		if node.Height < c.root.Height+step {
+			break
+		}
+
+		node = node.Ancestor(node.Height - step)
+		if len(locator) > 10 {
+			step *= 2
+		}
+	}
+
+	if last := locator[len(locator)-1]; !last.IsEqual(&c.root.Hash) {
+		rootHash := c.root.Hash
+		locator = append(locator, &rootHash)
+	}
+
+	return locator
+}
+
+// AddHeader validates the header and adds it to the chain. It returns the node of the header and whether the header
+// was not yet part of the chain. Errors wrap ErrUnknownParent if the previous header is unknown and ErrInvalidHeader
+// if the header failed validation.
+func (c *Chain) AddHeader(header *wire.BlockHeader) (*Node, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.addHeader(header)
+}
+
+// ProcessHeaders adds the headers of a headers message to the chain. Processing stops at the first header which
+// cannot be added. It returns the headers which have been newly added.
+func (c *Chain) ProcessHeaders(headers []*wire.BlockHeader) ([]*Node, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	added := make([]*Node, 0, len(headers))
+	for _, header := range headers {
+		node, isNew, err := c.addHeader(header)
+		if err != nil {
+			return added, err
+		}
+
+		if isNew {
+			added = append(added, node)
+		}
+	}
+
+	return added, nil
+}
+
+func (c *Chain) addHeader(header *wire.BlockHeader) (*Node, bool, error) {
+	hash := header.BlockHash()
+
+	if node, found := c.index[hash]; found {
+		return node, false, nil
+	}
+
+	if reason, found := c.invalid[hash]; found {
+		return nil, false, fmt.Errorf("%w %s: %w", ErrInvalidHeader, hash.String(), reason)
+	}
+
+	parent, found := c.index[header.PrevBlock]
+	if !found {
+		if _, parentInvalid := c.invalid[header.PrevBlock]; parentInvalid {
+			return nil, false, c.reject(hash, ErrInvalidAncestor)
+		}
+
+		return nil, false, fmt.Errorf("%w: %s of header %s", ErrUnknownParent, header.PrevBlock.String(), hash.String())
+	}
+
+	if err := CheckProofOfWork(header, c.params.PowLimit); err != nil {
+		return nil, false, c.reject(hash, err)
+	}
+
+	requiredBits, ok := c.requiredBits(parent, header)
+	if ok && header.Bits != requiredBits {
+		return nil, false, c.reject(hash, fmt.Errorf("%w: bits %08x, expected %08x", ErrBadDifficulty, header.Bits, requiredBits))
+	}
+
+	if medianTime, ok := medianTimePast(parent); ok && !header.Timestamp.After(medianTime) {
+		return nil, false, c.reject(hash, fmt.Errorf("%w: timestamp %s, median time past %s", ErrTimeTooOld, header.Timestamp.UTC(), medianTime.UTC()))
+	}
+
+	// a header from the future may become valid later, therefore it is not quarantined
+	if header.Timestamp.After(c.now().Add(maxFutureBlockTime)) {
+		return nil, false, fmt.Errorf("%w %s: %w: timestamp %s", ErrInvalidHeader, hash.String(), ErrTimeTooNew, header.Timestamp.UTC())
+	}
+
+	node := &Node{
+		Hash:      hash,
+		Header:    *header,
+		Height:    parent.Height + 1,
+		ChainWork: new(big.Int).Add(parent.ChainWork, CalcWork(header.Bits)),
+		parent:    parent,
+	}
+	c.index[hash] = node
+
+	if node.ChainWork.Cmp(c.tip.ChainWork) > 0 {
+		c.tip = node
+		c.prune()
+	}
+
+	return node, true, nil
+}
+
+// reject quarantines the header and returns the validation error.
+func (c *Chain) reject(hash chainhash.Hash, reason error) error {
+	if len(c.invalid) >= maxInvalidHeaders {
+		for invalidHash := range c.invalid {
+			delete(c.invalid, invalidHash)
+			break
+		}
+	}
+
+	c.invalid[hash] = reason
+
+	return fmt.Errorf("%w %s: %w", ErrInvalidHeader, hash.String(), reason)
+}
+
+// prune removes headers which are more than the retained number of headers below the tip.
+func (c *Chain) prune() {
+	if c.tip.Height < c.root.Height+2*c.retainedHeaders {
+		return
+	}
+
+	newRoot := c.tip.Ancestor(c.tip.Height - c.retainedHeaders)
+	if newRoot == nil {
+		return
+	}
+
+	for hash, node := range c.index {
+		if node.Height < newRoot.Height {
+			delete(c.index, hash)
+			continue
+		}
+
+		if node.parent != nil && node.parent.Height < newRoot.Height {
+			node.parent = nil
+		}
+	}
+
+	newRoot.parent = nil
+	c.root = newRoot
+}
+
+// requiredBits calculates the difficulty the header following the parent must have. It returns false if the chain
+// does not contain enough headers to calculate the difficulty.
+func (c *Chain) requiredBits(parent *Node, header *wire.BlockHeader) (uint32, bool) {
+	if c.params.NoRetargeting {
+		return parent.Header.Bits, true
+	}
+
+	if parent.Height >= c.params.DAAHeight {
+		return c.daaRequiredBits(parent, header)
+	}
+
+	return c.edaRequiredBits(parent, header)
+}
+
+// daaRequiredBits calculates the difficulty according to the difficulty adjustment algorithm which targets the
+// average work of the last 144 blocks.
+func (c *Chain) daaRequiredBits(parent *Node, header *wire.BlockHeader) (uint32, bool) {
+	if c.params.ReduceMinDifficulty && header.Timestamp.After(parent.Header.Timestamp.Add(2*c.params.TargetTimePerBlock)) {
+		return c.params.PowLimitBits, true
+	}
+
+	if parent.Height < daaWindow {
+		return 0, false
+	}
+
+	last := suitableBlock(parent)
+	first := suitableBlock(parent.Ancestor(parent.Height - daaWindow))
+	if last == nil || first == nil {
+		return 0, false
+	}
+
+	spacing := int64(c.params.TargetTimePerBlock / time.Second)
+
+	work := new(big.Int).Sub(last.ChainWork, first.ChainWork)
+	work.Mul(work, big.NewInt(spacing))
+
+	timespan := last.Header.Timestamp.Unix() - first.Header.Timestamp.Unix()
+	timespan = max(min(timespan, 288*spacing), 72*spacing)
+	work.Div(work, big.NewInt(timespan))
+
+	if work.Sign() <= 0 {
+		return 0, false
+	}
+
+	// target = (2^256 - work) / work
+	target := new(big.Int).Sub(oneLsh256, work)
+	target.Div(target, work)
+
+	if target.Cmp(c.params.PowLimit) > 0 {
+		return c.params.PowLimitBits, true
+	}
+
+	return BigToCompact(target), true
+}
+
+// edaRequiredBits calculates the difficulty according to the legacy difficulty adjustment every 2016 blocks
+// together with the emergency difficulty adjustment which has been active between the UAHF and the DAA.
+func (c *Chain) edaRequiredBits(parent *Node, header *wire.BlockHeader) (uint32, bool) {
+	interval := c.params.DifficultyAdjustmentInterval()
+	height := parent.Height + 1
+
+	if height%interval == 0 {
+		first := parent.Ancestor(height - interval)
+		if first == nil {
+			return 0, false
+		}
+
+		return c.retarget(parent, first.Header.Timestamp), true
+	}
+
+	if c.params.ReduceMinDifficulty {
+		if header.Timestamp.After(parent.Header.Timestamp.Add(2 * c.params.TargetTimePerBlock)) {
+			return c.params.PowLimitBits, true
+		}
+
+		// return the difficulty of the last block which did not use the minimum difficulty
+		node := parent
+		for node.parent != nil && node.Height%interval != 0 && node.Header.Bits == c.params.PowLimitBits {
+			node = node.parent
+		}
+
+		if node.parent == nil && node.Height != 0 && node.Height%interval != 0 && node.Header.Bits == c.params.PowLimitBits {
+			return 0, false
+		}
+
+		return node.Header.Bits, true
+	}
+
+	bits := parent.Header.Bits
+	if bits == c.params.PowLimitBits || parent.Height < c.params.UAHFHeight {
+		return bits, true
+	}
+
+	// if producing the last 6 blocks took more than 12h, the difficulty is reduced by 20%
+	if height < edaMedianTimeBlocks+1 {
+		return bits, true
+	}
+
+	parentMedianTime, ok := medianTimePast(parent)
+	if !ok {
+		return 0, false
+	}
+
+	ancestor := parent.Ancestor(height - edaMedianTimeBlocks - 1)
+	if ancestor == nil {
+		return 0, false
+	}
+
+	ancestorMedianTime, ok := medianTimePast(ancestor)
+	if !ok {
+		return 0, false
+	}
+
+	if parentMedianTime.Sub(ancestorMedianTime) < edaMedianTimeTimespan {
+		return bits, true
+	}
+
+	target := CompactToBig(bits)
+	target.Add(target, new(big.Int).Rsh(target, 2))
+	if target.Cmp(c.params.PowLimit) > 0 {
+		target = c.params.PowLimit
+	}
+
+	return BigToCompact(target), true
+}
+
+// retarget calculates the legacy difficulty adjustment based on the time it took to mine the last interval.
+func (c *Chain) retarget(parent *Node, firstBlockTime time.Time) uint32 {
+	targetTimespan := int64(c.params.TargetTimespan / time.Second)
+
+	actualTimespan := parent.Header.Timestamp.Unix() - firstBlockTime.Unix()
+	actualTimespan = max(min(actualTimespan, targetTimespan*4), targetTimespan/4)
+
+	target := CompactToBig(parent.Header.Bits)
+	target.Mul(target, big.NewInt(actualTimespan))
+	target.Div(target, big.NewInt(targetTimespan))
+
+	if target.Cmp(c.params.PowLimit) > 0 {
+		target = c.params.PowLimit
+	}
+
+	return BigToCompact(target)
+}
+
+// suitableBlock returns the header with the median timestamp of the given header and its two predecessors.
+func suitableBlock(node *Node) *Node {
+	if node == nil || node.parent == nil || node.parent.parent == nil {
+		return nil
+	}
+
+	blocks := []*Node{node.parent.parent, node.parent, node}
+	if blocks[0].Header.Timestamp.After(blocks[2].Header.Timestamp) {
+		blocks[0], blocks[2] = blocks[2], blocks[0]
+	}
+	if blocks[0].Header.Timestamp.After(blocks[1].Header.Timestamp) {
+		blocks[0], blocks[1] = blocks[1], blocks[0]
+	}
+	if blocks[1].Header.Timestamp.After(blocks[2].Header.Timestamp) {
+		blocks[1], blocks[2] = blocks[2], blocks[1]
+	}
+
+	return blocks[1]
+}
+
+// medianTimePast returns the median timestamp of the last 11 headers up to and including the given header. It returns
+// false if the chain does not contain enough headers.
+func medianTimePast(node *Node) (time.Time, bool) {
+	timestamps := make([]int64, 0, medianTimeBlocks)
+
+	current := node
+	for current != nil && len(timestamps) < medianTimeBlocks {
+		timestamps = append(timestamps, current.Header.Timestamp.Unix())
+
+		if current.parent == nil && current.Height != 0 && len(timestamps) < medianTimeBlocks {
+			return time.Time{}, false
+		}
+
+		current = current.parent
+	}
+
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+	return time.Unix(timestamps[len(timestamps)/2], 0), true
+}
@@ -0,0 +1,429 @@
+package headers
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// mineHeader returns a header on top of the parent whose hash satisfies the target of the given bits.
+func mineHeader(t testing.TB, parent *wire.BlockHeader, timestamp time.Time, bits uint32) *wire.BlockHeader {
+	t.Helper()
+
+	header := &wire.BlockHeader{
+		Version:    0x20000000,
+		PrevBlock:  parent.BlockHash(),
+		MerkleRoot: chainhash.DoubleHashH([]byte(timestamp.String())),
+		Timestamp:  timestamp,
+		Bits:       bits,
+	}
+
+	target := CompactToBig(bits)
+	for {
+		hash := header.BlockHash()
+		if HashToBig(&hash).Cmp(target) <= 0 {
+			return header
+		}
+		header.Nonce++
+	}
+}
+
+// failHeader returns a header on top of the parent whose hash does not satisfy the target of the given bits.
+func failHeader(t testing.TB, parent *wire.BlockHeader, timestamp time.Time, bits uint32) *wire.BlockHeader {
+	t.Helper()
+
+	header := mineHeader(t, parent, timestamp, bits)
+	target := CompactToBig(bits)
+	for {
+		hash := header.BlockHash()
+		if HashToBig(&hash).Cmp(target) > 0 {
+			return header
+		}
+		header.Nonce++
+	}
+}
+
+// mineChain returns a chain of headers on top of the parent with the given time between the headers.
+func mineChain(t testing.TB, parent *wire.BlockHeader, count int, spacing time.Duration, bits uint32) []*wire.BlockHeader {
+	t.Helper()
+
+	headers := make([]*wire.BlockHeader, count)
+	for i := range headers {
+		headers[i] = mineHeader(t, parent, parent.Timestamp.Add(spacing), bits)
+		parent = headers[i]
+	}
+
+	return headers
+}
+
+func newRegtestChain(t *testing.T, opts ...func(*Chain)) *Chain {
+	t.Helper()
+
+	opts = append([]func(*Chain){WithNow(func() time.Time { return RegressionNetParams.GenesisHeader.Timestamp.Add(365 * 24 * time.Hour) })}, opts...)
+	chain, err := NewChain(&RegressionNetParams, opts...)
+	require.NoError(t, err)
+
+	return chain
+}
+
+func TestChainProcessHeaders(t *testing.T) {
+	genesis := &RegressionNetParams.GenesisHeader
+	bits := RegressionNetParams.PowLimitBits
+	valid := mineChain(t, genesis, 20, 10*time.Minute, bits)
+
+	tt := []struct {
+		name    string
+		headers func() []*wire.BlockHeader
+
+		expectedAdded     int
+		expectedTipHeight uint64
+		expectedErr       error
+		expectedInvalid   bool
+	}{
+		{
+			name:    "valid chain",
+			headers: func() []*wire.BlockHeader { return valid },
+
+			expectedAdded:     20,
+			expectedTipHeight: 20,
+		},
+		{
+			name: "unknown parent",
+			headers: func() []*wire.BlockHeader {
+				return valid[1:]
+			},
+
+			expectedTipHeight: 0,
+			expectedErr:       ErrUnknownParent,
+		},
+		{
+			name: "hash above target",
+			headers: func() []*wire.BlockHeader {
+				return append(valid[:5:5], failHeader(t, valid[4], valid[4].Timestamp.Add(10*time.Minute), bits))
+			},
+
+			expectedAdded:     5,
+			expectedTipHeight: 5,
+			expectedErr:       ErrHashAboveTarget,
+			expectedInvalid:   true,
+		},
+		{
+			name: "target above pow limit",
+			headers: func() []*wire.BlockHeader {
+				return []*wire.BlockHeader{mineHeader(t, genesis, genesis.Timestamp.Add(10*time.Minute), 0x217fffff)}
+			},
+
+			expectedErr:     ErrTargetOutOfRange,
+			expectedInvalid: true,
+		},
+		{
+			name: "difficulty changed without retargeting",
+			headers: func() []*wire.BlockHeader {
+				return append(valid[:3:3], mineHeader(t, valid[2], valid[2].Timestamp.Add(10*time.Minute), 0x203fffff))
+			},
+
+			expectedAdded:     3,
+			expectedTipHeight: 3,
+			expectedErr:       ErrBadDifficulty,
+			expectedInvalid:   true,
+		},
+		{
+			name: "timestamp not after median time past",
+			headers: func() []*wire.BlockHeader {
+				return append(valid[:12:12], mineHeader(t, valid[11], valid[6].Timestamp, bits))
+			},
+
+			expectedAdded:     12,
+			expectedTipHeight: 12,
+			expectedErr:       ErrTimeTooOld,
+			expectedInvalid:   true,
+		},
+		{
+			name: "timestamp too far in the future",
+			headers: func() []*wire.BlockHeader {
+				return []*wire.BlockHeader{mineHeader(t, genesis, genesis.Timestamp.Add(400*24*time.Hour), bits)}
+			},
+
+			expectedErr: ErrTimeTooNew,
+		},
+		{
+			name: "duplicate headers",
+			headers: func() []*wire.BlockHeader {
+				return append(valid[:3:3], valid[:5]...)
+			},
+
+			expectedAdded:     5,
+			expectedTipHeight: 5,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			chain := newRegtestChain(t)
+			headers := tc.headers()
+
+			added, err := chain.ProcessHeaders(headers)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				if tc.expectedErr != ErrUnknownParent {
+					require.ErrorIs(t, err, ErrInvalidHeader)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, added, tc.expectedAdded)
+			require.Equal(t, tc.expectedTipHeight, chain.Tip().Height)
+
+			last := headers[len(headers)-1].BlockHash()
+			require.Equal(t, tc.expectedInvalid, chain.IsInvalid(&last))
+		})
+	}
+}
+
+func TestChainQuarantine(t *testing.T) {
+	genesis := &RegressionNetParams.GenesisHeader
+	bits := RegressionNetParams.PowLimitBits
+	chain := newRegtestChain(t)
+
+	invalid := failHeader(t, genesis, genesis.Timestamp.Add(10*time.Minute), bits)
+	descendants := mineChain(t, invalid, 3, 10*time.Minute, bits)
+
+	_, _, err := chain.AddHeader(invalid)
+	require.ErrorIs(t, err, ErrHashAboveTarget)
+
+	// the invalid header is rejected again without validation
+	_, _, err = chain.AddHeader(invalid)
+	require.ErrorIs(t, err, ErrInvalidHeader)
+	require.ErrorIs(t, err, ErrHashAboveTarget)
+
+	// descendants of the invalid header are rejected and quarantined as well
+	for _, header := range descendants {
+		_, _, err = chain.AddHeader(header)
+		require.ErrorIs(t, err, ErrInvalidAncestor)
+
+		hash := header.BlockHash()
+		require.True(t, chain.IsInvalid(&hash))
+	}
+
+	require.Equal(t, uint64(0), chain.Tip().Height)
+}
+
+func TestChainReorg(t *testing.T) {
+	genesis := &RegressionNetParams.GenesisHeader
+	bits := RegressionNetParams.PowLimitBits
+	chain := newRegtestChain(t)
+
+	main := mineChain(t, genesis, 10, 10*time.Minute, bits)
+	_, err := chain.ProcessHeaders(main)
+	require.NoError(t, err)
+
+	// competing chain forking at height 5 with less work
+	fork := mineChain(t, main[4], 3, 11*time.Minute, bits)
+	added, err := chain.ProcessHeaders(fork)
+	require.NoError(t, err)
+	require.Len(t, added, 3)
+
+	mainTip := main[9].BlockHash()
+	require.Equal(t, mainTip, chain.Tip().Hash)
+	require.False(t, chain.InBestChain(added[0]))
+
+	// the competing chain becomes the chain with the most work
+	longer := mineChain(t, fork[2], 3, 11*time.Minute, bits)
+	added, err = chain.ProcessHeaders(longer)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(11), chain.Tip().Height)
+	require.Equal(t, longer[2].BlockHash(), chain.Tip().Hash)
+	require.True(t, chain.InBestChain(added[0]))
+	require.False(t, chain.InBestChain(chain.Lookup(&mainTip)))
+}
+
+func TestChainLocator(t *testing.T) {
+	genesis := &RegressionNetParams.GenesisHeader
+	chain := newRegtestChain(t)
+
+	locator := chain.Locator()
+	require.Len(t, locator, 1)
+	require.Equal(t, RegressionNetParams.GenesisHash(), *locator[0])
+
+	_, err := chain.ProcessHeaders(mineChain(t, genesis, 100, 10*time.Minute, RegressionNetParams.PowLimitBits))
+	require.NoError(t, err)
+
+	locator = chain.Locator()
+
+	heights := make([]uint64, len(locator))
+	for i, hash := range locator {
+		heights[i] = chain.Lookup(hash).Height
+	}
+
+	require.Equal(t, []uint64{100, 99, 98, 97, 96, 95, 94, 93, 92, 91, 90, 89, 87, 83, 75, 59, 27, 0}, heights)
+}
+
+func TestChainPrune(t *testing.T) {
+	genesis := &RegressionNetParams.GenesisHeader
+	chain := newRegtestChain(t, WithRetainedHeaders(10))
+
+	headers := mineChain(t, genesis, 25, 10*time.Minute, RegressionNetParams.PowLimitBits)
+	_, err := chain.ProcessHeaders(headers)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(25), chain.Tip().Height)
+	require.Nil(t, chain.Lookup(&headers[8].PrevBlock))
+
+	root := headers[9].BlockHash()
+	require.NotNil(t, chain.Lookup(&root))
+	require.Nil(t, chain.Lookup(&root).Parent())
+
+	// headers connecting below the root are unknown
+	_, _, err = chain.AddHeader(mineHeader(t, headers[3], headers[3].Timestamp.Add(time.Minute), RegressionNetParams.PowLimitBits))
+	require.ErrorIs(t, err, ErrUnknownParent)
+}
+
+func TestChainAnchor(t *testing.T) {
+	genesis := &RegressionNetParams.GenesisHeader
+	bits := RegressionNetParams.PowLimitBits
+	headers := mineChain(t, genesis, 30, 10*time.Minute, bits)
+
+	chain := newRegtestChain(t, WithAnchor(11, headers[10:]))
+
+	require.Equal(t, uint64(30), chain.Tip().Height)
+	require.Equal(t, headers[29].BlockHash(), chain.Tip().Hash)
+
+	// the median time past is only validated with enough headers after the anchor
+	next := mineHeader(t, headers[29], headers[29].Timestamp.Add(10*time.Minute), bits)
+	node, isNew, err := chain.AddHeader(next)
+	require.NoError(t, err)
+	require.True(t, isNew)
+	require.Equal(t, uint64(31), node.Height)
+
+	_, err = NewChain(&RegressionNetParams, WithAnchor(11, []*wire.BlockHeader{headers[10], failHeader(t, headers[10], headers[10].Timestamp.Add(time.Minute), bits)}))
+	require.ErrorIs(t, err, ErrHashAboveTarget)
+}
+
+// retargetParams returns regtest parameters with difficulty adjustments enabled.
+func retargetParams(genesisBits uint32, modify func(*Params)) *Params {
+	params := RegressionNetParams
+	params.NoRetargeting = false
+	params.ReduceMinDifficulty = false
+	params.GenesisHeader.Bits = genesisBits
+	modify(&params)
+
+	return &params
+}
+
+func TestChainDAA(t *testing.T) {
+	params := retargetParams(RegressionNetParams.PowLimitBits, func(p *Params) {})
+	for {
+		if CheckProofOfWork(&params.GenesisHeader, params.PowLimit) == nil {
+			break
+		}
+		params.GenesisHeader.Nonce++
+	}
+	genesis := &params.GenesisHeader
+
+	newChain := func() *Chain {
+		chain, err := NewChain(params, WithNow(func() time.Time { return genesis.Timestamp.Add(365 * 24 * time.Hour) }))
+		require.NoError(t, err)
+		return chain
+	}
+
+	t.Run("target spacing keeps the pow limit", func(t *testing.T) {
+		chain := newChain()
+
+		_, err := chain.ProcessHeaders(mineChain(t, genesis, 160, 10*time.Minute, params.PowLimitBits))
+		require.NoError(t, err)
+	})
+
+	t.Run("fast blocks increase the difficulty", func(t *testing.T) {
+		chain := newChain()
+
+		// the difficulty is validated as soon as the chain contains 147 headers
+		fast := mineChain(t, genesis, 146, 5*time.Minute, params.PowLimitBits)
+		_, err := chain.ProcessHeaders(fast)
+		require.NoError(t, err)
+
+		// twice the work in the minimum timespan of 72 blocks results in target (2^256 - 4) / 4
+		next := mineHeader(t, fast[145], fast[145].Timestamp.Add(5*time.Minute), params.PowLimitBits)
+		_, _, err = chain.AddHeader(next)
+		require.ErrorIs(t, err, ErrBadDifficulty)
+
+		next = mineHeader(t, fast[145], fast[145].Timestamp.Add(5*time.Minute), 0x203fffff)
+		node, _, err := chain.AddHeader(next)
+		require.NoError(t, err)
+		require.Equal(t, uint64(147), node.Height)
+	})
+}
+
+func TestChainLegacyRetarget(t *testing.T) {
+	params := retargetParams(RegressionNetParams.PowLimitBits, func(p *Params) {
+		p.TargetTimespan = 10 * p.TargetTimePerBlock
+		p.UAHFHeight = 1000000
+		p.DAAHeight = 1000000
+	})
+	genesis := &params.GenesisHeader
+
+	chain, err := NewChain(params, WithNow(func() time.Time { return genesis.Timestamp.Add(365 * 24 * time.Hour) }))
+	require.NoError(t, err)
+
+	// the difficulty stays the same within an interval
+	headers := mineChain(t, genesis, 9, 5*time.Minute, params.PowLimitBits)
+	_, err = chain.ProcessHeaders(headers)
+	require.NoError(t, err)
+
+	// the interval of 10 blocks took 45 minutes instead of 100 minutes
+	target := CompactToBig(params.PowLimitBits)
+	target.Mul(target, big.NewInt(45*60))
+	target.Div(target, big.NewInt(100*60))
+	expectedBits := BigToCompact(target)
+
+	_, _, err = chain.AddHeader(mineHeader(t, headers[8], headers[8].Timestamp.Add(5*time.Minute), params.PowLimitBits))
+	require.ErrorIs(t, err, ErrBadDifficulty)
+
+	node, _, err := chain.AddHeader(mineHeader(t, headers[8], headers[8].Timestamp.Add(5*time.Minute), expectedBits))
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), node.Height)
+}
+
+func TestChainEDA(t *testing.T) {
+	const bits = 0x203fffff
+
+	params := retargetParams(bits, func(p *Params) {
+		p.UAHFHeight = 0
+		p.DAAHeight = 1000000
+	})
+	for {
+		if CheckProofOfWork(&params.GenesisHeader, params.PowLimit) == nil {
+			break
+		}
+		params.GenesisHeader.Nonce++
+	}
+	genesis := &params.GenesisHeader
+
+	chain, err := NewChain(params, WithNow(func() time.Time { return genesis.Timestamp.Add(365 * 24 * time.Hour) }))
+	require.NoError(t, err)
+
+	headers := mineChain(t, genesis, 12, 10*time.Minute, bits)
+	_, err = chain.ProcessHeaders(headers)
+	require.NoError(t, err)
+
+	// from height 22 on producing the last 6 blocks took more than 12 hours
+	slow := mineChain(t, headers[11], 9, 3*time.Hour, bits)
+	_, err = chain.ProcessHeaders(slow)
+	require.NoError(t, err)
+
+	target := CompactToBig(bits)
+	target.Add(target, new(big.Int).Rsh(target, 2))
+	expectedBits := BigToCompact(target)
+
+	_, _, err = chain.AddHeader(mineHeader(t, slow[8], slow[8].Timestamp.Add(3*time.Hour), bits))
+	require.ErrorIs(t, err, ErrBadDifficulty)
+
+	node, _, err := chain.AddHeader(mineHeader(t, slow[8], slow[8].Timestamp.Add(3*time.Hour), expectedBits))
+	require.NoError(t, err)
+	require.Equal(t, uint64(22), node.Height)
+}
@@ -0,0 +1,154 @@
+package headers
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+)
+
+// initialBlockSubsidy is the block subsidy of 50 coins in satoshis before the first halving.
+const initialBlockSubsidy = 50 * 100_000_000
+
+var (
+	// mainPowLimit is the highest proof of work target a block can have on mainnet and testnet (2^224 - 1).
+	mainPowLimit = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 224), big.NewInt(1))
+
+	// regressionPowLimit is the highest proof of work target a block can have on regtest (2^255 - 1).
+	regressionPowLimit = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+
+	genesisMerkleRoot = mustHash("4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b")
+)
+
+// Params defines the consensus rules of a network which are required to validate block headers.
+type Params struct {
+	Name string
+	Net  wire.BitcoinNet
+
+	GenesisHeader wire.BlockHeader
+
+	// PowLimit is the highest allowed proof of work target and PowLimitBits its compact representation.
+	PowLimit     *big.Int
+	PowLimitBits uint32
+
+	TargetTimespan     time.Duration
+	TargetTimePerBlock time.Duration
+
+	// ReduceMinDifficulty allows blocks with the minimum difficulty if no block was found for twice the target time
+	// per block.
+	ReduceMinDifficulty bool
+	// NoRetargeting disables the difficulty adjustment.
+	NoRetargeting bool
+
+	// UAHFHeight is the height after which the emergency difficulty adjustment (EDA) is active.
+	UAHFHeight uint64
+	// DAAHeight is the height after which the difficulty adjustment algorithm (DAA) over 144 blocks is active.
+	DAAHeight uint64
+
+	// SubsidyHalvingInterval is the number of blocks after which the block subsidy is halved.
+	SubsidyHalvingInterval uint64
+}
+
+// DifficultyAdjustmentInterval returns the number of blocks between two legacy difficulty adjustments.
+func (p *Params) DifficultyAdjustmentInterval() uint64 {
+	return uint64(p.TargetTimespan / p.TargetTimePerBlock)
+}
+
+// BlockSubsidy returns the amount of new coins in satoshis which the coinbase transaction of the block at the given
+// height may claim in addition to the fees.
+func (p *Params) BlockSubsidy(height uint64) uint64 {
+	halvings := height / p.SubsidyHalvingInterval
+	if halvings >= 64 {
+		return 0
+	}
+
+	return initialBlockSubsidy >> halvings
+}
+
+// GenesisHash returns the hash of the genesis block.
+func (p *Params) GenesisHash() chainhash.Hash {
+	return p.GenesisHeader.BlockHash()
+}
+
+var MainNetParams = Params{
+	Name: "mainnet",
+	Net:  wire.MainNet,
+	GenesisHeader: wire.BlockHeader{
+		Version:    1,
+		MerkleRoot: *genesisMerkleRoot,
+		Timestamp:  time.Unix(1231006505, 0),
+		Bits:       0x1d00ffff,
+		Nonce:      2083236893,
+	},
+	PowLimit:               mainPowLimit,
+	PowLimitBits:           0x1d00ffff,
+	TargetTimespan:         14 * 24 * time.Hour,
+	TargetTimePerBlock:     10 * time.Minute,
+	UAHFHeight:             478558,
+	DAAHeight:              504031,
+	SubsidyHalvingInterval: 210000,
+}
+
+var TestNetParams = Params{
+	Name: "testnet",
+	Net:  wire.TestNet3,
+	GenesisHeader: wire.BlockHeader{
+		Version:    1,
+		MerkleRoot: *genesisMerkleRoot,
+		Timestamp:  time.Unix(1296688602, 0),
+		Bits:       0x1d00ffff,
+		Nonce:      414098458,
+	},
+	PowLimit:               mainPowLimit,
+	PowLimitBits:           0x1d00ffff,
+	TargetTimespan:         14 * 24 * time.Hour,
+	TargetTimePerBlock:     10 * time.Minute,
+	ReduceMinDifficulty:    true,
+	UAHFHeight:             1155875,
+	DAAHeight:              1188697,
+	SubsidyHalvingInterval: 210000,
+}
+
+var RegressionNetParams = Params{
+	Name: "regtest",
+	Net:  wire.TestNet,
+	GenesisHeader: wire.BlockHeader{
+		Version:    1,
+		MerkleRoot: *genesisMerkleRoot,
+		Timestamp:  time.Unix(1296688602, 0),
+		Bits:       0x207fffff,
+		Nonce:      2,
+	},
+	PowLimit:               regressionPowLimit,
+	PowLimitBits:           0x207fffff,
+	TargetTimespan:         14 * 24 * time.Hour,
+	TargetTimePerBlock:     10 * time.Minute,
+	ReduceMinDifficulty:    true,
+	NoRetargeting:          true,
+	SubsidyHalvingInterval: 150,
+}
+
+// NetworkParams returns the parameters of the given network.
+func NetworkParams(network wire.BitcoinNet) (*Params, error) {
+	switch network {
+	case wire.MainNet:
+		return &MainNetParams, nil
+	case wire.TestNet3:
+		return &TestNetParams, nil
+	case wire.TestNet:
+		return &RegressionNetParams, nil
+	default:
+		return nil, fmt.Errorf("no chain parameters for network %s", network)
+	}
+}
+
+func mustHash(s string) *chainhash.Hash {
+	hash, err := chainhash.NewHashFromStr(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return hash
+}
@@ -0,0 +1,59 @@
+package headers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockSubsidy(t *testing.T) {
+	tt := []struct {
+		name   string
+		params *Params
+		height uint64
+
+		expectedSubsidy uint64
+	}{
+		{
+			name:   "mainnet genesis",
+			params: &MainNetParams,
+			height: 0,
+
+			expectedSubsidy: 5_000_000_000,
+		},
+		{
+			name:   "mainnet before first halving",
+			params: &MainNetParams,
+			height: 209999,
+
+			expectedSubsidy: 5_000_000_000,
+		},
+		{
+			name:   "mainnet after third halving",
+			params: &MainNetParams,
+			height: 630000,
+
+			expectedSubsidy: 625_000_000,
+		},
+		{
+			name:   "regtest after first halving",
+			params: &RegressionNetParams,
+			height: 150,
+
+			expectedSubsidy: 2_500_000_000,
+		},
+		{
+			name:   "no subsidy after 64 halvings",
+			params: &MainNetParams,
+			height: 64 * 210000,
+
+			expectedSubsidy: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedSubsidy, tc.params.BlockSubsidy(tc.height))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package headers
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+)
+
+var oneLsh256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// HashToBig converts a block hash into a big integer which can be compared to a target.
+func HashToBig(hash *chainhash.Hash) *big.Int {
+	// the hash is little endian, big.Int expects big endian
+	buf := *hash
+	for i := 0; i < chainhash.HashSize/2; i++ {
+		buf[i], buf[chainhash.HashSize-1-i] = buf[chainhash.HashSize-1-i], buf[i]
+	}
+
+	return new(big.Int).SetBytes(buf[:])
+}
+
+// CompactToBig converts the compact representation of a target as used in the bits field of a block header into a
+// big integer. The compact representation consists of an 8 bit exponent, a sign bit and a 23 bit mantissa.
+func CompactToBig(compact uint32) *big.Int {
+	mantissa := compact & 0x007fffff
+	isNegative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	var bn *big.Int
+	if exponent <= 3 {
+		mantissa >>= 8 * (3 - exponent)
+		bn = big.NewInt(int64(mantissa))
+	} else {
+		bn = big.NewInt(int64(mantissa))
+		bn.Lsh(bn, 8*(exponent-3))
+	}
+
+	if isNegative {
+		bn = bn.Neg(bn)
+	}
+
+	return bn
+}
+
+// BigToCompact converts a target into its compact representation.
+func BigToCompact(n *big.Int) uint32 {
+	if n.Sign() == 0 {
+		return 0
+	}
+
+	var mantissa uint32
+	exponent := uint(len(n.Bytes()))
+	if exponent <= 3 {
+		mantissa = uint32(n.Bits()[0])
+		mantissa <<= 8 * (3 - exponent)
+	} else {
+		tn := new(big.Int).Set(n)
+		mantissa = uint32(tn.Rsh(tn, 8*(exponent-3)).Bits()[0])
+	}
+
+	// the sign bit must not be set in the mantissa, therefore the mantissa is shifted into the exponent if needed
+	if mantissa&0x00800000 != 0 {
+		mantissa >>= 8
+		exponent++
+	}
+
+	compact := uint32(exponent<<24) | mantissa
+	if n.Sign() < 0 {
+		compact |= 0x00800000
+	}
+
+	return compact
+}
+
+// CalcWork returns the expected number of hashes required to find a block with the given target, which is
+// 2^256 / (target + 1).
+func CalcWork(bits uint32) *big.Int {
+	target := CompactToBig(bits)
+	if target.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+
+	denominator := new(big.Int).Add(target, big.NewInt(1))
+	return new(big.Int).Div(oneLsh256, denominator)
+}
+
+// CheckProofOfWork checks that the target of the header is within the allowed range and that the hash of the
+// header does not exceed the target.
+func CheckProofOfWork(header *wire.BlockHeader, powLimit *big.Int) error {
+	target := CompactToBig(header.Bits)
+	if target.Sign() <= 0 {
+		return fmt.Errorf("%w: target %064x is not positive", ErrTargetOutOfRange, target)
+	}
+
+	if target.Cmp(powLimit) > 0 {
+		return fmt.Errorf("%w: target %064x is higher than %064x", ErrTargetOutOfRange, target, powLimit)
+	}
+
+	hash := header.BlockHash()
+	if HashToBig(&hash).Cmp(target) > 0 {
+		return fmt.Errorf("%w: hash %s is higher than target %064x", ErrHashAboveTarget, hash.String(), target)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package headers
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactToBig(t *testing.T) {
+	tt := []struct {
+		name    string
+		compact uint32
+
+		expected string
+	}{
+		{
+			name:     "mainnet pow limit",
+			compact:  0x1d00ffff,
+			expected: "00000000ffff0000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:     "regtest pow limit",
+			compact:  0x207fffff,
+			expected: "7fffff0000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:     "mainnet difficulty",
+			compact:  0x1808e2fc,
+			expected: "000000000000000008e2fc000000000000000000000000000000000000000000",
+		},
+		{
+			name:     "small exponent",
+			compact:  0x01123456,
+			expected: "0000000000000000000000000000000000000000000000000000000000000012",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			expected, ok := new(big.Int).SetString(tc.expected, 16)
+			require.True(t, ok)
+
+			actual := CompactToBig(tc.compact)
+			require.Equal(t, 0, expected.Cmp(actual))
+
+			if tc.compact&0x00ff0000 != 0 && tc.compact>>24 > 3 {
+				require.Equal(t, tc.compact, BigToCompact(actual))
+			}
+		})
+	}
+}
+
+func TestBigToCompact(t *testing.T) {
+	require.Equal(t, uint32(0), BigToCompact(big.NewInt(0)))
+	require.Equal(t, uint32(0x1d00ffff), BigToCompact(mainPowLimit))
+	require.Equal(t, uint32(0x207fffff), BigToCompact(regressionPowLimit))
+	require.Equal(t, uint32(0x207fffff), BigToCompact(CompactToBig(0x207fffff)))
+	// the sign bit is not set for positive targets
+	require.Equal(t, uint32(0x02008000), BigToCompact(big.NewInt(0x80)))
+}
+
+func TestCalcWork(t *testing.T) {
+	require.Equal(t, int64(0x100010001), CalcWork(0x1d00ffff).Int64())
+	require.Equal(t, int64(2), CalcWork(0x207fffff).Int64())
+	require.Equal(t, int64(0), CalcWork(0).Int64())
+}
+
+func TestCheckProofOfWork(t *testing.T) {
+	block1 := &wire.BlockHeader{
+		Version:    1,
+		PrevBlock:  MainNetParams.GenesisHash(),
+		MerkleRoot: *mustHash("0e3e2357e806b6cdb1f70b54c3a3a17b6714ee1f0e68bebb44a74b1efd512098"),
+		Timestamp:  time.Unix(1231469665, 0),
+		Bits:       0x1d00ffff,
+		Nonce:      2573394689,
+	}
+
+	tt := []struct {
+		name     string
+		header   *wire.BlockHeader
+		powLimit *big.Int
+
+		expectedErr error
+	}{
+		{
+			name:     "mainnet genesis",
+			header:   &MainNetParams.GenesisHeader,
+			powLimit: mainPowLimit,
+		},
+		{
+			name:     "mainnet block 1",
+			header:   block1,
+			powLimit: mainPowLimit,
+		},
+		{
+			name: "hash above target",
+			header: func() *wire.BlockHeader {
+				header := *block1
+				header.Nonce++
+				return &header
+			}(),
+			powLimit: mainPowLimit,
+
+			expectedErr: ErrHashAboveTarget,
+		},
+		{
+			name: "target above pow limit",
+			header: func() *wire.BlockHeader {
+				header := *block1
+				header.Bits = 0x1d01ffff
+				return &header
+			}(),
+			powLimit: mainPowLimit,
+
+			expectedErr: ErrTargetOutOfRange,
+		},
+		{
+			name: "negative target",
+			header: func() *wire.BlockHeader {
+				header := *block1
+				header.Bits = 0x1d80ffff
+				return &header
+			}(),
+			powLimit: mainPowLimit,
+
+			expectedErr: ErrTargetOutOfRange,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckProofOfWork(tc.header, tc.powLimit)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNetworkParams(t *testing.T) {
+	tt := []struct {
+		network wire.BitcoinNet
+
+		expectedGenesisHash string
+	}{
+		{network: wire.MainNet, expectedGenesisHash: "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"},
+		{network: wire.TestNet3, expectedGenesisHash: "000000000933ea01ad0ee984209779baaec3ced90fa3f408719526f8d77f4943"},
+		{network: wire.TestNet, expectedGenesisHash: "0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.network.String(), func(t *testing.T) {
+			params, err := NetworkParams(tc.network)
+			require.NoError(t, err)
+
+			genesisHash := params.GenesisHash()
+			require.Equal(t, tc.expectedGenesisHash, genesisHash.String())
+			require.NoError(t, CheckProofOfWork(&params.GenesisHeader, params.PowLimit))
+		})
+	}
+
+	_, err := NetworkParams(wire.SimNet)
+	require.Error(t, err)
+}
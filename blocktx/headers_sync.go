@@ -0,0 +1,244 @@
+package blocktx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/bitcoin-sv/arc/blocktx/headers"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/ordishs/go-utils"
+	"github.com/ordishs/gocore"
+)
+
+// maxUnconnectingHeaders is the number of consecutive headers messages which do not connect to the header chain
+// after which no more headers are requested until the next block announcement.
+const maxUnconnectingHeaders = 10
+
+// NewHeaderChain returns the header chain of the network starting at the latest stored headers or at the genesis
+// block if no headers have been stored yet.
+func NewHeaderChain(ctx context.Context, storeI store.Interface, network wire.BitcoinNet) (*headers.Chain, error) {
+	params, err := headers.NetworkParams(network)
+	if err != nil {
+		return nil, err
+	}
+
+	storedHeaders, err := storeI.GetLatestBlockHeaders(ctx, headers.DefaultRetainedHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored block headers: %v", err)
+	}
+
+	if len(storedHeaders) == 0 {
+		return headers.NewChain(params)
+	}
+
+	// the root of the chain is the lowest stored ancestor of the highest header
+	byHash := make(map[string]*store.BlockHeader, len(storedHeaders))
+	for _, header := range storedHeaders {
+		byHash[header.Header.BlockHash().String()] = header
+	}
+
+	root := storedHeaders[len(storedHeaders)-1]
+	for {
+		parent, found := byHash[root.Header.PrevBlock.String()]
+		if !found {
+			break
+		}
+		root = parent
+	}
+
+	anchor := []*wire.BlockHeader{root.Header}
+	for _, header := range storedHeaders {
+		if header.Height > root.Height {
+			anchor = append(anchor, header.Header)
+		}
+	}
+
+	return headers.NewChain(params, headers.WithAnchor(root.Height, anchor))
+}
+
+// readHeadersMessage reads a headers message from the wire and passes it on to the headers worker.
+func (bs *PeerHandler) readHeadersMessage(reader io.Reader, length uint64, bytesRead int) (int, wire.Message, []byte, error) {
+	msg := wire.NewMsgHeaders()
+	if length > msg.MaxPayloadLength(wire.ProtocolVersion) {
+		return bytesRead, nil, nil, fmt.Errorf("headers message of size %d exceeds maximum size", length)
+	}
+
+	payload := make([]byte, length)
+	read, err := io.ReadFull(reader, payload)
+	bytesRead += read
+	if err != nil {
+		return bytesRead, nil, nil, err
+	}
+
+	if err = msg.Bsvdecode(bytes.NewReader(payload), wire.ProtocolVersion, wire.BaseEncoding); err != nil {
+		return bytesRead, nil, nil, err
+	}
+
+	utils.SafeSend(bs.headersCh, msg)
+
+	return bytesRead, msg, nil, nil
+}
+
+func (bs *PeerHandler) startHeadersWorker() {
+	go func() {
+		for msg := range bs.headersCh {
+			peer := bs.getHeadersSyncPeer()
+			if peer == nil {
+				bs.logger.Warn("received unrequested headers")
+				continue
+			}
+
+			if err := bs.processHeaders(msg.Headers, peer); err != nil {
+				bs.logger.Error("failed to process headers", slog.String("peer", peer.String()), slog.String("err", err.Error()))
+			}
+		}
+	}()
+}
+
+// processHeaders adds the received headers to the header chain, stores the valid headers and requests the blocks
+// of the new headers.
+func (bs *PeerHandler) processHeaders(blockHeaders []*wire.BlockHeader, peer p2p.PeerI) error {
+	if len(blockHeaders) == 0 {
+		return nil
+	}
+
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("processHeaders").AddTime(start)
+	}()
+
+	added, err := bs.headerChain.ProcessHeaders(blockHeaders)
+
+	if storeErr := bs.storeHeaders(added); storeErr != nil {
+		return storeErr
+	}
+
+	if err != nil {
+		if !errors.Is(err, headers.ErrUnknownParent) {
+			bs.logger.Warn("rejected invalid block header", slog.String("peer", peer.String()), slog.String("err", err.Error()))
+			bs.recordInvalidResponse(peer)
+			bs.requestBlocks(added, peer)
+			return nil
+		}
+
+		bs.unconnectingHeadersCount++
+		if bs.unconnectingHeadersCount > maxUnconnectingHeaders {
+			return fmt.Errorf("received %d headers messages which do not connect to the header chain: %w", bs.unconnectingHeadersCount, err)
+		}
+
+		// request the headers between the header chain and the received headers
+		return bs.requestHeaders(peer)
+	}
+
+	bs.unconnectingHeadersCount = 0
+
+	tip := bs.headerChain.Tip()
+	bs.logger.Info("processed headers", slog.Int("received", len(blockHeaders)), slog.Int("new", len(added)), slog.Uint64("tip height", tip.Height), slog.String("tip", tip.Hash.String()))
+
+	bs.requestBlocks(added, peer)
+
+	// the peer has more headers if the message is full
+	if len(blockHeaders) == wire.MaxBlockHeadersPerMsg {
+		return bs.requestHeaders(peer)
+	}
+
+	return nil
+}
+
+// validateBlockHeader validates the header of a received block against the header chain. It returns nil if the
+// header does not connect to the header chain, in which case the missing headers are requested.
+func (bs *PeerHandler) validateBlockHeader(header *wire.BlockHeader, peer p2p.PeerI) (*headers.Node, error) {
+	node, isNew, err := bs.headerChain.AddHeader(header)
+	if err != nil {
+		if errors.Is(err, headers.ErrUnknownParent) {
+			return nil, bs.requestHeaders(peer)
+		}
+
+		return nil, err
+	}
+
+	if isNew {
+		if err = bs.storeHeaders([]*headers.Node{node}); err != nil {
+			return nil, err
+		}
+	}
+
+	return node, nil
+}
+
+// requestBlocks requests the blocks of new headers of the best chain which are within the data retention period.
+func (bs *PeerHandler) requestBlocks(nodes []*headers.Node, peer p2p.PeerI) {
+	tip := bs.headerChain.Tip()
+
+	minHeight := uint64(bs.startingHeight)
+	heightRange := uint64(bs.dataRetentionDays * hoursPerDay * blocksPerHour)
+	if tip.Height > heightRange && tip.Height-heightRange > minHeight {
+		minHeight = tip.Height - heightRange
+	}
+
+	for _, node := range nodes {
+		if node.Height < minHeight || !bs.headerChain.InBestChain(node) {
+			continue
+		}
+
+		pair := utils.NewPair(&node.Hash, peer)
+		utils.SafeSend(bs.workerCh, pair)
+	}
+}
+
+func (bs *PeerHandler) storeHeaders(nodes []*headers.Node) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	blockHeaders := make([]*store.BlockHeader, len(nodes))
+	for i, node := range nodes {
+		blockHeaders[i] = &store.BlockHeader{Height: node.Height, Header: &node.Header}
+	}
+
+	if err := bs.store.InsertBlockHeaders(context.Background(), blockHeaders); err != nil {
+		return fmt.Errorf("failed to store block headers: %v", err)
+	}
+
+	return nil
+}
+
+// requestHeaders requests the headers following the tip of the header chain from the peer.
+func (bs *PeerHandler) requestHeaders(peer p2p.PeerI) error {
+	msg := wire.NewMsgGetHeaders()
+	for _, hash := range bs.headerChain.Locator() {
+		if err := msg.AddBlockLocatorHash(hash); err != nil {
+			return err
+		}
+	}
+
+	bs.setHeadersSyncPeer(peer)
+
+	if err := peer.WriteMsg(msg); err != nil {
+		return fmt.Errorf("failed to request headers: %v", err)
+	}
+
+	bs.logger.Debug("requested headers", slog.String("peer", peer.String()), slog.Uint64("tip height", bs.headerChain.Tip().Height))
+
+	return nil
+}
+
+func (bs *PeerHandler) setHeadersSyncPeer(peer p2p.PeerI) {
+	bs.headersSyncPeerMu.Lock()
+	defer bs.headersSyncPeerMu.Unlock()
+
+	bs.headersSyncPeer = peer
+}
+
+func (bs *PeerHandler) getHeadersSyncPeer() p2p.PeerI {
+	bs.headersSyncPeerMu.Lock()
+	defer bs.headersSyncPeerMu.Unlock()
+
+	return bs.headersSyncPeer
+}
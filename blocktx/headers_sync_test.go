@@ -0,0 +1,363 @@
+package blocktx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/headers"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/bitcoin-sv/arc/tracing"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/ordishs/go-utils"
+	"github.com/ordishs/go-utils/expiringmap"
+	"github.com/ordishs/go-utils/safemap"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPeer struct {
+	MockedPeer
+	messages []wire.Message
+}
+
+func (peer *recordingPeer) WriteMsg(msg wire.Message) error {
+	peer.messages = append(peer.messages, msg)
+	return nil
+}
+
+// mineRegtestHeaders returns a chain of regtest headers on top of the parent.
+func mineRegtestHeaders(parent *wire.BlockHeader, count int) []*wire.BlockHeader {
+	target := headers.CompactToBig(headers.RegressionNetParams.PowLimitBits)
+
+	blockHeaders := make([]*wire.BlockHeader, count)
+	for i := range blockHeaders {
+		header := &wire.BlockHeader{
+			Version:    0x20000000,
+			PrevBlock:  parent.BlockHash(),
+			MerkleRoot: chainhash.DoubleHashH([]byte{byte(i)}),
+			Timestamp:  parent.Timestamp.Add(10 * time.Minute),
+			Bits:       headers.RegressionNetParams.PowLimitBits,
+		}
+
+		for {
+			hash := header.BlockHash()
+			if headers.HashToBig(&hash).Cmp(target) <= 0 {
+				break
+			}
+			header.Nonce++
+		}
+
+		blockHeaders[i] = header
+		parent = header
+	}
+
+	return blockHeaders
+}
+
+func newHeadersPeerHandler(t *testing.T, storeMock store.Interface, startingHeight int) *PeerHandler {
+	t.Helper()
+
+	chain, err := headers.NewChain(&headers.RegressionNetParams, headers.WithNow(func() time.Time {
+		return headers.RegressionNetParams.GenesisHeader.Timestamp.Add(365 * 24 * time.Hour)
+	}))
+	require.NoError(t, err)
+
+	stats := safemap.New[string, *tracing.PeerHandlerStats]()
+
+	return &PeerHandler{
+		store:             storeMock,
+		logger:            slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		workerCh:          make(chan utils.Pair[*chainhash.Hash, p2p.PeerI], 100),
+		announcedCache:    expiringmap.New[chainhash.Hash, []p2p.PeerI](10 * time.Minute),
+		stats:             stats,
+		reputation:        newPeerReputation(stats),
+		startingHeight:    startingHeight,
+		dataRetentionDays: 1,
+		headerChain:       chain,
+	}
+}
+
+func TestProcessHeaders(t *testing.T) {
+	genesis := &headers.RegressionNetParams.GenesisHeader
+	valid := mineRegtestHeaders(genesis, 10)
+
+	invalid := *mineRegtestHeaders(valid[5], 1)[0]
+	invalid.Bits = 0x217fffff
+
+	tt := []struct {
+		name         string
+		blockHeaders []*wire.BlockHeader
+
+		expectedStoredHeaders   int
+		expectedRequestedBlocks int
+		expectedGetHeaders      int
+		expectedTipHeight       uint64
+	}{
+		{
+			name:         "valid headers",
+			blockHeaders: valid,
+
+			expectedStoredHeaders:   10,
+			expectedRequestedBlocks: 4,
+			expectedTipHeight:       10,
+		},
+		{
+			name:         "headers not connecting to the chain",
+			blockHeaders: valid[3:],
+
+			expectedGetHeaders: 1,
+		},
+		{
+			name:         "invalid header",
+			blockHeaders: append(valid[:6:6], &invalid),
+
+			expectedStoredHeaders:   6,
+			expectedRequestedBlocks: 0,
+			expectedTipHeight:       6,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var storedHeaders []*store.BlockHeader
+			storeMock := &store.InterfaceMock{
+				InsertBlockHeadersFunc: func(ctx context.Context, blockHeaders []*store.BlockHeader) error {
+					storedHeaders = append(storedHeaders, blockHeaders...)
+					return nil
+				},
+			}
+
+			peerHandler := newHeadersPeerHandler(t, storeMock, 7)
+			peer := &recordingPeer{}
+
+			err := peerHandler.processHeaders(tc.blockHeaders, peer)
+			require.NoError(t, err)
+
+			require.Len(t, storedHeaders, tc.expectedStoredHeaders)
+			for i, header := range storedHeaders {
+				require.Equal(t, uint64(i+1), header.Height)
+			}
+
+			require.Len(t, peerHandler.workerCh, tc.expectedRequestedBlocks)
+			for i := 0; i < tc.expectedRequestedBlocks; i++ {
+				pair := <-peerHandler.workerCh
+				require.Equal(t, valid[6+i].BlockHash(), *pair.First)
+			}
+
+			require.Len(t, peer.messages, tc.expectedGetHeaders)
+			for _, msg := range peer.messages {
+				getHeaders, ok := msg.(*wire.MsgGetHeaders)
+				require.True(t, ok)
+				require.Equal(t, headers.RegressionNetParams.GenesisHash(), *getHeaders.BlockLocatorHashes[0])
+			}
+
+			require.Equal(t, tc.expectedTipHeight, peerHandler.headerChain.Tip().Height)
+		})
+	}
+}
+
+func TestHandleBlockHeaderValidation(t *testing.T) {
+	genesis := &headers.RegressionNetParams.GenesisHeader
+	valid := mineRegtestHeaders(genesis, 3)
+
+	invalid := *valid[2]
+	invalid.Nonce++
+	for {
+		hash := invalid.BlockHash()
+		if headers.HashToBig(&hash).Cmp(headers.CompactToBig(invalid.Bits)) > 0 {
+			break
+		}
+		invalid.Nonce++
+	}
+
+	tt := []struct {
+		name   string
+		header *wire.BlockHeader
+
+		expectedErr         error
+		expectedInsert      bool
+		expectedHeight      uint64
+		expectedGetHeaders  int
+		expectedInvalidHash bool
+	}{
+		{
+			name:   "valid header",
+			header: valid[2],
+
+			expectedInsert: true,
+			expectedHeight: 3,
+		},
+		{
+			name:   "header with insufficient proof of work",
+			header: &invalid,
+
+			expectedErr:         headers.ErrHashAboveTarget,
+			expectedInvalidHash: true,
+		},
+		{
+			name:   "header not connecting to the chain",
+			header: mineRegtestHeaders(valid[2], 1)[0],
+
+			expectedGetHeaders: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var insertedBlock *blocktx_api.Block
+			storeMock := &store.InterfaceMock{
+				InsertBlockHeadersFunc: func(ctx context.Context, blockHeaders []*store.BlockHeader) error {
+					return nil
+				},
+				GetBlockFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+					return &blocktx_api.Block{}, nil
+				},
+				InsertBlockFunc: func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
+					insertedBlock = block
+					return 1, nil
+				},
+				InsertBlockMerkleTreeFunc: func(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+					return nil
+				},
+				InsertBlockMerkleSubtreesFunc: func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+					return nil
+				},
+				UpdateBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+					return nil
+				},
+				GetBlockStatsFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+					return nil, store.ErrBlockNotFound
+				},
+				InsertBlockStatsFunc: func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+					return nil
+				},
+				MarkBlockAsDoneFunc: func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
+					return nil
+				},
+			}
+
+			peerHandler := newHeadersPeerHandler(t, storeMock, 0)
+			peerHandler.transactionStorageBatchSize = 10
+			_, err := peerHandler.headerChain.ProcessHeaders(valid[:2])
+			require.NoError(t, err)
+
+			// the coinbase of the block claims a wrong height and the merkle root matches the single transaction
+			header := *tc.header
+			txHash := header.MerkleRoot
+			peer := &recordingPeer{}
+
+			err = peerHandler.HandleBlock(&p2p.BlockMessage{
+				Header:            &header,
+				Height:            1000,
+				TransactionHashes: []*chainhash.Hash{&txHash},
+			}, peer)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tc.expectedInsert {
+				require.NotNil(t, insertedBlock)
+				require.Equal(t, tc.expectedHeight, insertedBlock.GetHeight())
+			} else {
+				require.Nil(t, insertedBlock)
+			}
+
+			require.Len(t, peer.messages, tc.expectedGetHeaders)
+
+			hash := tc.header.BlockHash()
+			require.Equal(t, tc.expectedInvalidHash, peerHandler.headerChain.IsInvalid(&hash))
+		})
+	}
+}
+
+func TestHandleBlockRequestedAfterHeadersSync(t *testing.T) {
+	genesis := &headers.RegressionNetParams.GenesisHeader
+	valid := mineRegtestHeaders(genesis, 4)
+
+	storeMock := &store.InterfaceMock{
+		InsertBlockHeadersFunc: func(ctx context.Context, blockHeaders []*store.BlockHeader) error {
+			return nil
+		},
+	}
+
+	peerHandler := newHeadersPeerHandler(t, storeMock, 0)
+	_, err := peerHandler.headerChain.ProcessHeaders(valid[:2])
+	require.NoError(t, err)
+
+	peer := &recordingPeer{}
+	blockHash := valid[3].BlockHash()
+	peerHandler.requestBlock(&blockHash, peer)
+
+	// the header of the block does not connect to the header chain, the block is dropped
+	header := *valid[3]
+	err = peerHandler.HandleBlock(&p2p.BlockMessage{Header: &header, Height: 4}, peer)
+	require.NoError(t, err)
+
+	_, found := peerHandler.announcedCache.Get(blockHash)
+	require.False(t, found)
+
+	// the missing headers arrive and the blocks of the new headers are requested
+	peer.messages = nil
+	err = peerHandler.processHeaders(valid[2:], peer)
+	require.NoError(t, err)
+
+	require.Len(t, peerHandler.workerCh, 2)
+	for len(peerHandler.workerCh) > 0 {
+		pair := <-peerHandler.workerCh
+		if !peerHandler.isAnnounced(pair.First, pair.Second) {
+			peerHandler.requestBlock(pair.First, pair.Second)
+		}
+	}
+
+	var requested []chainhash.Hash
+	for _, msg := range peer.messages {
+		getData, ok := msg.(*wire.MsgGetData)
+		require.True(t, ok)
+		for _, inv := range getData.InvList {
+			requested = append(requested, inv.Hash)
+		}
+	}
+
+	require.Equal(t, []chainhash.Hash{valid[2].BlockHash(), blockHash}, requested)
+}
+
+func TestNewHeaderChain(t *testing.T) {
+	genesis := &headers.RegressionNetParams.GenesisHeader
+	main := mineRegtestHeaders(genesis, 20)
+	// a competing header at the lowest stored height which is not an ancestor of the tip
+	competing := mineRegtestHeaders(main[8], 1)[0]
+
+	storedHeaders := []*store.BlockHeader{{Height: 10, Header: competing}}
+	for i := 9; i < len(main); i++ {
+		storedHeaders = append(storedHeaders, &store.BlockHeader{Height: uint64(i + 1), Header: main[i]})
+	}
+
+	storeMock := &store.InterfaceMock{
+		GetLatestBlockHeadersFunc: func(ctx context.Context, count uint64) ([]*store.BlockHeader, error) {
+			require.Equal(t, uint64(headers.DefaultRetainedHeaders), count)
+			return storedHeaders, nil
+		},
+	}
+
+	chain, err := NewHeaderChain(context.Background(), storeMock, wire.TestNet)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(20), chain.Tip().Height)
+	require.Equal(t, main[19].BlockHash(), chain.Tip().Hash)
+
+	storeMock.GetLatestBlockHeadersFunc = func(ctx context.Context, count uint64) ([]*store.BlockHeader, error) {
+		return nil, nil
+	}
+
+	chain, err = NewHeaderChain(context.Background(), storeMock, wire.TestNet)
+	require.NoError(t, err)
+	require.Equal(t, headers.RegressionNetParams.GenesisHash(), chain.Tip().Hash)
+}
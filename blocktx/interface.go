@@ -10,4 +10,7 @@ var (
 	ErrTransactionNotFound = errors.New("transaction not found")
 
 	ErrTransactionNotFoundForMerklePath = errors.New("transaction not found for given Merkle path")
+
+	// ErrInvalidMerklePath is returned when a Merkle path which should be verified cannot be parsed.
+	ErrInvalidMerklePath = errors.New("invalid Merkle path")
 )
@@ -8,14 +8,13 @@ import (
 	"io"
 	"log/slog"
 	"math/rand"
-	"os"
-	"runtime"
+	"sync"
 	"time"
 
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/headers"
 	"github.com/bitcoin-sv/arc/blocktx/store"
 	"github.com/bitcoin-sv/arc/tracing"
-	"github.com/libsv/go-bc"
 	"github.com/libsv/go-bt/v2"
 	"github.com/libsv/go-p2p"
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
@@ -28,62 +27,18 @@ import (
 
 const (
 	transactionStoringBatchsizeDefault = 2048 // power of 2 for easier memory allocation
+	subtreesStoringBatchSize           = 64
 	maxRequestBlocks                   = 5
 	fillGapsInterval                   = 15 * time.Minute
 	maximumBlockSize                   = 4294967296 // 4Gb
 )
 
-func init() {
-	// override the default wire block handler with our own that streams and stores only the transaction ids
-	wire.SetExternalHandler(wire.CmdBlock, func(reader io.Reader, length uint64, bytesRead int) (int, wire.Message, []byte, error) {
-		blockMessage := &p2p.BlockMessage{
-			Header: &wire.BlockHeader{},
-		}
-
-		err := blockMessage.Header.Deserialize(reader)
-		if err != nil {
-			return bytesRead, nil, nil, err
-		}
-		bytesRead += 80 // the bitcoin header is always 80 bytes
+var (
+	ErrMerkleRootMismatch = errors.New("merkle root mismatch")
 
-		var read int64
-		var txCount bt.VarInt
-		read, err = txCount.ReadFrom(reader)
-		if err != nil {
-			return bytesRead, nil, nil, err
-		}
-		bytesRead += int(read)
-
-		blockMessage.TransactionHashes = make([]*chainhash.Hash, txCount)
-
-		var tx *bt.Tx
-		var hash *chainhash.Hash
-		var txBytes []byte
-		for i := 0; i < int(txCount); i++ {
-			tx = bt.NewTx()
-			read, err = tx.ReadFrom(reader)
-			if err != nil {
-				return bytesRead, nil, nil, err
-			}
-			bytesRead += int(read)
-			txBytes = tx.TxIDBytes() // this returns the bytes in BigEndian
-			hash, err = chainhash.NewHash(bt.ReverseBytes(txBytes))
-			if err != nil {
-				return 0, nil, nil, err
-			}
-
-			blockMessage.TransactionHashes[i] = hash
-
-			if i == 0 {
-				blockMessage.Height = extractHeightFromCoinbaseTx(tx)
-			}
-		}
-
-		blockMessage.Size = uint64(bytesRead)
-
-		return bytesRead, blockMessage, nil, nil
-	})
-}
+	// errBlockRequestedAgain is returned if a block could not be processed and has been requested from another peer.
+	errBlockRequestedAgain = errors.New("block requested from another peer")
+)
 
 type PeerHandler struct {
 	workerCh                    chan utils.Pair[*chainhash.Hash, p2p.PeerI]
@@ -99,6 +54,32 @@ type PeerHandler struct {
 	fillGapsTicker           *time.Ticker
 	quitFillBlockGap         chan struct{}
 	quitFillBlockGapComplete chan struct{}
+
+	headerChain              *headers.Chain
+	headersCh                chan *wire.MsgHeaders
+	headersSyncPeer          p2p.PeerI
+	headersSyncPeerMu        sync.Mutex
+	unconnectingHeadersCount int
+
+	spoolDir         string
+	spoolMemoryLimit int
+	blockTxIDs       *expiringmap.ExpiringMap[*p2p.BlockMessage, *blockTxIDs]
+
+	registeredTransactions *RegisteredTransactions
+	fullIndex              bool
+
+	pipeline *blockPipeline
+
+	peers                 []p2p.PeerI
+	reputation            *peerReputation
+	quitBlockRequestCheck chan struct{}
+
+	election *PrimaryElection
+
+	chainParams         *headers.Params
+	blockStatsCollector *blockStatsCollector
+
+	peerOptions []p2p.PeerOptions
 }
 
 func init() {
@@ -123,6 +104,76 @@ func WithFillGapsInterval(interval time.Duration) func(notifier *PeerHandler) {
 	}
 }
 
+// WithHeaderChain enables the headers-first sync. Block headers are validated against the header chain before the
+// blocks are requested and processed.
+func WithHeaderChain(chain *headers.Chain) func(handler *PeerHandler) {
+	return func(p *PeerHandler) {
+		p.headerChain = chain
+	}
+}
+
+// WithBlockSpool sets the directory and the memory limit of the transaction ids of a block. The transaction ids which
+// exceed the memory limit are written to a temporary file in the directory. By default, the directory is the default
+// directory for temporary files.
+func WithBlockSpool(dir string, memoryLimit int) func(handler *PeerHandler) {
+	return func(p *PeerHandler) {
+		p.spoolDir = dir
+		p.spoolMemoryLimit = memoryLimit
+	}
+}
+
+// WithRegisteredTransactions sets the index of registered transactions. Only the registered transactions of a block
+// are stored, the transactions which are not registered are filtered out before the store is queried. The index is not
+// used with the full index.
+func WithRegisteredTransactions(registeredTransactions *RegisteredTransactions) func(handler *PeerHandler) {
+	return func(p *PeerHandler) {
+		p.registeredTransactions = registeredTransactions
+	}
+}
+
+// WithFullIndex stores all transactions of a block whether they are registered or not.
+func WithFullIndex() func(handler *PeerHandler) {
+	return func(p *PeerHandler) {
+		p.fullIndex = true
+	}
+}
+
+// WithBlockPipelineDepth enables the pipelined download and processing of blocks with at most depth blocks in flight.
+// A received block is processed while the next blocks are downloaded.
+func WithBlockPipelineDepth(depth int) func(handler *PeerHandler) {
+	return func(p *PeerHandler) {
+		if depth > 0 {
+			p.pipeline = newBlockPipeline(depth)
+		}
+	}
+}
+
+// WithPeerReputation sets the score at which a peer is banned, the duration of a ban and the time after which a peer
+// which has not sent a requested block is penalized and the block is requested from another peer.
+func WithPeerReputation(banScore int, banDuration time.Duration, blockResponseTimeout time.Duration) func(handler *PeerHandler) {
+	return func(p *PeerHandler) {
+		p.reputation.banScore = int64(banScore)
+		p.reputation.banDuration = banDuration
+		p.reputation.responseTimeout = blockResponseTimeout
+	}
+}
+
+// WithPrimaryElection processes blocks only while the instance is elected primary and writes the blocks with the
+// epoch of its lease as fencing token. Without a primary election the instance processes blocks as the only instance.
+func WithPrimaryElection(election *PrimaryElection) func(handler *PeerHandler) {
+	return func(p *PeerHandler) {
+		p.election = election
+	}
+}
+
+// WithPeerOptions passes the options to the peers which are created for the peer URLs, e.g. p2p.WithDialer to connect
+// to the nodes of a simulated network.
+func WithPeerOptions(opts ...p2p.PeerOptions) func(handler *PeerHandler) {
+	return func(p *PeerHandler) {
+		p.peerOptions = append(p.peerOptions, opts...)
+	}
+}
+
 func NewPeerHandler(logger *slog.Logger, storeI store.Interface, startingHeight int, peerURLs []string, network wire.BitcoinNet, opts ...func(*PeerHandler)) (*PeerHandler, error) {
 	evictionFunc := func(hash chainhash.Hash, peers []p2p.PeerI) bool {
 		msg := wire.NewMsgGetData()
@@ -144,14 +195,21 @@ func NewPeerHandler(logger *slog.Logger, storeI store.Interface, startingHeight
 		return false
 	}
 
+	stats := safemap.New[string, *tracing.PeerHandlerStats]()
+
 	ph := &PeerHandler{
 		store:                       storeI,
 		logger:                      logger,
 		workerCh:                    make(chan utils.Pair[*chainhash.Hash, p2p.PeerI], 100),
 		announcedCache:              expiringmap.New[chainhash.Hash, []p2p.PeerI](10 * time.Minute).WithEvictionFunction(evictionFunc),
-		stats:                       safemap.New[string, *tracing.PeerHandlerStats](),
+		stats:                       stats,
+		reputation:                  newPeerReputation(stats),
+		quitBlockRequestCheck:       make(chan struct{}),
 		transactionStorageBatchSize: transactionStoringBatchsizeDefault,
 		startingHeight:              startingHeight,
+		spoolMemoryLimit:            spoolMemoryLimitDefault,
+		blockTxIDs:                  newBlockTxIDsCache(),
+		blockStatsCollector:         newBlockStatsCollector(),
 
 		fillGapsTicker:           time.NewTicker(fillGapsInterval),
 		quitFillBlockGap:         make(chan struct{}),
@@ -162,14 +220,31 @@ func NewPeerHandler(logger *slog.Logger, storeI store.Interface, startingHeight
 		opt(ph)
 	}
 
+	// without the parameters of the network the fees of a block are not calculated
+	if params, err := headers.NetworkParams(network); err == nil {
+		ph.chainParams = params
+	}
+
+	// override the default wire block handler with our own that streams and keeps only the transaction ids
+	wire.SetExternalHandler(wire.CmdBlock, ph.readBlockMessage)
+
 	ph.peerHandlerCollector = tracing.NewPeerHandlerCollector("blocktx", ph.stats)
 	tracing.Register(ph.peerHandlerCollector)
 
+	if ph.headerChain != nil {
+		ph.headersCh = make(chan *wire.MsgHeaders, 10)
+		// go-p2p does not pass headers messages to the peer handler, therefore they are read by an external handler
+		wire.SetExternalHandler(wire.CmdHeaders, ph.readHeadersMessage)
+		ph.startHeadersWorker()
+	}
+
 	peers := make([]*p2p.Peer, len(peerURLs))
+	ph.peers = make([]p2p.PeerI, len(peerURLs))
 	pm := p2p.NewPeerManager(logger, network, p2p.WithExcessiveBlockSize(maximumBlockSize))
 
 	for i, peerURL := range peerURLs {
-		peer, err := p2p.NewPeer(logger, peerURL, ph, network, p2p.WithMaximumMessageSize(maximumBlockSize))
+		peerOpts := append([]p2p.PeerOptions{p2p.WithMaximumMessageSize(maximumBlockSize)}, ph.peerOptions...)
+		peer, err := p2p.NewPeer(logger, peerURL, ph, network, peerOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("error creating peer %s: %v", peerURL, err)
 		}
@@ -179,10 +254,18 @@ func NewPeerHandler(logger *slog.Logger, storeI store.Interface, startingHeight
 		}
 
 		peers[i] = peer
+		ph.peers[i] = peer
 	}
 
 	ph.startFillGaps(peers)
-	ph.startPeerWorker()
+	ph.startBlockRequestCheck()
+
+	if ph.pipeline != nil {
+		ph.startPipelinedPeerWorker()
+		ph.startBlockProcessor()
+	} else {
+		ph.startPeerWorker()
+	}
 
 	return ph, nil
 }
@@ -190,43 +273,131 @@ func NewPeerHandler(logger *slog.Logger, storeI store.Interface, startingHeight
 func (bs *PeerHandler) startPeerWorker() {
 	go func() {
 		for pair := range bs.workerCh {
-			hash := pair.First
-			peer := pair.Second
-
-			item, found := bs.announcedCache.Get(*hash)
-			if !found {
-				bs.announcedCache.Set(*hash, []p2p.PeerI{peer})
-				bs.logger.Debug("added block hash with peer to announced cache", slog.String("hash", hash.String()), slog.String("peer", peer.String()))
-			} else {
-				// if already was announced to peer, continue
-				for _, announcedPeer := range item {
-					if announcedPeer.String() == peer.String() {
-						continue
-					}
-				}
-
-				item = append(item, peer)
-				bs.announcedCache.Set(*hash, item)
-				bs.logger.Debug("added peer to announced cache of block hash", slog.String("hash", hash.String()), slog.String("peer", peer.String()))
+			if bs.isAnnounced(pair.First, pair.Second) {
 				continue
 			}
 
-			msg := wire.NewMsgGetData()
-			if err := msg.AddInvVect(wire.NewInvVect(wire.InvTypeBlock, hash)); err != nil {
-				bs.logger.Error("ProcessBlock: could not create InvVect", slog.String("err", err.Error()))
-				continue
-			}
+			bs.requestBlock(pair.First, pair.Second)
+		}
+	}()
+}
 
-			if err := peer.WriteMsg(msg); err != nil {
-				bs.logger.Error("ProcessBlock: failed to write message to peer", slog.String("err", err.Error()))
-				continue
-			}
+// isAnnounced returns true if the block has been requested already, in which case the peer is added to the peers which
+// announced the block.
+func (bs *PeerHandler) isAnnounced(hash *chainhash.Hash, peer p2p.PeerI) bool {
+	item, found := bs.announcedCache.Get(*hash)
+	if !found {
+		return false
+	}
+
+	// if already was announced to peer, continue
+	for _, announcedPeer := range item {
+		if announcedPeer.String() == peer.String() {
+			continue
+		}
+	}
+
+	item = append(item, peer)
+	bs.announcedCache.Set(*hash, item)
+	bs.logger.Debug("added peer to announced cache of block hash", slog.String("hash", hash.String()), slog.String("peer", peer.String()))
+
+	return true
+}
+
+func (bs *PeerHandler) requestBlock(hash *chainhash.Hash, peer p2p.PeerI) {
+	if bs.reputation.isBanned(peer) {
+		peer = bs.selectPeer(peer)
+		if peer == nil {
+			bs.logger.Warn("no peer to request block from which is not banned", slog.String("hash", hash.String()))
+			return
+		}
+	}
+
+	bs.announcedCache.Set(*hash, []p2p.PeerI{peer})
+	bs.logger.Debug("added block hash with peer to announced cache", slog.String("hash", hash.String()), slog.String("peer", peer.String()))
+
+	msg := wire.NewMsgGetData()
+	if err := msg.AddInvVect(wire.NewInvVect(wire.InvTypeBlock, hash)); err != nil {
+		bs.logger.Error("ProcessBlock: could not create InvVect", slog.String("err", err.Error()))
+		return
+	}
+
+	if err := peer.WriteMsg(msg); err != nil {
+		bs.logger.Error("ProcessBlock: failed to write message to peer", slog.String("err", err.Error()))
+		return
+	}
+
+	bs.reputation.requested(hash, peer)
+	bs.logger.Info("ProcessBlock", slog.String("hash", hash.String()))
+}
+
+// selectPeer returns the connected and healthy peer with the lowest reputation score which is not banned, other than
+// the excluded peer. It returns nil if there is no such peer.
+func (bs *PeerHandler) selectPeer(exclude p2p.PeerI) p2p.PeerI {
+	var selected p2p.PeerI
+	for _, peer := range bs.peers {
+		if peer.String() == exclude.String() || !peer.Connected() || !peer.IsHealthy() || bs.reputation.isBanned(peer) {
+			continue
+		}
+
+		if selected == nil || bs.reputation.score(peer) < bs.reputation.score(selected) {
+			selected = peer
+		}
+	}
+
+	return selected
+}
+
+// requestBlockFromOtherPeer requests the block from another peer than the given one. It returns false if there is no
+// other peer to request the block from.
+func (bs *PeerHandler) requestBlockFromOtherPeer(hash *chainhash.Hash, peer p2p.PeerI) bool {
+	otherPeer := bs.selectPeer(peer)
+	if otherPeer == nil {
+		bs.logger.Warn("no other peer to request block from", slog.String("hash", hash.String()), slog.String("peer", peer.String()))
+		return false
+	}
+
+	// the block stays in flight until it has been received from the other peer
+	if bs.pipeline != nil {
+		bs.pipeline.tryAcquire(hash)
+	}
+
+	bs.logger.Info("requesting block from other peer", slog.String("hash", hash.String()), slog.String("peer", otherPeer.String()))
+	bs.requestBlock(hash, otherPeer)
+
+	return true
+}
 
-			bs.logger.Info("ProcessBlock", slog.String("hash", hash.String()))
+// startBlockRequestCheck penalizes the peers which do not send requested blocks in time and requests the blocks from
+// other peers.
+func (bs *PeerHandler) startBlockRequestCheck() {
+	go func() {
+		ticker := time.NewTicker(blockRequestCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-bs.quitBlockRequestCheck:
+				return
+			case <-ticker.C:
+				bs.checkBlockRequests()
+			}
 		}
 	}()
 }
 
+func (bs *PeerHandler) checkBlockRequests() {
+	for _, request := range bs.reputation.overdueRequests() {
+		bs.logger.Warn("peer did not send requested block in time", slog.String("hash", request.hash.String()), slog.String("peer", request.peer.String()))
+
+		if bs.reputation.recordSlowResponse(request.peer) {
+			bs.logger.Warn("banned peer", slog.String("peer", request.peer.String()))
+		}
+
+		bs.requestBlockFromOtherPeer(&request.hash, request.peer)
+	}
+}
+
 func (bn *PeerHandler) startFillGaps(peers []*p2p.Peer) {
 	go func() {
 		defer func() {
@@ -327,17 +498,11 @@ func (bs *PeerHandler) HandleTransaction(msg *wire.MsgTx, peer p2p.PeerI) error
 }
 
 func (bs *PeerHandler) CheckPrimary() (bool, error) {
-	primaryBlocktx, err := bs.store.GetPrimary(context.TODO())
-	if err != nil {
-		return false, err
+	if bs.election == nil {
+		return true, nil
 	}
 
-	hostName, err := os.Hostname()
-	if err != nil {
-		return false, err
-	}
-
-	if primaryBlocktx != hostName {
+	if !bs.election.IsPrimary() {
 		bs.logger.Info("Not primary, skipping block processing")
 		return false, nil
 	}
@@ -345,6 +510,25 @@ func (bs *PeerHandler) CheckPrimary() (bool, error) {
 	return true, nil
 }
 
+// fencedContext returns the context for writing a block. With a primary election it carries the epoch of the lease as
+// fencing token, so that the store rejects the block if the lease has been acquired by another instance.
+func (bs *PeerHandler) fencedContext() (context.Context, int64) {
+	if bs.election == nil {
+		return context.Background(), 0
+	}
+
+	epoch := bs.election.Epoch()
+
+	return store.WithFencingEpoch(context.Background(), epoch), epoch
+}
+
+// checkFenced gives up the primary status if the store rejected a block written with the given epoch.
+func (bs *PeerHandler) checkFenced(err error, epoch int64) {
+	if bs.election != nil && errors.Is(err, store.ErrFenced) {
+		bs.election.fenced(epoch)
+	}
+}
+
 func (bs *PeerHandler) HandleBlockAnnouncement(msg *wire.InvVect, peer p2p.PeerI) error {
 	primary, err := bs.CheckPrimary()
 	if err != nil {
@@ -365,18 +549,96 @@ func (bs *PeerHandler) HandleBlockAnnouncement(msg *wire.InvVect, peer p2p.PeerI
 
 	stat.BlockAnnouncement.Add(1)
 
+	if bs.reputation.isBanned(peer) {
+		bs.logger.Debug("ignoring block announcement of banned peer", slog.String("hash", msg.Hash.String()), slog.String("peer", peerStr))
+		return nil
+	}
+
 	start := gocore.CurrentNanos()
 	defer func() {
 		gocore.NewStat("blocktx").NewStat("HandleBlockAnnouncement").AddTime(start)
 	}()
 
+	if bs.headerChain != nil {
+		if bs.headerChain.IsInvalid(&msg.Hash) {
+			bs.logger.Warn("ignoring announced block with invalid header", slog.String("hash", msg.Hash.String()), slog.String("peer", peerStr))
+			return nil
+		}
+
+		// the header is requested first, the block is requested after the header has been validated
+		if bs.headerChain.Lookup(&msg.Hash) == nil {
+			return bs.requestHeaders(peer)
+		}
+	}
+
 	pair := utils.NewPair(&msg.Hash, peer)
 	utils.SafeSend(bs.workerCh, pair)
 
 	return nil
 }
 
+// newBlockTxIDsCache returns the cache of the transaction ids of blocks which have been read but not handled yet. The
+// transaction ids of blocks which are never handled are released on expiry.
+func newBlockTxIDsCache() *expiringmap.ExpiringMap[*p2p.BlockMessage, *blockTxIDs] {
+	return expiringmap.New[*p2p.BlockMessage, *blockTxIDs](10 * time.Minute).WithEvictionFunction(func(_ *p2p.BlockMessage, txIDs *blockTxIDs) bool {
+		_ = txIDs.close()
+		return true
+	})
+}
+
+// readBlockMessage reads a block from the reader and keeps only the header and the transaction ids. The transaction
+// ids are passed to HandleBlock through the cache, as the block message only holds transaction hashes in memory.
+func (bs *PeerHandler) readBlockMessage(reader io.Reader, _ uint64, bytesRead int) (int, wire.Message, []byte, error) {
+	bytesRead, msg, txIDs, err := readBlock(reader, bytesRead, bs.spoolDir, bs.spoolMemoryLimit)
+	if err != nil {
+		return bytesRead, nil, nil, err
+	}
+
+	bs.blockTxIDs.Set(msg, txIDs)
+
+	return bytesRead, msg, nil, nil
+}
+
+// takeBlockTxIDs removes the transaction ids of the block message from the cache. It returns nil if the block message
+// was not read by readBlockMessage.
+func (bs *PeerHandler) takeBlockTxIDs(msg *p2p.BlockMessage) *blockTxIDs {
+	if bs.blockTxIDs == nil {
+		return nil
+	}
+
+	txIDs, found := bs.blockTxIDs.Get(msg)
+	if !found {
+		return nil
+	}
+
+	bs.blockTxIDs.Delete(msg)
+
+	return txIDs
+}
+
 func (bs *PeerHandler) HandleBlock(wireMsg wire.Message, peer p2p.PeerI) error {
+	msg, ok := wireMsg.(*p2p.BlockMessage)
+	if !ok {
+		return fmt.Errorf("unable to cast wire.Message to p2p.BlockMessage")
+	}
+
+	block := &receivedBlock{
+		msg:       msg,
+		txIDs:     bs.takeBlockTxIDs(msg),
+		peer:      peer,
+		timeStart: time.Now(),
+	}
+
+	// the transaction ids are released here unless the block is passed on to be processed
+	passed := false
+	defer func() {
+		if !passed && block.txIDs != nil {
+			if err := block.txIDs.close(); err != nil {
+				bs.logger.Error("failed to release transaction ids of block", slog.String("err", err.Error()))
+			}
+		}
+	}()
+
 	peerStr := peer.String()
 
 	stat, ok := bs.stats.Get(peerStr)
@@ -386,6 +648,7 @@ func (bs *PeerHandler) HandleBlock(wireMsg wire.Message, peer p2p.PeerI) error {
 	}
 
 	stat.Block.Add(1)
+	block.stat = stat
 
 	start := gocore.CurrentNanos()
 	defer func() {
@@ -401,32 +664,137 @@ func (bs *PeerHandler) HandleBlock(wireMsg wire.Message, peer p2p.PeerI) error {
 		return nil
 	}
 
-	timeStart := time.Now()
+	blockHash := msg.Header.BlockHash()
 
-	msg, ok := wireMsg.(*p2p.BlockMessage)
-	if !ok {
-		return fmt.Errorf("unable to cast wire.Message to p2p.BlockMessage")
+	if bs.reputation.isBanned(peer) {
+		bs.logger.Warn("ignoring block of banned peer", slog.String("hash", blockHash.String()), slog.String("peer", peerStr))
+		return nil
 	}
 
-	blockHash := msg.Header.BlockHash()
+	bs.reputation.received(&blockHash)
 
-	previousBlockHash := msg.Header.PrevBlock
+	if bs.headerChain != nil {
+		node, err := bs.validateBlockHeader(msg.Header, peer)
+		if err != nil {
+			if errors.Is(err, headers.ErrInvalidHeader) {
+				bs.recordInvalidResponse(peer)
+			}
 
-	merkleRoot := msg.Header.MerkleRoot
+			return fmt.Errorf("unable to validate header of block %s: %w", blockHash.String(), err)
+		}
 
-	blockId, err := bs.insertBlock(&blockHash, &merkleRoot, &previousBlockHash, msg.Height, peer)
+		if node == nil {
+			// the block is requested again once the missing headers connect its header to the header chain
+			bs.announcedCache.Delete(blockHash)
+			bs.logger.Info("header of block is not connected to the header chain, requested missing headers", slog.String("hash", blockHash.String()))
+			return nil
+		}
+
+		// the height of the header chain is authoritative
+		if node.Height != msg.Height {
+			bs.logger.Warn("block height in coinbase differs from header chain", slog.String("hash", blockHash.String()), slog.Uint64("coinbase height", msg.Height), slog.Uint64("height", node.Height))
+			msg.Height = node.Height
+		}
+	}
+
+	if bs.pipeline != nil {
+		// the peer reads the next block while this block is processed
+		passed = utils.SafeSend(bs.pipeline.blocks, block)
+		return nil
+	}
+
+	passed = true
+	return bs.processReceivedBlock(block)
+}
+
+// processReceivedBlock processes a block received from a peer and releases its transaction ids.
+func (bs *PeerHandler) processReceivedBlock(block *receivedBlock) error {
+	if block.txIDs != nil {
+		defer func() {
+			if err := block.txIDs.close(); err != nil {
+				bs.logger.Error("failed to release transaction ids of block", slog.String("err", err.Error()))
+			}
+		}()
+	}
+
+	blockHash := block.msg.Header.BlockHash()
+
+	txCount, err := bs.processBlock(block.msg, block.txIDs, block.peer)
 	if err != nil {
-		return fmt.Errorf("unable to insert block %s at height %d: %v", blockHash.String(), msg.Height, err)
+		if errors.Is(err, ErrMerkleRootMismatch) {
+			bs.recordInvalidResponse(block.peer)
+
+			if bs.requestBlockFromOtherPeer(&blockHash, block.peer) {
+				return fmt.Errorf("%w: %w", errBlockRequestedAgain, err)
+			}
+		}
+
+		return err
 	}
 
-	calculatedMerkleTree := bc.BuildMerkleTreeStoreChainHash(msg.TransactionHashes)
+	bs.reputation.recordValidBlock(block.peer)
+
+	// add the total block processing time to the stats
+	block.stat.BlockProcessingMs.Add(uint64(time.Since(block.timeStart).Milliseconds()))
+	bs.logger.Info("Processed block", slog.String("hash", blockHash.String()), slog.Uint64("txs", txCount), slog.String("duration", time.Since(block.timeStart).String()))
 
-	if !merkleRoot.IsEqual(calculatedMerkleTree[len(calculatedMerkleTree)-1]) {
-		return fmt.Errorf("merkle root mismatch for block %s", blockHash.String())
+	return nil
+}
+
+func (bs *PeerHandler) recordInvalidResponse(peer p2p.PeerI) {
+	if bs.reputation.recordInvalidResponse(peer) {
+		bs.logger.Warn("banned peer", slog.String("peer", peer.String()))
 	}
+}
 
-	if err = bs.markTransactionsAsMined(blockId, calculatedMerkleTree, msg.Height); err != nil {
-		return fmt.Errorf("unable to mark block as mined %s: %v", blockHash.String(), err)
+// processBlock stores the block and its transactions and marks the block as processed. If the transaction ids are nil
+// they are taken from the transaction hashes of the block message. If the previous block is unknown it is requested from
+// the peer unless peer is nil. All writes of the block carry the same fencing epoch, so that none of them is stored once
+// the lease has been acquired by another instance. It returns the number of transactions of the block.
+func (bs *PeerHandler) processBlock(msg *p2p.BlockMessage, txIDs *blockTxIDs, peer p2p.PeerI) (uint64, error) {
+	blockHash := msg.Header.BlockHash()
+	previousBlockHash := msg.Header.PrevBlock
+	merkleRoot := msg.Header.MerkleRoot
+
+	if txIDs == nil {
+		var err error
+		txIDs, err = newBlockTxIDsFromHashes(msg.TransactionHashes, bs.spoolDir, bs.spoolMemoryLimit)
+		if err != nil {
+			return 0, fmt.Errorf("unable to calculate merkle tree for block %s: %v", blockHash.String(), err)
+		}
+		defer func() {
+			_ = txIDs.close()
+		}()
+	}
+
+	// the merkle root is verified before anything is stored
+	if !merkleRoot.IsEqual(txIDs.merkleRoot) {
+		return 0, fmt.Errorf("%w for block %s", ErrMerkleRootMismatch, blockHash.String())
+	}
+
+	ctx, epoch := bs.fencedContext()
+
+	blockId, err := bs.insertBlock(ctx, &blockHash, &merkleRoot, &previousBlockHash, msg.Height, peer)
+	if err != nil {
+		bs.checkFenced(err, epoch)
+		return 0, fmt.Errorf("unable to insert block %s at height %d: %w", blockHash.String(), msg.Height, err)
+	}
+
+	registeredCount, err := bs.markTransactionsAsMined(ctx, blockId, txIDs, msg.Height)
+	if err != nil {
+		bs.checkFenced(err, epoch)
+		return 0, fmt.Errorf("unable to mark block as mined %s: %w", blockHash.String(), err)
+	}
+
+	// the statistics are not essential, therefore the block is processed even if they cannot be stored
+	stats := bs.blockStats(msg, txIDs, registeredCount)
+	if err = bs.store.InsertBlockStats(ctx, blockId, stats); err != nil {
+		if errors.Is(err, store.ErrFenced) {
+			bs.checkFenced(err, epoch)
+			return 0, fmt.Errorf("unable to insert block stats %s: %w", blockHash.String(), err)
+		}
+
+		bs.logger.Error("failed to insert block stats", slog.String("hash", blockHash.String()), slog.String("err", err.Error()))
 	}
 
 	block := &p2p.Block{
@@ -435,18 +803,17 @@ func (bs *PeerHandler) HandleBlock(wireMsg wire.Message, peer p2p.PeerI) error {
 		PreviousHash: &previousBlockHash,
 		Height:       msg.Height,
 		Size:         msg.Size,
-		TxCount:      uint64(len(msg.TransactionHashes)),
+		TxCount:      txIDs.txCount(),
 	}
 
-	if err = bs.markBlockAsProcessed(block); err != nil {
-		return fmt.Errorf("unable to mark block as processed %s: %v", blockHash.String(), err)
+	if err = bs.markBlockAsProcessed(ctx, block); err != nil {
+		bs.checkFenced(err, epoch)
+		return 0, fmt.Errorf("unable to mark block as processed %s: %w", blockHash.String(), err)
 	}
 
-	// add the total block processing time to the stats
-	stat.BlockProcessingMs.Add(uint64(time.Since(timeStart).Milliseconds()))
-	bs.logger.Info("Processed block", slog.String("hash", blockHash.String()), slog.Int("txs", len(msg.TransactionHashes)), slog.String("duration", time.Since(timeStart).String()))
+	bs.blockStatsCollector.observe(stats)
 
-	return nil
+	return block.TxCount, nil
 }
 
 const (
@@ -464,6 +831,12 @@ func (bs *PeerHandler) FillGaps(peer p2p.PeerI) error {
 		return nil
 	}
 
+	if bs.headerChain != nil {
+		if err = bs.requestHeaders(peer); err != nil {
+			bs.logger.Error("failed to request headers", slog.String("peer", peer.String()), slog.String("err", err.Error()))
+		}
+	}
+
 	heightRange := bs.dataRetentionDays * hoursPerDay * blocksPerHour
 
 	blockHeightGaps, err := bs.store.GetBlockGaps(context.Background(), heightRange)
@@ -494,13 +867,13 @@ func (bs *PeerHandler) FillGaps(peer p2p.PeerI) error {
 	return nil
 }
 
-func (bs *PeerHandler) insertBlock(blockHash *chainhash.Hash, merkleRoot *chainhash.Hash, previousBlockHash *chainhash.Hash, height uint64, peer p2p.PeerI) (uint64, error) {
+func (bs *PeerHandler) insertBlock(ctx context.Context, blockHash *chainhash.Hash, merkleRoot *chainhash.Hash, previousBlockHash *chainhash.Hash, height uint64, peer p2p.PeerI) (uint64, error) {
 	start := gocore.CurrentNanos()
 	defer func() {
 		gocore.NewStat("blocktx").NewStat("HandleBlock").NewStat("insertBlock").AddTime(start)
 	}()
 
-	if height > uint64(bs.startingHeight) {
+	if peer != nil && height > uint64(bs.startingHeight) {
 		if _, found := bs.announcedCache.Get(*previousBlockHash); !found {
 			if _, err := bs.store.GetBlock(context.Background(), previousBlockHash); err != nil {
 				if errors.Is(err, store.ErrBlockNotFound) {
@@ -522,73 +895,146 @@ func (bs *PeerHandler) insertBlock(blockHash *chainhash.Hash, merkleRoot *chainh
 		Height:       height,
 	}
 
-	return bs.store.InsertBlock(context.Background(), block)
+	return bs.store.InsertBlock(ctx, block)
 }
 
-func (bs *PeerHandler) printMemStats() {
-	bToMb := func(b uint64) uint64 {
-		return b / 1024 / 1024
-	}
-	var mem runtime.MemStats
-	runtime.ReadMemStats(&mem)
-	bs.logger.Info(fmt.Sprintf("Alloc = %v MiB, TotalAlloc = %v MiB, Sys = %v MiB, NumGC = %v\n",
-		bToMb(mem.Alloc), bToMb(mem.TotalAlloc), bToMb(mem.Sys), mem.NumGC))
-
-}
-
-func (bs *PeerHandler) markTransactionsAsMined(blockId uint64, merkleTree []*chainhash.Hash, blockHeight uint64) error {
+// markTransactionsAsMined stores the merkle tree of the block and maps the transactions to the block. The transaction
+// ids are read from the spool one subtree at a time, the subtrees and the transactions are stored in batches so that the
+// memory needed does not depend on the size of the block. A transaction is mapped only after its subtree is stored.
+// Unless all transactions are indexed, only the registered transactions are passed to the store. It returns the number
+// of registered transactions of the block, which is counted by the store with the full index.
+func (bs *PeerHandler) markTransactionsAsMined(ctx context.Context, blockId uint64, txIDs *blockTxIDs, blockHeight uint64) (uint64, error) {
 	start := gocore.CurrentNanos()
 	defer func() {
 		gocore.NewStat("blocktx").NewStat("HandleBlock").NewStat("markTransactionsAsMined").AddTime(start)
 	}()
 
-	txs := make([]*blocktx_api.TransactionAndSource, 0, bs.transactionStorageBatchSize)
-	merklePaths := make([]string, 0, bs.transactionStorageBatchSize)
-	leaves := merkleTree[:(len(merkleTree)+1)/2]
+	// the merkle paths are calculated from the stored merkle tree when they are requested
+	if err := bs.store.InsertBlockMerkleTree(ctx, blockId, txIDs.merkleTree); err != nil {
+		return 0, fmt.Errorf("failed to insert merkle tree at block height %d: %w", blockHeight, err)
+	}
 
-	for txIndex, hash := range leaves {
-		// Everything to the right of the first nil will also be nil, as this is just padding upto the next PoT.
-		if hash == nil {
-			break
+	reader, err := txIDs.spool.reader()
+	if err != nil {
+		return 0, err
+	}
+
+	storeBlockTransactions := bs.store.UpdateBlockTransactions
+	if bs.fullIndex {
+		storeBlockTransactions = bs.store.InsertBlockTransactions
+	}
+
+	// with the full index all transactions are stored, the index would only grow by the transactions of every block
+	registeredTransactions := bs.registeredTransactions
+	if bs.fullIndex {
+		registeredTransactions = nil
+	}
+
+	if registeredTransactions != nil {
+		if err = registeredTransactions.Sync(ctx); err != nil {
+			// the store maps only registered transactions anyway, therefore all transactions are passed to the store
+			bs.logger.Error("failed to sync registered transactions", slog.String("err", err.Error()))
+			registeredTransactions = nil
 		}
+	}
 
-		// Otherwise they're txids, which should have merkle paths calculated.
-		txs = append(txs, &blocktx_api.TransactionAndSource{
-			Hash: hash[:],
-		})
+	registeredCount := uint64(0)
 
-		bump, err := bc.NewBUMPFromMerkleTreeAndIndex(blockHeight, merkleTree, uint64(txIndex))
-		if err != nil {
-			return fmt.Errorf("failed to create new bump for tx hash %s from merkle tree and index at block height %d: %v", hash.String(), blockHeight, err)
+	txCount := txIDs.txCount()
+	subtreeSize := txIDs.merkleTree.SubtreeSize
+
+	subtrees := make([][]byte, 0, subtreesStoringBatchSize)
+	storedSubtrees := uint64(0)
+	txs := make([]*blocktx_api.BlockTransaction, 0, bs.transactionStorageBatchSize)
+
+	storeSubtrees := func() error {
+		if len(subtrees) == 0 {
+			return nil
 		}
 
-		bumpHex, err := bump.String()
-		if err != nil {
-			return fmt.Errorf("failed to get string from bump for tx hash %s at block height %d: %v", hash.String(), blockHeight, err)
+		if err := bs.store.InsertBlockMerkleSubtrees(ctx, blockId, storedSubtrees, subtrees); err != nil {
+			return fmt.Errorf("failed to insert merkle subtrees at block height %d: %w", blockHeight, err)
 		}
 
-		merklePaths = append(merklePaths, bumpHex)
-		if (txIndex+1)%bs.transactionStorageBatchSize == 0 {
-			if err := bs.store.UpdateBlockTransactions(context.Background(), blockId, txs, merklePaths); err != nil {
-				return fmt.Errorf("failed to insert block transactions at block height %d: %v", blockHeight, err)
+		storedSubtrees += uint64(len(subtrees))
+		subtrees = make([][]byte, 0, subtreesStoringBatchSize)
+
+		return nil
+	}
+
+	storeTransactions := func() error {
+		if err := storeSubtrees(); err != nil {
+			return err
+		}
+
+		if len(txs) == 0 {
+			return nil
+		}
+
+		if err := storeBlockTransactions(ctx, blockId, txs); err != nil {
+			return fmt.Errorf("failed to insert block transactions at block height %d: %w", blockHeight, err)
+		}
+
+		txs = make([]*blocktx_api.BlockTransaction, 0, bs.transactionStorageBatchSize)
+
+		return nil
+	}
+
+	for pos := uint64(0); pos < txCount; pos += subtreeSize {
+		leaves := min(subtreeSize, txCount-pos)
+
+		subtree := make([]byte, leaves*chainhash.HashSize)
+		if _, err = io.ReadFull(reader, subtree); err != nil {
+			return 0, fmt.Errorf("failed to read transaction ids at block height %d: %v", blockHeight, err)
+		}
+
+		subtrees = append(subtrees, subtree)
+
+		for i := uint64(0); i < leaves; i++ {
+			hash := subtree[i*chainhash.HashSize : (i+1)*chainhash.HashSize]
+			registered := registeredTransactions != nil && registeredTransactions.Contains((*chainhash.Hash)(hash))
+			if registered {
+				registeredCount++
 			}
-			// free up memory
-			txs = make([]*blocktx_api.TransactionAndSource, 0, bs.transactionStorageBatchSize)
-			merklePaths = make([]string, 0, bs.transactionStorageBatchSize)
 
-			// print stats, call gc and chec the result
-			bs.printMemStats()
-			runtime.GC()
-			bs.printMemStats()
+			if registeredTransactions != nil && !registered {
+				continue
+			}
+
+			txs = append(txs, &blocktx_api.BlockTransaction{
+				Hash: hash,
+				Pos:  pos + i,
+			})
+
+			if len(txs) == bs.transactionStorageBatchSize {
+				if err = storeTransactions(); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+		if len(subtrees) == subtreesStoringBatchSize {
+			if err = storeSubtrees(); err != nil {
+				return 0, err
+			}
 		}
 	}
 
-	// update all remaining transactions
-	if err := bs.store.UpdateBlockTransactions(context.Background(), blockId, txs, merklePaths); err != nil {
-		return fmt.Errorf("failed to insert block transactions at block height %d: %v", blockHeight, err)
+	// store all remaining subtrees and transactions
+	if err = storeTransactions(); err != nil {
+		return 0, err
 	}
 
-	return nil
+	if bs.fullIndex {
+		// the count is only used for the statistics, therefore the block is processed even if it cannot be counted
+		registeredCount, err = bs.store.GetBlockRegisteredTxCount(ctx, blockId)
+		if err != nil {
+			bs.logger.Error("failed to count registered transactions of block", slog.Uint64("height", blockHeight), slog.String("err", err.Error()))
+			return 0, nil
+		}
+	}
+
+	return registeredCount, nil
 }
 
 func (bs *PeerHandler) getAnnouncedCacheBlockHashes() []string {
@@ -603,13 +1049,13 @@ func (bs *PeerHandler) getAnnouncedCacheBlockHashes() []string {
 	return blockHashes
 }
 
-func (bs *PeerHandler) markBlockAsProcessed(block *p2p.Block) error {
+func (bs *PeerHandler) markBlockAsProcessed(ctx context.Context, block *p2p.Block) error {
 	start := gocore.CurrentNanos()
 	defer func() {
 		gocore.NewStat("blocktx").NewStat("HandleBlock").NewStat("markBlockAsProcessed").AddTime(start)
 	}()
 
-	err := bs.store.MarkBlockAsDone(context.Background(), block.Hash, block.Size, block.TxCount)
+	err := bs.store.MarkBlockAsDone(ctx, block.Hash, block.Size, block.TxCount)
 	if err != nil {
 		return err
 	}
@@ -645,5 +1091,14 @@ func (bs *PeerHandler) Shutdown() {
 	<-bs.quitFillBlockGapComplete
 
 	bs.fillGapsTicker.Stop()
+	close(bs.quitBlockRequestCheck)
 	tracing.Unregister(bs.peerHandlerCollector)
+
+	if bs.headersCh != nil {
+		close(bs.headersCh)
+	}
+
+	if bs.pipeline != nil {
+		close(bs.pipeline.blocks)
+	}
 }
@@ -227,21 +227,13 @@ func TestHandleBlock(t *testing.T) {
 			InsertBlockFunc: func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
 				return 0, nil
 			},
-			MarkBlockAsDoneFunc: func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
-				return nil
+			GetBlockStatsFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+				return nil, store.ErrBlockNotFound
 			},
-			GetPrimaryFunc: func(ctx context.Context) (string, error) {
-				hostName, err := os.Hostname()
-				return hostName, err
-			},
-			TryToBecomePrimaryFunc: func(ctx context.Context, myHostName string) error {
+			InsertBlockStatsFunc: func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
 				return nil
 			},
-
-			// main assert for the test to make sure block with a single transaction doesn't have any merkle paths other than empty ones "0000"
-			UpdateBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error {
-				assert.Equal(t, uint64(1), uint64(len(merklePaths)))
-				assert.Equal(t, merklePaths[0], "fe12031800010100027fda0fc8f5d26a8616869add086c8421fa07245a96d1b6ac5ae8d46bbbb2643d")
+			MarkBlockAsDoneFunc: func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
 				return nil
 			},
 		}
@@ -252,31 +244,38 @@ func TestHandleBlock(t *testing.T) {
 		require.NoError(t, err)
 
 		t.Run(tc.name, func(t *testing.T) {
-			expectedInsertedTransactions := []*blocktx_api.TransactionAndSource{}
+			expectedInsertedTransactions := []*blocktx_api.BlockTransaction{}
 			transactionHashes := make([]*chainhash.Hash, len(tc.txHashes))
 			for i, hash := range tc.txHashes {
 				txHash, err := chainhash.NewHashFromStr(hash)
 				require.NoError(t, err)
 				transactionHashes[i] = txHash
 
-				expectedInsertedTransactions = append(expectedInsertedTransactions, &blocktx_api.TransactionAndSource{Hash: txHash[:]})
+				expectedInsertedTransactions = append(expectedInsertedTransactions, &blocktx_api.BlockTransaction{Hash: txHash[:], Pos: uint64(i)})
 			}
 
-			var insertedBlockTransactions []*blocktx_api.TransactionAndSource
+			var insertedBlockTransactions []*blocktx_api.BlockTransaction
+			var insertedTree *store.MerkleTree
+			var insertedSubtrees [][]byte
 
-			storeMock.UpdateBlockTransactionsFunc = func(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error {
-				require.True(t, len(merklePaths) <= batchSize)
-				require.True(t, len(transactions) <= batchSize)
+			storeMock.InsertBlockMerkleTreeFunc = func(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+				require.Equal(t, uint64(len(tc.txHashes)), tree.TxCount)
+				insertedTree = tree
+				return nil
+			}
+
+			storeMock.InsertBlockMerkleSubtreesFunc = func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+				require.Equal(t, uint64(len(insertedSubtrees)), firstIndex)
+				insertedSubtrees = append(insertedSubtrees, subtrees...)
+				return nil
+			}
 
-				for i, path := range merklePaths {
-					bump, err := bc.NewBUMPFromStr(path)
-					require.NoError(t, err)
-					tx, err := chainhash.NewHash(transactions[i].GetHash())
-					require.NoError(t, err)
-					root, err := bump.CalculateRootGivenTxid(tx.String())
-					require.NoError(t, err)
+			storeMock.UpdateBlockTransactionsFunc = func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+				require.True(t, len(transactions) <= batchSize)
 
-					require.Equal(t, root, tc.merkleRoot.String())
+				// the subtrees of the transactions have to be stored before the transactions are mapped to the block
+				for _, tx := range transactions {
+					require.Less(t, tx.GetPos()/insertedTree.SubtreeSize, uint64(len(insertedSubtrees)))
 				}
 
 				insertedBlockTransactions = append(insertedBlockTransactions, transactions...)
@@ -303,6 +302,20 @@ func TestHandleBlock(t *testing.T) {
 			require.NoError(t, err)
 
 			require.ElementsMatch(t, expectedInsertedTransactions, insertedBlockTransactions)
+
+			// the merkle paths calculated from the stored merkle tree have to lead to the merkle root of the block
+			require.NotNil(t, insertedTree)
+			for i, hash := range transactionHashes {
+				path, err := store.MerklePath(tc.height, insertedTree.TxCount, insertedTree.SubtreeSize, insertedTree.SubtreeRoots, insertedSubtrees[uint64(i)/insertedTree.SubtreeSize], uint64(i))
+				require.NoError(t, err)
+				bump, err := bc.NewBUMPFromStr(path)
+				require.NoError(t, err)
+				root, err := bump.CalculateRootGivenTxid(hash.String())
+				require.NoError(t, err)
+
+				require.Equal(t, root, tc.merkleRoot.String())
+			}
+
 			peerHandler.Shutdown()
 		})
 	}
@@ -316,19 +329,19 @@ func TestFillGaps(t *testing.T) {
 	hash822019, err := chainhash.NewHashFromStr("00000000000000000364332e1bbd61dc928141b9469c5daea26a4b506efc9656")
 	require.NoError(t, err)
 	tt := []struct {
-		name              string
-		blockGaps         []*store.BlockGap
-		getBlockGapsErr   error
-		primaryBlocktxErr error
-		hostname          string
+		name            string
+		blockGaps       []*store.BlockGap
+		getBlockGapsErr error
+		leaseHostName   string
+		acquireLeaseErr error
 
 		expectedGetBlockGapsCalls int
 		expectedErrorStr          string
 	}{
 		{
-			name:      "success - no gaps",
-			blockGaps: []*store.BlockGap{},
-			hostname:  hostname,
+			name:          "success - no gaps",
+			blockGaps:     []*store.BlockGap{},
+			leaseHostName: hostname,
 
 			expectedGetBlockGapsCalls: 1,
 		},
@@ -344,7 +357,7 @@ func TestFillGaps(t *testing.T) {
 					Hash:   hash822019,
 				},
 			},
-			hostname: hostname,
+			leaseHostName: hostname,
 
 			expectedGetBlockGapsCalls: 1,
 		},
@@ -352,26 +365,24 @@ func TestFillGaps(t *testing.T) {
 			name:            "error getting block gaps",
 			blockGaps:       []*store.BlockGap{},
 			getBlockGapsErr: errors.New("failed to get block gaps"),
-			hostname:        hostname,
+			leaseHostName:   hostname,
 
 			expectedGetBlockGapsCalls: 1,
 			expectedErrorStr:          "failed to get block gaps",
 		},
 		{
-			name:      "not primary",
-			blockGaps: []*store.BlockGap{},
-			hostname:  "not primary",
+			name:          "not primary",
+			blockGaps:     []*store.BlockGap{},
+			leaseHostName: "not primary",
 
 			expectedGetBlockGapsCalls: 0,
 		},
 		{
-			name:              "check primary - error",
-			blockGaps:         []*store.BlockGap{},
-			hostname:          "not primary",
-			primaryBlocktxErr: errors.New("failed to check primary"),
+			name:            "acquire lease - error",
+			blockGaps:       []*store.BlockGap{},
+			acquireLeaseErr: errors.New("failed to acquire lease"),
 
 			expectedGetBlockGapsCalls: 0,
-			expectedErrorStr:          "failed to check primary",
 		},
 	}
 
@@ -383,13 +394,16 @@ func TestFillGaps(t *testing.T) {
 				GetBlockGapsFunc: func(ctx context.Context, heightRange int) ([]*store.BlockGap, error) {
 					return tc.blockGaps, tc.getBlockGapsErr
 				},
-				GetPrimaryFunc: func(ctx context.Context) (string, error) {
-					return tc.hostname, tc.primaryBlocktxErr
+				AcquireLeaseFunc: func(ctx context.Context, hostName string, duration time.Duration) (*store.Lease, error) {
+					return &store.Lease{HostName: tc.leaseHostName, Epoch: 1}, tc.acquireLeaseErr
 				},
 			}
 
 			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
-			peerHandler, err := NewPeerHandler(logger, storeMock, 100, []string{}, wire.TestNet, WithTransactionBatchSize(batchSize))
+			election := NewPrimaryElection(logger, storeMock, hostname)
+			election.renew(context.Background())
+
+			peerHandler, err := NewPeerHandler(logger, storeMock, 100, []string{}, wire.TestNet, WithTransactionBatchSize(batchSize), WithPrimaryElection(election))
 			require.NoError(t, err)
 			peer := &MockedPeer{}
 			err = peerHandler.FillGaps(peer)
@@ -409,21 +423,16 @@ func TestFillGaps(t *testing.T) {
 func TestStartFillGaps(t *testing.T) {
 	hash822014, err := chainhash.NewHashFromStr("0000000000000000025855b62f4c2e3732dad363a6f2ead94e4657ef96877067")
 	require.NoError(t, err)
-	hostname, err := os.Hostname()
-	require.NoError(t, err)
 
 	tt := []struct {
 		name            string
-		hostname        string
 		getBlockGapsErr error
 	}{
 		{
-			name:     "success",
-			hostname: hostname,
+			name: "success",
 		},
 		{
 			name:            "error getting block gaps",
-			hostname:        hostname,
 			getBlockGapsErr: errors.New("failed to get block gaps"),
 		},
 	}
@@ -439,9 +448,6 @@ func TestStartFillGaps(t *testing.T) {
 						},
 					}, tc.getBlockGapsErr
 				},
-				GetPrimaryFunc: func(ctx context.Context) (string, error) {
-					return tc.hostname, nil
-				},
 			}
 
 			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
@@ -0,0 +1,171 @@
+package blocktx
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bitcoin-sv/arc/tracing"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/ordishs/go-utils/safemap"
+)
+
+const (
+	invalidResponsePenalty = 10
+	slowResponsePenalty    = 2
+	validBlockReward       = 1
+
+	peerBanScoreDefault         = 30
+	peerBanDurationDefault      = 24 * time.Hour
+	blockResponseTimeoutDefault = 5 * time.Minute
+	blockRequestCheckInterval   = 30 * time.Second
+)
+
+// blockRequest is a block which has been requested from a peer and has not been received yet.
+type blockRequest struct {
+	hash        chainhash.Hash
+	peer        p2p.PeerI
+	requestedAt time.Time
+}
+
+// peerReputation keeps a penalty score per peer. Invalid blocks and block headers and requested blocks which are not
+// sent within the response timeout increase the score, processed blocks decrease it. A peer whose score reaches the
+// ban score is banned for the ban duration, i.e. its announcements and blocks are ignored and no blocks are requested
+// from it. The peer stays connected, as the peers of go-p2p cannot be disconnected.
+type peerReputation struct {
+	stats           *safemap.Safemap[string, *tracing.PeerHandlerStats]
+	banScore        int64
+	banDuration     time.Duration
+	responseTimeout time.Duration
+
+	mu          sync.Mutex
+	bannedUntil map[string]time.Time
+	requests    map[chainhash.Hash]blockRequest
+}
+
+func newPeerReputation(stats *safemap.Safemap[string, *tracing.PeerHandlerStats]) *peerReputation {
+	return &peerReputation{
+		stats:           stats,
+		banScore:        peerBanScoreDefault,
+		banDuration:     peerBanDurationDefault,
+		responseTimeout: blockResponseTimeoutDefault,
+		bannedUntil:     make(map[string]time.Time),
+		requests:        make(map[chainhash.Hash]blockRequest),
+	}
+}
+
+func (r *peerReputation) peerStats(peer p2p.PeerI) *tracing.PeerHandlerStats {
+	peerStr := peer.String()
+
+	stat, ok := r.stats.Get(peerStr)
+	if !ok {
+		stat = &tracing.PeerHandlerStats{}
+		r.stats.Set(peerStr, stat)
+	}
+
+	return stat
+}
+
+// recordInvalidResponse penalizes the peer for an invalid block or block header. It returns true if the peer is
+// banned as a result.
+func (r *peerReputation) recordInvalidResponse(peer p2p.PeerI) bool {
+	r.peerStats(peer).InvalidResponse.Add(1)
+
+	return r.penalize(peer, invalidResponsePenalty)
+}
+
+// recordSlowResponse penalizes the peer for a requested block which it did not send in time. It returns true if the
+// peer is banned as a result.
+func (r *peerReputation) recordSlowResponse(peer p2p.PeerI) bool {
+	r.peerStats(peer).SlowResponse.Add(1)
+
+	return r.penalize(peer, slowResponsePenalty)
+}
+
+// recordValidBlock reduces the score of the peer which sent a valid block.
+func (r *peerReputation) recordValidBlock(peer p2p.PeerI) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stat := r.peerStats(peer)
+	stat.ReputationScore.Store(max(stat.ReputationScore.Load()-validBlockReward, 0))
+}
+
+func (r *peerReputation) penalize(peer p2p.PeerI, penalty int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stat := r.peerStats(peer)
+	score := stat.ReputationScore.Add(penalty)
+
+	if score < r.banScore || stat.Banned.Load() {
+		return false
+	}
+
+	r.bannedUntil[peer.String()] = time.Now().Add(r.banDuration)
+	stat.Banned.Store(true)
+
+	return true
+}
+
+// isBanned returns whether the peer is banned. The score of a peer whose ban has expired is reset.
+func (r *peerReputation) isBanned(peer p2p.PeerI) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bannedUntil, found := r.bannedUntil[peer.String()]
+	if !found {
+		return false
+	}
+
+	if time.Now().Before(bannedUntil) {
+		return true
+	}
+
+	delete(r.bannedUntil, peer.String())
+
+	stat := r.peerStats(peer)
+	stat.ReputationScore.Store(0)
+	stat.Banned.Store(false)
+
+	return false
+}
+
+func (r *peerReputation) score(peer p2p.PeerI) int64 {
+	return r.peerStats(peer).ReputationScore.Load()
+}
+
+// requested records that the block has been requested from the peer.
+func (r *peerReputation) requested(hash *chainhash.Hash, peer p2p.PeerI) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests[*hash] = blockRequest{hash: *hash, peer: peer, requestedAt: time.Now()}
+}
+
+// received records that the block has been received.
+func (r *peerReputation) received(hash *chainhash.Hash) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.requests, *hash)
+}
+
+// overdueRequests removes and returns the requests of blocks which have not been received within the response
+// timeout.
+func (r *peerReputation) overdueRequests() []blockRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	overdue := make([]blockRequest, 0)
+	for hash, request := range r.requests {
+		if time.Since(request.requestedAt) < r.responseTimeout {
+			continue
+		}
+
+		overdue = append(overdue, request)
+		delete(r.requests, hash)
+	}
+
+	return overdue
+}
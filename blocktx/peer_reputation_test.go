@@ -0,0 +1,181 @@
+package blocktx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/bitcoin-sv/arc/tracing"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/ordishs/go-utils/safemap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerReputation(t *testing.T) {
+	stats := safemap.New[string, *tracing.PeerHandlerStats]()
+	reputation := newPeerReputation(stats)
+	peer := &requestRecordingPeer{name: "peer"}
+
+	require.False(t, reputation.recordInvalidResponse(peer))
+	require.False(t, reputation.recordSlowResponse(peer))
+	require.Equal(t, int64(invalidResponsePenalty+slowResponsePenalty), reputation.score(peer))
+
+	reputation.recordValidBlock(peer)
+	require.Equal(t, int64(invalidResponsePenalty+slowResponsePenalty-validBlockReward), reputation.score(peer))
+	require.False(t, reputation.isBanned(peer))
+
+	// the peer is banned when the score reaches the ban score
+	require.False(t, reputation.recordInvalidResponse(peer))
+	require.True(t, reputation.recordInvalidResponse(peer))
+	require.False(t, reputation.recordInvalidResponse(peer))
+	require.True(t, reputation.isBanned(peer))
+
+	stat, found := stats.Get("peer")
+	require.True(t, found)
+	require.Equal(t, uint64(4), stat.InvalidResponse.Load())
+	require.Equal(t, uint64(1), stat.SlowResponse.Load())
+	require.True(t, stat.Banned.Load())
+
+	// the score is reset when the ban has expired
+	reputation.bannedUntil["peer"] = time.Now()
+	require.False(t, reputation.isBanned(peer))
+	require.False(t, stat.Banned.Load())
+	require.Equal(t, int64(0), reputation.score(peer))
+
+	// a valid block does not reduce the score below zero
+	reputation.recordValidBlock(peer)
+	require.Equal(t, int64(0), reputation.score(peer))
+}
+
+func TestPeerReputationOverdueRequests(t *testing.T) {
+	reputation := newPeerReputation(safemap.New[string, *tracing.PeerHandlerStats]())
+	reputation.responseTimeout = time.Minute
+	peer := &requestRecordingPeer{name: "peer"}
+	hashes := syntheticTxHashes(0, 3)
+
+	for _, hash := range hashes {
+		reputation.requested(hash, peer)
+	}
+	reputation.received(hashes[0])
+	reputation.requests[*hashes[1]] = blockRequest{hash: *hashes[1], peer: peer, requestedAt: time.Now().Add(-time.Minute)}
+
+	overdue := reputation.overdueRequests()
+	require.Len(t, overdue, 1)
+	require.Equal(t, *hashes[1], overdue[0].hash)
+	require.Equal(t, peer, overdue[0].peer)
+
+	// an overdue request is returned once
+	require.Empty(t, reputation.overdueRequests())
+}
+
+func TestPeerHandlerReputation(t *testing.T) {
+	txHashes := syntheticTxHashes(0, 10)
+	tree, err := store.NewMerkleTree(txHashes, store.DefaultMerkleSubtreeSize)
+	require.NoError(t, err)
+	merkleRoot, err := tree.Root()
+	require.NoError(t, err)
+
+	newPeerHandler := func(t *testing.T, opts ...func(*PeerHandler)) (*PeerHandler, *requestRecordingPeer, *requestRecordingPeer) {
+		storeMock := &store.InterfaceMock{
+			GetBlockFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+				return &blocktx_api.Block{}, nil
+			},
+			InsertBlockFunc: func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
+				return 1, nil
+			},
+			InsertBlockMerkleTreeFunc: func(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+				return nil
+			},
+			InsertBlockMerkleSubtreesFunc: func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+				return nil
+			},
+			InsertBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+				return nil
+			},
+			GetBlockRegisteredTxCountFunc: func(ctx context.Context, blockId uint64) (uint64, error) {
+				return 0, nil
+			},
+			GetBlockStatsFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+				return nil, store.ErrBlockNotFound
+			},
+			InsertBlockStatsFunc: func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+				return nil
+			},
+			MarkBlockAsDoneFunc: func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
+				return nil
+			},
+		}
+
+		logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+		peerHandler, err := NewPeerHandler(logger, storeMock, 0, []string{}, wire.TestNet, append(opts, WithFullIndex())...)
+		require.NoError(t, err)
+		t.Cleanup(peerHandler.Shutdown)
+
+		badPeer := &requestRecordingPeer{name: "bad peer"}
+		goodPeer := &requestRecordingPeer{name: "good peer"}
+		peerHandler.peers = []p2p.PeerI{badPeer, goodPeer}
+
+		return peerHandler, badPeer, goodPeer
+	}
+
+	t.Run("merkle root mismatch", func(t *testing.T) {
+		peerHandler, badPeer, goodPeer := newPeerHandler(t)
+
+		invalidBlock := &p2p.BlockMessage{
+			Header:            &wire.BlockHeader{MerkleRoot: chainhash.Hash{}},
+			Height:            100,
+			TransactionHashes: txHashes,
+		}
+		blockHash := invalidBlock.Header.BlockHash()
+
+		// the block is requested from the other peer
+		err := peerHandler.HandleBlock(invalidBlock, badPeer)
+		require.ErrorIs(t, err, ErrMerkleRootMismatch)
+		require.ErrorIs(t, err, errBlockRequestedAgain)
+		require.Equal(t, []chainhash.Hash{blockHash}, goodPeer.requestedBlocks())
+		require.Equal(t, int64(invalidResponsePenalty), peerHandler.reputation.score(badPeer))
+
+		// the peer is banned after repeated invalid blocks
+		for i := 0; i < 2; i++ {
+			require.ErrorIs(t, peerHandler.HandleBlock(invalidBlock, badPeer), ErrMerkleRootMismatch)
+		}
+		require.True(t, peerHandler.reputation.isBanned(badPeer))
+
+		// the announcements and blocks of the banned peer are ignored
+		require.NoError(t, peerHandler.HandleBlockAnnouncement(wire.NewInvVect(wire.InvTypeBlock, &blockHash), badPeer))
+		require.NoError(t, peerHandler.HandleBlock(invalidBlock, badPeer))
+		require.Equal(t, uint64(3), peerHandler.reputation.peerStats(badPeer).InvalidResponse.Load())
+
+		// blocks are not requested from the banned peer
+		peerHandler.requestBlock(txHashes[0], badPeer)
+		require.Empty(t, badPeer.requestedBlocks())
+		require.Equal(t, *txHashes[0], goodPeer.requestedBlocks()[len(goodPeer.requestedBlocks())-1])
+
+		// a valid block reduces the score of the peer
+		err = peerHandler.HandleBlock(&p2p.BlockMessage{
+			Header:            &wire.BlockHeader{MerkleRoot: *merkleRoot},
+			Height:            100,
+			TransactionHashes: txHashes,
+		}, goodPeer)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), peerHandler.reputation.score(goodPeer))
+	})
+
+	t.Run("slow response", func(t *testing.T) {
+		peerHandler, slowPeer, goodPeer := newPeerHandler(t, WithPeerReputation(peerBanScoreDefault, time.Hour, 0))
+
+		peerHandler.requestBlock(txHashes[0], slowPeer)
+		require.Equal(t, []chainhash.Hash{*txHashes[0]}, slowPeer.requestedBlocks())
+
+		peerHandler.checkBlockRequests()
+
+		require.Equal(t, uint64(1), peerHandler.reputation.peerStats(slowPeer).SlowResponse.Load())
+		require.Equal(t, []chainhash.Hash{*txHashes[0]}, goodPeer.requestedBlocks())
+	})
+}
@@ -0,0 +1,201 @@
+package blocktx
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+)
+
+const (
+	leaseDurationDefault      = 2 * time.Minute
+	leaseRenewIntervalDefault = 30 * time.Second
+)
+
+// PrimaryElection elects the blocktx instance which processes blocks by a lease in the store. The lease is acquired
+// and renewed in the background, so that the primary status can be checked without querying the store. Each time the
+// lease changes hands its epoch is increased. The primary writes blocks with its epoch as fencing token, which lets the
+// store reject the writes of an instance which lost the lease without noticing.
+type PrimaryElection struct {
+	store         store.Interface
+	logger        *slog.Logger
+	hostName      string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+
+	mu         sync.RWMutex
+	epoch      int64
+	validUntil time.Time
+
+	changes atomic.Uint64
+
+	quit    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// WithLeaseDuration sets the duration for which the lease is acquired or renewed.
+func WithLeaseDuration(duration time.Duration) func(*PrimaryElection) {
+	return func(e *PrimaryElection) {
+		e.leaseDuration = duration
+	}
+}
+
+// WithLeaseRenewInterval sets the interval in which the lease is renewed by the primary or acquired by the other
+// instances. It has to be shorter than the lease duration.
+func WithLeaseRenewInterval(interval time.Duration) func(*PrimaryElection) {
+	return func(e *PrimaryElection) {
+		e.renewInterval = interval
+	}
+}
+
+func NewPrimaryElection(logger *slog.Logger, storeI store.Interface, hostName string, opts ...func(*PrimaryElection)) *PrimaryElection {
+	e := &PrimaryElection{
+		store:         storeI,
+		logger:        logger.With(slog.String("module", "primary-election")),
+		hostName:      hostName,
+		leaseDuration: leaseDurationDefault,
+		renewInterval: leaseRenewIntervalDefault,
+		quit:          make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Start tries to acquire the lease immediately and then in the renew interval until Shutdown is called.
+func (e *PrimaryElection) Start() {
+	newPrimaryCollector(e)
+
+	e.renew(context.Background())
+
+	e.stopped.Add(1)
+	go func() {
+		defer e.stopped.Done()
+
+		ticker := time.NewTicker(e.renewInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-e.quit:
+				return
+			case <-ticker.C:
+				e.renew(context.Background())
+			}
+		}
+	}()
+}
+
+// renew acquires or renews the lease. The lease is considered valid until the lease duration has passed since the
+// request was sent. This is never later than the expiry calculated by the store, independent of the clock of the store.
+func (e *PrimaryElection) renew(ctx context.Context) {
+	requestedAt := time.Now()
+
+	lease, err := e.store.AcquireLease(ctx, e.hostName, e.leaseDuration)
+	if err != nil {
+		e.logger.Error("failed to acquire lease", slog.String("err", err.Error()))
+
+		// the lease is kept until it expires, as it may still be held
+		e.mu.Lock()
+		if e.epoch != 0 && time.Now().After(e.validUntil) {
+			e.lose("lease expired")
+		}
+		e.mu.Unlock()
+
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if lease.HostName != e.hostName {
+		if e.epoch != 0 {
+			e.lose("lease acquired by " + lease.HostName)
+		}
+
+		return
+	}
+
+	if e.epoch == 0 {
+		e.changes.Add(1)
+		e.logger.Info("became primary", slog.Int64("epoch", lease.Epoch))
+	} else if e.epoch != lease.Epoch {
+		e.logger.Warn("lease reacquired with new epoch", slog.Int64("previous", e.epoch), slog.Int64("epoch", lease.Epoch))
+	}
+
+	e.epoch = lease.Epoch
+	e.validUntil = requestedAt.Add(e.leaseDuration)
+}
+
+// lose gives up the primary status. It has to be called with the lock held.
+func (e *PrimaryElection) lose(reason string) {
+	e.logger.Warn("lost primary", slog.Int64("epoch", e.epoch), slog.String("reason", reason))
+
+	e.epoch = 0
+	e.validUntil = time.Time{}
+	e.changes.Add(1)
+}
+
+// IsPrimary returns whether this instance holds a valid lease.
+func (e *PrimaryElection) IsPrimary() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.epoch != 0 && time.Now().Before(e.validUntil)
+}
+
+// Epoch returns the epoch of the lease held by this instance, or 0 if it is not primary.
+func (e *PrimaryElection) Epoch() int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.epoch
+}
+
+// Changes returns how often this instance became or stopped being primary.
+func (e *PrimaryElection) Changes() uint64 {
+	return e.changes.Load()
+}
+
+// fenced gives up the primary status after the store rejected a write with the given epoch.
+func (e *PrimaryElection) fenced(epoch int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.epoch == epoch && epoch != 0 {
+		e.lose("write rejected by fencing epoch")
+	}
+}
+
+// Shutdown stops renewing the lease and releases it, so that another instance can take over without waiting for the
+// lease to expire.
+func (e *PrimaryElection) Shutdown() {
+	close(e.quit)
+	e.stopped.Wait()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.epoch == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := e.store.ReleaseLease(ctx, e.hostName, e.epoch); err != nil {
+		e.logger.Error("failed to release lease", slog.String("err", err.Error()))
+	} else {
+		e.logger.Info("released lease", slog.Int64("epoch", e.epoch))
+	}
+
+	e.epoch = 0
+	e.validUntil = time.Time{}
+	e.changes.Add(1)
+}
@@ -0,0 +1,202 @@
+package blocktx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrimaryElection(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	var lease *store.Lease
+	var acquireErr error
+
+	storeMock := &store.InterfaceMock{
+		AcquireLeaseFunc: func(ctx context.Context, hostName string, duration time.Duration) (*store.Lease, error) {
+			return lease, acquireErr
+		},
+		ReleaseLeaseFunc: func(ctx context.Context, hostName string, epoch int64) error {
+			return nil
+		},
+	}
+
+	election := NewPrimaryElection(logger, storeMock, "instance-a", WithLeaseDuration(time.Minute))
+	require.False(t, election.IsPrimary())
+
+	// the lease is acquired
+	lease = &store.Lease{HostName: "instance-a", Epoch: 1}
+	election.renew(context.Background())
+	require.True(t, election.IsPrimary())
+	require.Equal(t, int64(1), election.Epoch())
+	require.Equal(t, uint64(1), election.Changes())
+
+	// the lease is kept while it cannot be renewed but has not expired
+	acquireErr = errors.New("failed to acquire lease")
+	election.renew(context.Background())
+	require.True(t, election.IsPrimary())
+
+	election.validUntil = time.Now()
+	require.False(t, election.IsPrimary())
+	election.renew(context.Background())
+	require.Equal(t, int64(0), election.Epoch())
+	require.Equal(t, uint64(2), election.Changes())
+
+	// the lease has been acquired by another instance
+	acquireErr = nil
+	election.renew(context.Background())
+	require.True(t, election.IsPrimary())
+	lease = &store.Lease{HostName: "instance-b", Epoch: 2}
+	election.renew(context.Background())
+	require.False(t, election.IsPrimary())
+	require.Equal(t, uint64(4), election.Changes())
+
+	// a rejected write gives up the lease
+	lease = &store.Lease{HostName: "instance-a", Epoch: 3}
+	election.renew(context.Background())
+	election.fenced(2)
+	require.True(t, election.IsPrimary())
+	election.fenced(3)
+	require.False(t, election.IsPrimary())
+
+	// the lease is released on shutdown
+	election.renew(context.Background())
+	require.True(t, election.IsPrimary())
+	election.Shutdown()
+	require.False(t, election.IsPrimary())
+	require.Len(t, storeMock.ReleaseLeaseCalls(), 1)
+	require.Equal(t, "instance-a", storeMock.ReleaseLeaseCalls()[0].HostName)
+	require.Equal(t, int64(3), storeMock.ReleaseLeaseCalls()[0].Epoch)
+}
+
+func TestHandleBlockFenced(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	txHashes := syntheticTxHashes(0, 10)
+	tree, err := store.NewMerkleTree(txHashes, store.DefaultMerkleSubtreeSize)
+	require.NoError(t, err)
+	merkleRoot, err := tree.Root()
+	require.NoError(t, err)
+
+	var insertEpoch int64
+	storeMock := &store.InterfaceMock{
+		AcquireLeaseFunc: func(ctx context.Context, hostName string, duration time.Duration) (*store.Lease, error) {
+			return &store.Lease{HostName: hostName, Epoch: 7}, nil
+		},
+		GetBlockFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+			return &blocktx_api.Block{}, nil
+		},
+		InsertBlockFunc: func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
+			insertEpoch, _ = store.FencingEpoch(ctx)
+			return 0, store.ErrFenced
+		},
+	}
+
+	election := NewPrimaryElection(logger, storeMock, "instance-a")
+	election.renew(context.Background())
+
+	peerHandler, err := NewPeerHandler(logger, storeMock, 0, []string{}, wire.TestNet, WithPrimaryElection(election), WithFullIndex())
+	require.NoError(t, err)
+	defer peerHandler.Shutdown()
+
+	block := &p2p.BlockMessage{
+		Header:            &wire.BlockHeader{MerkleRoot: *merkleRoot},
+		Height:            100,
+		TransactionHashes: txHashes,
+	}
+
+	// the block is written with the epoch of the lease and rejected, after which the instance is not primary anymore
+	err = peerHandler.HandleBlock(block, &MockedPeer{})
+	require.ErrorIs(t, err, store.ErrFenced)
+	require.Equal(t, int64(7), insertEpoch)
+	require.False(t, election.IsPrimary())
+
+	// blocks are not processed until the lease has been acquired again
+	require.NoError(t, peerHandler.HandleBlock(block, &MockedPeer{}))
+	require.Len(t, storeMock.InsertBlockCalls(), 1)
+}
+
+func TestHandleBlockFencedAfterInsert(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	txHashes := syntheticTxHashes(0, 10)
+	tree, err := store.NewMerkleTree(txHashes, store.DefaultMerkleSubtreeSize)
+	require.NoError(t, err)
+	merkleRoot, err := tree.Root()
+	require.NoError(t, err)
+
+	// the lease is acquired by another instance right after the block has been inserted
+	currentEpoch := int64(7)
+	var writeEpochs []int64
+	fenced := func(ctx context.Context) error {
+		epoch, _ := store.FencingEpoch(ctx)
+		writeEpochs = append(writeEpochs, epoch)
+		if epoch != currentEpoch {
+			return store.ErrFenced
+		}
+		return nil
+	}
+
+	storeMock := &store.InterfaceMock{
+		AcquireLeaseFunc: func(ctx context.Context, hostName string, duration time.Duration) (*store.Lease, error) {
+			return &store.Lease{HostName: hostName, Epoch: 7}, nil
+		},
+		GetBlockFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+			return &blocktx_api.Block{}, nil
+		},
+		InsertBlockFunc: func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
+			if err := fenced(ctx); err != nil {
+				return 0, err
+			}
+			currentEpoch = 8
+			return 1, nil
+		},
+		InsertBlockMerkleTreeFunc: func(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+			return fenced(ctx)
+		},
+		InsertBlockMerkleSubtreesFunc: func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+			return fenced(ctx)
+		},
+		UpdateBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+			return fenced(ctx)
+		},
+		InsertBlockStatsFunc: func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+			return fenced(ctx)
+		},
+		MarkBlockAsDoneFunc: func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
+			return fenced(ctx)
+		},
+	}
+
+	election := NewPrimaryElection(logger, storeMock, "instance-a")
+	election.renew(context.Background())
+
+	peerHandler, err := NewPeerHandler(logger, storeMock, 0, []string{}, wire.TestNet, WithPrimaryElection(election))
+	require.NoError(t, err)
+	defer peerHandler.Shutdown()
+
+	err = peerHandler.HandleBlock(&p2p.BlockMessage{
+		Header:            &wire.BlockHeader{MerkleRoot: *merkleRoot},
+		Height:            100,
+		TransactionHashes: txHashes,
+	}, &MockedPeer{})
+	require.ErrorIs(t, err, store.ErrFenced)
+	require.False(t, election.IsPrimary())
+
+	// the block is written with the epoch of the lease and no further write follows the rejected one
+	require.Equal(t, []int64{7, 7}, writeEpochs)
+	require.Len(t, storeMock.InsertBlockMerkleTreeCalls(), 1)
+	require.Empty(t, storeMock.UpdateBlockTransactionsCalls())
+	require.Empty(t, storeMock.InsertBlockStatsCalls())
+	require.Empty(t, storeMock.MarkBlockAsDoneCalls())
+}
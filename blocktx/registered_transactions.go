@@ -0,0 +1,226 @@
+package blocktx
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+)
+
+const (
+	registeredLoadBatchSize         = 100_000
+	registeredSyncOverlap           = 10_000
+	registeredReloadIntervalDefault = 24 * time.Hour
+
+	bloomFilterBitsPerItem = 10 // false positive rate of about 1% with 7 hash functions
+	bloomFilterHashes      = 7
+	bloomFilterMinCapacity = 1 << 16
+)
+
+// bloomFilter is a Bloom filter of transaction hashes. The transaction hashes are uniformly distributed already,
+// therefore the bit positions are derived from the hash itself by double hashing.
+type bloomFilter struct {
+	bits     []uint64
+	size     uint64
+	capacity uint64
+}
+
+func newBloomFilter(capacity uint64) *bloomFilter {
+	capacity = max(capacity, bloomFilterMinCapacity)
+	size := capacity * bloomFilterBitsPerItem
+
+	return &bloomFilter{
+		bits:     make([]uint64, (size+63)/64),
+		size:     size,
+		capacity: capacity,
+	}
+}
+
+func (f *bloomFilter) add(hash *chainhash.Hash) {
+	h1, h2 := bloomFilterHashPair(hash)
+	for i := uint64(0); i < bloomFilterHashes; i++ {
+		position := (h1 + i*h2) % f.size
+		f.bits[position/64] |= 1 << (position % 64)
+	}
+}
+
+// mayContain returns false if the hash has not been added. It may return true for hashes which have not been added.
+func (f *bloomFilter) mayContain(hash *chainhash.Hash) bool {
+	h1, h2 := bloomFilterHashPair(hash)
+	for i := uint64(0); i < bloomFilterHashes; i++ {
+		position := (h1 + i*h2) % f.size
+		if f.bits[position/64]&(1<<(position%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func bloomFilterHashPair(hash *chainhash.Hash) (uint64, uint64) {
+	// the second hash is odd so that it is never zero
+	return binary.LittleEndian.Uint64(hash[0:8]), binary.LittleEndian.Uint64(hash[8:16]) | 1
+}
+
+// RegisteredTransactions is an in-memory index of the transactions registered with RegisterTransaction. It consists
+// of a Bloom filter which rejects most transactions that are not registered and the exact set of registered transaction
+// hashes, which is only checked for the transactions passing the filter. Registrations of other BlockTx instances are
+// picked up by Sync, which reads the registrations since the last sync from the store.
+type RegisteredTransactions struct {
+	store          store.Interface
+	logger         *slog.Logger
+	reloadInterval time.Duration
+
+	mu       sync.RWMutex
+	filter   *bloomFilter
+	hashes   map[chainhash.Hash]struct{}
+	lastID   uint64
+	loadedAt time.Time
+}
+
+// WithRegisteredReloadInterval sets the interval after which the index is loaded completely from the store, which
+// drops the transactions which have been deleted from the store in the meantime.
+func WithRegisteredReloadInterval(interval time.Duration) func(*RegisteredTransactions) {
+	return func(r *RegisteredTransactions) {
+		r.reloadInterval = interval
+	}
+}
+
+func NewRegisteredTransactions(logger *slog.Logger, storeI store.Interface, opts ...func(*RegisteredTransactions)) *RegisteredTransactions {
+	r := &RegisteredTransactions{
+		store:          storeI,
+		logger:         logger,
+		reloadInterval: registeredReloadIntervalDefault,
+		filter:         newBloomFilter(0),
+		hashes:         make(map[chainhash.Hash]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Load replaces the index with all registered transactions of the store.
+func (r *RegisteredTransactions) Load(ctx context.Context) error {
+	start := time.Now()
+
+	hashes := make(map[chainhash.Hash]struct{})
+	lastID, err := r.read(ctx, 0, func(hash *chainhash.Hash) {
+		hashes[*hash] = struct{}{}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load registered transactions: %v", err)
+	}
+
+	filter := newBloomFilter(uint64(2 * len(hashes)))
+	for hash := range hashes {
+		filter.add(&hash)
+	}
+
+	r.mu.Lock()
+	r.filter = filter
+	r.hashes = hashes
+	r.lastID = lastID
+	r.loadedAt = time.Now()
+	r.mu.Unlock()
+
+	r.logger.Info("loaded registered transactions", slog.Int("count", len(hashes)), slog.String("duration", time.Since(start).String()))
+
+	return nil
+}
+
+// Sync adds the transactions which have been registered since the last sync. The ids of concurrent registrations may
+// become visible out of order, therefore the last ids before the last sync are read again. The index is loaded
+// completely if it has not been loaded within the reload interval.
+func (r *RegisteredTransactions) Sync(ctx context.Context) error {
+	r.mu.RLock()
+	lastID := r.lastID
+	loadedAt := r.loadedAt
+	r.mu.RUnlock()
+
+	if time.Since(loadedAt) >= r.reloadInterval {
+		return r.Load(ctx)
+	}
+
+	lastID, err := r.read(ctx, lastID-min(lastID, registeredSyncOverlap), r.Add)
+	if err != nil {
+		return fmt.Errorf("failed to sync registered transactions: %v", err)
+	}
+
+	r.mu.Lock()
+	r.lastID = max(r.lastID, lastID)
+	r.mu.Unlock()
+
+	return nil
+}
+
+// read calls fn for all registered transactions with an id greater than afterID and returns the last id.
+func (r *RegisteredTransactions) read(ctx context.Context, afterID uint64, fn func(hash *chainhash.Hash)) (uint64, error) {
+	for {
+		transactions, err := r.store.GetRegisteredTransactions(ctx, afterID, registeredLoadBatchSize)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, tx := range transactions {
+			fn(tx.Hash)
+			afterID = tx.ID
+		}
+
+		if len(transactions) < registeredLoadBatchSize {
+			return afterID, nil
+		}
+	}
+}
+
+// Add adds a registered transaction to the index.
+func (r *RegisteredTransactions) Add(hash *chainhash.Hash) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.hashes[*hash]; found {
+		return
+	}
+
+	r.hashes[*hash] = struct{}{}
+
+	// the filter is rebuilt with twice the capacity before the false positive rate rises above the expected rate
+	if uint64(len(r.hashes)) > r.filter.capacity {
+		r.filter = newBloomFilter(2 * r.filter.capacity)
+		for h := range r.hashes {
+			r.filter.add(&h)
+		}
+
+		return
+	}
+
+	r.filter.add(hash)
+}
+
+// Contains returns whether the transaction is registered.
+func (r *RegisteredTransactions) Contains(hash *chainhash.Hash) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.filter.mayContain(hash) {
+		return false
+	}
+
+	_, found := r.hashes[*hash]
+	return found
+}
+
+// Len returns the number of registered transactions in the index.
+func (r *RegisteredTransactions) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.hashes)
+}
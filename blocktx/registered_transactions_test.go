@@ -0,0 +1,288 @@
+package blocktx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func syntheticTxHashes(from int, count int) []*chainhash.Hash {
+	hashes := make([]*chainhash.Hash, count)
+	b := make([]byte, 8)
+	for i := range hashes {
+		binary.LittleEndian.PutUint64(b, uint64(from+i))
+		hash := chainhash.Hash(sha256.Sum256(b))
+		hashes[i] = &hash
+	}
+
+	return hashes
+}
+
+func TestBloomFilter(t *testing.T) {
+	filter := newBloomFilter(10_000)
+
+	added := syntheticTxHashes(0, 10_000)
+	for _, hash := range added {
+		filter.add(hash)
+	}
+
+	for _, hash := range added {
+		require.True(t, filter.mayContain(hash))
+	}
+
+	falsePositives := 0
+	for _, hash := range syntheticTxHashes(10_000, 100_000) {
+		if filter.mayContain(hash) {
+			falsePositives++
+		}
+	}
+
+	// the filter has the minimum capacity, therefore the false positive rate is far below 1%
+	require.Less(t, falsePositives, 1000)
+}
+
+// registeredStore stores registered transactions with increasing ids.
+type registeredStore struct {
+	transactions []*store.RegisteredTransaction
+	err          error
+	calls        int
+}
+
+func (s *registeredStore) register(hashes ...*chainhash.Hash) {
+	for _, hash := range hashes {
+		s.transactions = append(s.transactions, &store.RegisteredTransaction{ID: uint64(len(s.transactions) + 1), Hash: hash})
+	}
+}
+
+func (s *registeredStore) mock() *store.InterfaceMock {
+	return &store.InterfaceMock{
+		GetRegisteredTransactionsFunc: func(ctx context.Context, afterID uint64, limit uint64) ([]*store.RegisteredTransaction, error) {
+			s.calls++
+			if s.err != nil {
+				return nil, s.err
+			}
+
+			result := make([]*store.RegisteredTransaction, 0)
+			for _, tx := range s.transactions {
+				if tx.ID > afterID && uint64(len(result)) < limit {
+					result = append(result, tx)
+				}
+			}
+
+			return result, nil
+		},
+	}
+}
+
+func TestRegisteredTransactions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	ctx := context.Background()
+
+	t.Run("load and sync", func(t *testing.T) {
+		hashes := syntheticTxHashes(0, 250_000)
+		registered := &registeredStore{}
+		registered.register(hashes[:200_000]...)
+
+		r := NewRegisteredTransactions(logger, registered.mock())
+		require.NoError(t, r.Load(ctx))
+		require.Equal(t, 200_000, r.Len())
+		require.Equal(t, 3, registered.calls)
+
+		registered.register(hashes[200_000:]...)
+		require.False(t, r.Contains(hashes[200_000]))
+
+		require.NoError(t, r.Sync(ctx))
+		require.Equal(t, 250_000, r.Len())
+
+		for _, hash := range hashes {
+			require.True(t, r.Contains(hash))
+		}
+
+		for _, hash := range syntheticTxHashes(250_000, 1000) {
+			require.False(t, r.Contains(hash))
+		}
+	})
+
+	t.Run("sync reads registrations which became visible out of order", func(t *testing.T) {
+		hashes := syntheticTxHashes(0, 3)
+		registered := &registeredStore{}
+		registered.register(hashes[0], hashes[1], hashes[2])
+
+		// the registration with id 2 is not visible yet during the first load
+		late := registered.transactions[1]
+		registered.transactions = append(registered.transactions[:1], registered.transactions[2:]...)
+
+		r := NewRegisteredTransactions(logger, registered.mock())
+		require.NoError(t, r.Load(ctx))
+		require.False(t, r.Contains(hashes[1]))
+
+		registered.transactions = append(registered.transactions, late)
+		require.NoError(t, r.Sync(ctx))
+		require.True(t, r.Contains(hashes[1]))
+	})
+
+	t.Run("first sync loads", func(t *testing.T) {
+		registered := &registeredStore{}
+		registered.register(syntheticTxHashes(0, 10)...)
+
+		r := NewRegisteredTransactions(logger, registered.mock())
+		require.NoError(t, r.Sync(ctx))
+		require.Equal(t, 10, r.Len())
+	})
+
+	t.Run("reload drops deleted transactions", func(t *testing.T) {
+		hashes := syntheticTxHashes(0, 10)
+		registered := &registeredStore{}
+		registered.register(hashes...)
+
+		r := NewRegisteredTransactions(logger, registered.mock(), WithRegisteredReloadInterval(time.Nanosecond))
+		require.NoError(t, r.Load(ctx))
+		require.True(t, r.Contains(hashes[0]))
+
+		registered.transactions = registered.transactions[1:]
+		require.NoError(t, r.Sync(ctx))
+		require.False(t, r.Contains(hashes[0]))
+		require.Equal(t, 9, r.Len())
+	})
+
+	t.Run("add beyond filter capacity", func(t *testing.T) {
+		r := NewRegisteredTransactions(logger, (&registeredStore{}).mock())
+
+		hashes := syntheticTxHashes(0, 2*bloomFilterMinCapacity+1)
+		for _, hash := range hashes {
+			r.Add(hash)
+		}
+
+		require.Equal(t, len(hashes), r.Len())
+		require.Equal(t, uint64(4*bloomFilterMinCapacity), r.filter.capacity)
+		for _, hash := range hashes {
+			require.True(t, r.Contains(hash))
+		}
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		registered := &registeredStore{err: errors.New("failed")}
+
+		r := NewRegisteredTransactions(logger, registered.mock())
+		require.Error(t, r.Load(ctx))
+		require.Error(t, r.Sync(ctx))
+	})
+}
+
+func TestHandleBlockRegisteredTransactions(t *testing.T) {
+	txHashes := syntheticTxHashes(0, 3000)
+	tree, err := store.NewMerkleTree(txHashes, store.DefaultMerkleSubtreeSize)
+	require.NoError(t, err)
+	merkleRoot, err := tree.Root()
+	require.NoError(t, err)
+
+	registeredPositions := []uint64{0, 1500, 2999}
+
+	tt := []struct {
+		name      string
+		fullIndex bool
+
+		expectedPositions []uint64
+	}{
+		{
+			name: "registered transactions only",
+
+			expectedPositions: registeredPositions,
+		},
+		{
+			name:      "full index",
+			fullIndex: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			registered := &registeredStore{}
+			for _, pos := range registeredPositions {
+				registered.register(txHashes[pos])
+			}
+
+			var updatedTransactions []*blocktx_api.BlockTransaction
+			var insertedTransactions []*blocktx_api.BlockTransaction
+
+			storeMock := registered.mock()
+			storeMock.GetBlockFunc = func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+				return &blocktx_api.Block{}, nil
+			}
+			storeMock.InsertBlockFunc = func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
+				return 1, nil
+			}
+			storeMock.InsertBlockMerkleTreeFunc = func(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+				return nil
+			}
+			storeMock.InsertBlockMerkleSubtreesFunc = func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+				return nil
+			}
+			storeMock.UpdateBlockTransactionsFunc = func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+				updatedTransactions = append(updatedTransactions, transactions...)
+				return nil
+			}
+			storeMock.InsertBlockTransactionsFunc = func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+				insertedTransactions = append(insertedTransactions, transactions...)
+				return nil
+			}
+			storeMock.GetBlockRegisteredTxCountFunc = func(ctx context.Context, blockId uint64) (uint64, error) {
+				return uint64(len(registeredPositions)), nil
+			}
+			storeMock.GetBlockStatsFunc = func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+				return nil, store.ErrBlockNotFound
+			}
+			storeMock.InsertBlockStatsFunc = func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+				return nil
+			}
+			storeMock.MarkBlockAsDoneFunc = func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
+				return nil
+			}
+
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+			registeredTransactions := NewRegisteredTransactions(logger, storeMock)
+			opts := []func(*PeerHandler){WithRegisteredTransactions(registeredTransactions)}
+			if tc.fullIndex {
+				opts = append(opts, WithFullIndex())
+			}
+
+			peerHandler, err := NewPeerHandler(logger, storeMock, 0, []string{}, wire.TestNet, opts...)
+			require.NoError(t, err)
+			defer peerHandler.Shutdown()
+
+			err = peerHandler.HandleBlock(&p2p.BlockMessage{
+				Header:            &wire.BlockHeader{MerkleRoot: *merkleRoot},
+				Height:            100,
+				TransactionHashes: txHashes,
+			}, &MockedPeer{})
+			require.NoError(t, err)
+
+			if tc.fullIndex {
+				require.Empty(t, updatedTransactions)
+				require.Len(t, insertedTransactions, len(txHashes))
+				// the index is not synced, as all transactions are stored anyway
+				require.Empty(t, storeMock.GetRegisteredTransactionsCalls())
+				return
+			}
+
+			require.Empty(t, insertedTransactions)
+			require.Len(t, updatedTransactions, len(tc.expectedPositions))
+			for i, pos := range tc.expectedPositions {
+				require.Equal(t, pos, updatedTransactions[i].GetPos())
+				require.Equal(t, txHashes[pos][:], updatedTransactions[i].GetHash())
+			}
+		})
+	}
+}
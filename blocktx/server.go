@@ -10,31 +10,50 @@ import (
 
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
 	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/bitcoin-sv/arc/lib/spv"
 	"github.com/bitcoin-sv/arc/tracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
 	"github.com/ordishs/gocore"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const maxBlockTransactionsLimit = 1000
+
 // Server type carries the logger within it.
 type Server struct {
 	blocktx_api.UnsafeBlockTxAPIServer
-	store      store.Interface
-	logger     *slog.Logger
-	grpcServer *grpc.Server
+	store                  store.Interface
+	logger                 *slog.Logger
+	grpcServer             *grpc.Server
+	registeredTransactions *RegisteredTransactions
+}
+
+// WithServerRegisteredTransactions sets the index of registered transactions to which registered transactions are added.
+func WithServerRegisteredTransactions(registeredTransactions *RegisteredTransactions) func(*Server) {
+	return func(s *Server) {
+		s.registeredTransactions = registeredTransactions
+	}
 }
 
 // NewServer will return a server instance with the logger stored within it.
-func NewServer(storeI store.Interface, logger *slog.Logger) *Server {
-	return &Server{
+func NewServer(storeI store.Interface, logger *slog.Logger, opts ...func(*Server)) *Server {
+	s := &Server{
 		store:  storeI,
 		logger: logger,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // StartGRPCServer function.
@@ -102,13 +121,197 @@ func (s *Server) RegisterTransaction(ctx context.Context, transaction *blocktx_a
 	if err != nil {
 		return &emptypb.Empty{}, err
 	}
-	return &emptypb.Empty{}, err
+
+	if s.registeredTransactions != nil {
+		hash, err := chainhash.NewHash(transaction.GetHash())
+		if err == nil {
+			s.registeredTransactions.Add(hash)
+		}
+	}
+
+	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) GetTransactionBlocks(ctx context.Context, transaction *blocktx_api.Transactions) (*blocktx_api.TransactionBlocks, error) {
 	return s.store.GetTransactionBlocks(ctx, transaction)
 }
 
+func (s *Server) GetBlock(ctx context.Context, req *blocktx_api.Hash) (*blocktx_api.Block, error) {
+	hash, err := chainhash.NewHash(req.GetHash())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	block, err := s.store.GetBlock(ctx, hash)
+	if err != nil {
+		return nil, blockError(err)
+	}
+
+	return block, nil
+}
+
+func (s *Server) GetBlockStats(ctx context.Context, req *blocktx_api.Hash) (*blocktx_api.BlockStats, error) {
+	hash, err := chainhash.NewHash(req.GetHash())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	stats, err := s.store.GetBlockStats(ctx, hash)
+	if err != nil {
+		return nil, blockError(err)
+	}
+
+	return stats, nil
+}
+
+func (s *Server) GetBlockByHeight(ctx context.Context, req *blocktx_api.Height) (*blocktx_api.Block, error) {
+	block, err := s.store.GetBlockByHeight(ctx, req.GetHeight())
+	if err != nil {
+		return nil, blockError(err)
+	}
+
+	return block, nil
+}
+
+func (s *Server) GetChainTip(ctx context.Context, _ *emptypb.Empty) (*blocktx_api.Block, error) {
+	block, err := s.store.GetChainTip(ctx)
+	if err != nil {
+		return nil, blockError(err)
+	}
+
+	return block, nil
+}
+
+func (s *Server) GetBlockTransactions(ctx context.Context, req *blocktx_api.BlockTransactionsRequest) (*blocktx_api.BlockTransactions, error) {
+	hash, err := chainhash.NewHash(req.GetBlockHash())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := req.GetLimit()
+	if limit == 0 || limit > maxBlockTransactionsLimit {
+		limit = maxBlockTransactionsLimit
+	}
+
+	// make sure that the block exists, so that an unknown block can be distinguished from a block without registered transactions
+	_, err = s.store.GetBlock(ctx, hash)
+	if err != nil {
+		return nil, blockError(err)
+	}
+
+	// request one more transaction than the limit to find out whether there is a next page
+	transactions, err := s.store.GetBlockTransactions(ctx, hash, req.GetOffset(), limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &blocktx_api.BlockTransactions{Transactions: transactions}
+	if uint64(len(transactions)) > limit {
+		result.Transactions = transactions[:limit]
+		result.NextOffset = transactions[limit].GetPos()
+	}
+
+	return result, nil
+}
+
+func (s *Server) GetConfirmations(ctx context.Context, transaction *blocktx_api.Transaction) (*blocktx_api.Confirmations, error) {
+	if _, err := chainhash.NewHash(transaction.GetHash()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	transactionBlocks, err := s.store.GetTransactionBlocks(ctx, &blocktx_api.Transactions{Transactions: []*blocktx_api.Transaction{transaction}})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactionBlocks.GetTransactionBlocks()) == 0 {
+		return nil, status.Error(codes.NotFound, ErrTransactionNotFound.Error())
+	}
+
+	transactionBlock := transactionBlocks.GetTransactionBlocks()[0]
+
+	tip, err := s.store.GetChainTip(ctx)
+	if err != nil {
+		return nil, blockError(err)
+	}
+
+	confirmations := &blocktx_api.Confirmations{
+		TransactionHash: transactionBlock.GetTransactionHash(),
+		BlockHash:       transactionBlock.GetBlockHash(),
+		BlockHeight:     transactionBlock.GetBlockHeight(),
+	}
+
+	if tip.GetHeight() >= transactionBlock.GetBlockHeight() {
+		confirmations.Confirmations = tip.GetHeight() - transactionBlock.GetBlockHeight() + 1
+	}
+
+	return confirmations, nil
+}
+
+func (s *Server) VerifyMerklePath(ctx context.Context, req *blocktx_api.MerklePathVerificationRequest) (*blocktx_api.MerklePathVerification, error) {
+	txHash, err := chainhash.NewHash(req.GetTransactionHash())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	merklePath, err := spv.NewMerklePathFromHex(req.GetMerklePath())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to parse merkle path: %v", err))
+	}
+
+	verification := &blocktx_api.MerklePathVerification{
+		Result:      blocktx_api.MerklePathVerificationResult_UNKNOWN,
+		BlockHeight: merklePath.BlockHeight,
+	}
+
+	block, err := s.store.GetBlockByHeight(ctx, merklePath.BlockHeight)
+	if err != nil {
+		if errors.Is(err, store.ErrBlockNotFound) {
+			return verification, nil
+		}
+		return nil, err
+	}
+
+	verification.BlockHash = block.GetHash()
+	verification.Result = blocktx_api.MerklePathVerificationResult_INVALID
+
+	calculatedMerkleRoot, err := merklePath.ComputeRoot(txHash)
+	if err != nil {
+		return verification, nil
+	}
+	verification.MerkleRoot = calculatedMerkleRoot[:]
+
+	merkleRoot, err := chainhash.NewHash(block.GetMerkleRoot())
+	if err != nil {
+		return nil, err
+	}
+
+	if !merkleRoot.IsEqual(calculatedMerkleRoot) {
+		return verification, nil
+	}
+
+	tip, err := s.store.GetChainTip(ctx)
+	if err != nil {
+		return nil, blockError(err)
+	}
+
+	verification.Result = blocktx_api.MerklePathVerificationResult_VALID
+	if tip.GetHeight() >= block.GetHeight() {
+		verification.Confirmations = tip.GetHeight() - block.GetHeight() + 1
+	}
+
+	return verification, nil
+}
+
+// blockError converts a store error into a gRPC error, so that clients can recognize a block which was not found.
+func blockError(err error) error {
+	if errors.Is(err, store.ErrBlockNotFound) {
+		return status.Error(codes.NotFound, ErrBlockNotFound.Error())
+	}
+
+	return err
+}
+
 func (s *Server) Shutdown() {
 	s.logger.Info("Shutting down")
 	s.grpcServer.Stop()
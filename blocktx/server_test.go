@@ -1,13 +1,20 @@
 package blocktx
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
 	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestStartGRPCServer(t *testing.T) {
@@ -36,3 +43,338 @@ func TestStartGRPCServer(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlockTransactions(t *testing.T) {
+	blockHash, err := chainhash.NewHashFromStr("000000000000000001d29fef90f0f86eb7a99f043b994c7e363e0aa72db05862")
+	require.NoError(t, err)
+
+	tt := []struct {
+		name           string
+		limit          uint64
+		getBlockErr    error
+		transactions   []*blocktx_api.BlockTransaction
+		transactionErr error
+
+		expectedLimit      uint64
+		expectedTxs        int
+		expectedNextOffset uint64
+		expectedCode       codes.Code
+		expectedErrorStr   string
+	}{
+		{
+			name:         "last page",
+			limit:        2,
+			transactions: []*blocktx_api.BlockTransaction{{Pos: 3}, {Pos: 7}},
+
+			expectedLimit: 3,
+			expectedTxs:   2,
+		},
+		{
+			name:         "next page",
+			limit:        2,
+			transactions: []*blocktx_api.BlockTransaction{{Pos: 3}, {Pos: 7}, {Pos: 9}},
+
+			expectedLimit:      3,
+			expectedTxs:        2,
+			expectedNextOffset: 9,
+		},
+		{
+			name:         "limit exceeds maximum",
+			limit:        5000,
+			transactions: []*blocktx_api.BlockTransaction{},
+
+			expectedLimit: maxBlockTransactionsLimit + 1,
+		},
+		{
+			name:        "block not found",
+			getBlockErr: store.ErrBlockNotFound,
+
+			expectedCode:     codes.NotFound,
+			expectedErrorStr: "block not found",
+		},
+		{
+			name:           "store error",
+			transactionErr: errors.New("db connection error"),
+
+			expectedLimit:    maxBlockTransactionsLimit + 1,
+			expectedErrorStr: "db connection error",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+			storeMock := &store.InterfaceMock{
+				GetBlockFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
+					return &blocktx_api.Block{}, tc.getBlockErr
+				},
+				GetBlockTransactionsFunc: func(ctx context.Context, hash *chainhash.Hash, offset uint64, limit uint64) ([]*blocktx_api.BlockTransaction, error) {
+					require.Equal(t, tc.expectedLimit, limit)
+					return tc.transactions, tc.transactionErr
+				},
+			}
+
+			server := NewServer(storeMock, logger)
+
+			result, err := server.GetBlockTransactions(context.Background(), &blocktx_api.BlockTransactionsRequest{
+				BlockHash: blockHash[:],
+				Limit:     tc.limit,
+			})
+
+			if tc.expectedErrorStr != "" || err != nil {
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+				if tc.expectedCode != codes.OK {
+					require.Equal(t, tc.expectedCode, status.Code(err))
+				}
+				return
+			}
+
+			require.Len(t, result.GetTransactions(), tc.expectedTxs)
+			require.Equal(t, tc.expectedNextOffset, result.GetNextOffset())
+		})
+	}
+}
+
+func TestGetConfirmations(t *testing.T) {
+	txHash, err := chainhash.NewHashFromStr("b0926372c449731ffb84b7d2f808087d4b5e6e26aafee872232bbcd5a5854e16")
+	require.NoError(t, err)
+
+	tt := []struct {
+		name              string
+		transactionBlocks []*blocktx_api.TransactionBlock
+		chainTipHeight    uint64
+		chainTipErr       error
+
+		expectedConfirmations uint64
+		expectedCode          codes.Code
+		expectedErrorStr      string
+	}{
+		{
+			name:              "success",
+			transactionBlocks: []*blocktx_api.TransactionBlock{{BlockHeight: 100, TransactionHash: txHash[:]}},
+			chainTipHeight:    105,
+
+			expectedConfirmations: 6,
+		},
+		{
+			name:              "mined in chain tip",
+			transactionBlocks: []*blocktx_api.TransactionBlock{{BlockHeight: 105, TransactionHash: txHash[:]}},
+			chainTipHeight:    105,
+
+			expectedConfirmations: 1,
+		},
+		{
+			name: "transaction not found",
+
+			expectedCode:     codes.NotFound,
+			expectedErrorStr: "transaction not found",
+		},
+		{
+			name:              "chain tip not found",
+			transactionBlocks: []*blocktx_api.TransactionBlock{{BlockHeight: 100, TransactionHash: txHash[:]}},
+			chainTipErr:       store.ErrBlockNotFound,
+
+			expectedCode:     codes.NotFound,
+			expectedErrorStr: "block not found",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+			storeMock := &store.InterfaceMock{
+				GetTransactionBlocksFunc: func(ctx context.Context, transactions *blocktx_api.Transactions) (*blocktx_api.TransactionBlocks, error) {
+					return &blocktx_api.TransactionBlocks{TransactionBlocks: tc.transactionBlocks}, nil
+				},
+				GetChainTipFunc: func(ctx context.Context) (*blocktx_api.Block, error) {
+					if tc.chainTipErr != nil {
+						return nil, tc.chainTipErr
+					}
+					return &blocktx_api.Block{Height: tc.chainTipHeight}, nil
+				},
+			}
+
+			server := NewServer(storeMock, logger)
+
+			result, err := server.GetConfirmations(context.Background(), &blocktx_api.Transaction{Hash: txHash[:]})
+
+			if tc.expectedErrorStr != "" || err != nil {
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+				require.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+
+			require.Equal(t, tc.expectedConfirmations, result.GetConfirmations())
+			require.Equal(t, txHash[:], result.GetTransactionHash())
+		})
+	}
+}
+
+func TestVerifyMerklePath(t *testing.T) {
+	leaves := make([]*chainhash.Hash, 5)
+	for i := range leaves {
+		hash := chainhash.DoubleHashH([]byte{byte(i)})
+		leaves[i] = &hash
+	}
+
+	fullTree := bc.BuildMerkleTreeStoreChainHash(leaves)
+	merkleRoot := fullTree[len(fullTree)-1]
+	blockHash := chainhash.DoubleHashH([]byte("block"))
+
+	bump, err := bc.NewBUMPFromMerkleTreeAndIndex(100, fullTree, 3)
+	require.NoError(t, err)
+	merklePath, err := bump.String()
+	require.NoError(t, err)
+
+	otherTxHash := chainhash.DoubleHashH([]byte("other"))
+
+	tt := []struct {
+		name       string
+		merklePath string
+		txHash     *chainhash.Hash
+		block      *blocktx_api.Block
+
+		expectedResult        blocktx_api.MerklePathVerificationResult
+		expectedConfirmations uint64
+		expectedCode          codes.Code
+	}{
+		{
+			name:       "valid",
+			merklePath: merklePath,
+			txHash:     leaves[3],
+			block:      &blocktx_api.Block{Hash: blockHash[:], MerkleRoot: merkleRoot[:], Height: 100},
+
+			expectedResult:        blocktx_api.MerklePathVerificationResult_VALID,
+			expectedConfirmations: 6,
+		},
+		{
+			name:       "merkle root mismatch",
+			merklePath: merklePath,
+			txHash:     leaves[3],
+			block:      &blocktx_api.Block{Hash: blockHash[:], MerkleRoot: otherTxHash[:], Height: 100},
+
+			expectedResult: blocktx_api.MerklePathVerificationResult_INVALID,
+		},
+		{
+			name:       "transaction not in merkle path",
+			merklePath: merklePath,
+			txHash:     &otherTxHash,
+			block:      &blocktx_api.Block{Hash: blockHash[:], MerkleRoot: merkleRoot[:], Height: 100},
+
+			expectedResult: blocktx_api.MerklePathVerificationResult_INVALID,
+		},
+		{
+			name:       "block not found",
+			merklePath: merklePath,
+			txHash:     leaves[3],
+
+			expectedResult: blocktx_api.MerklePathVerificationResult_UNKNOWN,
+		},
+		{
+			name:       "invalid merkle path",
+			merklePath: "invalid",
+			txHash:     leaves[3],
+
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+			storeMock := &store.InterfaceMock{
+				GetBlockByHeightFunc: func(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+					require.Equal(t, uint64(100), height)
+					if tc.block == nil {
+						return nil, store.ErrBlockNotFound
+					}
+					return tc.block, nil
+				},
+				GetChainTipFunc: func(ctx context.Context) (*blocktx_api.Block, error) {
+					return &blocktx_api.Block{Height: 105}, nil
+				},
+			}
+
+			server := NewServer(storeMock, logger)
+
+			result, err := server.VerifyMerklePath(context.Background(), &blocktx_api.MerklePathVerificationRequest{
+				MerklePath:      tc.merklePath,
+				TransactionHash: tc.txHash[:],
+			})
+
+			if tc.expectedCode != codes.OK {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result.GetResult())
+			require.Equal(t, uint64(100), result.GetBlockHeight())
+			require.Equal(t, tc.expectedConfirmations, result.GetConfirmations())
+
+			if tc.expectedResult == blocktx_api.MerklePathVerificationResult_VALID {
+				require.Equal(t, merkleRoot[:], result.GetMerkleRoot())
+				require.Equal(t, blockHash[:], result.GetBlockHash())
+			}
+		})
+	}
+}
+
+func TestGetBlockStats(t *testing.T) {
+	blockHash, err := chainhash.NewHashFromStr("0000000000000000025855b1f8b1f3bb7e3ad1df36e1ca4b92e54bfcd91e4c4a")
+	require.NoError(t, err)
+
+	tt := []struct {
+		name     string
+		hash     []byte
+		storeErr error
+
+		expectedCode codes.Code
+	}{
+		{
+			name: "success",
+			hash: blockHash[:],
+		},
+		{
+			name: "invalid hash",
+			hash: []byte("invalid"),
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:     "block not found",
+			hash:     blockHash[:],
+			storeErr: store.ErrBlockNotFound,
+
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+			storeMock := &store.InterfaceMock{
+				GetBlockStatsFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+					if tc.storeErr != nil {
+						return nil, tc.storeErr
+					}
+					return &blocktx_api.BlockStats{BlockHash: hash[:], TotalFees: 1200}, nil
+				},
+			}
+
+			server := NewServer(storeMock, logger)
+
+			stats, err := server.GetBlockStats(context.Background(), &blocktx_api.Hash{Hash: tc.hash})
+
+			if tc.expectedCode != codes.OK {
+				require.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, blockHash[:], stats.GetBlockHash())
+			require.Equal(t, uint64(1200), stats.GetTotalFees())
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package simulation contains the tests which run BlockTx against the simulated network of lib/p2psim.
+//
+// The tests are kept in their own package, because the peers of go-p2p cannot be stopped and read the global message
+// handlers and limits of the wire package, which every new peer handler of BlockTx overrides. In a separate test
+// binary they do not race with the other tests of BlockTx.
+package simulation
@@ -0,0 +1,65 @@
+package simulation
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx"
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/lib/p2psim"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatedNetwork(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	// the network is not closed, because peers of go-p2p race when they are disconnected
+	network, err := p2psim.NewNetwork(wire.TestNet)
+	require.NoError(t, err)
+
+	node, err := network.AddNode("node1:18333")
+	require.NoError(t, err)
+
+	blocktxStore, err := blocktx.NewStore("sqlite_memory")
+	require.NoError(t, err)
+	defer blocktxStore.Close()
+
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), []byte{0x51}))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+	txHash := tx.TxHash()
+
+	err = blocktxStore.RegisterTransaction(context.Background(), &blocktx_api.TransactionAndSource{Hash: txHash[:]})
+	require.NoError(t, err)
+
+	peerHandler, err := blocktx.NewPeerHandler(logger, blocktxStore, 0, []string{node.Address()}, wire.TestNet, blocktx.WithPeerOptions(p2p.WithDialer(network.Dial)))
+	require.NoError(t, err)
+	defer peerHandler.Shutdown()
+
+	require.Eventually(t, func() bool { return node.Peers() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, network.SubmitTransaction(tx))
+	block, err := network.MineBlock(tx)
+	require.NoError(t, err)
+	blockHash := block.BlockHash()
+
+	require.Eventually(t, func() bool {
+		storedBlock, err := blocktxStore.GetBlock(context.Background(), &blockHash)
+		return err == nil && storedBlock.Processed
+	}, 5*time.Second, 10*time.Millisecond)
+
+	storedBlock, err := blocktxStore.GetBlock(context.Background(), &blockHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), storedBlock.Height)
+	require.Equal(t, uint64(2), storedBlock.TxCount)
+
+	merklePath, err := blocktxStore.GetTransactionMerklePath(context.Background(), &txHash)
+	require.NoError(t, err)
+	require.NotEmpty(t, merklePath)
+}
@@ -0,0 +1,17 @@
+package store
+
+import "context"
+
+type fencingEpochKey struct{}
+
+// WithFencingEpoch returns a context which carries the epoch of the lease held by the primary blocktx instance. Blocks
+// written with this context are rejected with ErrFenced if the lease has been acquired by another instance meanwhile.
+func WithFencingEpoch(ctx context.Context, epoch int64) context.Context {
+	return context.WithValue(ctx, fencingEpochKey{}, epoch)
+}
+
+// FencingEpoch returns the fencing epoch carried by the context, if any.
+func FencingEpoch(ctx context.Context) (int64, bool) {
+	epoch, ok := ctx.Value(fencingEpochKey{}).(int64)
+	return epoch, ok
+}
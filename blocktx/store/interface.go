@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
@@ -13,18 +14,34 @@ var (
 
 	// ErrBlockNotFound is returned when a block is not found.
 	ErrBlockNotFound = errors.New("block not found")
+
+	// ErrFenced is returned when a block is written with the fencing epoch of a lease which is not current anymore.
+	ErrFenced = errors.New("fencing epoch is not current")
 )
 
 type Interface interface {
 	RegisterTransaction(ctx context.Context, transaction *blocktx_api.TransactionAndSource) error
-	TryToBecomePrimary(ctx context.Context, myHostName string) error
-	GetPrimary(ctx context.Context) (string, error)
+	AcquireLease(ctx context.Context, hostName string, duration time.Duration) (*Lease, error)
+	ReleaseLease(ctx context.Context, hostName string, epoch int64) error
+	GetLease(ctx context.Context) (*Lease, error)
 	GetTransactionMerklePath(ctx context.Context, hash *chainhash.Hash) (string, error)
 	GetBlock(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error)
+	GetBlockByHeight(ctx context.Context, height uint64) (*blocktx_api.Block, error)
+	GetChainTip(ctx context.Context) (*blocktx_api.Block, error)
+	GetBlockTransactions(ctx context.Context, hash *chainhash.Hash, offset uint64, limit uint64) ([]*blocktx_api.BlockTransaction, error)
 	GetTransactionBlocks(ctx context.Context, transactions *blocktx_api.Transactions) (*blocktx_api.TransactionBlocks, error)
 	InsertBlock(ctx context.Context, block *blocktx_api.Block) (uint64, error)
-	UpdateBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error
+	InsertBlockMerkleTree(ctx context.Context, blockId uint64, tree *MerkleTree) error
+	InsertBlockMerkleSubtrees(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error
+	UpdateBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error
+	InsertBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error
+	GetRegisteredTransactions(ctx context.Context, afterID uint64, limit uint64) ([]*RegisteredTransaction, error)
+	GetBlockRegisteredTxCount(ctx context.Context, blockId uint64) (uint64, error)
 	MarkBlockAsDone(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error
+	InsertBlockStats(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error
+	GetBlockStats(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error)
 	GetBlockGaps(ctx context.Context, heightRange int) ([]*BlockGap, error)
+	InsertBlockHeaders(ctx context.Context, headers []*BlockHeader) error
+	GetLatestBlockHeaders(ctx context.Context, count uint64) ([]*BlockHeader, error)
 	Close() error
 }
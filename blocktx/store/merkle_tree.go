@@ -0,0 +1,288 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bitcoin-sv/arc/lib/spv"
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+)
+
+// DefaultMerkleSubtreeSize is the number of leaves per subtree. A BUMP can be calculated from the leaves of a single
+// subtree and the roots of all subtrees, which requires at most 2 * DefaultMerkleSubtreeSize hashes for blocks with
+// up to DefaultMerkleSubtreeSize^2 transactions.
+const DefaultMerkleSubtreeSize = 1024
+
+var (
+	ErrInvalidSubtreeSize   = errors.New("subtree size must be a power of two")
+	ErrTxIndexOutOfRange    = errors.New("transaction index out of range")
+	ErrInvalidSubtreeLeaves = errors.New("invalid subtree leaves")
+)
+
+// MerkleTree is the compact representation of the Merkle tree of a block. Instead of all nodes of the tree only the
+// leaves split into subtrees and the root of each subtree are stored.
+type MerkleTree struct {
+	TxCount     uint64
+	SubtreeSize uint64
+	// SubtreeRoots contains the concatenated roots of all subtrees.
+	SubtreeRoots []byte
+	// Subtrees contains the concatenated leaves of each subtree.
+	Subtrees [][]byte
+}
+
+// NewMerkleTree splits the leaves into subtrees of the given size and calculates the root of each subtree.
+func NewMerkleTree(leaves []*chainhash.Hash, subtreeSize uint64) (*MerkleTree, error) {
+	if subtreeSize == 0 || subtreeSize&(subtreeSize-1) != 0 {
+		return nil, ErrInvalidSubtreeSize
+	}
+
+	txCount := uint64(len(leaves))
+	numberOfSubtrees := (txCount + subtreeSize - 1) / subtreeSize
+
+	tree := &MerkleTree{
+		TxCount:      txCount,
+		SubtreeSize:  subtreeSize,
+		SubtreeRoots: make([]byte, 0, numberOfSubtrees*chainhash.HashSize),
+		Subtrees:     make([][]byte, 0, numberOfSubtrees),
+	}
+
+	subtreeHeight := log2(subtreeSize)
+
+	for start := uint64(0); start < txCount; start += subtreeSize {
+		end := min(start+subtreeSize, txCount)
+
+		subtree := make([]byte, 0, (end-start)*chainhash.HashSize)
+		for _, leaf := range leaves[start:end] {
+			subtree = append(subtree, leaf[:]...)
+		}
+		tree.Subtrees = append(tree.Subtrees, subtree)
+
+		tree.SubtreeRoots = append(tree.SubtreeRoots, subtreeRoot(leaves[start:end], subtreeHeight)[:]...)
+	}
+
+	return tree, nil
+}
+
+// MerkleTreeBuilder calculates the compact Merkle tree of a block incrementally from the leaves in the order of the
+// block. Only the leaves of the current subtree are kept in memory, therefore the resulting Merkle tree contains the
+// subtree roots but not the leaves of the subtrees.
+type MerkleTreeBuilder struct {
+	tree          *MerkleTree
+	subtreeHeight int
+	leaves        []*chainhash.Hash
+}
+
+func NewMerkleTreeBuilder(subtreeSize uint64) (*MerkleTreeBuilder, error) {
+	if subtreeSize == 0 || subtreeSize&(subtreeSize-1) != 0 {
+		return nil, ErrInvalidSubtreeSize
+	}
+
+	return &MerkleTreeBuilder{
+		tree:          &MerkleTree{SubtreeSize: subtreeSize},
+		subtreeHeight: log2(subtreeSize),
+		leaves:        make([]*chainhash.Hash, 0, subtreeSize),
+	}, nil
+}
+
+// Add adds the next leaf of the block.
+func (b *MerkleTreeBuilder) Add(leaf *chainhash.Hash) {
+	b.leaves = append(b.leaves, leaf)
+	b.tree.TxCount++
+
+	if uint64(len(b.leaves)) == b.tree.SubtreeSize {
+		b.completeSubtree()
+	}
+}
+
+// Finish completes the last subtree and returns the Merkle tree without subtree leaves and the Merkle root of the
+// block. The builder must not be used afterwards.
+func (b *MerkleTreeBuilder) Finish() (*MerkleTree, *chainhash.Hash, error) {
+	if b.tree.TxCount == 0 {
+		return nil, nil, errors.New("merkle tree is empty")
+	}
+
+	// the subtree root of small blocks contains padding, therefore the Merkle root is calculated from the leaves
+	if b.tree.TxCount < b.tree.SubtreeSize {
+		root := subtreeRoot(b.leaves, log2(nextPowerOfTwo(b.tree.TxCount)))
+		b.completeSubtree()
+		return b.tree, root, nil
+	}
+
+	if len(b.leaves) > 0 {
+		b.completeSubtree()
+	}
+
+	roots, err := hashesFromBytes(b.tree.SubtreeRoots)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b.tree, subtreeRoot(roots, log2(nextPowerOfTwo(uint64(len(roots))))), nil
+}
+
+func (b *MerkleTreeBuilder) completeSubtree() {
+	b.tree.SubtreeRoots = append(b.tree.SubtreeRoots, subtreeRoot(b.leaves, b.subtreeHeight)[:]...)
+	b.leaves = b.leaves[:0]
+}
+
+// subtreeRoot calculates the root of a subtree of the given height. Missing leaves are padded by hashing the last
+// node of each level with itself.
+func subtreeRoot(leaves []*chainhash.Hash, height int) *chainhash.Hash {
+	level := leaves
+	for i := 0; i < height; i++ {
+		level = parentLevel(level)
+	}
+
+	return level[0]
+}
+
+// Root returns the Merkle root of the block.
+func (t *MerkleTree) Root() (*chainhash.Hash, error) {
+	roots, err := hashesFromBytes(t.SubtreeRoots)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roots) == 0 {
+		return nil, errors.New("merkle tree is empty")
+	}
+
+	// the subtree roots contain the padding of small blocks, therefore small blocks are calculated from the leaves
+	if t.TxCount < t.SubtreeSize {
+		if len(t.Subtrees) == 0 {
+			return nil, errors.New("merkle tree does not contain the leaves of the block")
+		}
+
+		leaves, err := hashesFromBytes(t.Subtrees[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return spv.MerkleRoot(leaves)
+	}
+
+	for len(roots) > 1 {
+		roots = parentLevel(roots)
+	}
+
+	return roots[0], nil
+}
+
+// MerklePath calculates the BUMP of the transaction at the given index from the leaves of the subtree containing the
+// transaction and the roots of all subtrees. The result is identical to the BUMP calculated from the full Merkle tree.
+func MerklePath(blockHeight uint64, txCount uint64, subtreeSize uint64, subtreeRoots []byte, subtreeLeaves []byte, txIndex uint64) (string, error) {
+	if subtreeSize == 0 || subtreeSize&(subtreeSize-1) != 0 {
+		return "", ErrInvalidSubtreeSize
+	}
+
+	if txIndex >= txCount {
+		return "", fmt.Errorf("%w: index %d, tx count %d", ErrTxIndexOutOfRange, txIndex, txCount)
+	}
+
+	leaves, err := hashesFromBytes(subtreeLeaves)
+	if err != nil {
+		return "", err
+	}
+
+	indexInSubtree := txIndex % subtreeSize
+	if indexInSubtree >= uint64(len(leaves)) {
+		return "", fmt.Errorf("%w: subtree does not contain index %d", ErrInvalidSubtreeLeaves, txIndex)
+	}
+
+	merklePath := &spv.MerklePath{BlockHeight: blockHeight}
+
+	// a block with a single transaction has no path, the txid is the Merkle root
+	if txCount == 1 {
+		merklePath.Path = [][]*spv.Leaf{{{Offset: 0, Hash: leaves[0], TxID: true}}}
+		return merklePath.Hex(), nil
+	}
+
+	treeHeight := log2(nextPowerOfTwo(txCount))
+	subtreeHeight := log2(subtreeSize)
+	merklePath.Path = make([][]*spv.Leaf, treeHeight)
+
+	level := leaves
+	index := indexInSubtree
+	for height := 0; height < treeHeight; height++ {
+		if height == subtreeHeight {
+			// continue with the subtree roots above the subtrees
+			level, err = hashesFromBytes(subtreeRoots)
+			if err != nil {
+				return "", err
+			}
+			index = txIndex / subtreeSize
+		}
+
+		// a missing sibling is a duplicate of the last hash of the level
+		sibling := &spv.Leaf{Offset: (txIndex >> height) ^ 1}
+		if siblingIndex := index ^ 1; siblingIndex < uint64(len(level)) {
+			sibling.Hash = level[siblingIndex]
+		}
+
+		if height == 0 {
+			tx := &spv.Leaf{Offset: txIndex, Hash: level[index], TxID: true}
+			if sibling.Offset < txIndex {
+				merklePath.Path[height] = []*spv.Leaf{sibling, tx}
+			} else {
+				merklePath.Path[height] = []*spv.Leaf{tx, sibling}
+			}
+		} else {
+			merklePath.Path[height] = []*spv.Leaf{sibling}
+		}
+
+		level = parentLevel(level)
+		index >>= 1
+	}
+
+	return merklePath.Hex(), nil
+}
+
+// parentLevel calculates the next level of a Merkle tree. A node without right sibling is hashed with itself.
+func parentLevel(level []*chainhash.Hash) []*chainhash.Hash {
+	parents := make([]*chainhash.Hash, (len(level)+1)/2)
+	for i := range parents {
+		left := level[2*i]
+		right := left
+		if 2*i+1 < len(level) {
+			right = level[2*i+1]
+		}
+
+		parents[i] = bc.MerkleTreeParentBytes(left, right)
+	}
+
+	return parents
+}
+
+func hashesFromBytes(b []byte) ([]*chainhash.Hash, error) {
+	if len(b)%chainhash.HashSize != 0 {
+		return nil, fmt.Errorf("%w: length %d is not a multiple of %d", ErrInvalidSubtreeLeaves, len(b), chainhash.HashSize)
+	}
+
+	hashes := make([]*chainhash.Hash, len(b)/chainhash.HashSize)
+	for i := range hashes {
+		hash := chainhash.Hash(b[i*chainhash.HashSize : (i+1)*chainhash.HashSize])
+		hashes[i] = &hash
+	}
+
+	return hashes, nil
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	p := uint64(1)
+	for p < n {
+		p <<= 1
+	}
+
+	return p
+}
+
+func log2(n uint64) int {
+	height := 0
+	for n > 1 {
+		n >>= 1
+		height++
+	}
+
+	return height
+}
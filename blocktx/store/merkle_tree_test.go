@@ -0,0 +1,216 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func syntheticLeaves(n int) []*chainhash.Hash {
+	leaves := make([]*chainhash.Hash, n)
+	b := make([]byte, 8)
+	for i := range leaves {
+		binary.LittleEndian.PutUint64(b, uint64(i))
+		hash := chainhash.Hash(sha256.Sum256(b))
+		leaves[i] = &hash
+	}
+
+	return leaves
+}
+
+func TestNewMerkleTree(t *testing.T) {
+	tt := []struct {
+		name        string
+		txCount     int
+		subtreeSize uint64
+
+		expectedSubtrees int
+		expectedErr      error
+	}{
+		{
+			name:        "subtree size not a power of two",
+			txCount:     10,
+			subtreeSize: 3,
+
+			expectedErr: ErrInvalidSubtreeSize,
+		},
+		{
+			name:        "single transaction",
+			txCount:     1,
+			subtreeSize: 4,
+
+			expectedSubtrees: 1,
+		},
+		{
+			name:        "partial last subtree",
+			txCount:     11,
+			subtreeSize: 4,
+
+			expectedSubtrees: 3,
+		},
+		{
+			name:        "full subtrees",
+			txCount:     16,
+			subtreeSize: 4,
+
+			expectedSubtrees: 4,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			leaves := syntheticLeaves(tc.txCount)
+
+			tree, err := NewMerkleTree(leaves, tc.subtreeSize)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, tree.Subtrees, tc.expectedSubtrees)
+			require.Len(t, tree.SubtreeRoots, tc.expectedSubtrees*chainhash.HashSize)
+
+			fullTree := bc.BuildMerkleTreeStoreChainHash(leaves)
+			root, err := tree.Root()
+			require.NoError(t, err)
+			require.Equal(t, fullTree[len(fullTree)-1], root)
+		})
+	}
+}
+
+func TestMerkleTreeBuilder(t *testing.T) {
+	for _, txCount := range []int{1, 2, 3, 4, 5, 11, 16, 17, 1000} {
+		leaves := syntheticLeaves(txCount)
+
+		builder, err := NewMerkleTreeBuilder(4)
+		require.NoError(t, err)
+		for _, leaf := range leaves {
+			builder.Add(leaf)
+		}
+
+		tree, root, err := builder.Finish()
+		require.NoError(t, err)
+
+		expectedTree, err := NewMerkleTree(leaves, 4)
+		require.NoError(t, err)
+		require.Equal(t, expectedTree.TxCount, tree.TxCount)
+		require.Equal(t, expectedTree.SubtreeRoots, tree.SubtreeRoots)
+		require.Empty(t, tree.Subtrees)
+
+		fullTree := bc.BuildMerkleTreeStoreChainHash(leaves)
+		require.Equal(t, fullTree[len(fullTree)-1], root, "tx count %d", txCount)
+	}
+
+	_, err := NewMerkleTreeBuilder(3)
+	require.ErrorIs(t, err, ErrInvalidSubtreeSize)
+
+	builder, err := NewMerkleTreeBuilder(4)
+	require.NoError(t, err)
+	_, _, err = builder.Finish()
+	require.Error(t, err)
+}
+
+func TestMerklePath(t *testing.T) {
+	const blockHeight = 826481
+
+	tt := []struct {
+		name        string
+		txCount     int
+		subtreeSize uint64
+	}{
+		{name: "single transaction", txCount: 1, subtreeSize: 4},
+		{name: "two transactions", txCount: 2, subtreeSize: 4},
+		{name: "block smaller than subtree", txCount: 3, subtreeSize: 8},
+		{name: "block equal to subtree", txCount: 8, subtreeSize: 8},
+		{name: "partial last subtree", txCount: 11, subtreeSize: 4},
+		{name: "single leaf in last subtree", txCount: 17, subtreeSize: 4},
+		{name: "power of two", txCount: 32, subtreeSize: 4},
+		{name: "subtree size 1", txCount: 7, subtreeSize: 1},
+		{name: "many subtrees", txCount: 1000, subtreeSize: 16},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			leaves := syntheticLeaves(tc.txCount)
+			fullTree := bc.BuildMerkleTreeStoreChainHash(leaves)
+
+			tree, err := NewMerkleTree(leaves, tc.subtreeSize)
+			require.NoError(t, err)
+
+			for txIndex := range leaves {
+				expectedBump, err := bc.NewBUMPFromMerkleTreeAndIndex(blockHeight, fullTree, uint64(txIndex))
+				require.NoError(t, err)
+				expected, err := expectedBump.String()
+				require.NoError(t, err)
+
+				subtree := tree.Subtrees[uint64(txIndex)/tc.subtreeSize]
+				actual, err := MerklePath(blockHeight, tree.TxCount, tree.SubtreeSize, tree.SubtreeRoots, subtree, uint64(txIndex))
+				require.NoError(t, err)
+
+				require.Equal(t, expected, actual, "tx index %d", txIndex)
+			}
+		})
+	}
+
+	t.Run("index out of range", func(t *testing.T) {
+		tree, err := NewMerkleTree(syntheticLeaves(5), 4)
+		require.NoError(t, err)
+
+		_, err = MerklePath(blockHeight, tree.TxCount, tree.SubtreeSize, tree.SubtreeRoots, tree.Subtrees[1], 5)
+		require.ErrorIs(t, err, ErrTxIndexOutOfRange)
+	})
+
+	t.Run("wrong subtree", func(t *testing.T) {
+		tree, err := NewMerkleTree(syntheticLeaves(5), 4)
+		require.NoError(t, err)
+
+		_, err = MerklePath(blockHeight, tree.TxCount, tree.SubtreeSize, tree.SubtreeRoots, tree.Subtrees[1], 2)
+		require.ErrorIs(t, err, ErrInvalidSubtreeLeaves)
+	})
+}
+
+const benchmarkTxCount = 1_000_000
+
+// BenchmarkNewMerkleTree measures storing the compact Merkle tree of a block with 1M transactions.
+func BenchmarkNewMerkleTree(b *testing.B) {
+	leaves := syntheticLeaves(benchmarkTxCount)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := NewMerkleTree(leaves, DefaultMerkleSubtreeSize)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkMerklePath measures the lazy calculation of the BUMP of a single transaction in a block with 1M transactions.
+func BenchmarkMerklePath(b *testing.B) {
+	tree, err := NewMerkleTree(syntheticLeaves(benchmarkTxCount), DefaultMerkleSubtreeSize)
+	require.NoError(b, err)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		txIndex := uint64(i*7919) % benchmarkTxCount
+		_, err = MerklePath(826481, tree.TxCount, tree.SubtreeSize, tree.SubtreeRoots, tree.Subtrees[txIndex/tree.SubtreeSize], txIndex)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkBUMPFromFullMerkleTree measures the previous approach of calculating the BUMP of a single transaction
+// from the full Merkle tree of a block with 1M transactions, which was done for every transaction of the block.
+func BenchmarkBUMPFromFullMerkleTree(b *testing.B) {
+	fullTree := bc.BuildMerkleTreeStoreChainHash(syntheticLeaves(benchmarkTxCount))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		txIndex := uint64(i*7919) % benchmarkTxCount
+		bump, err := bc.NewBUMPFromMerkleTreeAndIndex(826481, fullTree, txIndex)
+		require.NoError(b, err)
+		_, err = bump.String()
+		require.NoError(b, err)
+	}
+}
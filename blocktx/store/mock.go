@@ -8,6 +8,7 @@ import (
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
 	"sync"
+	"time"
 )
 
 // Ensure, that InterfaceMock does implement Interface.
@@ -20,17 +21,41 @@ var _ Interface = &InterfaceMock{}
 //
 //		// make and configure a mocked Interface
 //		mockedInterface := &InterfaceMock{
+//			AcquireLeaseFunc: func(ctx context.Context, hostName string, duration time.Duration) (*Lease, error) {
+//				panic("mock out the AcquireLease method")
+//			},
 //			CloseFunc: func() error {
 //				panic("mock out the Close method")
 //			},
 //			GetBlockFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error) {
 //				panic("mock out the GetBlock method")
 //			},
+//			GetBlockByHeightFunc: func(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+//				panic("mock out the GetBlockByHeight method")
+//			},
 //			GetBlockGapsFunc: func(ctx context.Context, heightRange int) ([]*BlockGap, error) {
 //				panic("mock out the GetBlockGaps method")
 //			},
-//			GetPrimaryFunc: func(ctx context.Context) (string, error) {
-//				panic("mock out the GetPrimary method")
+//			GetBlockRegisteredTxCountFunc: func(ctx context.Context, blockId uint64) (uint64, error) {
+//				panic("mock out the GetBlockRegisteredTxCount method")
+//			},
+//			GetBlockStatsFunc: func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+//				panic("mock out the GetBlockStats method")
+//			},
+//			GetBlockTransactionsFunc: func(ctx context.Context, hash *chainhash.Hash, offset uint64, limit uint64) ([]*blocktx_api.BlockTransaction, error) {
+//				panic("mock out the GetBlockTransactions method")
+//			},
+//			GetChainTipFunc: func(ctx context.Context) (*blocktx_api.Block, error) {
+//				panic("mock out the GetChainTip method")
+//			},
+//			GetLatestBlockHeadersFunc: func(ctx context.Context, count uint64) ([]*BlockHeader, error) {
+//				panic("mock out the GetLatestBlockHeaders method")
+//			},
+//			GetLeaseFunc: func(ctx context.Context) (*Lease, error) {
+//				panic("mock out the GetLease method")
+//			},
+//			GetRegisteredTransactionsFunc: func(ctx context.Context, afterID uint64, limit uint64) ([]*RegisteredTransaction, error) {
+//				panic("mock out the GetRegisteredTransactions method")
 //			},
 //			GetTransactionBlocksFunc: func(ctx context.Context, transactions *blocktx_api.Transactions) (*blocktx_api.TransactionBlocks, error) {
 //				panic("mock out the GetTransactionBlocks method")
@@ -41,16 +66,31 @@ var _ Interface = &InterfaceMock{}
 //			InsertBlockFunc: func(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
 //				panic("mock out the InsertBlock method")
 //			},
+//			InsertBlockHeadersFunc: func(ctx context.Context, headers []*BlockHeader) error {
+//				panic("mock out the InsertBlockHeaders method")
+//			},
+//			InsertBlockMerkleSubtreesFunc: func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+//				panic("mock out the InsertBlockMerkleSubtrees method")
+//			},
+//			InsertBlockMerkleTreeFunc: func(ctx context.Context, blockId uint64, tree *MerkleTree) error {
+//				panic("mock out the InsertBlockMerkleTree method")
+//			},
+//			InsertBlockStatsFunc: func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+//				panic("mock out the InsertBlockStats method")
+//			},
+//			InsertBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+//				panic("mock out the InsertBlockTransactions method")
+//			},
 //			MarkBlockAsDoneFunc: func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
 //				panic("mock out the MarkBlockAsDone method")
 //			},
 //			RegisterTransactionFunc: func(ctx context.Context, transaction *blocktx_api.TransactionAndSource) error {
 //				panic("mock out the RegisterTransaction method")
 //			},
-//			TryToBecomePrimaryFunc: func(ctx context.Context, myHostName string) error {
-//				panic("mock out the TryToBecomePrimary method")
+//			ReleaseLeaseFunc: func(ctx context.Context, hostName string, epoch int64) error {
+//				panic("mock out the ReleaseLease method")
 //			},
-//			UpdateBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error {
+//			UpdateBlockTransactionsFunc: func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
 //				panic("mock out the UpdateBlockTransactions method")
 //			},
 //		}
@@ -60,17 +100,41 @@ var _ Interface = &InterfaceMock{}
 //
 //	}
 type InterfaceMock struct {
+	// AcquireLeaseFunc mocks the AcquireLease method.
+	AcquireLeaseFunc func(ctx context.Context, hostName string, duration time.Duration) (*Lease, error)
+
 	// CloseFunc mocks the Close method.
 	CloseFunc func() error
 
 	// GetBlockFunc mocks the GetBlock method.
 	GetBlockFunc func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.Block, error)
 
+	// GetBlockByHeightFunc mocks the GetBlockByHeight method.
+	GetBlockByHeightFunc func(ctx context.Context, height uint64) (*blocktx_api.Block, error)
+
 	// GetBlockGapsFunc mocks the GetBlockGaps method.
 	GetBlockGapsFunc func(ctx context.Context, heightRange int) ([]*BlockGap, error)
 
-	// GetPrimaryFunc mocks the GetPrimary method.
-	GetPrimaryFunc func(ctx context.Context) (string, error)
+	// GetBlockRegisteredTxCountFunc mocks the GetBlockRegisteredTxCount method.
+	GetBlockRegisteredTxCountFunc func(ctx context.Context, blockId uint64) (uint64, error)
+
+	// GetBlockStatsFunc mocks the GetBlockStats method.
+	GetBlockStatsFunc func(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error)
+
+	// GetBlockTransactionsFunc mocks the GetBlockTransactions method.
+	GetBlockTransactionsFunc func(ctx context.Context, hash *chainhash.Hash, offset uint64, limit uint64) ([]*blocktx_api.BlockTransaction, error)
+
+	// GetChainTipFunc mocks the GetChainTip method.
+	GetChainTipFunc func(ctx context.Context) (*blocktx_api.Block, error)
+
+	// GetLatestBlockHeadersFunc mocks the GetLatestBlockHeaders method.
+	GetLatestBlockHeadersFunc func(ctx context.Context, count uint64) ([]*BlockHeader, error)
+
+	// GetLeaseFunc mocks the GetLease method.
+	GetLeaseFunc func(ctx context.Context) (*Lease, error)
+
+	// GetRegisteredTransactionsFunc mocks the GetRegisteredTransactions method.
+	GetRegisteredTransactionsFunc func(ctx context.Context, afterID uint64, limit uint64) ([]*RegisteredTransaction, error)
 
 	// GetTransactionBlocksFunc mocks the GetTransactionBlocks method.
 	GetTransactionBlocksFunc func(ctx context.Context, transactions *blocktx_api.Transactions) (*blocktx_api.TransactionBlocks, error)
@@ -81,20 +145,44 @@ type InterfaceMock struct {
 	// InsertBlockFunc mocks the InsertBlock method.
 	InsertBlockFunc func(ctx context.Context, block *blocktx_api.Block) (uint64, error)
 
+	// InsertBlockHeadersFunc mocks the InsertBlockHeaders method.
+	InsertBlockHeadersFunc func(ctx context.Context, headers []*BlockHeader) error
+
+	// InsertBlockMerkleSubtreesFunc mocks the InsertBlockMerkleSubtrees method.
+	InsertBlockMerkleSubtreesFunc func(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error
+
+	// InsertBlockMerkleTreeFunc mocks the InsertBlockMerkleTree method.
+	InsertBlockMerkleTreeFunc func(ctx context.Context, blockId uint64, tree *MerkleTree) error
+
+	// InsertBlockStatsFunc mocks the InsertBlockStats method.
+	InsertBlockStatsFunc func(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error
+
+	// InsertBlockTransactionsFunc mocks the InsertBlockTransactions method.
+	InsertBlockTransactionsFunc func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error
+
 	// MarkBlockAsDoneFunc mocks the MarkBlockAsDone method.
 	MarkBlockAsDoneFunc func(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error
 
 	// RegisterTransactionFunc mocks the RegisterTransaction method.
 	RegisterTransactionFunc func(ctx context.Context, transaction *blocktx_api.TransactionAndSource) error
 
-	// TryToBecomePrimaryFunc mocks the TryToBecomePrimary method.
-	TryToBecomePrimaryFunc func(ctx context.Context, myHostName string) error
+	// ReleaseLeaseFunc mocks the ReleaseLease method.
+	ReleaseLeaseFunc func(ctx context.Context, hostName string, epoch int64) error
 
 	// UpdateBlockTransactionsFunc mocks the UpdateBlockTransactions method.
-	UpdateBlockTransactionsFunc func(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error
+	UpdateBlockTransactionsFunc func(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AcquireLease holds details about calls to the AcquireLease method.
+		AcquireLease []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// HostName is the hostName argument value.
+			HostName string
+			// Duration is the duration argument value.
+			Duration time.Duration
+		}
 		// Close holds details about calls to the Close method.
 		Close []struct {
 		}
@@ -105,6 +193,13 @@ type InterfaceMock struct {
 			// Hash is the hash argument value.
 			Hash *chainhash.Hash
 		}
+		// GetBlockByHeight holds details about calls to the GetBlockByHeight method.
+		GetBlockByHeight []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Height is the height argument value.
+			Height uint64
+		}
 		// GetBlockGaps holds details about calls to the GetBlockGaps method.
 		GetBlockGaps []struct {
 			// Ctx is the ctx argument value.
@@ -112,10 +207,56 @@ type InterfaceMock struct {
 			// HeightRange is the heightRange argument value.
 			HeightRange int
 		}
-		// GetPrimary holds details about calls to the GetPrimary method.
-		GetPrimary []struct {
+		// GetBlockRegisteredTxCount holds details about calls to the GetBlockRegisteredTxCount method.
+		GetBlockRegisteredTxCount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockId is the blockId argument value.
+			BlockId uint64
+		}
+		// GetBlockStats holds details about calls to the GetBlockStats method.
+		GetBlockStats []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash *chainhash.Hash
+		}
+		// GetBlockTransactions holds details about calls to the GetBlockTransactions method.
+		GetBlockTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash *chainhash.Hash
+			// Offset is the offset argument value.
+			Offset uint64
+			// Limit is the limit argument value.
+			Limit uint64
+		}
+		// GetChainTip holds details about calls to the GetChainTip method.
+		GetChainTip []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetLatestBlockHeaders holds details about calls to the GetLatestBlockHeaders method.
+		GetLatestBlockHeaders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Count is the count argument value.
+			Count uint64
+		}
+		// GetLease holds details about calls to the GetLease method.
+		GetLease []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetRegisteredTransactions holds details about calls to the GetRegisteredTransactions method.
+		GetRegisteredTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AfterID is the afterID argument value.
+			AfterID uint64
+			// Limit is the limit argument value.
+			Limit uint64
 		}
 		// GetTransactionBlocks holds details about calls to the GetTransactionBlocks method.
 		GetTransactionBlocks []struct {
@@ -138,6 +279,51 @@ type InterfaceMock struct {
 			// Block is the block argument value.
 			Block *blocktx_api.Block
 		}
+		// InsertBlockHeaders holds details about calls to the InsertBlockHeaders method.
+		InsertBlockHeaders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Headers is the headers argument value.
+			Headers []*BlockHeader
+		}
+		// InsertBlockMerkleSubtrees holds details about calls to the InsertBlockMerkleSubtrees method.
+		InsertBlockMerkleSubtrees []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockId is the blockId argument value.
+			BlockId uint64
+			// FirstIndex is the firstIndex argument value.
+			FirstIndex uint64
+			// Subtrees is the subtrees argument value.
+			Subtrees [][]byte
+		}
+		// InsertBlockMerkleTree holds details about calls to the InsertBlockMerkleTree method.
+		InsertBlockMerkleTree []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockId is the blockId argument value.
+			BlockId uint64
+			// Tree is the tree argument value.
+			Tree *MerkleTree
+		}
+		// InsertBlockStats holds details about calls to the InsertBlockStats method.
+		InsertBlockStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockId is the blockId argument value.
+			BlockId uint64
+			// Stats is the stats argument value.
+			Stats *blocktx_api.BlockStats
+		}
+		// InsertBlockTransactions holds details about calls to the InsertBlockTransactions method.
+		InsertBlockTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockId is the blockId argument value.
+			BlockId uint64
+			// Transactions is the transactions argument value.
+			Transactions []*blocktx_api.BlockTransaction
+		}
 		// MarkBlockAsDone holds details about calls to the MarkBlockAsDone method.
 		MarkBlockAsDone []struct {
 			// Ctx is the ctx argument value.
@@ -156,12 +342,14 @@ type InterfaceMock struct {
 			// Transaction is the transaction argument value.
 			Transaction *blocktx_api.TransactionAndSource
 		}
-		// TryToBecomePrimary holds details about calls to the TryToBecomePrimary method.
-		TryToBecomePrimary []struct {
+		// ReleaseLease holds details about calls to the ReleaseLease method.
+		ReleaseLease []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// MyHostName is the myHostName argument value.
-			MyHostName string
+			// HostName is the hostName argument value.
+			HostName string
+			// Epoch is the epoch argument value.
+			Epoch int64
 		}
 		// UpdateBlockTransactions holds details about calls to the UpdateBlockTransactions method.
 		UpdateBlockTransactions []struct {
@@ -170,22 +358,73 @@ type InterfaceMock struct {
 			// BlockId is the blockId argument value.
 			BlockId uint64
 			// Transactions is the transactions argument value.
-			Transactions []*blocktx_api.TransactionAndSource
-			// MerklePaths is the merklePaths argument value.
-			MerklePaths []string
+			Transactions []*blocktx_api.BlockTransaction
 		}
 	}
-	lockClose                    sync.RWMutex
-	lockGetBlock                 sync.RWMutex
-	lockGetBlockGaps             sync.RWMutex
-	lockGetPrimary               sync.RWMutex
-	lockGetTransactionBlocks     sync.RWMutex
-	lockGetTransactionMerklePath sync.RWMutex
-	lockInsertBlock              sync.RWMutex
-	lockMarkBlockAsDone          sync.RWMutex
-	lockRegisterTransaction      sync.RWMutex
-	lockTryToBecomePrimary       sync.RWMutex
-	lockUpdateBlockTransactions  sync.RWMutex
+	lockAcquireLease              sync.RWMutex
+	lockClose                     sync.RWMutex
+	lockGetBlock                  sync.RWMutex
+	lockGetBlockByHeight          sync.RWMutex
+	lockGetBlockGaps              sync.RWMutex
+	lockGetBlockRegisteredTxCount sync.RWMutex
+	lockGetBlockStats             sync.RWMutex
+	lockGetBlockTransactions      sync.RWMutex
+	lockGetChainTip               sync.RWMutex
+	lockGetLatestBlockHeaders     sync.RWMutex
+	lockGetLease                  sync.RWMutex
+	lockGetRegisteredTransactions sync.RWMutex
+	lockGetTransactionBlocks      sync.RWMutex
+	lockGetTransactionMerklePath  sync.RWMutex
+	lockInsertBlock               sync.RWMutex
+	lockInsertBlockHeaders        sync.RWMutex
+	lockInsertBlockMerkleSubtrees sync.RWMutex
+	lockInsertBlockMerkleTree     sync.RWMutex
+	lockInsertBlockStats          sync.RWMutex
+	lockInsertBlockTransactions   sync.RWMutex
+	lockMarkBlockAsDone           sync.RWMutex
+	lockRegisterTransaction       sync.RWMutex
+	lockReleaseLease              sync.RWMutex
+	lockUpdateBlockTransactions   sync.RWMutex
+}
+
+// AcquireLease calls AcquireLeaseFunc.
+func (mock *InterfaceMock) AcquireLease(ctx context.Context, hostName string, duration time.Duration) (*Lease, error) {
+	if mock.AcquireLeaseFunc == nil {
+		panic("InterfaceMock.AcquireLeaseFunc: method is nil but Interface.AcquireLease was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		HostName string
+		Duration time.Duration
+	}{
+		Ctx:      ctx,
+		HostName: hostName,
+		Duration: duration,
+	}
+	mock.lockAcquireLease.Lock()
+	mock.calls.AcquireLease = append(mock.calls.AcquireLease, callInfo)
+	mock.lockAcquireLease.Unlock()
+	return mock.AcquireLeaseFunc(ctx, hostName, duration)
+}
+
+// AcquireLeaseCalls gets all the calls that were made to AcquireLease.
+// Check the length with:
+//
+//	len(mockedInterface.AcquireLeaseCalls())
+func (mock *InterfaceMock) AcquireLeaseCalls() []struct {
+	Ctx      context.Context
+	HostName string
+	Duration time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		HostName string
+		Duration time.Duration
+	}
+	mock.lockAcquireLease.RLock()
+	calls = mock.calls.AcquireLease
+	mock.lockAcquireLease.RUnlock()
+	return calls
 }
 
 // Close calls CloseFunc.
@@ -251,6 +490,42 @@ func (mock *InterfaceMock) GetBlockCalls() []struct {
 	return calls
 }
 
+// GetBlockByHeight calls GetBlockByHeightFunc.
+func (mock *InterfaceMock) GetBlockByHeight(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+	if mock.GetBlockByHeightFunc == nil {
+		panic("InterfaceMock.GetBlockByHeightFunc: method is nil but Interface.GetBlockByHeight was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Height uint64
+	}{
+		Ctx:    ctx,
+		Height: height,
+	}
+	mock.lockGetBlockByHeight.Lock()
+	mock.calls.GetBlockByHeight = append(mock.calls.GetBlockByHeight, callInfo)
+	mock.lockGetBlockByHeight.Unlock()
+	return mock.GetBlockByHeightFunc(ctx, height)
+}
+
+// GetBlockByHeightCalls gets all the calls that were made to GetBlockByHeight.
+// Check the length with:
+//
+//	len(mockedInterface.GetBlockByHeightCalls())
+func (mock *InterfaceMock) GetBlockByHeightCalls() []struct {
+	Ctx    context.Context
+	Height uint64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Height uint64
+	}
+	mock.lockGetBlockByHeight.RLock()
+	calls = mock.calls.GetBlockByHeight
+	mock.lockGetBlockByHeight.RUnlock()
+	return calls
+}
+
 // GetBlockGaps calls GetBlockGapsFunc.
 func (mock *InterfaceMock) GetBlockGaps(ctx context.Context, heightRange int) ([]*BlockGap, error) {
 	if mock.GetBlockGapsFunc == nil {
@@ -287,35 +562,259 @@ func (mock *InterfaceMock) GetBlockGapsCalls() []struct {
 	return calls
 }
 
-// GetPrimary calls GetPrimaryFunc.
-func (mock *InterfaceMock) GetPrimary(ctx context.Context) (string, error) {
-	if mock.GetPrimaryFunc == nil {
-		panic("InterfaceMock.GetPrimaryFunc: method is nil but Interface.GetPrimary was just called")
+// GetBlockRegisteredTxCount calls GetBlockRegisteredTxCountFunc.
+func (mock *InterfaceMock) GetBlockRegisteredTxCount(ctx context.Context, blockId uint64) (uint64, error) {
+	if mock.GetBlockRegisteredTxCountFunc == nil {
+		panic("InterfaceMock.GetBlockRegisteredTxCountFunc: method is nil but Interface.GetBlockRegisteredTxCount was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		BlockId uint64
+	}{
+		Ctx:     ctx,
+		BlockId: blockId,
+	}
+	mock.lockGetBlockRegisteredTxCount.Lock()
+	mock.calls.GetBlockRegisteredTxCount = append(mock.calls.GetBlockRegisteredTxCount, callInfo)
+	mock.lockGetBlockRegisteredTxCount.Unlock()
+	return mock.GetBlockRegisteredTxCountFunc(ctx, blockId)
+}
+
+// GetBlockRegisteredTxCountCalls gets all the calls that were made to GetBlockRegisteredTxCount.
+// Check the length with:
+//
+//	len(mockedInterface.GetBlockRegisteredTxCountCalls())
+func (mock *InterfaceMock) GetBlockRegisteredTxCountCalls() []struct {
+	Ctx     context.Context
+	BlockId uint64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		BlockId uint64
+	}
+	mock.lockGetBlockRegisteredTxCount.RLock()
+	calls = mock.calls.GetBlockRegisteredTxCount
+	mock.lockGetBlockRegisteredTxCount.RUnlock()
+	return calls
+}
+
+// GetBlockStats calls GetBlockStatsFunc.
+func (mock *InterfaceMock) GetBlockStats(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+	if mock.GetBlockStatsFunc == nil {
+		panic("InterfaceMock.GetBlockStatsFunc: method is nil but Interface.GetBlockStats was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash *chainhash.Hash
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockGetBlockStats.Lock()
+	mock.calls.GetBlockStats = append(mock.calls.GetBlockStats, callInfo)
+	mock.lockGetBlockStats.Unlock()
+	return mock.GetBlockStatsFunc(ctx, hash)
+}
+
+// GetBlockStatsCalls gets all the calls that were made to GetBlockStats.
+// Check the length with:
+//
+//	len(mockedInterface.GetBlockStatsCalls())
+func (mock *InterfaceMock) GetBlockStatsCalls() []struct {
+	Ctx  context.Context
+	Hash *chainhash.Hash
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash *chainhash.Hash
+	}
+	mock.lockGetBlockStats.RLock()
+	calls = mock.calls.GetBlockStats
+	mock.lockGetBlockStats.RUnlock()
+	return calls
+}
+
+// GetBlockTransactions calls GetBlockTransactionsFunc.
+func (mock *InterfaceMock) GetBlockTransactions(ctx context.Context, hash *chainhash.Hash, offset uint64, limit uint64) ([]*blocktx_api.BlockTransaction, error) {
+	if mock.GetBlockTransactionsFunc == nil {
+		panic("InterfaceMock.GetBlockTransactionsFunc: method is nil but Interface.GetBlockTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Hash   *chainhash.Hash
+		Offset uint64
+		Limit  uint64
+	}{
+		Ctx:    ctx,
+		Hash:   hash,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetBlockTransactions.Lock()
+	mock.calls.GetBlockTransactions = append(mock.calls.GetBlockTransactions, callInfo)
+	mock.lockGetBlockTransactions.Unlock()
+	return mock.GetBlockTransactionsFunc(ctx, hash, offset, limit)
+}
+
+// GetBlockTransactionsCalls gets all the calls that were made to GetBlockTransactions.
+// Check the length with:
+//
+//	len(mockedInterface.GetBlockTransactionsCalls())
+func (mock *InterfaceMock) GetBlockTransactionsCalls() []struct {
+	Ctx    context.Context
+	Hash   *chainhash.Hash
+	Offset uint64
+	Limit  uint64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Hash   *chainhash.Hash
+		Offset uint64
+		Limit  uint64
+	}
+	mock.lockGetBlockTransactions.RLock()
+	calls = mock.calls.GetBlockTransactions
+	mock.lockGetBlockTransactions.RUnlock()
+	return calls
+}
+
+// GetChainTip calls GetChainTipFunc.
+func (mock *InterfaceMock) GetChainTip(ctx context.Context) (*blocktx_api.Block, error) {
+	if mock.GetChainTipFunc == nil {
+		panic("InterfaceMock.GetChainTipFunc: method is nil but Interface.GetChainTip was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetChainTip.Lock()
+	mock.calls.GetChainTip = append(mock.calls.GetChainTip, callInfo)
+	mock.lockGetChainTip.Unlock()
+	return mock.GetChainTipFunc(ctx)
+}
+
+// GetChainTipCalls gets all the calls that were made to GetChainTip.
+// Check the length with:
+//
+//	len(mockedInterface.GetChainTipCalls())
+func (mock *InterfaceMock) GetChainTipCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetChainTip.RLock()
+	calls = mock.calls.GetChainTip
+	mock.lockGetChainTip.RUnlock()
+	return calls
+}
+
+// GetLatestBlockHeaders calls GetLatestBlockHeadersFunc.
+func (mock *InterfaceMock) GetLatestBlockHeaders(ctx context.Context, count uint64) ([]*BlockHeader, error) {
+	if mock.GetLatestBlockHeadersFunc == nil {
+		panic("InterfaceMock.GetLatestBlockHeadersFunc: method is nil but Interface.GetLatestBlockHeaders was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Count uint64
+	}{
+		Ctx:   ctx,
+		Count: count,
+	}
+	mock.lockGetLatestBlockHeaders.Lock()
+	mock.calls.GetLatestBlockHeaders = append(mock.calls.GetLatestBlockHeaders, callInfo)
+	mock.lockGetLatestBlockHeaders.Unlock()
+	return mock.GetLatestBlockHeadersFunc(ctx, count)
+}
+
+// GetLatestBlockHeadersCalls gets all the calls that were made to GetLatestBlockHeaders.
+// Check the length with:
+//
+//	len(mockedInterface.GetLatestBlockHeadersCalls())
+func (mock *InterfaceMock) GetLatestBlockHeadersCalls() []struct {
+	Ctx   context.Context
+	Count uint64
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Count uint64
+	}
+	mock.lockGetLatestBlockHeaders.RLock()
+	calls = mock.calls.GetLatestBlockHeaders
+	mock.lockGetLatestBlockHeaders.RUnlock()
+	return calls
+}
+
+// GetLease calls GetLeaseFunc.
+func (mock *InterfaceMock) GetLease(ctx context.Context) (*Lease, error) {
+	if mock.GetLeaseFunc == nil {
+		panic("InterfaceMock.GetLeaseFunc: method is nil but Interface.GetLease was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
 	}{
 		Ctx: ctx,
 	}
-	mock.lockGetPrimary.Lock()
-	mock.calls.GetPrimary = append(mock.calls.GetPrimary, callInfo)
-	mock.lockGetPrimary.Unlock()
-	return mock.GetPrimaryFunc(ctx)
+	mock.lockGetLease.Lock()
+	mock.calls.GetLease = append(mock.calls.GetLease, callInfo)
+	mock.lockGetLease.Unlock()
+	return mock.GetLeaseFunc(ctx)
 }
 
-// GetPrimaryCalls gets all the calls that were made to GetPrimary.
+// GetLeaseCalls gets all the calls that were made to GetLease.
 // Check the length with:
 //
-//	len(mockedInterface.GetPrimaryCalls())
-func (mock *InterfaceMock) GetPrimaryCalls() []struct {
+//	len(mockedInterface.GetLeaseCalls())
+func (mock *InterfaceMock) GetLeaseCalls() []struct {
 	Ctx context.Context
 } {
 	var calls []struct {
 		Ctx context.Context
 	}
-	mock.lockGetPrimary.RLock()
-	calls = mock.calls.GetPrimary
-	mock.lockGetPrimary.RUnlock()
+	mock.lockGetLease.RLock()
+	calls = mock.calls.GetLease
+	mock.lockGetLease.RUnlock()
+	return calls
+}
+
+// GetRegisteredTransactions calls GetRegisteredTransactionsFunc.
+func (mock *InterfaceMock) GetRegisteredTransactions(ctx context.Context, afterID uint64, limit uint64) ([]*RegisteredTransaction, error) {
+	if mock.GetRegisteredTransactionsFunc == nil {
+		panic("InterfaceMock.GetRegisteredTransactionsFunc: method is nil but Interface.GetRegisteredTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AfterID uint64
+		Limit   uint64
+	}{
+		Ctx:     ctx,
+		AfterID: afterID,
+		Limit:   limit,
+	}
+	mock.lockGetRegisteredTransactions.Lock()
+	mock.calls.GetRegisteredTransactions = append(mock.calls.GetRegisteredTransactions, callInfo)
+	mock.lockGetRegisteredTransactions.Unlock()
+	return mock.GetRegisteredTransactionsFunc(ctx, afterID, limit)
+}
+
+// GetRegisteredTransactionsCalls gets all the calls that were made to GetRegisteredTransactions.
+// Check the length with:
+//
+//	len(mockedInterface.GetRegisteredTransactionsCalls())
+func (mock *InterfaceMock) GetRegisteredTransactionsCalls() []struct {
+	Ctx     context.Context
+	AfterID uint64
+	Limit   uint64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AfterID uint64
+		Limit   uint64
+	}
+	mock.lockGetRegisteredTransactions.RLock()
+	calls = mock.calls.GetRegisteredTransactions
+	mock.lockGetRegisteredTransactions.RUnlock()
 	return calls
 }
 
@@ -427,6 +926,206 @@ func (mock *InterfaceMock) InsertBlockCalls() []struct {
 	return calls
 }
 
+// InsertBlockHeaders calls InsertBlockHeadersFunc.
+func (mock *InterfaceMock) InsertBlockHeaders(ctx context.Context, headers []*BlockHeader) error {
+	if mock.InsertBlockHeadersFunc == nil {
+		panic("InterfaceMock.InsertBlockHeadersFunc: method is nil but Interface.InsertBlockHeaders was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Headers []*BlockHeader
+	}{
+		Ctx:     ctx,
+		Headers: headers,
+	}
+	mock.lockInsertBlockHeaders.Lock()
+	mock.calls.InsertBlockHeaders = append(mock.calls.InsertBlockHeaders, callInfo)
+	mock.lockInsertBlockHeaders.Unlock()
+	return mock.InsertBlockHeadersFunc(ctx, headers)
+}
+
+// InsertBlockHeadersCalls gets all the calls that were made to InsertBlockHeaders.
+// Check the length with:
+//
+//	len(mockedInterface.InsertBlockHeadersCalls())
+func (mock *InterfaceMock) InsertBlockHeadersCalls() []struct {
+	Ctx     context.Context
+	Headers []*BlockHeader
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Headers []*BlockHeader
+	}
+	mock.lockInsertBlockHeaders.RLock()
+	calls = mock.calls.InsertBlockHeaders
+	mock.lockInsertBlockHeaders.RUnlock()
+	return calls
+}
+
+// InsertBlockMerkleSubtrees calls InsertBlockMerkleSubtreesFunc.
+func (mock *InterfaceMock) InsertBlockMerkleSubtrees(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+	if mock.InsertBlockMerkleSubtreesFunc == nil {
+		panic("InterfaceMock.InsertBlockMerkleSubtreesFunc: method is nil but Interface.InsertBlockMerkleSubtrees was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		BlockId    uint64
+		FirstIndex uint64
+		Subtrees   [][]byte
+	}{
+		Ctx:        ctx,
+		BlockId:    blockId,
+		FirstIndex: firstIndex,
+		Subtrees:   subtrees,
+	}
+	mock.lockInsertBlockMerkleSubtrees.Lock()
+	mock.calls.InsertBlockMerkleSubtrees = append(mock.calls.InsertBlockMerkleSubtrees, callInfo)
+	mock.lockInsertBlockMerkleSubtrees.Unlock()
+	return mock.InsertBlockMerkleSubtreesFunc(ctx, blockId, firstIndex, subtrees)
+}
+
+// InsertBlockMerkleSubtreesCalls gets all the calls that were made to InsertBlockMerkleSubtrees.
+// Check the length with:
+//
+//	len(mockedInterface.InsertBlockMerkleSubtreesCalls())
+func (mock *InterfaceMock) InsertBlockMerkleSubtreesCalls() []struct {
+	Ctx        context.Context
+	BlockId    uint64
+	FirstIndex uint64
+	Subtrees   [][]byte
+} {
+	var calls []struct {
+		Ctx        context.Context
+		BlockId    uint64
+		FirstIndex uint64
+		Subtrees   [][]byte
+	}
+	mock.lockInsertBlockMerkleSubtrees.RLock()
+	calls = mock.calls.InsertBlockMerkleSubtrees
+	mock.lockInsertBlockMerkleSubtrees.RUnlock()
+	return calls
+}
+
+// InsertBlockMerkleTree calls InsertBlockMerkleTreeFunc.
+func (mock *InterfaceMock) InsertBlockMerkleTree(ctx context.Context, blockId uint64, tree *MerkleTree) error {
+	if mock.InsertBlockMerkleTreeFunc == nil {
+		panic("InterfaceMock.InsertBlockMerkleTreeFunc: method is nil but Interface.InsertBlockMerkleTree was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		BlockId uint64
+		Tree    *MerkleTree
+	}{
+		Ctx:     ctx,
+		BlockId: blockId,
+		Tree:    tree,
+	}
+	mock.lockInsertBlockMerkleTree.Lock()
+	mock.calls.InsertBlockMerkleTree = append(mock.calls.InsertBlockMerkleTree, callInfo)
+	mock.lockInsertBlockMerkleTree.Unlock()
+	return mock.InsertBlockMerkleTreeFunc(ctx, blockId, tree)
+}
+
+// InsertBlockMerkleTreeCalls gets all the calls that were made to InsertBlockMerkleTree.
+// Check the length with:
+//
+//	len(mockedInterface.InsertBlockMerkleTreeCalls())
+func (mock *InterfaceMock) InsertBlockMerkleTreeCalls() []struct {
+	Ctx     context.Context
+	BlockId uint64
+	Tree    *MerkleTree
+} {
+	var calls []struct {
+		Ctx     context.Context
+		BlockId uint64
+		Tree    *MerkleTree
+	}
+	mock.lockInsertBlockMerkleTree.RLock()
+	calls = mock.calls.InsertBlockMerkleTree
+	mock.lockInsertBlockMerkleTree.RUnlock()
+	return calls
+}
+
+// InsertBlockStats calls InsertBlockStatsFunc.
+func (mock *InterfaceMock) InsertBlockStats(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+	if mock.InsertBlockStatsFunc == nil {
+		panic("InterfaceMock.InsertBlockStatsFunc: method is nil but Interface.InsertBlockStats was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		BlockId uint64
+		Stats   *blocktx_api.BlockStats
+	}{
+		Ctx:     ctx,
+		BlockId: blockId,
+		Stats:   stats,
+	}
+	mock.lockInsertBlockStats.Lock()
+	mock.calls.InsertBlockStats = append(mock.calls.InsertBlockStats, callInfo)
+	mock.lockInsertBlockStats.Unlock()
+	return mock.InsertBlockStatsFunc(ctx, blockId, stats)
+}
+
+// InsertBlockStatsCalls gets all the calls that were made to InsertBlockStats.
+// Check the length with:
+//
+//	len(mockedInterface.InsertBlockStatsCalls())
+func (mock *InterfaceMock) InsertBlockStatsCalls() []struct {
+	Ctx     context.Context
+	BlockId uint64
+	Stats   *blocktx_api.BlockStats
+} {
+	var calls []struct {
+		Ctx     context.Context
+		BlockId uint64
+		Stats   *blocktx_api.BlockStats
+	}
+	mock.lockInsertBlockStats.RLock()
+	calls = mock.calls.InsertBlockStats
+	mock.lockInsertBlockStats.RUnlock()
+	return calls
+}
+
+// InsertBlockTransactions calls InsertBlockTransactionsFunc.
+func (mock *InterfaceMock) InsertBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+	if mock.InsertBlockTransactionsFunc == nil {
+		panic("InterfaceMock.InsertBlockTransactionsFunc: method is nil but Interface.InsertBlockTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		BlockId      uint64
+		Transactions []*blocktx_api.BlockTransaction
+	}{
+		Ctx:          ctx,
+		BlockId:      blockId,
+		Transactions: transactions,
+	}
+	mock.lockInsertBlockTransactions.Lock()
+	mock.calls.InsertBlockTransactions = append(mock.calls.InsertBlockTransactions, callInfo)
+	mock.lockInsertBlockTransactions.Unlock()
+	return mock.InsertBlockTransactionsFunc(ctx, blockId, transactions)
+}
+
+// InsertBlockTransactionsCalls gets all the calls that were made to InsertBlockTransactions.
+// Check the length with:
+//
+//	len(mockedInterface.InsertBlockTransactionsCalls())
+func (mock *InterfaceMock) InsertBlockTransactionsCalls() []struct {
+	Ctx          context.Context
+	BlockId      uint64
+	Transactions []*blocktx_api.BlockTransaction
+} {
+	var calls []struct {
+		Ctx          context.Context
+		BlockId      uint64
+		Transactions []*blocktx_api.BlockTransaction
+	}
+	mock.lockInsertBlockTransactions.RLock()
+	calls = mock.calls.InsertBlockTransactions
+	mock.lockInsertBlockTransactions.RUnlock()
+	return calls
+}
+
 // MarkBlockAsDone calls MarkBlockAsDoneFunc.
 func (mock *InterfaceMock) MarkBlockAsDone(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
 	if mock.MarkBlockAsDoneFunc == nil {
@@ -507,62 +1206,64 @@ func (mock *InterfaceMock) RegisterTransactionCalls() []struct {
 	return calls
 }
 
-// TryToBecomePrimary calls TryToBecomePrimaryFunc.
-func (mock *InterfaceMock) TryToBecomePrimary(ctx context.Context, myHostName string) error {
-	if mock.TryToBecomePrimaryFunc == nil {
-		panic("InterfaceMock.TryToBecomePrimaryFunc: method is nil but Interface.TryToBecomePrimary was just called")
+// ReleaseLease calls ReleaseLeaseFunc.
+func (mock *InterfaceMock) ReleaseLease(ctx context.Context, hostName string, epoch int64) error {
+	if mock.ReleaseLeaseFunc == nil {
+		panic("InterfaceMock.ReleaseLeaseFunc: method is nil but Interface.ReleaseLease was just called")
 	}
 	callInfo := struct {
-		Ctx        context.Context
-		MyHostName string
+		Ctx      context.Context
+		HostName string
+		Epoch    int64
 	}{
-		Ctx:        ctx,
-		MyHostName: myHostName,
+		Ctx:      ctx,
+		HostName: hostName,
+		Epoch:    epoch,
 	}
-	mock.lockTryToBecomePrimary.Lock()
-	mock.calls.TryToBecomePrimary = append(mock.calls.TryToBecomePrimary, callInfo)
-	mock.lockTryToBecomePrimary.Unlock()
-	return mock.TryToBecomePrimaryFunc(ctx, myHostName)
+	mock.lockReleaseLease.Lock()
+	mock.calls.ReleaseLease = append(mock.calls.ReleaseLease, callInfo)
+	mock.lockReleaseLease.Unlock()
+	return mock.ReleaseLeaseFunc(ctx, hostName, epoch)
 }
 
-// TryToBecomePrimaryCalls gets all the calls that were made to TryToBecomePrimary.
+// ReleaseLeaseCalls gets all the calls that were made to ReleaseLease.
 // Check the length with:
 //
-//	len(mockedInterface.TryToBecomePrimaryCalls())
-func (mock *InterfaceMock) TryToBecomePrimaryCalls() []struct {
-	Ctx        context.Context
-	MyHostName string
+//	len(mockedInterface.ReleaseLeaseCalls())
+func (mock *InterfaceMock) ReleaseLeaseCalls() []struct {
+	Ctx      context.Context
+	HostName string
+	Epoch    int64
 } {
 	var calls []struct {
-		Ctx        context.Context
-		MyHostName string
+		Ctx      context.Context
+		HostName string
+		Epoch    int64
 	}
-	mock.lockTryToBecomePrimary.RLock()
-	calls = mock.calls.TryToBecomePrimary
-	mock.lockTryToBecomePrimary.RUnlock()
+	mock.lockReleaseLease.RLock()
+	calls = mock.calls.ReleaseLease
+	mock.lockReleaseLease.RUnlock()
 	return calls
 }
 
 // UpdateBlockTransactions calls UpdateBlockTransactionsFunc.
-func (mock *InterfaceMock) UpdateBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error {
+func (mock *InterfaceMock) UpdateBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
 	if mock.UpdateBlockTransactionsFunc == nil {
 		panic("InterfaceMock.UpdateBlockTransactionsFunc: method is nil but Interface.UpdateBlockTransactions was just called")
 	}
 	callInfo := struct {
 		Ctx          context.Context
 		BlockId      uint64
-		Transactions []*blocktx_api.TransactionAndSource
-		MerklePaths  []string
+		Transactions []*blocktx_api.BlockTransaction
 	}{
 		Ctx:          ctx,
 		BlockId:      blockId,
 		Transactions: transactions,
-		MerklePaths:  merklePaths,
 	}
 	mock.lockUpdateBlockTransactions.Lock()
 	mock.calls.UpdateBlockTransactions = append(mock.calls.UpdateBlockTransactions, callInfo)
 	mock.lockUpdateBlockTransactions.Unlock()
-	return mock.UpdateBlockTransactionsFunc(ctx, blockId, transactions, merklePaths)
+	return mock.UpdateBlockTransactionsFunc(ctx, blockId, transactions)
 }
 
 // UpdateBlockTransactionsCalls gets all the calls that were made to UpdateBlockTransactions.
@@ -572,14 +1273,12 @@ func (mock *InterfaceMock) UpdateBlockTransactions(ctx context.Context, blockId
 func (mock *InterfaceMock) UpdateBlockTransactionsCalls() []struct {
 	Ctx          context.Context
 	BlockId      uint64
-	Transactions []*blocktx_api.TransactionAndSource
-	MerklePaths  []string
+	Transactions []*blocktx_api.BlockTransaction
 } {
 	var calls []struct {
 		Ctx          context.Context
 		BlockId      uint64
-		Transactions []*blocktx_api.TransactionAndSource
-		MerklePaths  []string
+		Transactions []*blocktx_api.BlockTransaction
 	}
 	mock.lockUpdateBlockTransactions.RLock()
 	calls = mock.calls.UpdateBlockTransactions
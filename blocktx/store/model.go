@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
 )
 
 type Block struct {
@@ -34,7 +35,27 @@ type BlockTransactionMap struct {
 	Pos           int64 `db:"pos"`
 }
 
+// RegisteredTransaction is a transaction registered by RegisterTransaction. The id increases with each registration.
+type RegisteredTransaction struct {
+	ID   uint64
+	Hash *chainhash.Hash
+}
+
 type BlockGap struct {
 	Height uint64
 	Hash   *chainhash.Hash
 }
+
+// BlockHeader is a validated header of the header chain at the given height.
+type BlockHeader struct {
+	Height uint64
+	Header *wire.BlockHeader
+}
+
+// Lease is the lease of the primary blocktx instance. The epoch is increased each time the lease is acquired by an
+// instance which did not hold it before, and serves as fencing token for the blocks written by the primary.
+type Lease struct {
+	HostName  string
+	Epoch     int64
+	ExpiresAt time.Time
+}
@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/ordishs/gocore"
+)
+
+// AcquireLease acquires the lease of the primary blocktx instance for the given duration if it is not held by another
+// instance, or renews it if it is held by the given host. The epoch is increased if the lease changes hands or has
+// expired. The expiry is calculated by the database clock, so that the clocks of the instances do not matter. The
+// current lease is returned whether it has been acquired or not.
+func (s *SQL) AcquireLease(ctx context.Context, hostName string, duration time.Duration) (*store.Lease, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("AcquireLease").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var err error
+
+	switch s.engine {
+	case sqliteEngine:
+		fallthrough
+	case sqliteMemoryEngine:
+		_, err = s.db.ExecContext(ctx, `
+			INSERT INTO primary_blocktx (id, host_name, primary_until, epoch)
+			VALUES (1, $1, strftime('%Y-%m-%d %H:%M:%f', 'now', $2), 1)
+			ON CONFLICT (id) DO UPDATE SET
+			 host_name = excluded.host_name
+			,primary_until = excluded.primary_until
+			,epoch = CASE
+				WHEN primary_blocktx.host_name = excluded.host_name AND primary_blocktx.primary_until >= strftime('%Y-%m-%d %H:%M:%f', 'now')
+				THEN primary_blocktx.epoch
+				ELSE primary_blocktx.epoch + 1
+			END
+			WHERE primary_blocktx.host_name = excluded.host_name OR primary_blocktx.primary_until < strftime('%Y-%m-%d %H:%M:%f', 'now')
+		`, hostName, fmt.Sprintf("+%.3f seconds", duration.Seconds()))
+	case postgresEngine:
+		_, err = s.db.ExecContext(ctx, `
+			INSERT INTO primary_blocktx (id, host_name, primary_until, epoch)
+			VALUES (1, $1, NOW() + make_interval(secs => $2), 1)
+			ON CONFLICT (id) DO UPDATE SET
+			 host_name = EXCLUDED.host_name
+			,primary_until = EXCLUDED.primary_until
+			,epoch = CASE
+				WHEN primary_blocktx.host_name = EXCLUDED.host_name AND primary_blocktx.primary_until >= NOW()
+				THEN primary_blocktx.epoch
+				ELSE primary_blocktx.epoch + 1
+			END
+			WHERE primary_blocktx.host_name = EXCLUDED.host_name OR primary_blocktx.primary_until < NOW()
+		`, hostName, duration.Seconds())
+	default:
+		return nil, fmt.Errorf("engine not supported: %s", s.engine)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lease: %v", err)
+	}
+
+	return s.GetLease(ctx)
+}
@@ -0,0 +1,50 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+)
+
+// inFencedTx executes fn within a database transaction. If the context carries a fencing epoch, the transaction is
+// only committed if the epoch is the epoch of the current lease, otherwise store.ErrFenced is returned. On Postgres
+// the lease is locked until the transaction ends, so that it cannot change hands while the block is written.
+func (s *SQL) inFencedTx(ctx context.Context, fn func(dbTx *sql.Tx, epoch sql.NullInt64) error) error {
+	dbTx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = dbTx.Rollback()
+	}()
+
+	var fencingEpoch sql.NullInt64
+
+	if epoch, ok := store.FencingEpoch(ctx); ok {
+		q := `SELECT epoch FROM primary_blocktx WHERE id = 1`
+		if s.engine == postgresEngine {
+			q += ` FOR SHARE`
+		}
+
+		var currentEpoch int64
+		err = dbTx.QueryRowContext(ctx, q).Scan(&currentEpoch)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get epoch of lease: %v", err)
+		}
+
+		if currentEpoch != epoch {
+			return store.ErrFenced
+		}
+
+		fencingEpoch = sql.NullInt64{Int64: epoch, Valid: true}
+	}
+
+	if err = fn(dbTx, fencingEpoch); err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
+}
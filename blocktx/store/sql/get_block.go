@@ -20,7 +20,14 @@ func (s *SQL) GetBlock(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	q := `
+	q := querySelectBlock + `
+		WHERE b.hash = $1
+	`
+
+	return scanBlock(s.db.QueryRowContext(ctx, q, hash[:]))
+}
+
+const querySelectBlock = `
 		SELECT
 		 b.hash
 		,b.prevhash
@@ -28,21 +35,26 @@ func (s *SQL) GetBlock(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.
 		,b.height
 		,b.processed_at
 		,b.orphanedyn
-		FROM blocks b
-		WHERE b.hash = $1
-	`
+		,b.size
+		,b.tx_count
+		FROM blocks b`
 
+func scanBlock(row *sql.Row) (*blocktx_api.Block, error) {
 	var block blocktx_api.Block
 
 	var processed_at sql.NullString
+	var size sql.NullInt64
+	var txCount sql.NullInt64
 
-	if err := s.db.QueryRowContext(ctx, q, hash[:]).Scan(
+	if err := row.Scan(
 		&block.Hash,
 		&block.PreviousHash,
 		&block.MerkleRoot,
 		&block.Height,
 		&processed_at,
 		&block.Orphaned,
+		&size,
+		&txCount,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, store.ErrBlockNotFound
@@ -51,6 +63,8 @@ func (s *SQL) GetBlock(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.
 	}
 
 	block.Processed = processed_at.Valid
+	block.Size = uint64(size.Int64)
+	block.TxCount = uint64(txCount.Int64)
 
 	return &block, nil
 }
@@ -0,0 +1,24 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/ordishs/gocore"
+)
+
+func (s *SQL) GetBlockByHeight(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("GetBlockByHeight").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	q := querySelectBlock + `
+		WHERE b.height = $1 AND b.orphanedyn = FALSE
+	`
+
+	return scanBlock(s.db.QueryRowContext(ctx, q, height))
+}
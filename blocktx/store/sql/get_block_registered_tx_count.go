@@ -0,0 +1,34 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/ordishs/gocore"
+)
+
+// GetBlockRegisteredTxCount returns the number of registered transactions which are mapped to the block. Transactions
+// which have been inserted for the full index are not counted unless they have been registered.
+func (s *SQL) GetBlockRegisteredTxCount(ctx context.Context, blockId uint64) (uint64, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("GetBlockRegisteredTxCount").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	q := `
+		SELECT COUNT(*)
+		FROM block_transactions_map m
+		INNER JOIN transactions t ON m.txid = t.id
+		WHERE m.blockid = $1
+		AND t.is_registered
+	`
+
+	var count uint64
+	if err := s.db.QueryRowContext(ctx, q, blockId).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/ordishs/gocore"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetBlockStats returns the statistics of the block with the given hash. It returns store.ErrBlockNotFound if the
+// block has not been processed.
+func (s *SQL) GetBlockStats(ctx context.Context, hash *chainhash.Hash) (*blocktx_api.BlockStats, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("GetBlockStats").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	q := `
+		SELECT
+		 b.hash
+		,b.height
+		,b.size
+		,b.tx_count
+		,s.block_time
+		,s.coinbase_value
+		,s.subsidy
+		,s.total_fees
+		,s.tx_per_second
+		,s.registered_tx_count
+		FROM blocks b
+		JOIN block_stats s ON s.blockid = b.id
+		WHERE b.hash = $1
+	`
+
+	var stats blocktx_api.BlockStats
+
+	var size sql.NullInt64
+	var txCount sql.NullInt64
+	var blockTime int64
+
+	err := s.db.QueryRowContext(ctx, q, hash[:]).Scan(
+		&stats.BlockHash,
+		&stats.Height,
+		&size,
+		&txCount,
+		&blockTime,
+		&stats.CoinbaseValue,
+		&stats.Subsidy,
+		&stats.TotalFees,
+		&stats.TxPerSecond,
+		&stats.RegisteredTxCount,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store.ErrBlockNotFound
+		}
+
+		return nil, err
+	}
+
+	stats.Size = uint64(size.Int64)
+	stats.TxCount = uint64(txCount.Int64)
+	stats.Timestamp = timestamppb.New(time.Unix(blockTime, 0))
+
+	if stats.TxCount > 0 {
+		stats.RegisteredTxShare = float64(stats.RegisteredTxCount) / float64(stats.TxCount)
+	}
+
+	return &stats, nil
+}
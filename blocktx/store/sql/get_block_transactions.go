@@ -0,0 +1,50 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/ordishs/gocore"
+)
+
+// GetBlockTransactions returns at most limit registered transactions of the block, starting at position offset.
+func (s *SQL) GetBlockTransactions(ctx context.Context, hash *chainhash.Hash, offset uint64, limit uint64) ([]*blocktx_api.BlockTransaction, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("GetBlockTransactions").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	q := `
+		SELECT
+		 t.hash
+		,m.pos
+		FROM blocks b
+		INNER JOIN block_transactions_map m ON m.blockid = b.id
+		INNER JOIN transactions t ON m.txid = t.id
+		WHERE b.hash = $1 AND m.pos >= $2
+		ORDER BY m.pos
+		LIMIT $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, hash[:], offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]*blocktx_api.BlockTransaction, 0)
+	for rows.Next() {
+		tx := &blocktx_api.BlockTransaction{}
+		if err = rows.Scan(&tx.Hash, &tx.Pos); err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, tx)
+	}
+
+	return transactions, rows.Err()
+}
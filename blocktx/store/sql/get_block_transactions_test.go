@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockTransactions(t *testing.T) {
+	blockHash, err := chainhash.NewHashFromStr("000000000000000001d29fef90f0f86eb7a99f043b994c7e363e0aa72db05862")
+	require.NoError(t, err)
+	txHash, err := chainhash.NewHashFromStr("b0926372c449731ffb84b7d2f808087d4b5e6e26aafee872232bbcd5a5854e16")
+	require.NoError(t, err)
+
+	query := `
+		SELECT
+		 t.hash
+		,m.pos
+		FROM blocks b
+		INNER JOIN block_transactions_map m ON m.blockid = b.id
+		INNER JOIN transactions t ON m.txid = t.id
+		WHERE b.hash = $1 AND m.pos >= $2
+		ORDER BY m.pos
+		LIMIT $3
+	`
+
+	tt := []struct {
+		name                string
+		sqlmockExpectations func(mock sqlmock.Sqlmock)
+
+		expectedTxs      int
+		expectedErrorStr string
+	}{
+		{
+			name: "success",
+			sqlmockExpectations: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(blockHash[:], 5, 10).WillReturnRows(
+					sqlmock.NewRows([]string{"hash", "pos"}).AddRow(txHash[:], 7),
+				)
+			},
+
+			expectedTxs: 1,
+		},
+		{
+			name: "query fails",
+			sqlmockExpectations: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnError(errors.New("db connection error"))
+			},
+
+			expectedErrorStr: "db connection error",
+		},
+		{
+			name: "scanning fails",
+			sqlmockExpectations: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnRows(
+					sqlmock.NewRows([]string{"hash", "pos"}).AddRow(txHash[:], "not a number"),
+				)
+			},
+
+			expectedErrorStr: "Scan error on column index 1",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			dbEngine := SQL{
+				db:     db,
+				engine: postgresEngine,
+			}
+
+			tc.sqlmockExpectations(mock)
+
+			transactions, err := dbEngine.GetBlockTransactions(context.Background(), blockHash, 5, 10)
+			if tc.expectedErrorStr != "" || err != nil {
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+				return
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+			require.Len(t, transactions, tc.expectedTxs)
+			require.Equal(t, txHash[:], transactions[0].GetHash())
+			require.Equal(t, uint64(7), transactions[0].GetPos())
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/ordishs/gocore"
+)
+
+func (s *SQL) GetChainTip(ctx context.Context) (*blocktx_api.Block, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("GetChainTip").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	q := querySelectBlock + `
+		WHERE b.orphanedyn = FALSE
+		ORDER BY b.height DESC
+		LIMIT 1
+	`
+
+	return scanBlock(s.db.QueryRowContext(ctx, q))
+}
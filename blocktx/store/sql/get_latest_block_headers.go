@@ -0,0 +1,53 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/ordishs/gocore"
+)
+
+// GetLatestBlockHeaders returns the stored headers of the highest count heights ordered by height.
+func (s *SQL) GetLatestBlockHeaders(ctx context.Context, count uint64) ([]*store.BlockHeader, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("GetLatestBlockHeaders").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	q := `
+		SELECT height, header
+		FROM block_headers
+		WHERE height > (SELECT COALESCE(MAX(height), 0) FROM block_headers) - $1
+		ORDER BY height ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, int64(count))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	headers := make([]*store.BlockHeader, 0, count)
+	for rows.Next() {
+		var height uint64
+		var rawHeader []byte
+		if err = rows.Scan(&height, &rawHeader); err != nil {
+			return nil, err
+		}
+
+		header := &wire.BlockHeader{}
+		if err = header.Deserialize(bytes.NewReader(rawHeader)); err != nil {
+			return nil, fmt.Errorf("failed to deserialize block header at height %d: %v", height, err)
+		}
+
+		headers = append(headers, &store.BlockHeader{Height: height, Header: header})
+	}
+
+	return headers, rows.Err()
+}
@@ -0,0 +1,58 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/ordishs/gocore"
+)
+
+// sqliteTimeFormat is the format in which SQLite stores the expiry of the lease. Times in this format are ordered
+// like strings.
+const sqliteTimeFormat = "2006-01-02 15:04:05.000"
+
+// GetLease returns the lease of the primary blocktx instance, which may have expired.
+func (s *SQL) GetLease(ctx context.Context) (*store.Lease, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("GetLease").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	row := s.db.QueryRowContext(ctx, `SELECT host_name, epoch, primary_until FROM primary_blocktx WHERE id = 1`)
+
+	lease := &store.Lease{}
+	var err error
+
+	switch s.engine {
+	case sqliteEngine:
+		fallthrough
+	case sqliteMemoryEngine:
+		var expiresAt string
+		if err = row.Scan(&lease.HostName, &lease.Epoch, &expiresAt); err != nil {
+			break
+		}
+
+		lease.ExpiresAt, err = time.ParseInLocation(sqliteTimeFormat, expiresAt, time.UTC)
+	case postgresEngine:
+		err = row.Scan(&lease.HostName, &lease.Epoch, &lease.ExpiresAt)
+	default:
+		return nil, fmt.Errorf("engine not supported: %s", s.engine)
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get lease: %v", err)
+	}
+
+	return lease, nil
+}
@@ -0,0 +1,54 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/ordishs/gocore"
+)
+
+// GetRegisteredTransactions returns at most limit registered transactions with an id greater than afterID ordered by id.
+// Transactions which were inserted by InsertBlockTransactions are only returned once they have been registered.
+func (s *SQL) GetRegisteredTransactions(ctx context.Context, afterID uint64, limit uint64) ([]*store.RegisteredTransaction, error) {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("GetRegisteredTransactions").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	q := `
+		SELECT id, hash
+		FROM transactions
+		WHERE id > $1
+		AND is_registered
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]*store.RegisteredTransaction, 0)
+	for rows.Next() {
+		var id uint64
+		var hash []byte
+		if err = rows.Scan(&id, &hash); err != nil {
+			return nil, err
+		}
+
+		txHash, err := chainhash.NewHash(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, &store.RegisteredTransaction{ID: id, Hash: txHash})
+	}
+
+	return transactions, rows.Err()
+}
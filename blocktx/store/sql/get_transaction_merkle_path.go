@@ -10,7 +10,8 @@ import (
 	"github.com/pkg/errors"
 )
 
-// GetTransactionMerklePath returns the merkle path of a transaction.
+// GetTransactionMerklePath returns the merkle path of a transaction. The merkle path is calculated from the stored
+// merkle tree of the block in which the transaction was mined.
 func (s *SQL) GetTransactionMerklePath(ctx context.Context, txhash *chainhash.Hash) (string, error) {
 	start := gocore.CurrentNanos()
 	defer func() {
@@ -21,6 +22,41 @@ func (s *SQL) GetTransactionMerklePath(ctx context.Context, txhash *chainhash.Ha
 	defer cancel()
 
 	q := `
+		SELECT
+		 b.height
+		,mt.tx_count
+		,mt.subtree_size
+		,mt.subtree_roots
+		,st.leaves
+		,m.pos
+		FROM transactions t
+		INNER JOIN block_transactions_map m ON m.txid = t.id
+		INNER JOIN blocks b ON b.id = m.blockid
+		INNER JOIN block_merkle_trees mt ON mt.blockid = b.id
+		INNER JOIN block_merkle_subtrees st ON st.blockid = b.id AND st.subtree_index = m.pos / mt.subtree_size
+		WHERE t.hash = $1 AND b.orphanedyn = FALSE
+		ORDER BY b.height DESC
+		LIMIT 1
+	`
+
+	var blockHeight uint64
+	var txCount uint64
+	var subtreeSize uint64
+	var subtreeRoots []byte
+	var leaves []byte
+	var pos uint64
+
+	err := s.db.QueryRowContext(ctx, q, txhash[:]).Scan(&blockHeight, &txCount, &subtreeSize, &subtreeRoots, &leaves, &pos)
+	if err == nil {
+		return store.MerklePath(blockHeight, txCount, subtreeSize, subtreeRoots, leaves, pos)
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	// the transaction is not mined yet or the merkle path was stored with the transaction before merkle trees were stored
+	q = `
 		SELECT
 		 t.merkle_path
 		FROM transactions t
@@ -2,12 +2,15 @@ package sql
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
 	"github.com/ordishs/gocore"
 )
 
+// InsertBlock inserts the block and returns its id. If the context carries a fencing epoch, the epoch is stored with
+// the block and the block is only inserted if the epoch is current.
 func (s *SQL) InsertBlock(ctx context.Context, block *blocktx_api.Block) (uint64, error) {
 	start := gocore.CurrentNanos()
 	defer func() {
@@ -18,17 +21,24 @@ func (s *SQL) InsertBlock(ctx context.Context, block *blocktx_api.Block) (uint64
 	defer cancel()
 
 	qInsert := `
-		INSERT INTO blocks (hash, prevhash, merkleroot, height)
-		VALUES ($1 ,$2 , $3, $4)
-		ON CONFLICT (hash) DO UPDATE SET orphanedyn = FALSE
+		INSERT INTO blocks (hash, prevhash, merkleroot, height, fencing_epoch)
+		VALUES ($1 ,$2 , $3, $4, $5)
+		ON CONFLICT (hash) DO UPDATE SET orphanedyn = FALSE, fencing_epoch = EXCLUDED.fencing_epoch
 		RETURNING id
 	`
 
 	var blockId uint64
 
-	err := s.db.QueryRowContext(ctx, qInsert, block.GetHash(), block.GetPreviousHash(), block.GetMerkleRoot(), block.GetHeight()).Scan(&blockId)
+	err := s.inFencedTx(ctx, func(dbTx *sql.Tx, epoch sql.NullInt64) error {
+		err := dbTx.QueryRowContext(ctx, qInsert, block.GetHash(), block.GetPreviousHash(), block.GetMerkleRoot(), block.GetHeight(), epoch).Scan(&blockId)
+		if err != nil {
+			return fmt.Errorf("failed when inserting block: %v", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed when inserting block: %v", err)
+		return 0, err
 	}
 
 	return blockId, nil
@@ -0,0 +1,89 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/lib/pq"
+	"github.com/ordishs/gocore"
+)
+
+// InsertBlockHeaders stores validated headers of the header chain.
+func (s *SQL) InsertBlockHeaders(ctx context.Context, headers []*store.BlockHeader) error {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("InsertBlockHeaders").AddTime(start)
+	}()
+
+	if len(headers) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	hashes := make([][]byte, len(headers))
+	prevHashes := make([][]byte, len(headers))
+	heights := make([]uint64, len(headers))
+	rawHeaders := make([][]byte, len(headers))
+
+	for i, header := range headers {
+		buf := bytes.NewBuffer(make([]byte, 0, 80))
+		if err := header.Header.Serialize(buf); err != nil {
+			return fmt.Errorf("failed to serialize block header at height %d: %v", header.Height, err)
+		}
+
+		hash := header.Header.BlockHash()
+		hashes[i] = hash[:]
+		prevHashes[i] = header.Header.PrevBlock[:]
+		heights[i] = header.Height
+		rawHeaders[i] = buf.Bytes()
+	}
+
+	switch s.engine {
+	case sqliteEngine:
+		fallthrough
+	case sqliteMemoryEngine:
+		dbTx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %v", err)
+		}
+		defer func() {
+			_ = dbTx.Rollback()
+		}()
+
+		qHeader, err := dbTx.PrepareContext(ctx, `
+			INSERT INTO block_headers (hash, prevhash, height, header)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT DO NOTHING
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to prepare query for insertion into block headers: %v", err)
+		}
+		defer qHeader.Close()
+
+		for i := range headers {
+			if _, err = qHeader.ExecContext(ctx, hashes[i], prevHashes[i], heights[i], rawHeaders[i]); err != nil {
+				return fmt.Errorf("failed to insert block header at height %d: %v", heights[i], err)
+			}
+		}
+
+		return dbTx.Commit()
+	case postgresEngine:
+		q := `
+			INSERT INTO block_headers (hash, prevhash, height, header)
+			SELECT * FROM UNNEST($1::BYTEA[], $2::BYTEA[], $3::BIGINT[], $4::BYTEA[])
+			ON CONFLICT DO NOTHING
+		`
+
+		if _, err := s.db.ExecContext(ctx, q, pq.Array(hashes), pq.Array(prevHashes), pq.Array(heights), pq.Array(rawHeaders)); err != nil {
+			return fmt.Errorf("failed to bulk insert block headers: %v", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("engine not supported: %s", s.engine)
+	}
+}
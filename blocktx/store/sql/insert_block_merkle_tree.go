@@ -0,0 +1,110 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bitcoin-sv/arc/blocktx/store"
+	"github.com/lib/pq"
+	"github.com/ordishs/gocore"
+)
+
+const maxPostgresBulkInsertSubtrees = 100
+
+// InsertBlockMerkleTree stores the compact Merkle tree of a block from which the Merkle paths of its transactions are calculated.
+// The subtrees of the Merkle tree may be empty if they are stored separately with InsertBlockMerkleSubtrees.
+func (s *SQL) InsertBlockMerkleTree(ctx context.Context, blockId uint64, tree *store.MerkleTree) error {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("InsertBlockMerkleTree").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	qTree := `
+		INSERT INTO block_merkle_trees (blockid, tx_count, subtree_size, subtree_roots)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (blockid) DO UPDATE SET tx_count = $2, subtree_size = $3, subtree_roots = $4
+	`
+
+	return s.inFencedTx(ctx, func(dbTx *sql.Tx, _ sql.NullInt64) error {
+		_, err := dbTx.ExecContext(ctx, qTree, blockId, tree.TxCount, tree.SubtreeSize, tree.SubtreeRoots)
+		if err != nil {
+			return fmt.Errorf("failed to insert merkle tree of block with id %d: %v", blockId, err)
+		}
+
+		return s.insertSubtrees(ctx, dbTx, blockId, 0, tree.Subtrees)
+	})
+}
+
+// InsertBlockMerkleSubtrees stores the leaves of consecutive subtrees of the Merkle tree of a block starting at the
+// subtree with the given index.
+func (s *SQL) InsertBlockMerkleSubtrees(ctx context.Context, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("InsertBlockMerkleSubtrees").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	return s.inFencedTx(ctx, func(dbTx *sql.Tx, _ sql.NullInt64) error {
+		return s.insertSubtrees(ctx, dbTx, blockId, firstIndex, subtrees)
+	})
+}
+
+func (s *SQL) insertSubtrees(ctx context.Context, dbTx *sql.Tx, blockId uint64, firstIndex uint64, subtrees [][]byte) error {
+	if len(subtrees) == 0 {
+		return nil
+	}
+
+	switch s.engine {
+	case sqliteEngine:
+		fallthrough
+	case sqliteMemoryEngine:
+		qSubtree, err := dbTx.PrepareContext(ctx, `
+			INSERT INTO block_merkle_subtrees (blockid, subtree_index, leaves)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to prepare query for insertion into block merkle subtrees: %v", err)
+		}
+		defer qSubtree.Close()
+
+		for i, leaves := range subtrees {
+			index := firstIndex + uint64(i)
+			if _, err = qSubtree.ExecContext(ctx, blockId, index, leaves); err != nil {
+				return fmt.Errorf("failed to insert subtree %d of block with id %d: %v", index, blockId, err)
+			}
+		}
+	case postgresEngine:
+		qSubtrees := `
+			INSERT INTO block_merkle_subtrees (blockid, subtree_index, leaves)
+			SELECT * FROM UNNEST($1::BIGINT[], $2::BIGINT[], $3::BYTEA[])
+			ON CONFLICT DO NOTHING
+		`
+
+		for first := 0; first < len(subtrees); first += maxPostgresBulkInsertSubtrees {
+			last := min(first+maxPostgresBulkInsertSubtrees, len(subtrees))
+
+			blockIDs := make([]uint64, 0, last-first)
+			indices := make([]uint64, 0, last-first)
+			for i := first; i < last; i++ {
+				blockIDs = append(blockIDs, blockId)
+				indices = append(indices, firstIndex+uint64(i))
+			}
+
+			_, err := dbTx.ExecContext(ctx, qSubtrees, pq.Array(blockIDs), pq.Array(indices), pq.Array(subtrees[first:last]))
+			if err != nil {
+				return fmt.Errorf("failed to bulk insert subtrees of block with id %d: %v", blockId, err)
+			}
+		}
+	default:
+		return fmt.Errorf("engine not supported: %s", s.engine)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/ordishs/gocore"
+)
+
+// InsertBlockStats stores the statistics of a block. Height, size and transaction count are stored with the block and
+// the share of registered transactions is calculated when the statistics are read.
+func (s *SQL) InsertBlockStats(ctx context.Context, blockId uint64, stats *blocktx_api.BlockStats) error {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("InsertBlockStats").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	q := `
+		INSERT INTO block_stats (blockid, block_time, coinbase_value, subsidy, total_fees, tx_per_second, registered_tx_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (blockid) DO UPDATE SET
+		 block_time = $2
+		,coinbase_value = $3
+		,subsidy = $4
+		,total_fees = $5
+		,tx_per_second = $6
+		,registered_tx_count = $7
+	`
+
+	return s.inFencedTx(ctx, func(dbTx *sql.Tx, _ sql.NullInt64) error {
+		_, err := dbTx.ExecContext(ctx, q,
+			blockId,
+			stats.GetTimestamp().GetSeconds(),
+			int64(stats.GetCoinbaseValue()),
+			int64(stats.GetSubsidy()),
+			int64(stats.GetTotalFees()),
+			stats.GetTxPerSecond(),
+			int64(stats.GetRegisteredTxCount()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert stats of block with id %d: %v", blockId, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,19 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/ordishs/gocore"
+)
+
+// InsertBlockTransactions inserts the given transactions whether they have been registered or not and maps them to
+// the block at their positions in the block.
+func (s *SQL) InsertBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("InsertBlockTransactions").AddTime(start)
+	}()
+
+	return s.storeBlockTransactions(ctx, blockId, transactions, true)
+}
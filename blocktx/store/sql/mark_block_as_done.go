@@ -2,11 +2,14 @@ package sql
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
 	"github.com/ordishs/gocore"
 )
 
+// MarkBlockAsDone marks the block as processed. If the context carries a fencing epoch, the block is only marked if
+// the epoch is current.
 func (s *SQL) MarkBlockAsDone(ctx context.Context, hash *chainhash.Hash, size uint64, txCount uint64) error {
 	start := gocore.CurrentNanos()
 	defer func() {
@@ -24,9 +27,8 @@ func (s *SQL) MarkBlockAsDone(ctx context.Context, hash *chainhash.Hash, size ui
 		WHERE hash = $3
 		`
 
-	if _, err := s.db.ExecContext(ctx, q, size, txCount, hash[:]); err != nil {
+	return s.inFencedTx(ctx, func(dbTx *sql.Tx, _ sql.NullInt64) error {
+		_, err := dbTx.ExecContext(ctx, q, size, txCount, hash[:])
 		return err
-	}
-
-	return nil
+	})
 }
@@ -13,7 +13,8 @@ var (
 	ErrRegisterTransactionMissingHash = errors.New("invalid request - no hash")
 )
 
-// RegisterTransaction registers a transaction in the database.
+// RegisterTransaction registers a transaction in the database. A transaction which has been inserted for the full index
+// already is marked as registered.
 func (s *SQL) RegisterTransaction(ctx context.Context, transaction *blocktx_api.TransactionAndSource) error {
 	start := gocore.CurrentNanos()
 	defer func() {
@@ -26,7 +27,11 @@ func (s *SQL) RegisterTransaction(ctx context.Context, transaction *blocktx_api.
 		return ErrRegisterTransactionMissingHash
 	}
 
-	q := `INSERT INTO transactions (hash, source) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	q := `
+		INSERT INTO transactions (hash, source, is_registered) VALUES ($1, $2, TRUE)
+		ON CONFLICT (hash) DO UPDATE SET is_registered = TRUE, source = excluded.source
+		WHERE NOT transactions.is_registered
+	`
 	_, err := s.db.ExecContext(ctx, q, transaction.Hash[:], transaction.Source)
 	if err != nil {
 		return err
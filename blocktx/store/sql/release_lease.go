@@ -0,0 +1,39 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ordishs/gocore"
+)
+
+// ReleaseLease lets the lease of the primary blocktx instance expire immediately if it is still held by the given host
+// with the given epoch, so that another instance can acquire it without waiting for the expiry.
+func (s *SQL) ReleaseLease(ctx context.Context, hostName string, epoch int64) error {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("blocktx").NewStat("ReleaseLease").AddTime(start)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var q string
+
+	switch s.engine {
+	case sqliteEngine:
+		fallthrough
+	case sqliteMemoryEngine:
+		q = `UPDATE primary_blocktx SET primary_until = strftime('%Y-%m-%d %H:%M:%f', 'now', '-1 seconds') WHERE host_name = $1 AND epoch = $2`
+	case postgresEngine:
+		q = `UPDATE primary_blocktx SET primary_until = NOW() - INTERVAL '1 second' WHERE host_name = $1 AND epoch = $2`
+	default:
+		return fmt.Errorf("engine not supported: %s", s.engine)
+	}
+
+	if _, err := s.db.ExecContext(ctx, q, hostName, epoch); err != nil {
+		return fmt.Errorf("failed to release lease: %v", err)
+	}
+
+	return nil
+}
@@ -182,12 +182,19 @@ func createSqliteSchema(db *sql.DB) error {
 		,size					BIGINT
 		,tx_count			BIGINT
 		,orphanedyn   BOOLEAN NOT NULL DEFAULT FALSE
+		,fencing_epoch BIGINT
 	 	);
 	`); err != nil {
 		db.Close()
 		return fmt.Errorf("could not create blocks table - [%+v]", err)
 	}
 
+	// blocks tables created before the fencing epoch was introduced lack the column
+	if err := addSqliteColumn(db, "blocks", "fencing_epoch", "BIGINT"); err != nil {
+		db.Close()
+		return fmt.Errorf("could not add fencing_epoch column to blocks table - [%+v]", err)
+	}
+
 	if _, err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ux_blocks_hash ON blocks (hash);`); err != nil {
 		db.Close()
 		return fmt.Errorf("could not create ux_blocks_hash index - [%+v]", err)
@@ -204,12 +211,33 @@ func createSqliteSchema(db *sql.DB) error {
 		 hash         BLOB NOT NULL
 	  ,source				TEXT
 	  ,merkle_path			TEXT DEFAULT('')
+	  ,is_registered		BOOLEAN NOT NULL DEFAULT FALSE
 	 	);
 	`); err != nil {
 		db.Close()
 		return fmt.Errorf("could not create transactions table - [%+v]", err)
 	}
 
+	// transactions tables created before the registration was recorded lack the column
+	hasIsRegistered, err := sqliteColumnExists(db, "transactions", "is_registered")
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("could not get columns of transactions table - [%+v]", err)
+	}
+
+	if !hasIsRegistered {
+		if err = addSqliteColumn(db, "transactions", "is_registered", "BOOLEAN NOT NULL DEFAULT FALSE"); err != nil {
+			db.Close()
+			return fmt.Errorf("could not add is_registered column to transactions table - [%+v]", err)
+		}
+
+		// only registered transactions have a source, transactions inserted for the full index do not
+		if _, err = db.Exec(`UPDATE transactions SET is_registered = TRUE WHERE source IS NOT NULL;`); err != nil {
+			db.Close()
+			return fmt.Errorf("could not set is_registered of transactions - [%+v]", err)
+		}
+	}
+
 	if _, err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ux_transactions_hash ON transactions (hash);`); err != nil {
 		db.Close()
 		return fmt.Errorf("could not create transactions hash index - [%+v]", err)
@@ -229,10 +257,85 @@ func createSqliteSchema(db *sql.DB) error {
 		return fmt.Errorf("could not create block_transactions_map table - [%+v]", err)
 	}
 
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS block_merkle_trees (
+		 blockid       INTEGER PRIMARY KEY
+		,tx_count      BIGINT NOT NULL
+		,subtree_size  BIGINT NOT NULL
+		,subtree_roots BLOB NOT NULL
+		,FOREIGN KEY (blockid) REFERENCES blocks(id)
+		);
+	`); err != nil {
+		db.Close()
+		return fmt.Errorf("could not create block_merkle_trees table - [%+v]", err)
+	}
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS block_merkle_subtrees (
+		 blockid       INTEGER NOT NULL
+		,subtree_index INTEGER NOT NULL
+		,leaves        BLOB NOT NULL
+		,FOREIGN KEY (blockid) REFERENCES blocks(id)
+		,PRIMARY KEY (blockid, subtree_index)
+		);
+	`); err != nil {
+		db.Close()
+		return fmt.Errorf("could not create block_merkle_subtrees table - [%+v]", err)
+	}
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS block_stats (
+		 blockid             INTEGER PRIMARY KEY
+		,block_time          BIGINT NOT NULL
+		,coinbase_value      BIGINT NOT NULL
+		,subsidy             BIGINT NOT NULL
+		,total_fees          BIGINT NOT NULL
+		,tx_per_second       REAL NOT NULL
+		,registered_tx_count BIGINT NOT NULL
+		,FOREIGN KEY (blockid) REFERENCES blocks(id)
+		);
+	`); err != nil {
+		db.Close()
+		return fmt.Errorf("could not create block_stats table - [%+v]", err)
+	}
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS block_headers (
+		 hash     BLOB PRIMARY KEY
+		,prevhash BLOB NOT NULL
+		,height   BIGINT NOT NULL
+		,header   BLOB NOT NULL
+		);
+	`); err != nil {
+		db.Close()
+		return fmt.Errorf("could not create block_headers table - [%+v]", err)
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS ix_block_headers_height ON block_headers (height);`); err != nil {
+		db.Close()
+		return fmt.Errorf("could not create ix_block_headers_height index - [%+v]", err)
+	}
+
+	// the primary_blocktx table without epoch only held a lease which is not valid anymore
+	hasEpoch, err := sqliteColumnExists(db, "primary_blocktx", "epoch")
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("could not get columns of primary_blocktx table - [%+v]", err)
+	}
+
+	if !hasEpoch {
+		if _, err := db.Exec(`DROP TABLE IF EXISTS primary_blocktx;`); err != nil {
+			db.Close()
+			return fmt.Errorf("could not drop primary_blocktx table - [%+v]", err)
+		}
+	}
+
 	if _, err := db.Exec(`
 	CREATE TABLE IF NOT EXISTS primary_blocktx (
-		host_name TEXT PRIMARY KEY,
-		primary_until TIMESTAMP
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		host_name TEXT NOT NULL,
+		primary_until TEXT NOT NULL,
+		epoch BIGINT NOT NULL
 	);
 	`); err != nil {
 		db.Close()
@@ -240,3 +343,23 @@ func createSqliteSchema(db *sql.DB) error {
 	}
 	return nil
 }
+
+func sqliteColumnExists(db *sql.DB, table string, column string) (bool, error) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info($1) WHERE name = $2`, table, column).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func addSqliteColumn(db *sql.DB, table string, column string, definition string) error {
+	exists, err := sqliteColumnExists(db, table, column)
+	if err != nil || exists {
+		return err
+	}
+
+	_, err = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s;`, table, column, definition))
+	return err
+}
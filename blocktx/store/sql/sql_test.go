@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
-	"github.com/ordishs/gocore"
 	"testing"
+	"time"
 
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/blocktx/store"
 	_ "github.com/lib/pq"
+	"github.com/libsv/go-bc"
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/ordishs/gocore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Todo: Revisit this test and check if it still makes sense
@@ -53,7 +58,12 @@ func TestInOut(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	err = s.UpdateBlockTransactions(ctx, blockId, transactions, make([]string, len(transactions)))
+	blockTransactions := make([]*blocktx_api.BlockTransaction, len(transactions))
+	for i, txn := range transactions {
+		blockTransactions[i] = &blocktx_api.BlockTransaction{Hash: txn.GetHash(), Pos: uint64(i)}
+	}
+
+	err = s.UpdateBlockTransactions(ctx, blockId, blockTransactions)
 	require.NoError(t, err)
 
 	txns, err := getBlockTransactions(ctx, block, s.db)
@@ -80,6 +90,104 @@ func TestInOut(t *testing.T) {
 	assert.Nil(t, block3)
 }
 
+func TestGetTransactionMerklePathFromMerkleTree(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := New("sqlite_memory")
+	require.NoError(t, err)
+
+	blockId, err := s.InsertBlock(ctx, &blocktx_api.Block{
+		Hash:         []byte("test block hash"),
+		MerkleRoot:   []byte("test merkleroot"),
+		PreviousHash: []byte("test prevhash"),
+		Height:       826481,
+	})
+	require.NoError(t, err)
+
+	leaves := make([]*chainhash.Hash, 11)
+	blockTransactions := make([]*blocktx_api.BlockTransaction, len(leaves))
+	for i := range leaves {
+		hash := chainhash.DoubleHashH([]byte{byte(i)})
+		leaves[i] = &hash
+		blockTransactions[i] = &blocktx_api.BlockTransaction{Hash: hash[:], Pos: uint64(i)}
+	}
+
+	// only the transaction at position 6 is registered
+	err = s.RegisterTransaction(ctx, &blocktx_api.TransactionAndSource{Hash: leaves[6][:], Source: "TEST"})
+	require.NoError(t, err)
+
+	tree, err := store.NewMerkleTree(leaves, 4)
+	require.NoError(t, err)
+
+	// the first subtree is stored with the merkle tree and the remaining subtrees separately
+	subtrees := tree.Subtrees
+	tree.Subtrees = subtrees[:1]
+
+	err = s.InsertBlockMerkleTree(ctx, blockId, tree)
+	require.NoError(t, err)
+
+	err = s.InsertBlockMerkleSubtrees(ctx, blockId, 1, subtrees[1:])
+	require.NoError(t, err)
+
+	err = s.UpdateBlockTransactions(ctx, blockId, blockTransactions)
+	require.NoError(t, err)
+
+	merklePath, err := s.GetTransactionMerklePath(ctx, leaves[6])
+	require.NoError(t, err)
+
+	bump, err := bc.NewBUMPFromMerkleTreeAndIndex(826481, bc.BuildMerkleTreeStoreChainHash(leaves), 6)
+	require.NoError(t, err)
+	expected, err := bump.String()
+	require.NoError(t, err)
+	require.Equal(t, expected, merklePath)
+
+	_, err = s.GetTransactionMerklePath(ctx, leaves[7])
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestBlockHeaders(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := New("sqlite_memory")
+	require.NoError(t, err)
+
+	headers, err := s.GetLatestBlockHeaders(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+
+	blockHeaders := make([]*store.BlockHeader, 20)
+	prevBlock := chainhash.Hash{}
+	for i := range blockHeaders {
+		header := &wire.BlockHeader{
+			Version:    0x20000000,
+			PrevBlock:  prevBlock,
+			MerkleRoot: chainhash.DoubleHashH([]byte{byte(i)}),
+			Timestamp:  time.Unix(1700000000+int64(i)*600, 0),
+			Bits:       0x207fffff,
+			Nonce:      uint32(i),
+		}
+		blockHeaders[i] = &store.BlockHeader{Height: uint64(100 + i), Header: header}
+		prevBlock = header.BlockHash()
+	}
+
+	err = s.InsertBlockHeaders(ctx, blockHeaders)
+	require.NoError(t, err)
+
+	// inserting the same headers again is ignored
+	err = s.InsertBlockHeaders(ctx, blockHeaders[15:])
+	require.NoError(t, err)
+
+	headers, err = s.GetLatestBlockHeaders(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, headers, 5)
+
+	for i, header := range headers {
+		expected := blockHeaders[15+i]
+		require.Equal(t, expected.Height, header.Height)
+		require.Equal(t, expected.Header.BlockHash(), header.Header.BlockHash())
+	}
+}
+
 func TestBlockNotExists(t *testing.T) {
 	ctx := context.Background()
 
@@ -174,3 +282,208 @@ func getBlockForHeight(ctx context.Context, db *sql.DB, height uint64) (*blocktx
 
 	return &block, nil
 }
+
+func TestRegisteredTransactions(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := New("sqlite_memory")
+	require.NoError(t, err)
+
+	blockId, err := s.InsertBlock(ctx, &blocktx_api.Block{
+		Hash:         []byte("test block hash"),
+		MerkleRoot:   []byte("test merkleroot"),
+		PreviousHash: []byte("test prevhash"),
+		Height:       1,
+	})
+	require.NoError(t, err)
+
+	registered := chainhash.DoubleHashB([]byte("registered transaction"))
+	err = s.RegisterTransaction(ctx, &blocktx_api.TransactionAndSource{Hash: registered, Source: "TEST"})
+	require.NoError(t, err)
+
+	// the block transactions of the full index are inserted in addition to the registered transactions
+	err = s.InsertBlockTransactions(ctx, blockId, []*blocktx_api.BlockTransaction{
+		{Hash: registered, Pos: 0},
+		{Hash: chainhash.DoubleHashB([]byte("transaction 2")), Pos: 1},
+		{Hash: chainhash.DoubleHashB([]byte("transaction 3")), Pos: 2},
+	})
+	require.NoError(t, err)
+
+	txns, err := getBlockTransactions(ctx, &blocktx_api.Block{Hash: []byte("test block hash")}, s.db)
+	require.NoError(t, err)
+	require.Len(t, txns.GetTransactions(), 3)
+
+	// the transactions inserted for the full index are not registered
+	transactions, err := s.GetRegisteredTransactions(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+	require.Equal(t, registered, transactions[0].Hash[:])
+
+	registeredCount, err := s.GetBlockRegisteredTxCount(ctx, blockId)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), registeredCount)
+
+	// a transaction inserted for the full index is registered afterwards
+	registeredLater := chainhash.DoubleHashB([]byte("transaction 3"))
+	err = s.RegisterTransaction(ctx, &blocktx_api.TransactionAndSource{Hash: registeredLater, Source: "TEST"})
+	require.NoError(t, err)
+
+	registered2 := chainhash.DoubleHashB([]byte("registered transaction 2"))
+	err = s.RegisterTransaction(ctx, &blocktx_api.TransactionAndSource{Hash: registered2, Source: "TEST"})
+	require.NoError(t, err)
+
+	firstPage, err := s.GetRegisteredTransactions(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+	require.Equal(t, registered, firstPage[0].Hash[:])
+	require.Equal(t, registeredLater, firstPage[1].Hash[:])
+
+	secondPage, err := s.GetRegisteredTransactions(ctx, firstPage[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+	require.Equal(t, registered2, secondPage[0].Hash[:])
+	require.Greater(t, secondPage[0].ID, firstPage[1].ID)
+
+	registeredCount, err = s.GetBlockRegisteredTxCount(ctx, blockId)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), registeredCount)
+}
+
+func TestLease(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := New("sqlite_memory")
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, err = s.GetLease(ctx)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	// the first instance acquires the lease
+	lease, err := s.AcquireLease(ctx, "instance-a", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "instance-a", lease.HostName)
+	require.Equal(t, int64(1), lease.Epoch)
+	require.WithinDuration(t, time.Now().Add(time.Minute), lease.ExpiresAt, 5*time.Second)
+
+	// renewing the lease keeps the epoch
+	lease, err = s.AcquireLease(ctx, "instance-a", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), lease.Epoch)
+
+	// the lease cannot be acquired while it is held by another instance
+	lease, err = s.AcquireLease(ctx, "instance-b", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "instance-a", lease.HostName)
+	require.Equal(t, int64(1), lease.Epoch)
+
+	block := &blocktx_api.Block{Hash: []byte("block 1"), PreviousHash: []byte("block 0"), MerkleRoot: []byte("merkle root"), Height: 1}
+	blockId, err := s.InsertBlock(store.WithFencingEpoch(ctx, 1), block)
+	require.NoError(t, err)
+
+	// the lease is only released by the instance holding it
+	require.NoError(t, s.ReleaseLease(ctx, "instance-b", 1))
+	lease, err = s.AcquireLease(ctx, "instance-b", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "instance-a", lease.HostName)
+
+	// a released lease is acquired by the other instance with the next epoch
+	require.NoError(t, s.ReleaseLease(ctx, "instance-a", 1))
+	lease, err = s.AcquireLease(ctx, "instance-b", 50*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, "instance-b", lease.HostName)
+	require.Equal(t, int64(2), lease.Epoch)
+
+	// blocks written with the previous epoch are rejected
+	blockHash := chainhash.Hash{}
+	copy(blockHash[:], "block 2")
+	_, err = s.InsertBlock(store.WithFencingEpoch(ctx, 1), &blocktx_api.Block{Hash: blockHash[:], PreviousHash: []byte("block 1"), MerkleRoot: []byte("merkle root"), Height: 2})
+	require.ErrorIs(t, err, store.ErrFenced)
+	require.ErrorIs(t, s.MarkBlockAsDone(store.WithFencingEpoch(ctx, 1), &blockHash, 100, 1), store.ErrFenced)
+
+	// the other writes of a block inserted with the previous epoch are rejected as well
+	txHash := chainhash.DoubleHashB([]byte("transaction"))
+	tree, err := store.NewMerkleTree([]*chainhash.Hash{(*chainhash.Hash)(txHash)}, store.DefaultMerkleSubtreeSize)
+	require.NoError(t, err)
+	transactions := []*blocktx_api.BlockTransaction{{Hash: txHash, Pos: 0}}
+
+	fencedCtx := store.WithFencingEpoch(ctx, 1)
+	require.ErrorIs(t, s.InsertBlockMerkleTree(fencedCtx, blockId, tree), store.ErrFenced)
+	require.ErrorIs(t, s.InsertBlockMerkleSubtrees(fencedCtx, blockId, 0, tree.Subtrees), store.ErrFenced)
+	require.ErrorIs(t, s.InsertBlockTransactions(fencedCtx, blockId, transactions), store.ErrFenced)
+	require.ErrorIs(t, s.UpdateBlockTransactions(fencedCtx, blockId, transactions), store.ErrFenced)
+	require.ErrorIs(t, s.InsertBlockStats(fencedCtx, blockId, &blocktx_api.BlockStats{}), store.ErrFenced)
+
+	for _, table := range []string{"block_merkle_trees", "block_merkle_subtrees", "transactions", "block_transactions_map", "block_stats"} {
+		var count int
+		require.NoError(t, s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table).Scan(&count))
+		require.Zero(t, count, table)
+	}
+
+	fencedCtx = store.WithFencingEpoch(ctx, 2)
+	require.NoError(t, s.InsertBlockMerkleTree(fencedCtx, blockId, tree))
+	require.NoError(t, s.InsertBlockTransactions(fencedCtx, blockId, transactions))
+	require.NoError(t, s.InsertBlockStats(fencedCtx, blockId, &blocktx_api.BlockStats{}))
+
+	_, err = s.InsertBlock(store.WithFencingEpoch(ctx, 2), &blocktx_api.Block{Hash: blockHash[:], PreviousHash: []byte("block 1"), MerkleRoot: []byte("merkle root"), Height: 2})
+	require.NoError(t, err)
+	require.NoError(t, s.MarkBlockAsDone(store.WithFencingEpoch(ctx, 2), &blockHash, 100, 1))
+
+	var fencingEpoch int64
+	require.NoError(t, s.db.QueryRowContext(ctx, `SELECT fencing_epoch FROM blocks WHERE hash = $1`, blockHash[:]).Scan(&fencingEpoch))
+	require.Equal(t, int64(2), fencingEpoch)
+
+	// an expired lease is acquired by the other instance with the next epoch
+	time.Sleep(100 * time.Millisecond)
+	lease, err = s.AcquireLease(ctx, "instance-a", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "instance-a", lease.HostName)
+	require.Equal(t, int64(3), lease.Epoch)
+}
+
+func TestBlockStats(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := New("sqlite_memory")
+	require.NoError(t, err)
+	defer s.Close()
+
+	blockHash := chainhash.Hash{}
+	copy(blockHash[:], "block 1")
+
+	_, err = s.GetBlockStats(ctx, &blockHash)
+	require.ErrorIs(t, err, store.ErrBlockNotFound)
+
+	blockId, err := s.InsertBlock(ctx, &blocktx_api.Block{Hash: blockHash[:], PreviousHash: []byte("block 0"), MerkleRoot: []byte("merkle root"), Height: 1})
+	require.NoError(t, err)
+	require.NoError(t, s.MarkBlockAsDone(ctx, &blockHash, 1000, 4))
+
+	blockTime := time.Unix(1700000000, 0)
+	stats := &blocktx_api.BlockStats{
+		Timestamp:         timestamppb.New(blockTime),
+		CoinbaseValue:     625_000_100,
+		Subsidy:           625_000_000,
+		TotalFees:         100,
+		TxPerSecond:       0.5,
+		RegisteredTxCount: 1,
+	}
+	require.NoError(t, s.InsertBlockStats(ctx, blockId, stats))
+
+	// the statistics of a block which is processed again are replaced
+	stats.RegisteredTxCount = 2
+	require.NoError(t, s.InsertBlockStats(ctx, blockId, stats))
+
+	actual, err := s.GetBlockStats(ctx, &blockHash)
+	require.NoError(t, err)
+	require.Equal(t, blockHash[:], actual.GetBlockHash())
+	require.Equal(t, uint64(1), actual.GetHeight())
+	require.Equal(t, uint64(1000), actual.GetSize())
+	require.Equal(t, uint64(4), actual.GetTxCount())
+	require.True(t, blockTime.Equal(actual.GetTimestamp().AsTime()))
+	require.Equal(t, uint64(625_000_100), actual.GetCoinbaseValue())
+	require.Equal(t, uint64(625_000_000), actual.GetSubsidy())
+	require.Equal(t, uint64(100), actual.GetTotalFees())
+	require.Equal(t, 0.5, actual.GetTxPerSecond())
+	require.Equal(t, uint64(2), actual.GetRegisteredTxCount())
+	require.Equal(t, 0.5, actual.GetRegisteredTxShare())
+}
@@ -3,201 +3,274 @@ package sql
 import (
 	"context"
 	"database/sql"
-	"encoding/hex"
-	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
 	"github.com/lib/pq"
-	"github.com/ordishs/go-utils"
 	"github.com/ordishs/gocore"
 )
 
 const (
-	maxPostgresBulkInsertRows = 1000
+	// sqliteBatchSize is the number of rows inserted by one prepared statement. It keeps the number of parameters of
+	// a statement below the limit of SQLite.
+	sqliteBatchSize = 100
 )
 
-// UpdateBlockTransactions updates the transaction hashes for a given block hash.
-func (s *SQL) UpdateBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error {
+// UpdateBlockTransactions maps the given transactions to the block at their positions in the block. Only
+// transactions which have been registered are mapped.
+func (s *SQL) UpdateBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
 	start := gocore.CurrentNanos()
 	defer func() {
 		gocore.NewStat("blocktx").NewStat("UpdateBlockTransactions").AddTime(start)
 	}()
 
+	return s.storeBlockTransactions(ctx, blockId, transactions, false)
+}
+
+// storeBlockTransactions maps the given transactions to the block within one database transaction, which is fenced by
+// the epoch of the context. If insertTransactions is true, the transactions which have not been registered are
+// inserted first.
+func (s *SQL) storeBlockTransactions(ctx context.Context, blockId uint64, transactions []*blocktx_api.BlockTransaction, insertTransactions bool) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	if len(transactions) != len(merklePaths) {
-		return fmt.Errorf("transactions (len=%d) and Merkle paths (len=%d) have not the same lengths", len(transactions), len(merklePaths))
-	}
-
-	switch s.engine {
-	case sqliteEngine:
-		fallthrough
-	case sqliteMemoryEngine:
-		return s.updateBlockTransactionsSqLite(ctx, blockId, transactions, merklePaths)
-	case postgresEngine:
-		return s.updateBlockTransactionsPostgres(ctx, blockId, transactions, merklePaths)
-	}
+	return s.inFencedTx(ctx, func(dbTx *sql.Tx, _ sql.NullInt64) error {
+		switch s.engine {
+		case sqliteEngine:
+			fallthrough
+		case sqliteMemoryEngine:
+			return s.storeBlockTransactionsSqLite(ctx, dbTx, blockId, transactions, insertTransactions)
+		case postgresEngine:
+			return s.storeBlockTransactionsPostgres(ctx, dbTx, blockId, transactions, insertTransactions)
+		}
 
-	return fmt.Errorf("engine not supported: %s", s.engine)
+		return fmt.Errorf("engine not supported: %s", s.engine)
+	})
 }
 
-func (s *SQL) updateBlockTransactionsSqLite(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error {
-	start := gocore.CurrentNanos()
-	defer func() {
-		gocore.NewStat("blocktx").NewStat("InsertBlockTransactions").AddTime(start)
-	}()
+func (s *SQL) storeBlockTransactionsSqLite(ctx context.Context, dbTx *sql.Tx, blockId uint64, transactions []*blocktx_api.BlockTransaction, insertTransactions bool) error {
+	if insertTransactions {
+		insertTxs, err := newBatchInsert(ctx, dbTx, `INSERT INTO transactions (hash) VALUES `, ` ON CONFLICT DO NOTHING`, 1)
+		if err != nil {
+			return fmt.Errorf("failed to prepare query for insertion into transactions: %v", err)
+		}
+		defer insertTxs.close()
 
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+		for _, tx := range transactions {
+			if err = insertTxs.add(ctx, tx.GetHash()); err != nil {
+				return fmt.Errorf("failed to insert transactions of block with id %d: %v", blockId, err)
+			}
+		}
 
-	qTx, err := s.db.Prepare(`
-			UPDATE transactions SET merkle_path = $2 WHERE hash = $1 RETURNING id;
-		`)
-	if err != nil {
-		return fmt.Errorf("failed to prepare query for insertion into transactions: %v", err)
+		if err = insertTxs.flush(ctx); err != nil {
+			return fmt.Errorf("failed to insert transactions of block with id %d: %v", blockId, err)
+		}
 	}
 
-	qMap := `
-		INSERT INTO block_transactions_map (
-		 blockid
-		,txid
-		,pos
-		) VALUES
-	`
+	insertMap, err := newBatchInsert(ctx, dbTx, `INSERT INTO block_transactions_map (blockid, txid, pos) VALUES `, ` ON CONFLICT DO NOTHING`, 3)
+	if err != nil {
+		return fmt.Errorf("failed to prepare query for insertion into block transactions map: %v", err)
+	}
+	defer insertMap.close()
 
-	qMapRows := make([]string, 0, len(transactions))
-	for pos, tx := range transactions {
-		var txid uint64
+	for batchStart := 0; batchStart < len(transactions); batchStart += sqliteBatchSize {
+		batch := transactions[batchStart:min(batchStart+sqliteBatchSize, len(transactions))]
 
-		err = qTx.QueryRowContext(ctx, tx.GetHash(), merklePaths[pos]).Scan(&txid)
+		registered, err := selectRegisteredTransactionsSqLite(ctx, dbTx, batch)
 		if err != nil {
-			if !errors.Is(err, sql.ErrNoRows) {
-				return fmt.Errorf("failed to execute insertion of tx %s into transactions table: %v", utils.ReverseAndHexEncodeSlice(tx.GetHash()), err)
-			}
-
-			err = s.db.QueryRowContext(ctx, `
-					SELECT id
-					FROM transactions
-					WHERE hash = $1
-				`, tx.GetHash()).Scan(&txid)
-			if err != nil {
-				return fmt.Errorf("failed to query for transactions with id %d: %v", txid, err)
-			}
+			return err
 		}
 
-		// this is ugly, but a lot faster than sprintf
-		qMapRows = append(qMapRows, " ("+strconv.FormatUint(blockId, 10)+", "+strconv.FormatUint(txid, 10)+", "+strconv.Itoa(pos)+")")
+		// only registered transactions are mapped to the block
+		for _, tx := range batch {
+			txid, found := registered[string(tx.GetHash())]
+			if !found {
+				continue
+			}
 
-		// maximum of 1000 rows per query is allowed in postgres
-		if len(qMapRows) >= 1000 {
-			if err = s.bulkInsert(ctx, qMap, qMapRows); err != nil {
+			if err = insertMap.add(ctx, blockId, txid, tx.GetPos()); err != nil {
 				return fmt.Errorf("failed to bulk insert transactions into block transactions map for block with id %d: %v", blockId, err)
 			}
-			qMapRows = qMapRows[:0]
 		}
 	}
 
-	// insert the remaining rows
-	if len(qMapRows) > 0 {
-		if err = s.bulkInsert(ctx, qMap, qMapRows); err != nil {
-			return fmt.Errorf("failed to bulk insert transactions into block transactions map for block with id %d: %v", blockId, err)
-		}
+	if err = insertMap.flush(ctx); err != nil {
+		return fmt.Errorf("failed to bulk insert transactions into block transactions map for block with id %d: %v", blockId, err)
 	}
 
 	return nil
 }
 
-func (s *SQL) bulkInsert(ctx context.Context, queryTemplate string, queryRows []string) error {
-	// remove the last comma
-	query := queryTemplate + strings.Join(queryRows, ",")
-	query += ` ON CONFLICT DO NOTHING;`
+// selectRegisteredTransactionsSqLite returns the ids of the registered transactions by their hashes.
+func selectRegisteredTransactionsSqLite(ctx context.Context, dbTx *sql.Tx, transactions []*blocktx_api.BlockTransaction) (map[string]uint64, error) {
+	args := make([]any, len(transactions))
+	for i, tx := range transactions {
+		args[i] = tx.GetHash()
+	}
 
-	// insert the block / transaction map in 1 query
-	_, err := s.db.ExecContext(ctx, query)
+	rows, err := dbTx.QueryContext(ctx, `SELECT id, hash FROM transactions WHERE hash IN `+placeholders(1, len(transactions)), args...)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to execute transaction selection query: %v", err)
 	}
+	defer rows.Close()
 
-	return nil
+	registered := make(map[string]uint64, len(transactions))
+	for rows.Next() {
+		var txid uint64
+		var hash []byte
+		if err = rows.Scan(&txid, &hash); err != nil {
+			return nil, fmt.Errorf("failed to get rows: %v", err)
+		}
+
+		registered[string(hash)] = txid
+	}
+
+	return registered, rows.Err()
 }
 
-func (s *SQL) updateBlockTransactionsPostgres(ctx context.Context, blockId uint64, transactions []*blocktx_api.TransactionAndSource, merklePaths []string) error {
-	txHashes := make([][]byte, len(transactions))
-	txHashesMap := map[string]int{}
-	for pos, tx := range transactions {
-
-		txHashes[pos] = tx.Hash
-
-		txHashesMap[hex.EncodeToString(tx.Hash)] = pos
-	}
-
-	qBulkUpdate := `
-		UPDATE transactions
-			SET
-			  merkle_path=bulk_query.merkle_path
-			FROM
-			  (
-				SELECT *
-				FROM
-				  UNNEST($1::BYTEA[], $2::TEXT[])
-				  AS t(hash, merkle_path)
-			  ) AS bulk_query
-			WHERE
-			  transactions.hash=bulk_query.hash
-		RETURNING transactions.id, transactions.hash
-`
-
-	qMap := `
-		INSERT INTO block_transactions_map (
-		 blockid
-		,txid
-		,pos
-		) SELECT * FROM UNNEST($1::INT[], $2::INT[], $3::INT[])
-		ON CONFLICT DO NOTHING
-	`
+// batchInsert inserts rows with a prepared statement of sqliteBatchSize rows. The remaining rows are inserted by a
+// separate statement when the batch is flushed.
+type batchInsert struct {
+	dbTx    *sql.Tx
+	query   string
+	suffix  string
+	columns int
+	stmt    *sql.Stmt
+	args    []any
+}
 
-	rows, err := s.db.QueryContext(ctx, qBulkUpdate, pq.Array(txHashes), pq.Array(merklePaths))
+func newBatchInsert(ctx context.Context, dbTx *sql.Tx, query string, suffix string, columns int) (*batchInsert, error) {
+	stmt, err := dbTx.PrepareContext(ctx, batchInsertQuery(query, suffix, columns, sqliteBatchSize))
 	if err != nil {
-		return fmt.Errorf("failed to execute transaction update query: %v", err)
+		return nil, err
 	}
 
-	txIDs := make([]uint64, 0)
-	blockIDs := make([]uint64, 0)
-	positions := make([]int, 0)
+	return &batchInsert{
+		dbTx:    dbTx,
+		query:   query,
+		suffix:  suffix,
+		columns: columns,
+		stmt:    stmt,
+		args:    make([]any, 0, columns*sqliteBatchSize),
+	}, nil
+}
 
-	for rows.Next() {
-		var txID uint64
-		var txHash []byte
-		err = rows.Scan(&txID, &txHash)
-		if err != nil {
-			return fmt.Errorf("failed to get rows: %v", err)
-		}
+func (b *batchInsert) add(ctx context.Context, row ...any) error {
+	b.args = append(b.args, row...)
+	if len(b.args) < b.columns*sqliteBatchSize {
+		return nil
+	}
 
-		txIDs = append(txIDs, txID)
-		blockIDs = append(blockIDs, blockId)
+	_, err := b.stmt.ExecContext(ctx, b.args...)
+	b.args = b.args[:0]
 
-		positions = append(positions, txHashesMap[hex.EncodeToString(txHash)])
+	return err
+}
 
-		if len(txIDs) >= maxPostgresBulkInsertRows {
-			_, err = s.db.ExecContext(ctx, qMap, pq.Array(blockIDs), pq.Array(txIDs), pq.Array(positions))
-			if err != nil {
-				return fmt.Errorf("failed to bulk insert transactions into block transactions map for block with id %d: %v", blockId, err)
+func (b *batchInsert) flush(ctx context.Context) error {
+	if len(b.args) == 0 {
+		return nil
+	}
+
+	_, err := b.dbTx.ExecContext(ctx, batchInsertQuery(b.query, b.suffix, b.columns, len(b.args)/b.columns), b.args...)
+	b.args = b.args[:0]
+
+	return err
+}
+
+func (b *batchInsert) close() {
+	_ = b.stmt.Close()
+}
+
+// batchInsertQuery returns the insert query of the given number of rows.
+func batchInsertQuery(query string, suffix string, columns int, rows int) string {
+	return query + placeholders(rows, columns) + suffix
+}
+
+// placeholders returns the placeholders of the given number of rows, e.g. (?, ?), (?, ?). SQLite binds anonymous
+// placeholders faster than numbered ones.
+func placeholders(rows int, columns int) string {
+	var sb strings.Builder
+
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString("(")
+		for column := 0; column < columns; column++ {
+			if column > 0 {
+				sb.WriteString(", ")
 			}
-			txIDs = make([]uint64, 0)
-			blockIDs = make([]uint64, 0)
+			sb.WriteString("?")
 		}
+		sb.WriteString(")")
 	}
 
-	if len(txIDs) > 0 {
-		_, err = s.db.ExecContext(ctx, qMap, pq.Array(blockIDs), pq.Array(txIDs), pq.Array(positions))
+	return sb.String()
+}
+
+// storeBlockTransactionsPostgres copies the transactions into a temporary table, from which the transactions are
+// inserted and mapped to the block by one statement each.
+func (s *SQL) storeBlockTransactionsPostgres(ctx context.Context, dbTx *sql.Tx, blockId uint64, transactions []*blocktx_api.BlockTransaction, insertTransactions bool) error {
+	_, err := dbTx.ExecContext(ctx, `
+		CREATE TEMPORARY TABLE block_transactions_copy (
+		 hash BYTEA NOT NULL
+		,pos BIGINT NOT NULL
+		) ON COMMIT DROP
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary table for block transactions: %v", err)
+	}
+
+	stmt, err := dbTx.PrepareContext(ctx, pq.CopyIn("block_transactions_copy", "hash", "pos"))
+	if err != nil {
+		return fmt.Errorf("failed to prepare copy of block transactions: %v", err)
+	}
+
+	for _, tx := range transactions {
+		if _, err = stmt.ExecContext(ctx, tx.GetHash(), int64(tx.GetPos())); err != nil {
+			_ = stmt.Close()
+			return fmt.Errorf("failed to copy transactions of block with id %d: %v", blockId, err)
+		}
+	}
+
+	// the copy is finished by an execution without arguments
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		_ = stmt.Close()
+		return fmt.Errorf("failed to copy transactions of block with id %d: %v", blockId, err)
+	}
+
+	if err = stmt.Close(); err != nil {
+		return fmt.Errorf("failed to copy transactions of block with id %d: %v", blockId, err)
+	}
+
+	if insertTransactions {
+		_, err = dbTx.ExecContext(ctx, `
+			INSERT INTO transactions (hash)
+			SELECT hash FROM block_transactions_copy
+			ON CONFLICT DO NOTHING
+		`)
 		if err != nil {
-			return fmt.Errorf("failed to bulk insert transactions into block transactions map for block with id %d: %v", blockId, err)
+			return fmt.Errorf("failed to bulk insert transactions of block with id %d: %v", blockId, err)
 		}
 	}
 
+	// only registered transactions are mapped to the block
+	_, err = dbTx.ExecContext(ctx, `
+		INSERT INTO block_transactions_map (
+		 blockid
+		,txid
+		,pos
+		) SELECT $1, t.id, c.pos
+		FROM block_transactions_copy c
+		JOIN transactions t ON t.hash = c.hash
+		ON CONFLICT DO NOTHING
+	`, blockId)
+	if err != nil {
+		return fmt.Errorf("failed to bulk insert transactions into block transactions map for block with id %d: %v", blockId, err)
+	}
+
 	return nil
 }
@@ -3,6 +3,9 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
 	"github.com/go-testfixtures/testfixtures/v3"
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
 	"testing"
@@ -11,6 +14,7 @@ import (
 	"github.com/bitcoin-sv/arc/blocktx/store"
 	"github.com/bitcoin-sv/arc/database_testing"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -43,7 +47,6 @@ func (s *UpdateBlockTransactionsSuite) Test() {
 
 	err = fixtures.Load()
 	require.NoError(s.T(), err)
-	testMerklePaths := []string{"test1", "test2", "test3"}
 	testBlockID := uint64(9736)
 
 	txHash1, err := chainhash.NewHashFromStr("3b8c1676470f44043069f66fdc0d5df9bdad1865a8f03b8da1268359802b7376")
@@ -55,31 +58,20 @@ func (s *UpdateBlockTransactionsSuite) Test() {
 	txHashNotRegistered, err := chainhash.NewHashFromStr("edd33fdcdfa68444d227780e2b62a4437c00120c5320d2026aeb24a781f4c3f1")
 	require.NoError(s.T(), err)
 
-	err = st.UpdateBlockTransactions(context.Background(), testBlockID, []*blocktx_api.TransactionAndSource{
+	err = st.UpdateBlockTransactions(context.Background(), testBlockID, []*blocktx_api.BlockTransaction{
 		{
 			Hash: txHash1[:],
+			Pos:  1000,
 		},
 		{
 			Hash: txHash2[:],
+			Pos:  1001,
 		},
 		{
 			Hash: txHashNotRegistered[:],
+			Pos:  1002,
 		},
-	}, []string{"test1"})
-
-	require.ErrorContains(s.T(), err, "transactions (len=3) and Merkle paths (len=1) have not the same lengths")
-
-	err = st.UpdateBlockTransactions(context.Background(), testBlockID, []*blocktx_api.TransactionAndSource{
-		{
-			Hash: txHash1[:],
-		},
-		{
-			Hash: txHash2[:],
-		},
-		{
-			Hash: txHashNotRegistered[:],
-		},
-	}, testMerklePaths)
+	})
 	require.NoError(s.T(), err)
 
 	d, err := sqlx.Open("postgres", database_testing.DefaultParams.String())
@@ -91,7 +83,6 @@ func (s *UpdateBlockTransactionsSuite) Test() {
 	require.NoError(s.T(), err)
 
 	require.Equal(s.T(), txHash1[:], storedtx.Hash)
-	require.Equal(s.T(), testMerklePaths[0], storedtx.MerklePath)
 
 	var mp store.BlockTransactionMap
 	err = d.Get(&mp, "SELECT blockid, txid, pos from block_transactions_map WHERE txid=$1", storedtx.ID)
@@ -99,6 +90,7 @@ func (s *UpdateBlockTransactionsSuite) Test() {
 
 	require.Equal(s.T(), storedtx.ID, mp.TransactionID)
 	require.Equal(s.T(), testBlockID, uint64(mp.BlockID))
+	require.Equal(s.T(), int64(1000), mp.Pos)
 
 	var storedtx2 Tx
 
@@ -106,7 +98,6 @@ func (s *UpdateBlockTransactionsSuite) Test() {
 	require.NoError(s.T(), err)
 
 	require.Equal(s.T(), txHash2[:], storedtx2.Hash)
-	require.Equal(s.T(), testMerklePaths[1], storedtx2.MerklePath)
 
 	var mp2 store.BlockTransactionMap
 	err = d.Get(&mp2, "SELECT blockid, txid, pos from block_transactions_map WHERE txid=$1", storedtx2.ID)
@@ -114,6 +105,7 @@ func (s *UpdateBlockTransactionsSuite) Test() {
 
 	require.Equal(s.T(), storedtx2.ID, mp2.TransactionID)
 	require.Equal(s.T(), testBlockID, uint64(mp2.BlockID))
+	require.Equal(s.T(), int64(1001), mp2.Pos)
 
 }
 
@@ -121,3 +113,219 @@ func TestUpdateBlockTransactionsSuite(t *testing.T) {
 	s := new(UpdateBlockTransactionsSuite)
 	suite.Run(t, s)
 }
+
+// blockTransactions returns count transactions with distinct hashes starting at the given number.
+func blockTransactions(from int, count int) []*blocktx_api.BlockTransaction {
+	transactions := make([]*blocktx_api.BlockTransaction, count)
+	b := make([]byte, 8)
+	for i := range transactions {
+		binary.LittleEndian.PutUint64(b, uint64(from+i))
+		transactions[i] = &blocktx_api.BlockTransaction{Hash: chainhash.DoubleHashB(b), Pos: uint64(i)}
+	}
+
+	return transactions
+}
+
+func TestUpdateBlockTransactionsSqLite(t *testing.T) {
+	tt := []struct {
+		name               string
+		insertTransactions bool
+
+		expectedMapped int
+	}{
+		{
+			name: "registered transactions only",
+
+			expectedMapped: 125,
+		},
+		{
+			name:               "insert transactions",
+			insertTransactions: true,
+
+			expectedMapped: 250,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			s, err := New("sqlite_memory")
+			require.NoError(t, err)
+
+			blockId, err := s.InsertBlock(ctx, &blocktx_api.Block{Hash: []byte(tc.name), PreviousHash: []byte("prevhash"), MerkleRoot: []byte("merkleroot"), Height: 1})
+			require.NoError(t, err)
+
+			// more than two batches with every other transaction registered
+			transactions := blockTransactions(0, 250)
+			for i := 0; i < len(transactions); i += 2 {
+				err = s.RegisterTransaction(ctx, &blocktx_api.TransactionAndSource{Hash: transactions[i].GetHash(), Source: "TEST"})
+				require.NoError(t, err)
+			}
+
+			if tc.insertTransactions {
+				err = s.InsertBlockTransactions(ctx, blockId, transactions)
+			} else {
+				err = s.UpdateBlockTransactions(ctx, blockId, transactions)
+			}
+			require.NoError(t, err)
+
+			var mapped int
+			err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM block_transactions_map WHERE blockid = $1", blockId).Scan(&mapped)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMapped, mapped)
+
+			var pos uint64
+			err = s.db.QueryRowContext(ctx, `
+				SELECT m.pos FROM block_transactions_map m
+				JOIN transactions t ON t.id = m.txid
+				WHERE m.blockid = $1 AND t.hash = $2`, blockId, transactions[248].GetHash()).Scan(&pos)
+			require.NoError(t, err)
+			require.Equal(t, uint64(248), pos)
+
+			// storing the transactions again does not fail
+			err = s.UpdateBlockTransactions(ctx, blockId, transactions)
+			require.NoError(t, err)
+		})
+	}
+}
+
+// updateBlockTransactionsUnnest maps the registered transactions to the block by selecting their ids and inserting
+// the map in batches of UNNEST statements. It is the baseline of BenchmarkUpdateBlockTransactions.
+func updateBlockTransactionsUnnest(ctx context.Context, db *sql.DB, blockId uint64, transactions []*blocktx_api.BlockTransaction) error {
+	const batchSize = 1000
+
+	txHashes := make([][]byte, len(transactions))
+	txPositions := map[string]uint64{}
+	for i, tx := range transactions {
+		txHashes[i] = tx.GetHash()
+		txPositions[hex.EncodeToString(tx.GetHash())] = tx.GetPos()
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT id, hash FROM transactions WHERE hash = ANY($1)`, pq.Array(txHashes))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	qMap := `
+		INSERT INTO block_transactions_map (blockid, txid, pos)
+		SELECT * FROM UNNEST($1::INT[], $2::INT[], $3::INT[])
+		ON CONFLICT DO NOTHING
+	`
+
+	blockIDs := make([]uint64, 0, batchSize)
+	txIDs := make([]uint64, 0, batchSize)
+	positions := make([]uint64, 0, batchSize)
+
+	for rows.Next() {
+		var txID uint64
+		var txHash []byte
+		if err = rows.Scan(&txID, &txHash); err != nil {
+			return err
+		}
+
+		blockIDs = append(blockIDs, blockId)
+		txIDs = append(txIDs, txID)
+		positions = append(positions, txPositions[hex.EncodeToString(txHash)])
+
+		if len(txIDs) >= batchSize {
+			if _, err = db.ExecContext(ctx, qMap, pq.Array(blockIDs), pq.Array(txIDs), pq.Array(positions)); err != nil {
+				return err
+			}
+			blockIDs, txIDs, positions = blockIDs[:0], txIDs[:0], positions[:0]
+		}
+	}
+
+	if len(txIDs) > 0 {
+		_, err = db.ExecContext(ctx, qMap, pq.Array(blockIDs), pq.Array(txIDs), pq.Array(positions))
+	}
+
+	return err
+}
+
+// BenchmarkUpdateBlockTransactions compares the mapping of registered transactions to a block by COPY with the UNNEST
+// statements on Postgres and by prepared batch statements on SQLite. The Postgres benchmarks need the database of
+// the database_testing package and are skipped if it is not available.
+func BenchmarkUpdateBlockTransactions(b *testing.B) {
+	ctx := context.Background()
+
+	sqlite, err := New("sqlite_memory")
+	require.NoError(b, err)
+
+	var postgres *SQL
+	db, err := sql.Open("postgres", database_testing.DefaultParams.String())
+	require.NoError(b, err)
+	if err = db.PingContext(ctx); err == nil {
+		database_testing.MigrateUpBlockTX(b)
+		postgres = &SQL{db: db, engine: postgresEngine}
+	}
+
+	for _, txCount := range []int{1_000, 10_000, 100_000} {
+		transactions := blockTransactions(0, txCount)
+
+		benchmarks := []struct {
+			name   string
+			store  *SQL
+			update func(s *SQL, blockId uint64) error
+		}{
+			{
+				name:  "postgres copy",
+				store: postgres,
+				update: func(s *SQL, blockId uint64) error {
+					return s.UpdateBlockTransactions(ctx, blockId, transactions)
+				},
+			},
+			{
+				name:  "postgres unnest",
+				store: postgres,
+				update: func(s *SQL, blockId uint64) error {
+					return updateBlockTransactionsUnnest(ctx, s.db, blockId, transactions)
+				},
+			},
+			{
+				name:  "sqlite prepared batch",
+				store: sqlite,
+				update: func(s *SQL, blockId uint64) error {
+					return s.UpdateBlockTransactions(ctx, blockId, transactions)
+				},
+			},
+		}
+
+		for _, bm := range benchmarks {
+			b.Run(fmt.Sprintf("%s %d txs", bm.name, txCount), func(b *testing.B) {
+				if bm.store == nil {
+					b.Skip("postgres is not available")
+				}
+
+				_, err = bm.store.db.ExecContext(ctx, "DELETE FROM block_transactions_map")
+				require.NoError(b, err)
+				_, err = bm.store.db.ExecContext(ctx, "DELETE FROM transactions")
+				require.NoError(b, err)
+				_, err = bm.store.db.ExecContext(ctx, "DELETE FROM blocks")
+				require.NoError(b, err)
+
+				// all transactions of the block are registered
+				registrationBlockId, err := bm.store.InsertBlock(ctx, &blocktx_api.Block{Hash: []byte("registration"), PreviousHash: []byte("prevhash"), MerkleRoot: []byte("merkleroot"), Height: 0})
+				require.NoError(b, err)
+				require.NoError(b, bm.store.InsertBlockTransactions(ctx, registrationBlockId, transactions))
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					blockId, err := bm.store.InsertBlock(ctx, &blocktx_api.Block{
+						Hash:         []byte(fmt.Sprintf("%s %d %d", bm.name, txCount, i)),
+						PreviousHash: []byte("prevhash"),
+						MerkleRoot:   []byte("merkleroot"),
+						Height:       uint64(i + 1),
+					})
+					require.NoError(b, err)
+					b.StartTimer()
+
+					require.NoError(b, bm.update(bm.store, blockId))
+				}
+			})
+		}
+	}
+}
@@ -16,8 +16,10 @@ import (
 )
 
 type APIBroadcaster struct {
-	arcServer string
-	auth      *Auth
+	arcServer     string
+	auth          *Auth
+	callbackURL   string
+	callbackToken string
 }
 
 type Auth struct {
@@ -33,11 +35,25 @@ type Response struct {
 	BlockHash   string `json:"blockHash"`
 }
 
-func NewHTTPBroadcaster(arcServer string, auth *Auth) *APIBroadcaster {
-	return &APIBroadcaster{
+// WithCallback requests the callbacks of the submitted transactions to be sent to the given URL with the given token.
+func WithCallback(url string, token string) func(*APIBroadcaster) {
+	return func(a *APIBroadcaster) {
+		a.callbackURL = url
+		a.callbackToken = token
+	}
+}
+
+func NewHTTPBroadcaster(arcServer string, auth *Auth, opts ...func(*APIBroadcaster)) *APIBroadcaster {
+	a := &APIBroadcaster{
 		arcServer: arcServer,
 		auth:      auth,
 	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
 }
 
 func (a *APIBroadcaster) BroadcastTransactions(ctx context.Context, txs []*bt.Tx, waitFor metamorph_api.Status) ([]*metamorph_api.TransactionStatus, error) {
@@ -50,6 +66,10 @@ func (a *APIBroadcaster) BroadcastTransactions(ctx context.Context, txs []*bt.Tx
 	params := &api.POSTTransactionsParams{
 		XWaitForStatus: &waitForStatus,
 	}
+	if a.callbackURL != "" {
+		params.XCallbackUrl = &a.callbackURL
+		params.XCallbackToken = &a.callbackToken
+	}
 
 	var body []byte
 	for _, tx := range txs {
@@ -113,6 +133,10 @@ func (a *APIBroadcaster) BroadcastTransaction(ctx context.Context, tx *bt.Tx, wa
 	params := &api.POSTTransactionParams{
 		XWaitForStatus: &waitForStatus,
 	}
+	if a.callbackURL != "" {
+		params.XCallbackUrl = &a.callbackURL
+		params.XCallbackToken = &a.callbackToken
+	}
 
 	arcBody := api.POSTTransactionJSONRequestBody{
 		RawTx: hex.EncodeToString(tx.ExtendedBytes()),
@@ -180,5 +204,36 @@ func (a *APIBroadcaster) getArcClient() (*api.ClientWithResponses, error) {
 }
 
 func (a *APIBroadcaster) GetTransactionStatus(ctx context.Context, txID string) (*metamorph_api.TransactionStatus, error) {
-	return nil, nil
+	arcClient, err := a.getArcClient()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := arcClient.GETTransactionStatusWithResponse(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.JSON200 == nil {
+		return nil, errors.New("error: " + response.Status())
+	}
+
+	res := &metamorph_api.TransactionStatus{
+		Txid: response.JSON200.Txid,
+	}
+
+	if response.JSON200.TxStatus != nil {
+		res.Status = metamorph_api.Status(metamorph_api.Status_value[*response.JSON200.TxStatus])
+	}
+	if response.JSON200.BlockHeight != nil {
+		res.BlockHeight = *response.JSON200.BlockHeight
+	}
+	if response.JSON200.BlockHash != nil {
+		res.BlockHash = *response.JSON200.BlockHash
+	}
+	if response.JSON200.ExtraInfo != nil {
+		res.RejectReason = *response.JSON200.ExtraInfo
+	}
+
+	return res, nil
 }
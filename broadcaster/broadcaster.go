@@ -7,7 +7,9 @@ import (
 	"math"
 	"net/url"
 	"runtime"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bitcoin-sv/arc/api"
@@ -17,6 +19,7 @@ import (
 	"github.com/labstack/gommon/random"
 	"github.com/libsv/go-bk/base58"
 	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
 	"github.com/libsv/go-bt/v2/unlocker"
 	"github.com/ordishs/go-bitcoin"
 	"github.com/ordishs/go-utils"
@@ -55,6 +58,12 @@ type ClientI interface {
 	GetTransactionStatus(ctx context.Context, txID string) (*metamorph_api.TransactionStatus, error)
 }
 
+type UtxoPool interface {
+	GetUnspent(ctx context.Context, script *bscript.Script, satoshis uint64) ([]*bt.UTXO, error)
+	Spend(ctx context.Context, tx *bt.Tx) error
+	Reject(ctx context.Context, txID string) error
+}
+
 type Broadcaster struct {
 	logger        utils.Logger
 	Client        ClientI
@@ -73,6 +82,28 @@ type Broadcaster struct {
 	FeeQuote      *bt.FeeQuote
 	summary       map[string]uint64
 	summaryMu     sync.Mutex
+
+	// OpReturnMix generates transactions with OP_RETURN outputs of the payload sizes of the mix
+	OpReturnMix *OpReturnMix
+	// LoadProfile sends the transactions at the rates of the profile instead of as fast as possible
+	LoadProfile *LoadProfile
+	// UtxoPool records the outputs of the sent transactions and funds the funding transactions instead of WhatsOnChain
+	UtxoPool UtxoPool
+	// Chain sends a tree of unconfirmed transactions built from one funding UTXO instead of the batches
+	Chain *ChainConfig
+	// DoubleSpend sends groups of conflicting transactions instead of the batches
+	DoubleSpend *DoubleSpendConfig
+	// CallbackAddress is the address on which the callbacks of the submitted transactions are received
+	CallbackAddress string
+	// PollInterval is the interval in which the statuses of the submitted transactions are polled
+	PollInterval time.Duration
+	// StatusTimeout is the time to wait for the submitted transactions to be mined
+	StatusTimeout time.Duration
+
+	latency *latencyRecorder
+	sent    atomic.Uint64
+	errors  atomic.Uint64
+	report  *Report
 }
 
 func New(logger utils.Logger, client ClientI, fromKeySet *keyset.KeySet, toKeySet *keyset.KeySet, outputs int64) *Broadcaster {
@@ -92,6 +123,7 @@ func New(logger utils.Logger, client ClientI, fromKeySet *keyset.KeySet, toKeySe
 		WaitForStatus: 0,
 		FeeQuote:      fq,
 		summary:       make(map[string]uint64),
+		latency:       newLatencyRecorder(),
 	}
 }
 
@@ -157,6 +189,25 @@ func (b *Broadcaster) ConsolidateOutputsToOriginal(ctx context.Context, txs []*b
 }
 
 func (b *Broadcaster) Run(ctx context.Context, concurrency int) error {
+	if b.Chain != nil {
+		if err := b.runChain(ctx); err != nil {
+			return err
+		}
+
+		b.logSummary()
+
+		return nil
+	}
+
+	if b.DoubleSpend != nil {
+		if err := b.runDoubleSpend(ctx); err != nil {
+			return err
+		}
+
+		b.logSummary()
+
+		return nil
+	}
 
 	timeStart := time.Now()
 
@@ -186,8 +237,11 @@ func (b *Broadcaster) Run(ctx context.Context, concurrency int) error {
 		}
 		b.logger.Infof("[%d] funding tx: %s", iteration, fundingTx.TxID())
 
+		b.spendUtxos(ctx, fundingTx)
+
 		_, err := b.Client.BroadcastTransaction(ctx, fundingTx, metamorph_api.Status(b.WaitForStatus))
 		if err != nil {
+			b.rejectUtxos(ctx, fundingTx.TxID())
 			b.logger.Fatalf("[%d] error broadcasting funding tx: %s", iteration, err.Error())
 		}
 
@@ -196,12 +250,59 @@ func (b *Broadcaster) Run(ctx context.Context, concurrency int) error {
 
 	b.logger.Infof("Created %d funding batches in %0.2f seconds", batches, time.Since(timeStart).Seconds())
 
+	if b.CallbackAddress != "" {
+		stopCallbackServer, err := b.startCallbackServer(b.CallbackAddress)
+		if err != nil {
+			return fmt.Errorf("failed to start callback server: %v", err)
+		}
+		defer stopCallbackServer()
+	}
+
 	// wait for things to settle down
 	time.Sleep(2 * time.Second)
 
 	// start the timer for the transaction sending
 	timeStart = time.Now()
 
+	if b.LoadProfile != nil {
+		b.runProfile(ctx, concurrency, fundingTxs)
+	} else {
+		b.runBatches(ctx, concurrency, fundingTxs)
+	}
+
+	sendDuration := time.Since(timeStart)
+	b.logger.Infof("sent %d txs in %0.2f seconds", b.sent.Load(), sendDuration.Seconds())
+
+	b.waitForStatuses(ctx)
+
+	b.report = b.newReport(timeStart, sendDuration)
+
+	b.logSummary()
+
+	return nil
+}
+
+// logSummary logs the statuses and latencies of the last run.
+func (b *Broadcaster) logSummary() {
+	// print summary
+	b.logger.Infof("Summary:")
+	totalCount := uint64(0)
+	for summaryString, count := range b.summary {
+		b.logger.Infof("%s: %d", summaryString, count)
+		totalCount += count
+	}
+	b.logger.Infof("Total: %d", totalCount)
+
+	b.logger.Infof("Latencies:")
+	for _, stage := range latencyStages {
+		summary := b.report.Latencies[stage]
+		b.logger.Infof("  %s: count %d, p50 %0.1fms, p95 %0.1fms, p99 %0.1fms", stage, summary.Count, summary.P50Ms, summary.P95Ms, summary.P99Ms)
+	}
+
+}
+
+// runBatches sends the transactions of the funding transactions in batches of b.BatchSize.
+func (b *Broadcaster) runBatches(ctx context.Context, concurrency int, fundingTxs []*bt.Tx) {
 	// loop through the number of outputs in batches of b.BatchSize (default 500)
 	var wg sync.WaitGroup
 	limit := make(chan struct{}, runtime.NumCPU())
@@ -233,22 +334,77 @@ func (b *Broadcaster) Run(ctx context.Context, concurrency int) error {
 		}(i)
 	}
 	wg.Wait()
+}
 
-	b.logger.Infof("sent %d txs in %0.2f seconds", b.Outputs, time.Since(timeStart).Seconds())
+// runProfile sends the transactions of all funding transactions at the rates of the load profile. It stops when the
+// profile has ended or all transactions have been sent.
+func (b *Broadcaster) runProfile(ctx context.Context, concurrency int, fundingTxs []*bt.Tx) {
+	batches := make([][]*bt.Tx, len(fundingTxs))
+	txs := make([]*bt.Tx, 0, b.Outputs)
+	for i, fundingTx := range fundingTxs {
+		batches[i] = b.newBatchTransactions(fundingTx)
+		txs = append(txs, batches[i]...)
+	}
 
-	// print summary
-	b.logger.Infof("Summary:")
-	totalCount := uint64(0)
-	for summaryString, count := range b.summary {
-		b.logger.Infof("%s: %d", summaryString, count)
-		totalCount += count
+	expected := int(b.LoadProfile.Transactions(b.LoadProfile.Duration()))
+	if expected > len(txs) {
+		b.logger.Warnf("load profile %s needs %d transactions, only %d are available", b.LoadProfile.String(), expected, len(txs))
 	}
-	b.logger.Infof("Total: %d", totalCount)
 
-	return nil
+	b.logger.Infof("running load profile %s for %s", b.LoadProfile.String(), b.LoadProfile.Duration())
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, concurrency)
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	start := time.Now()
+	next := 0
+	for next < len(txs) {
+		elapsed := time.Since(start)
+		if elapsed >= b.LoadProfile.Duration() {
+			break
+		}
+
+		// the transactions which are due are sent, if the concurrency limit is reached the rate falls behind the profile
+		due := min(int(b.LoadProfile.Transactions(elapsed)), len(txs))
+		for ; next < due; next++ {
+			wg.Add(1)
+			limit <- struct{}{}
+
+			go func(tx *bt.Tx) {
+				defer func() {
+					wg.Done()
+					<-limit
+				}()
+
+				if err := b.ProcessTransaction(ctx, tx, 0); err != nil {
+					b.logger.Infof("Error in %s: %s", tx.TxID(), err.Error())
+					// mark the tx as invalid
+					tx.Version = 0
+				}
+			}(txs[next])
+		}
+
+		select {
+		case <-ctx.Done():
+			next = len(txs)
+		case <-ticker.C:
+		}
+	}
+	wg.Wait()
+
+	if b.Consolidate {
+		for i, batch := range batches {
+			if err := b.ConsolidateOutputsToOriginal(ctx, batch, int64(i+1)); err != nil {
+				panic(err)
+			}
+		}
+	}
 }
 
-func (b *Broadcaster) runBatch(ctx context.Context, concurrency int, fundingTx *bt.Tx, iteration int64) error {
+func (b *Broadcaster) newBatchTransactions(fundingTx *bt.Tx) []*bt.Tx {
 	txs := make([]*bt.Tx, len(fundingTx.Outputs)-1)
 	for i := 0; i < len(fundingTx.Outputs)-1; i++ {
 		u := &bt.UTXO{
@@ -260,6 +416,44 @@ func (b *Broadcaster) runBatch(ctx context.Context, concurrency int, fundingTx *
 		txs[i] = b.NewTransaction(b.ToKeySet, u)
 	}
 
+	return txs
+}
+
+// Report returns the report of the last run.
+func (b *Broadcaster) Report() *Report {
+	return b.report
+}
+
+func (b *Broadcaster) newReport(start time.Time, sendDuration time.Duration) *Report {
+	report := &Report{
+		Start:           start,
+		DurationSeconds: sendDuration.Seconds(),
+		Sent:            b.sent.Load(),
+		Errors:          b.errors.Load(),
+		Statuses:        make(map[string]uint64),
+		Latencies:       b.latency.summaries(),
+	}
+
+	if b.LoadProfile != nil {
+		report.Profile = b.LoadProfile.String()
+	}
+
+	if sendDuration > 0 {
+		report.RatePerSecond = float64(report.Sent) / sendDuration.Seconds()
+	}
+
+	b.summaryMu.Lock()
+	for summaryString, count := range b.summary {
+		report.Statuses[strings.TrimSpace(summaryString)] = count
+	}
+	b.summaryMu.Unlock()
+
+	return report
+}
+
+func (b *Broadcaster) runBatch(ctx context.Context, concurrency int, fundingTx *bt.Tx, iteration int64) error {
+	txs := b.newBatchTransactions(fundingTx)
+
 	if b.IsDryRun {
 		for _, tx := range txs {
 			// b.logger.Infof("Processing tx %d / %d", i+1, len(b.txs))
@@ -283,13 +477,36 @@ func (b *Broadcaster) runBatch(ctx context.Context, concurrency int, fundingTx *
 				go func(txs []*bt.Tx, indexStart, indexEnd int) {
 					defer wg.Done()
 
+					txIDs := make([]string, len(txs))
+					submittedAt := time.Now()
+					for i, tx := range txs {
+						txIDs[i] = tx.TxID()
+						b.latency.submit(txIDs[i], submittedAt)
+					}
+
+					b.spendUtxos(ctx, txs...)
+
 					txStatus, err := b.Client.BroadcastTransactions(ctx, txs, metamorph_api.Status(b.WaitForStatus))
 					if err != nil {
+						b.errors.Add(uint64(len(txs)))
+						for _, txID := range txIDs {
+							b.latency.discard(txID)
+						}
+						b.rejectUtxos(ctx, txIDs...)
 						b.logger.Errorf("[%d]   batch of %d - %d failed %s", iteration, indexStart, indexEnd, err.Error())
 					} else {
+						b.sent.Add(uint64(len(txs)))
 						b.logger.Infof("[%d]   batch of %d - %d successful", iteration, indexStart, indexEnd)
 					}
-					for _, res := range txStatus {
+
+					respondedAt := time.Now()
+					for i, res := range txStatus {
+						if res == nil {
+							continue
+						}
+						if i < len(txIDs) {
+							b.latency.response(txIDs[i], res.GetStatus(), respondedAt)
+						}
 						b.processResult(res, iteration)
 					}
 				}(txs[i:j], i, j)
@@ -331,11 +548,20 @@ func (b *Broadcaster) runBatch(ctx context.Context, concurrency int, fundingTx *
 }
 
 func (b *Broadcaster) ProcessTransaction(ctx context.Context, tx *bt.Tx, iteration int64) error {
+	txID := tx.TxID()
+	b.latency.submit(txID, time.Now())
+	b.spendUtxos(ctx, tx)
+
 	res, err := b.Client.BroadcastTransaction(ctx, tx, metamorph_api.Status(b.WaitForStatus))
 	if err != nil {
-		return fmt.Errorf("error broadcasting transaction %s: %s", tx.TxID(), err.Error())
+		b.errors.Add(1)
+		b.latency.discard(txID)
+		b.rejectUtxos(ctx, txID)
+		return fmt.Errorf("error broadcasting transaction %s: %s", txID, err.Error())
 	}
 
+	b.sent.Add(1)
+	b.latency.response(txID, res.GetStatus(), time.Now())
 	b.processResult(res, iteration)
 
 	return nil
@@ -352,6 +578,8 @@ func (b *Broadcaster) processResult(res *metamorph_api.TransactionStatus, iterat
 		}
 	}
 
+	b.reconcileUtxos(context.Background(), res.Txid, res.Status)
+
 	summaryString := "  " + res.Status.String()
 	if res.TimedOut {
 		summaryString += " (TIMEOUT)"
@@ -370,11 +598,51 @@ func (b *Broadcaster) NewFundingTransaction(outputs, iteration int64) *bt.Tx {
 	fq.AddQuote(bt.FeeTypeStandard, stdFee)
 	fq.AddQuote(bt.FeeTypeData, dataFee)
 
+	tx := bt.NewTx()
+
+	estimateFee := fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, 1)
+	outputSatoshis := make([]uint64, outputs)
+	totalSatoshis := fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, uint64(outputs)+1)
+	for i := range outputSatoshis {
+		// the data fee of the OP_RETURN output of the spending transaction is added to each output
+		opReturnFee := b.opReturnFee(uint32(i))
+
+		if b.Consolidate {
+			// we send triple the fee to the output arcUrl
+			// this will allow us to send the change back to the original arcUrl
+			outputSatoshis[i] = estimateFee*3 + opReturnFee
+		} else {
+			outputSatoshis[i] = estimateFee + 1 + opReturnFee // add 1 satoshi to allow for our longer OP_RETURN
+		}
+		totalSatoshis += outputSatoshis[i]
+	}
+
+	err := b.addFundingInputs(tx, iteration, totalSatoshis)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, satoshis := range outputSatoshis {
+		_ = tx.PayTo(b.ToKeySet.Script, satoshis)
+	}
+
+	_ = tx.Change(b.FromKeySet.Script, fq)
+
+	unlockerGetter := unlocker.Getter{PrivateKey: b.FromKeySet.PrivateKey}
+	if err = tx.FillAllInputs(context.Background(), &unlockerGetter); err != nil {
+		panic(err)
+	}
+
+	return tx
+}
+
+// addFundingInputs adds the inputs which fund the transaction with at least the given satoshis. In regtest mode the node
+// sends 1 BSV to the funding key. Otherwise the unspent outputs of the UTXO pool are spent if they suffice, or else all
+// UTXOs of the funding key.
+func (b *Broadcaster) addFundingInputs(tx *bt.Tx, iteration int64, satoshis uint64) error {
 	var err error
 	addr := b.FromKeySet.Address(!b.IsRegtest)
 
-	tx := bt.NewTx()
-
 	if b.IsRegtest {
 		var txid string
 		var vout uint32
@@ -392,48 +660,33 @@ func (b *Broadcaster) NewFundingTransaction(outputs, iteration int64) *bt.Tx {
 			b.logger.Infof(".")
 			time.Sleep(100 * time.Millisecond)
 		}
-		err = tx.From(txid, vout, scriptPubKey, 100_000_000)
-		if err != nil {
-			panic(err)
-		}
-	} else {
-		// live mode, we need to get the utxos from woc
-		var utxos []*bt.UTXO
-		utxos, err = b.FromKeySet.GetUTXOs(!b.IsTestnet)
-		if err != nil {
-			panic(err)
-		}
-		if len(utxos) == 0 {
-			panic("no utxos for arcUrl: " + addr)
-		}
 
-		// this consumes all the utxos from the key
-		err = tx.FromUTXOs(utxos...)
+		return tx.From(txid, vout, scriptPubKey, 100_000_000)
+	}
+
+	if b.UtxoPool != nil {
+		added, err := b.addPoolFundingInputs(tx, satoshis)
 		if err != nil {
-			panic(err)
+			return err
+		}
+		if added {
+			return nil
 		}
 
+		b.logger.Warnf("[%d] UTXO pool has less than %d satoshis, funding from WhatsOnChain", iteration, satoshis)
 	}
 
-	estimateFee := fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, 1)
-	for i := int64(0); i < outputs; i++ {
-		if b.Consolidate {
-			// we send triple the fee to the output arcUrl
-			// this will allow us to send the change back to the original arcUrl
-			_ = tx.PayTo(b.ToKeySet.Script, estimateFee*3)
-		} else {
-			_ = tx.PayTo(b.ToKeySet.Script, estimateFee+1) // add 1 satoshi to allow for our longer OP_RETURN
-		}
+	// live mode, we need to get the utxos from woc
+	utxos, err := b.FromKeySet.GetUTXOs(!b.IsTestnet)
+	if err != nil {
+		return err
 	}
-
-	_ = tx.Change(b.FromKeySet.Script, fq)
-
-	unlockerGetter := unlocker.Getter{PrivateKey: b.FromKeySet.PrivateKey}
-	if err = tx.FillAllInputs(context.Background(), &unlockerGetter); err != nil {
-		panic(err)
+	if len(utxos) == 0 {
+		return errors.New("no utxos for arcUrl: " + addr)
 	}
 
-	return tx
+	// this consumes all the utxos from the key
+	return tx.FromUTXOs(utxos...)
 }
 
 func (b *Broadcaster) NewTransaction(key *keyset.KeySet, useUtxo *bt.UTXO) *bt.Tx {
@@ -443,13 +696,16 @@ func (b *Broadcaster) NewTransaction(key *keyset.KeySet, useUtxo *bt.UTXO) *bt.T
 	_ = tx.FromUTXOs(useUtxo)
 
 	if b.Consolidate {
-		// the output value of the utxo should be exactly 3x the fee
+		// the output value of the utxo should be exactly 3x the fee plus the data fee
 		// in this way we can consolidate the output back into the original arcUrl
 		// even for the smallest transactions with only 1 output
-		_ = tx.PayTo(key.Script, 2*useUtxo.Satoshis/3)
+		_ = tx.PayTo(key.Script, 2*(useUtxo.Satoshis-b.opReturnFee(useUtxo.Vout))/3)
+		if b.OpReturnMix != nil {
+			_ = tx.AddOpReturnOutput(opReturnPayload(b.opReturnSize(useUtxo.Vout)))
+		}
 		_ = tx.Change(key.Script, b.FeeQuote)
 	} else {
-		_ = tx.AddOpReturnOutput([]byte("ARC is here ... https://bitcoin-sv.github.io/arc/"))
+		_ = tx.AddOpReturnOutput(opReturnPayload(b.opReturnSize(useUtxo.Vout)))
 	}
 
 	unlockerGetter := unlocker.Getter{PrivateKey: key.PrivateKey}
@@ -0,0 +1,385 @@
+package broadcaster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/bitcoin-sv/arc/lib/fees"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/unlocker"
+)
+
+type ChainOrder string
+
+const (
+	// ChainOrderInOrder submits the parents before their children.
+	ChainOrderInOrder ChainOrder = "in-order"
+	// ChainOrderReverse submits the children before their parents.
+	ChainOrderReverse ChainOrder = "reverse"
+	// ChainOrderShuffled submits the transactions in random order.
+	ChainOrderShuffled ChainOrder = "shuffled"
+
+	// maxChainTransactions limits the number of transactions of a chain, which grows exponentially with the fan-out.
+	maxChainTransactions = 100_000
+
+	statusError = "ERROR"
+)
+
+var ErrInvalidChainConfig = errors.New("invalid chain config")
+
+// ChainConfig configures a tree of unconfirmed transactions built from one funding UTXO. The transaction at depth 1
+// spends the funding UTXO, each transaction has FanOut outputs which are spent by the transactions at the next depth.
+// With a fan-out of 1 the tree is a single chain.
+type ChainConfig struct {
+	Depth  int
+	FanOut int
+	Order  ChainOrder
+	// BatchSize is the number of transactions submitted in one request, 0 submits each transaction by itself
+	BatchSize int
+}
+
+// Validate checks the config and returns the number of transactions of the chain.
+func (c *ChainConfig) Validate() (int, error) {
+	if c.Depth < 1 {
+		return 0, fmt.Errorf("%w: depth has to be at least 1", ErrInvalidChainConfig)
+	}
+
+	if c.FanOut < 1 {
+		return 0, fmt.Errorf("%w: fan-out has to be at least 1", ErrInvalidChainConfig)
+	}
+
+	switch c.Order {
+	case ChainOrderInOrder, ChainOrderReverse, ChainOrderShuffled:
+	default:
+		return 0, fmt.Errorf("%w: unknown order %q", ErrInvalidChainConfig, c.Order)
+	}
+
+	if c.BatchSize < 0 {
+		return 0, fmt.Errorf("%w: batch size must not be negative", ErrInvalidChainConfig)
+	}
+
+	total := 0
+	width := 1
+	for depth := 1; depth <= c.Depth; depth++ {
+		total += width
+		if total > maxChainTransactions {
+			return 0, fmt.Errorf("%w: more than %d transactions", ErrInvalidChainConfig, maxChainTransactions)
+		}
+		width *= c.FanOut
+	}
+
+	return total, nil
+}
+
+// ChainDepthResult are the results of the transactions at one depth of the chain.
+type ChainDepthResult struct {
+	Depth        int               `json:"depth"`
+	Transactions int               `json:"transactions"`
+	Statuses     map[string]uint64 `json:"statuses"`
+	Rejected     int               `json:"rejected"`
+	Orphaned     int               `json:"orphaned"`
+}
+
+// ChainReport reports the results of the chain by depth and the first depths at which transactions were rejected or
+// seen in the orphan mempool, 0 if none were.
+type ChainReport struct {
+	Depth              int                 `json:"depth"`
+	FanOut             int                 `json:"fanOut"`
+	Order              ChainOrder          `json:"order"`
+	BatchSize          int                 `json:"batchSize"`
+	Depths             []*ChainDepthResult `json:"depths"`
+	FirstRejectedDepth int                 `json:"firstRejectedDepth"`
+	FirstOrphanedDepth int                 `json:"firstOrphanedDepth"`
+}
+
+type chainTx struct {
+	tx    *bt.Tx
+	txID  string
+	depth int
+	// err is the error by which the submission failed
+	err error
+}
+
+// chainInputSatoshis returns the satoshis which a transaction at the given depth needs to pay for itself and all of
+// its descendants. The outputs of the transactions at the last depth have the dust limit.
+func (c *ChainConfig) chainInputSatoshis(depth int) uint64 {
+	fee := fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, uint64(c.FanOut))
+
+	if depth == c.Depth {
+		return uint64(c.FanOut)*bt.DustLimit + fee
+	}
+
+	return uint64(c.FanOut)*c.chainInputSatoshis(depth+1) + fee
+}
+
+// buildChain builds the transactions of the chain which spends the given UTXO in the order parents before children.
+func (b *Broadcaster) buildChain(utxo *bt.UTXO) ([]*chainTx, error) {
+	unlockerGetter := unlocker.Getter{PrivateKey: b.ToKeySet.PrivateKey}
+
+	chain := make([]*chainTx, 0)
+	parents := []*bt.UTXO{utxo}
+
+	for depth := 1; depth <= b.Chain.Depth; depth++ {
+		outputSatoshis := uint64(bt.DustLimit)
+		if depth < b.Chain.Depth {
+			outputSatoshis = b.Chain.chainInputSatoshis(depth + 1)
+		}
+
+		children := make([]*bt.UTXO, 0, len(parents)*b.Chain.FanOut)
+
+		for _, parent := range parents {
+			tx := bt.NewTx()
+			if err := tx.FromUTXOs(parent); err != nil {
+				return nil, err
+			}
+
+			for i := 0; i < b.Chain.FanOut; i++ {
+				if err := tx.PayTo(b.ToKeySet.Script, outputSatoshis); err != nil {
+					return nil, err
+				}
+			}
+
+			if err := tx.FillAllInputs(context.Background(), &unlockerGetter); err != nil {
+				return nil, err
+			}
+
+			chain = append(chain, &chainTx{tx: tx, txID: tx.TxID(), depth: depth})
+
+			for i, output := range tx.Outputs {
+				children = append(children, &bt.UTXO{
+					TxID:          tx.TxIDBytes(),
+					Vout:          uint32(i),
+					LockingScript: output.LockingScript,
+					Satoshis:      output.Satoshis,
+				})
+			}
+		}
+
+		parents = children
+	}
+
+	return chain, nil
+}
+
+// newChainFundingTransaction returns the transaction which funds the chain with a single output.
+func (b *Broadcaster) newChainFundingTransaction() (*bt.Tx, error) {
+	tx := bt.NewTx()
+
+	satoshis := b.Chain.chainInputSatoshis(1)
+	if err := b.addFundingInputs(tx, 1, satoshis+fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, 2)); err != nil {
+		return nil, err
+	}
+
+	if tx.TotalInputSatoshis() < satoshis+fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), uint64(len(tx.Inputs)), 2) {
+		return nil, fmt.Errorf("chain needs %d satoshis, funding inputs have %d satoshis", satoshis, tx.TotalInputSatoshis())
+	}
+
+	if err := tx.PayTo(b.ToKeySet.Script, satoshis); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Change(b.FromKeySet.Script, b.FeeQuote); err != nil {
+		return nil, err
+	}
+
+	unlockerGetter := unlocker.Getter{PrivateKey: b.FromKeySet.PrivateKey}
+	if err := tx.FillAllInputs(context.Background(), &unlockerGetter); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// runChain funds and builds the chain and submits its transactions in the configured order.
+func (b *Broadcaster) runChain(ctx context.Context) error {
+	total, err := b.Chain.Validate()
+	if err != nil {
+		return err
+	}
+
+	fundingTx, err := b.newChainFundingTransaction()
+	if err != nil {
+		return err
+	}
+
+	b.logger.Infof("chain funding tx: %s", fundingTx.TxID())
+
+	b.spendUtxos(ctx, fundingTx)
+
+	_, err = b.Client.BroadcastTransaction(ctx, fundingTx, metamorph_api.Status(b.WaitForStatus))
+	if err != nil {
+		b.rejectUtxos(ctx, fundingTx.TxID())
+		return fmt.Errorf("error broadcasting chain funding tx: %v", err)
+	}
+
+	chain, err := b.buildChain(&bt.UTXO{
+		TxID:          fundingTx.TxIDBytes(),
+		Vout:          0,
+		LockingScript: fundingTx.Outputs[0].LockingScript,
+		Satoshis:      fundingTx.Outputs[0].Satoshis,
+	})
+	if err != nil {
+		return err
+	}
+
+	if b.CallbackAddress != "" {
+		stopCallbackServer, err := b.startCallbackServer(b.CallbackAddress)
+		if err != nil {
+			return fmt.Errorf("failed to start callback server: %v", err)
+		}
+		defer stopCallbackServer()
+	}
+
+	// wait for things to settle down
+	time.Sleep(2 * time.Second)
+
+	b.logger.Infof("submitting chain of %d transactions with depth %d and fan-out %d %s", total, b.Chain.Depth, b.Chain.FanOut, b.Chain.Order)
+
+	timeStart := time.Now()
+	b.submitChain(ctx, orderChain(chain, b.Chain.Order))
+	sendDuration := time.Since(timeStart)
+
+	b.logger.Infof("sent %d txs in %0.2f seconds", b.sent.Load(), sendDuration.Seconds())
+
+	b.waitForStatuses(ctx)
+
+	b.report = b.newReport(timeStart, sendDuration)
+	b.report.Chain = b.newChainReport(chain)
+
+	for _, result := range b.report.Chain.Depths {
+		b.logger.Infof("depth %d: %d txs, %d rejected, %d orphaned, statuses %v", result.Depth, result.Transactions, result.Rejected, result.Orphaned, result.Statuses)
+	}
+	b.logger.Infof("first rejected depth: %d, first orphaned depth: %d", b.report.Chain.FirstRejectedDepth, b.report.Chain.FirstOrphanedDepth)
+
+	return nil
+}
+
+// orderChain returns the transactions of the chain in the order in which they are submitted.
+func orderChain(chain []*chainTx, order ChainOrder) []*chainTx {
+	ordered := make([]*chainTx, len(chain))
+	copy(ordered, chain)
+
+	switch order {
+	case ChainOrderReverse:
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	case ChainOrderShuffled:
+		rand.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+	}
+
+	return ordered
+}
+
+// submitChain submits the transactions one after another, either by themselves or in batches.
+func (b *Broadcaster) submitChain(ctx context.Context, chain []*chainTx) {
+	batchSize := max(b.Chain.BatchSize, 1)
+
+	for start := 0; start < len(chain); start += batchSize {
+		batch := chain[start:min(start+batchSize, len(chain))]
+
+		submittedAt := time.Now()
+		for _, link := range batch {
+			b.latency.submit(link.txID, submittedAt)
+			b.spendUtxos(ctx, link.tx)
+		}
+
+		if b.Chain.BatchSize == 0 {
+			res, err := b.Client.BroadcastTransaction(ctx, batch[0].tx, metamorph_api.Status(b.WaitForStatus))
+			b.chainResponses(batch, []*metamorph_api.TransactionStatus{res}, err)
+			continue
+		}
+
+		txs := make([]*bt.Tx, len(batch))
+		for i, link := range batch {
+			txs[i] = link.tx
+		}
+
+		res, err := b.Client.BroadcastTransactions(ctx, txs, metamorph_api.Status(b.WaitForStatus))
+		b.chainResponses(batch, res, err)
+	}
+}
+
+func (b *Broadcaster) chainResponses(batch []*chainTx, res []*metamorph_api.TransactionStatus, err error) {
+	respondedAt := time.Now()
+
+	if err != nil {
+		b.errors.Add(uint64(len(batch)))
+		for _, link := range batch {
+			link.err = err
+			b.latency.discard(link.txID)
+			b.rejectUtxos(context.Background(), link.txID)
+		}
+
+		b.logger.Errorf("failed to submit %d chain transactions: %v", len(batch), err)
+		return
+	}
+
+	b.sent.Add(uint64(len(batch)))
+
+	for i, link := range batch {
+		if i >= len(res) || res[i] == nil {
+			continue
+		}
+
+		b.latency.response(link.txID, res[i].GetStatus(), respondedAt)
+		b.processResult(res[i], int64(link.depth))
+	}
+}
+
+func (b *Broadcaster) newChainReport(chain []*chainTx) *ChainReport {
+	report := &ChainReport{
+		Depth:     b.Chain.Depth,
+		FanOut:    b.Chain.FanOut,
+		Order:     b.Chain.Order,
+		BatchSize: b.Chain.BatchSize,
+		Depths:    make([]*ChainDepthResult, b.Chain.Depth),
+	}
+
+	for i := range report.Depths {
+		report.Depths[i] = &ChainDepthResult{Depth: i + 1, Statuses: make(map[string]uint64)}
+	}
+
+	for _, link := range chain {
+		result := report.Depths[link.depth-1]
+		result.Transactions++
+
+		if link.err != nil {
+			result.Statuses[statusError]++
+			result.Rejected++
+			continue
+		}
+
+		status, found := b.latency.lastStatus(link.txID)
+		if !found {
+			result.Statuses[metamorph_api.Status_UNKNOWN.String()]++
+			continue
+		}
+
+		result.Statuses[status.String()]++
+
+		switch status {
+		case metamorph_api.Status_REJECTED:
+			result.Rejected++
+		case metamorph_api.Status_SEEN_IN_ORPHAN_MEMPOOL:
+			result.Orphaned++
+		}
+	}
+
+	for _, result := range report.Depths {
+		if result.Rejected > 0 && report.FirstRejectedDepth == 0 {
+			report.FirstRejectedDepth = result.Depth
+		}
+		if result.Orphaned > 0 && report.FirstOrphanedDepth == 0 {
+			report.FirstOrphanedDepth = result.Depth
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,256 @@
+package broadcaster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bitcoin-sv/arc/lib/keyset"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/libsv/go-bt/v2"
+	"github.com/ordishs/gocore"
+	"github.com/stretchr/testify/require"
+)
+
+type chainClient struct {
+	statuses func(depth int) (metamorph_api.Status, error)
+	depths   map[string]int
+	batches  []int
+}
+
+func (c *chainClient) BroadcastTransaction(_ context.Context, tx *bt.Tx, _ metamorph_api.Status) (*metamorph_api.TransactionStatus, error) {
+	c.batches = append(c.batches, 1)
+
+	status, err := c.statuses(c.depths[tx.TxID()])
+	if err != nil {
+		return nil, err
+	}
+
+	return &metamorph_api.TransactionStatus{Txid: tx.TxID(), Status: status}, nil
+}
+
+func (c *chainClient) BroadcastTransactions(_ context.Context, txs []*bt.Tx, _ metamorph_api.Status) ([]*metamorph_api.TransactionStatus, error) {
+	c.batches = append(c.batches, len(txs))
+
+	res := make([]*metamorph_api.TransactionStatus, len(txs))
+	for i, tx := range txs {
+		status, err := c.statuses(c.depths[tx.TxID()])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = &metamorph_api.TransactionStatus{Txid: tx.TxID(), Status: status}
+	}
+
+	return res, nil
+}
+
+func (c *chainClient) GetTransactionStatus(_ context.Context, txID string) (*metamorph_api.TransactionStatus, error) {
+	return &metamorph_api.TransactionStatus{Txid: txID}, nil
+}
+
+func TestChainConfigValidate(t *testing.T) {
+	tt := []struct {
+		name   string
+		config ChainConfig
+
+		expectedTotal    int
+		expectedErrorStr string
+	}{
+		{
+			name:   "single chain",
+			config: ChainConfig{Depth: 25, FanOut: 1, Order: ChainOrderInOrder},
+
+			expectedTotal: 25,
+		},
+		{
+			name:   "tree",
+			config: ChainConfig{Depth: 3, FanOut: 3, Order: ChainOrderShuffled, BatchSize: 5},
+
+			expectedTotal: 13,
+		},
+		{
+			name:   "invalid depth",
+			config: ChainConfig{Depth: 0, FanOut: 1, Order: ChainOrderInOrder},
+
+			expectedErrorStr: "depth",
+		},
+		{
+			name:   "invalid fan-out",
+			config: ChainConfig{Depth: 1, FanOut: 0, Order: ChainOrderInOrder},
+
+			expectedErrorStr: "fan-out",
+		},
+		{
+			name:   "unknown order",
+			config: ChainConfig{Depth: 1, FanOut: 1, Order: "sideways"},
+
+			expectedErrorStr: "unknown order",
+		},
+		{
+			name:   "too many transactions",
+			config: ChainConfig{Depth: 20, FanOut: 2, Order: ChainOrderInOrder},
+
+			expectedErrorStr: "more than 100000 transactions",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			total, err := tc.config.Validate()
+
+			if tc.expectedErrorStr != "" {
+				require.ErrorIs(t, err, ErrInvalidChainConfig)
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedTotal, total)
+		})
+	}
+}
+
+func TestBuildChain(t *testing.T) {
+	key, err := keyset.New()
+	require.NoError(t, err)
+
+	b := New(nil, nil, key, key, 0)
+	b.Chain = &ChainConfig{Depth: 3, FanOut: 2, Order: ChainOrderInOrder}
+
+	chain, err := b.buildChain(&bt.UTXO{
+		TxID:          bytes.Repeat([]byte{1}, 32),
+		Vout:          0,
+		LockingScript: key.Script,
+		Satoshis:      b.Chain.chainInputSatoshis(1),
+	})
+	require.NoError(t, err)
+	require.Len(t, chain, 7)
+
+	txIDs := make(map[string]int)
+	for i, link := range chain {
+		txIDs[link.txID] = link.depth
+
+		// parents come before their children
+		if link.depth > 1 {
+			parentDepth, found := txIDs[link.tx.Inputs[0].PreviousTxIDStr()]
+			require.True(t, found, "tx %d", i)
+			require.Equal(t, link.depth-1, parentDepth)
+		}
+
+		require.Len(t, link.tx.Outputs, 2)
+		if link.depth == 3 {
+			require.Equal(t, uint64(bt.DustLimit), link.tx.Outputs[0].Satoshis)
+		}
+
+		feePaidEnough, err := link.tx.IsFeePaidEnough(b.FeeQuote)
+		require.NoError(t, err)
+		require.True(t, feePaidEnough, "tx %d", i)
+	}
+
+	reversed := orderChain(chain, ChainOrderReverse)
+	require.Equal(t, chain[0], reversed[6])
+	require.Equal(t, chain[6], reversed[0])
+
+	shuffled := orderChain(chain, ChainOrderShuffled)
+	require.ElementsMatch(t, chain, shuffled)
+}
+
+func TestSubmitChain(t *testing.T) {
+	tt := []struct {
+		name      string
+		batchSize int
+		statuses  func(depth int) (metamorph_api.Status, error)
+
+		expectedBatches            []int
+		expectedFirstRejectedDepth int
+		expectedFirstOrphanedDepth int
+		expectedRejected           []int
+		expectedOrphaned           []int
+	}{
+		{
+			name:      "single - all seen",
+			batchSize: 0,
+			statuses: func(_ int) (metamorph_api.Status, error) {
+				return metamorph_api.Status_SEEN_ON_NETWORK, nil
+			},
+
+			expectedBatches:  []int{1, 1, 1, 1, 1, 1, 1},
+			expectedRejected: []int{0, 0, 0},
+			expectedOrphaned: []int{0, 0, 0},
+		},
+		{
+			name:      "batched - rejected from depth 3",
+			batchSize: 3,
+			statuses: func(depth int) (metamorph_api.Status, error) {
+				if depth >= 3 {
+					return metamorph_api.Status_REJECTED, nil
+				}
+				return metamorph_api.Status_SEEN_ON_NETWORK, nil
+			},
+
+			expectedBatches:            []int{3, 3, 1},
+			expectedFirstRejectedDepth: 3,
+			expectedRejected:           []int{0, 0, 4},
+			expectedOrphaned:           []int{0, 0, 0},
+		},
+		{
+			name:      "single - orphaned from depth 2, error at depth 3",
+			batchSize: 0,
+			statuses: func(depth int) (metamorph_api.Status, error) {
+				switch depth {
+				case 1:
+					return metamorph_api.Status_SEEN_ON_NETWORK, nil
+				case 2:
+					return metamorph_api.Status_SEEN_IN_ORPHAN_MEMPOOL, nil
+				default:
+					return 0, errors.New("too long mempool chain")
+				}
+			},
+
+			expectedBatches:            []int{1, 1, 1, 1, 1, 1, 1},
+			expectedFirstRejectedDepth: 3,
+			expectedFirstOrphanedDepth: 2,
+			expectedRejected:           []int{0, 0, 4},
+			expectedOrphaned:           []int{0, 2, 0},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := keyset.New()
+			require.NoError(t, err)
+
+			client := &chainClient{statuses: tc.statuses, depths: make(map[string]int)}
+
+			b := New(gocore.Log("test"), client, key, key, 0)
+			b.Chain = &ChainConfig{Depth: 3, FanOut: 2, Order: ChainOrderInOrder, BatchSize: tc.batchSize}
+
+			chain, err := b.buildChain(&bt.UTXO{
+				TxID:          bytes.Repeat([]byte{1}, 32),
+				Vout:          0,
+				LockingScript: key.Script,
+				Satoshis:      b.Chain.chainInputSatoshis(1),
+			})
+			require.NoError(t, err)
+
+			for _, link := range chain {
+				client.depths[link.txID] = link.depth
+			}
+
+			b.submitChain(context.Background(), chain)
+			report := b.newChainReport(chain)
+
+			require.Equal(t, tc.expectedBatches, client.batches)
+			require.Equal(t, tc.expectedFirstRejectedDepth, report.FirstRejectedDepth)
+			require.Equal(t, tc.expectedFirstOrphanedDepth, report.FirstOrphanedDepth)
+
+			for i, result := range report.Depths {
+				require.Equal(t, i+1, result.Depth)
+				require.Equal(t, 1<<i, result.Transactions)
+				require.Equal(t, tc.expectedRejected[i], result.Rejected, "depth %d", i+1)
+				require.Equal(t, tc.expectedOrphaned[i], result.Orphaned, "depth %d", i+1)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package broadcaster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bitcoin-sv/arc/lib/fees"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/unlocker"
+)
+
+var ErrInvalidDoubleSpendConfig = errors.New("invalid double spend config")
+
+// DoubleSpendConfig configures groups of conflicting transactions. The transactions of a group spend the same UTXO and
+// are submitted simultaneously, or each with the delay after the previous one. The transactions of a group are
+// submitted to the clients in turn.
+type DoubleSpendConfig struct {
+	Groups    int
+	Conflicts int
+	Delay     time.Duration
+	// Clients are the endpoints to which the conflicting transactions are submitted, by default the broadcaster client
+	Clients []ClientI
+}
+
+// Validate checks the config.
+func (c *DoubleSpendConfig) Validate() error {
+	if c.Groups < 1 {
+		return fmt.Errorf("%w: number of groups has to be at least 1", ErrInvalidDoubleSpendConfig)
+	}
+
+	if c.Conflicts < 2 {
+		return fmt.Errorf("%w: number of conflicting transactions has to be at least 2", ErrInvalidDoubleSpendConfig)
+	}
+
+	if c.Delay < 0 {
+		return fmt.Errorf("%w: delay must not be negative", ErrInvalidDoubleSpendConfig)
+	}
+
+	return nil
+}
+
+// DoubleSpendResult is the result of one of the conflicting transactions of a group.
+type DoubleSpendResult struct {
+	TxID           string  `json:"txid"`
+	Endpoint       int     `json:"endpoint"`
+	DelayMs        float64 `json:"delayMs"`
+	ResponseStatus string  `json:"responseStatus,omitempty"`
+	RejectReason   string  `json:"rejectReason,omitempty"`
+	Error          string  `json:"error,omitempty"`
+	FinalStatus    string  `json:"finalStatus"`
+	Callbacks      int     `json:"callbacks"`
+}
+
+// DoubleSpendGroup is the result of a group of conflicting transactions. The winner is the transaction which has been
+// seen on the network or mined, if only one of them has been.
+type DoubleSpendGroup struct {
+	Utxo         string               `json:"utxo"`
+	Winner       string               `json:"winner,omitempty"`
+	Accepted     int                  `json:"accepted"`
+	Transactions []*DoubleSpendResult `json:"transactions"`
+}
+
+// DoubleSpendReport reports the results of all groups and how many groups had a single winner, no winner or several
+// accepted transactions.
+type DoubleSpendReport struct {
+	Conflicts      int                 `json:"conflicts"`
+	DelayMs        float64             `json:"delayMs"`
+	Endpoints      int                 `json:"endpoints"`
+	Groups         []*DoubleSpendGroup `json:"groups"`
+	SingleWinner   int                 `json:"singleWinner"`
+	NoWinner       int                 `json:"noWinner"`
+	MultipleWinner int                 `json:"multipleWinner"`
+}
+
+type doubleSpendTx struct {
+	tx       *bt.Tx
+	txID     string
+	endpoint int
+	delay    time.Duration
+	res      *metamorph_api.TransactionStatus
+	err      error
+}
+
+// doubleSpendSatoshis returns the satoshis of each UTXO which is double spent. The conflicting transactions pay the
+// satoshis which are left after the fee back to the funding key, each transaction one satoshi less than the previous.
+func (c *DoubleSpendConfig) doubleSpendSatoshis() uint64 {
+	return fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, 1) + uint64(c.Conflicts)
+}
+
+// newDoubleSpendFundingTransaction returns the transaction which funds one output for each group.
+func (b *Broadcaster) newDoubleSpendFundingTransaction() (*bt.Tx, error) {
+	tx := bt.NewTx()
+
+	satoshis := b.DoubleSpend.doubleSpendSatoshis()
+	fee := fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, uint64(b.DoubleSpend.Groups)+1)
+	if err := b.addFundingInputs(tx, 1, uint64(b.DoubleSpend.Groups)*satoshis+fee); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < b.DoubleSpend.Groups; i++ {
+		if err := tx.PayTo(b.ToKeySet.Script, satoshis); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Change(b.FromKeySet.Script, b.FeeQuote); err != nil {
+		return nil, err
+	}
+
+	unlockerGetter := unlocker.Getter{PrivateKey: b.FromKeySet.PrivateKey}
+	if err := tx.FillAllInputs(context.Background(), &unlockerGetter); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// newConflictingTransactions returns the conflicting transactions which spend the UTXO.
+func (b *Broadcaster) newConflictingTransactions(utxo *bt.UTXO) ([]*doubleSpendTx, error) {
+	unlockerGetter := unlocker.Getter{PrivateKey: b.ToKeySet.PrivateKey}
+	fee := fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, 1)
+	endpoints := max(len(b.DoubleSpend.Clients), 1)
+
+	conflicts := make([]*doubleSpendTx, b.DoubleSpend.Conflicts)
+	for i := range conflicts {
+		tx := bt.NewTx()
+		if err := tx.FromUTXOs(utxo); err != nil {
+			return nil, err
+		}
+
+		// the transactions differ by the amount they pay back
+		if err := tx.PayTo(b.FromKeySet.Script, utxo.Satoshis-fee-uint64(i)); err != nil {
+			return nil, err
+		}
+
+		if err := tx.FillAllInputs(context.Background(), &unlockerGetter); err != nil {
+			return nil, err
+		}
+
+		conflicts[i] = &doubleSpendTx{
+			tx:       tx,
+			txID:     tx.TxID(),
+			endpoint: i % endpoints,
+			delay:    time.Duration(i) * b.DoubleSpend.Delay,
+		}
+	}
+
+	return conflicts, nil
+}
+
+// runDoubleSpend funds the groups of conflicting transactions and submits them.
+func (b *Broadcaster) runDoubleSpend(ctx context.Context) error {
+	if err := b.DoubleSpend.Validate(); err != nil {
+		return err
+	}
+
+	fundingTx, err := b.newDoubleSpendFundingTransaction()
+	if err != nil {
+		return err
+	}
+
+	b.logger.Infof("double spend funding tx: %s", fundingTx.TxID())
+
+	b.spendUtxos(ctx, fundingTx)
+
+	_, err = b.Client.BroadcastTransaction(ctx, fundingTx, metamorph_api.Status(b.WaitForStatus))
+	if err != nil {
+		b.rejectUtxos(ctx, fundingTx.TxID())
+		return fmt.Errorf("error broadcasting double spend funding tx: %v", err)
+	}
+
+	groups := make([][]*doubleSpendTx, b.DoubleSpend.Groups)
+	for i := range groups {
+		groups[i], err = b.newConflictingTransactions(&bt.UTXO{
+			TxID:          fundingTx.TxIDBytes(),
+			Vout:          uint32(i),
+			LockingScript: fundingTx.Outputs[i].LockingScript,
+			Satoshis:      fundingTx.Outputs[i].Satoshis,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.CallbackAddress != "" {
+		stopCallbackServer, err := b.startCallbackServer(b.CallbackAddress)
+		if err != nil {
+			return fmt.Errorf("failed to start callback server: %v", err)
+		}
+		defer stopCallbackServer()
+	}
+
+	// wait for things to settle down
+	time.Sleep(2 * time.Second)
+
+	b.logger.Infof("submitting %d groups of %d conflicting transactions with a delay of %s", b.DoubleSpend.Groups, b.DoubleSpend.Conflicts, b.DoubleSpend.Delay)
+
+	timeStart := time.Now()
+	b.submitDoubleSpends(ctx, groups)
+	sendDuration := time.Since(timeStart)
+
+	b.logger.Infof("sent %d txs in %0.2f seconds", b.sent.Load(), sendDuration.Seconds())
+
+	b.waitForStatuses(ctx)
+
+	b.report = b.newReport(timeStart, sendDuration)
+	b.report.DoubleSpend = b.newDoubleSpendReport(fundingTx, groups)
+
+	// only the winners spend the UTXOs
+	for i, group := range groups {
+		for _, conflict := range group {
+			if conflict.txID == b.report.DoubleSpend.Groups[i].Winner {
+				b.spendUtxos(ctx, conflict.tx)
+			}
+		}
+	}
+
+	for _, group := range b.report.DoubleSpend.Groups {
+		b.logger.Infof("utxo %s: winner %s, %d accepted", group.Utxo, group.Winner, group.Accepted)
+		for _, result := range group.Transactions {
+			b.logger.Infof("  tx %s: endpoint %d, response %s, final status %s, %d callbacks %s", result.TxID, result.Endpoint, result.ResponseStatus, result.FinalStatus, result.Callbacks, result.Error)
+		}
+	}
+	b.logger.Infof("single winner: %d, no winner: %d, multiple winners: %d", b.report.DoubleSpend.SingleWinner, b.report.DoubleSpend.NoWinner, b.report.DoubleSpend.MultipleWinner)
+
+	return nil
+}
+
+// submitDoubleSpends submits the conflicting transactions of all groups at the same time, each after its delay.
+func (b *Broadcaster) submitDoubleSpends(ctx context.Context, groups [][]*doubleSpendTx) {
+	client := func(endpoint int) ClientI {
+		if len(b.DoubleSpend.Clients) == 0 {
+			return b.Client
+		}
+		return b.DoubleSpend.Clients[endpoint]
+	}
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for _, group := range groups {
+		for _, conflict := range group {
+			wg.Add(1)
+
+			go func(conflict *doubleSpendTx) {
+				defer wg.Done()
+
+				time.Sleep(time.Until(start.Add(conflict.delay)))
+
+				b.latency.submit(conflict.txID, time.Now())
+
+				conflict.res, conflict.err = client(conflict.endpoint).BroadcastTransaction(ctx, conflict.tx, metamorph_api.Status(b.WaitForStatus))
+				if conflict.err != nil {
+					b.errors.Add(1)
+					b.latency.discard(conflict.txID)
+					return
+				}
+
+				b.sent.Add(1)
+				b.latency.response(conflict.txID, conflict.res.GetStatus(), time.Now())
+				b.processResult(conflict.res, int64(conflict.endpoint))
+			}(conflict)
+		}
+	}
+	wg.Wait()
+}
+
+func (b *Broadcaster) newDoubleSpendReport(fundingTx *bt.Tx, groups [][]*doubleSpendTx) *DoubleSpendReport {
+	report := &DoubleSpendReport{
+		Conflicts: b.DoubleSpend.Conflicts,
+		DelayMs:   float64(b.DoubleSpend.Delay.Microseconds()) / 1000,
+		Endpoints: max(len(b.DoubleSpend.Clients), 1),
+		Groups:    make([]*DoubleSpendGroup, len(groups)),
+	}
+
+	for i, group := range groups {
+		result := &DoubleSpendGroup{
+			Utxo:         fmt.Sprintf("%s:%d", fundingTx.TxID(), i),
+			Transactions: make([]*DoubleSpendResult, len(group)),
+		}
+
+		for j, conflict := range group {
+			txResult := &DoubleSpendResult{
+				TxID:      conflict.txID,
+				Endpoint:  conflict.endpoint,
+				DelayMs:   float64(conflict.delay.Microseconds()) / 1000,
+				Callbacks: b.latency.callbackCount(conflict.txID),
+			}
+
+			if conflict.err != nil {
+				txResult.Error = conflict.err.Error()
+				txResult.FinalStatus = statusError
+			} else {
+				txResult.ResponseStatus = conflict.res.GetStatus().String()
+				txResult.RejectReason = conflict.res.GetRejectReason()
+				txResult.FinalStatus = metamorph_api.Status_UNKNOWN.String()
+
+				if status, found := b.latency.lastStatus(conflict.txID); found {
+					txResult.FinalStatus = status.String()
+
+					switch status {
+					case metamorph_api.Status_SEEN_ON_NETWORK, metamorph_api.Status_MINED, metamorph_api.Status_CONFIRMED:
+						result.Accepted++
+						result.Winner = conflict.txID
+					}
+				}
+			}
+
+			result.Transactions[j] = txResult
+		}
+
+		switch result.Accepted {
+		case 0:
+			report.NoWinner++
+		case 1:
+			report.SingleWinner++
+		default:
+			result.Winner = ""
+			report.MultipleWinner++
+		}
+
+		report.Groups[i] = result
+	}
+
+	return report
+}
@@ -0,0 +1,203 @@
+package broadcaster
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/lib/keyset"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/libsv/go-bt/v2"
+	"github.com/ordishs/gocore"
+	"github.com/stretchr/testify/require"
+)
+
+// doubleSpendClient accepts the first transaction which spends an output and rejects the others.
+type doubleSpendClient struct {
+	mu    sync.Mutex
+	spent map[string]string
+	err   error
+}
+
+func (c *doubleSpendClient) BroadcastTransaction(_ context.Context, tx *bt.Tx, _ metamorph_api.Status) (*metamorph_api.TransactionStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	outpoint := fmt.Sprintf("%s:%d", hex.EncodeToString(tx.Inputs[0].PreviousTxID()), tx.Inputs[0].PreviousTxOutIndex)
+	if spentBy, found := c.spent[outpoint]; found && spentBy != tx.TxID() {
+		return &metamorph_api.TransactionStatus{Txid: tx.TxID(), Status: metamorph_api.Status_REJECTED, RejectReason: "double spend"}, nil
+	}
+	c.spent[outpoint] = tx.TxID()
+
+	return &metamorph_api.TransactionStatus{Txid: tx.TxID(), Status: metamorph_api.Status_SEEN_ON_NETWORK}, nil
+}
+
+func (c *doubleSpendClient) BroadcastTransactions(ctx context.Context, txs []*bt.Tx, waitForStatus metamorph_api.Status) ([]*metamorph_api.TransactionStatus, error) {
+	res := make([]*metamorph_api.TransactionStatus, len(txs))
+	for i, tx := range txs {
+		status, err := c.BroadcastTransaction(ctx, tx, waitForStatus)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = status
+	}
+
+	return res, nil
+}
+
+func (c *doubleSpendClient) GetTransactionStatus(_ context.Context, txID string) (*metamorph_api.TransactionStatus, error) {
+	return &metamorph_api.TransactionStatus{Txid: txID}, nil
+}
+
+func TestDoubleSpendConfigValidate(t *testing.T) {
+	require.NoError(t, (&DoubleSpendConfig{Groups: 1, Conflicts: 2}).Validate())
+	require.ErrorIs(t, (&DoubleSpendConfig{Groups: 0, Conflicts: 2}).Validate(), ErrInvalidDoubleSpendConfig)
+	require.ErrorIs(t, (&DoubleSpendConfig{Groups: 1, Conflicts: 1}).Validate(), ErrInvalidDoubleSpendConfig)
+	require.ErrorIs(t, (&DoubleSpendConfig{Groups: 1, Conflicts: 2, Delay: -time.Second}).Validate(), ErrInvalidDoubleSpendConfig)
+}
+
+func TestDoubleSpend(t *testing.T) {
+	tt := []struct {
+		name      string
+		endpoints int
+		delay     time.Duration
+		clientErr error
+
+		expectedSingleWinner int
+		expectedNoWinner     int
+		expectedSubmitted    []int
+	}{
+		{
+			name:      "single endpoint, simultaneously",
+			endpoints: 1,
+
+			expectedSingleWinner: 3,
+			expectedSubmitted:    []int{9},
+		},
+		{
+			name:      "several endpoints with delay",
+			endpoints: 2,
+			delay:     20 * time.Millisecond,
+
+			expectedSingleWinner: 3,
+			expectedSubmitted:    []int{6, 3},
+		},
+		{
+			name:      "error",
+			endpoints: 1,
+			clientErr: errors.New("connection refused"),
+
+			expectedNoWinner:  3,
+			expectedSubmitted: []int{9},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := keyset.New()
+			require.NoError(t, err)
+
+			// the endpoints share the spent outputs as if they were connected to the same network
+			network := &doubleSpendClient{spent: make(map[string]string), err: tc.clientErr}
+			clients := make([]ClientI, tc.endpoints)
+			for i := range clients {
+				clients[i] = &countingClient{ClientI: network}
+			}
+
+			b := New(gocore.Log("test"), clients[0], key, key, 0)
+			b.DoubleSpend = &DoubleSpendConfig{Groups: 3, Conflicts: 3, Delay: tc.delay, Clients: clients}
+
+			groups := make([][]*doubleSpendTx, b.DoubleSpend.Groups)
+			for i := range groups {
+				groups[i], err = b.newConflictingTransactions(&bt.UTXO{
+					TxID:          bytes.Repeat([]byte{1}, 32),
+					Vout:          uint32(i),
+					LockingScript: key.Script,
+					Satoshis:      b.DoubleSpend.doubleSpendSatoshis(),
+				})
+				require.NoError(t, err)
+
+				txIDs := make(map[string]struct{})
+				for _, conflict := range groups[i] {
+					txIDs[conflict.txID] = struct{}{}
+
+					feePaidEnough, err := conflict.tx.IsFeePaidEnough(b.FeeQuote)
+					require.NoError(t, err)
+					require.True(t, feePaidEnough)
+				}
+				require.Len(t, txIDs, 3)
+			}
+
+			b.submitDoubleSpends(context.Background(), groups)
+
+			// a callback is delivered for the winner of the first group
+			winner := groups[0][0].txID
+			if tc.delay == 0 {
+				winner = network.spent[fmt.Sprintf("%s:%d", hex.EncodeToString(bytes.Repeat([]byte{1}, 32)), 0)]
+			}
+			recorder := httptest.NewRecorder()
+			b.CallbackHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(`{"txid":"`+winner+`","txStatus":"MINED"}`)))
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			fundingTx := bt.NewTx()
+			report := b.newDoubleSpendReport(fundingTx, groups)
+
+			require.Equal(t, tc.expectedSingleWinner, report.SingleWinner)
+			require.Equal(t, tc.expectedNoWinner, report.NoWinner)
+			require.Equal(t, 0, report.MultipleWinner)
+			require.Equal(t, tc.endpoints, report.Endpoints)
+
+			for i, client := range clients {
+				require.Equal(t, tc.expectedSubmitted[i], client.(*countingClient).submitted)
+			}
+
+			if tc.clientErr != nil {
+				for _, result := range report.Groups[0].Transactions {
+					require.Equal(t, "connection refused", result.Error)
+				}
+				return
+			}
+
+			require.Equal(t, winner, report.Groups[0].Winner)
+			for _, result := range report.Groups[0].Transactions {
+				if result.TxID == winner {
+					require.Equal(t, metamorph_api.Status_MINED.String(), result.FinalStatus)
+					require.Equal(t, 1, result.Callbacks)
+					continue
+				}
+
+				require.Equal(t, metamorph_api.Status_REJECTED.String(), result.ResponseStatus)
+				require.Equal(t, metamorph_api.Status_REJECTED.String(), result.FinalStatus)
+				require.Equal(t, "double spend", result.RejectReason)
+				require.Equal(t, 0, result.Callbacks)
+			}
+		})
+	}
+}
+
+// countingClient counts the transactions submitted to one of several endpoints.
+type countingClient struct {
+	ClientI
+	mu        sync.Mutex
+	submitted int
+}
+
+func (c *countingClient) BroadcastTransaction(ctx context.Context, tx *bt.Tx, waitForStatus metamorph_api.Status) (*metamorph_api.TransactionStatus, error) {
+	c.mu.Lock()
+	c.submitted++
+	c.mu.Unlock()
+
+	return c.ClientI.BroadcastTransaction(ctx, tx, waitForStatus)
+}
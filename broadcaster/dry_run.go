@@ -50,7 +50,6 @@ func (d DryRunClient) BroadcastTransaction(ctx context.Context, tx *bt.Tx, _ met
 	return &metamorph_api.TransactionStatus{}, nil
 }
 
-func (d DryRunClient) GetTransactionStatus(_ context.Context, _ string) (*metamorph_api.TransactionStatus, error) {
-	//TODO implement me
-	panic("implement me")
+func (d DryRunClient) GetTransactionStatus(_ context.Context, txID string) (*metamorph_api.TransactionStatus, error) {
+	return &metamorph_api.TransactionStatus{Txid: txID}, nil
 }
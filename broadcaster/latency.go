@@ -0,0 +1,166 @@
+package broadcaster
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+)
+
+type LatencyStage string
+
+const (
+	// LatencySubmitResponse is the time from submitting a transaction until the response has been received.
+	LatencySubmitResponse LatencyStage = "submit_response"
+	// LatencySubmitSeen is the time from submitting a transaction until it has been seen on the network.
+	LatencySubmitSeen LatencyStage = "submit_seen"
+	// LatencySubmitMined is the time from submitting a transaction until it has been mined.
+	LatencySubmitMined LatencyStage = "submit_mined"
+)
+
+var latencyStages = []LatencyStage{LatencySubmitResponse, LatencySubmitSeen, LatencySubmitMined}
+
+// latencyRecorder records the time between the submission of each transaction and the stages it reaches. Each stage
+// is recorded once per transaction, the statuses are taken from responses, callbacks or polling.
+type latencyRecorder struct {
+	mu        sync.Mutex
+	submitted map[string]time.Time
+	reached   map[LatencyStage]map[string]struct{}
+	samples   map[LatencyStage][]time.Duration
+	last      map[string]metamorph_api.Status
+	callbacks map[string]int
+}
+
+func newLatencyRecorder() *latencyRecorder {
+	r := &latencyRecorder{
+		submitted: make(map[string]time.Time),
+		reached:   make(map[LatencyStage]map[string]struct{}),
+		samples:   make(map[LatencyStage][]time.Duration),
+		last:      make(map[string]metamorph_api.Status),
+		callbacks: make(map[string]int),
+	}
+
+	for _, stage := range latencyStages {
+		r.reached[stage] = make(map[string]struct{})
+	}
+
+	return r
+}
+
+func (r *latencyRecorder) submit(txID string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.submitted[txID] = at
+}
+
+// response records the response to the submission of the transaction and the stage of its status.
+func (r *latencyRecorder) response(txID string, status metamorph_api.Status, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.record(txID, LatencySubmitResponse, at)
+	r.recordStatus(txID, status, at)
+}
+
+// status records the stage of a status received by callback or polling.
+func (r *latencyRecorder) status(txID string, status metamorph_api.Status, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recordStatus(txID, status, at)
+}
+
+func (r *latencyRecorder) recordStatus(txID string, status metamorph_api.Status, at time.Time) {
+	if _, found := r.submitted[txID]; found {
+		r.last[txID] = status
+	}
+
+	switch status {
+	case metamorph_api.Status_SEEN_ON_NETWORK:
+		r.record(txID, LatencySubmitSeen, at)
+	case metamorph_api.Status_MINED, metamorph_api.Status_CONFIRMED:
+		// a transaction which is mined has been seen, even if the status was not observed
+		r.record(txID, LatencySubmitSeen, at)
+		r.record(txID, LatencySubmitMined, at)
+	}
+}
+
+func (r *latencyRecorder) record(txID string, stage LatencyStage, at time.Time) {
+	submittedAt, found := r.submitted[txID]
+	if !found {
+		return
+	}
+
+	if _, found = r.reached[stage][txID]; found {
+		return
+	}
+
+	r.reached[stage][txID] = struct{}{}
+	r.samples[stage] = append(r.samples[stage], at.Sub(submittedAt))
+}
+
+// callback counts a callback received for the transaction.
+func (r *latencyRecorder) callback(txID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.callbacks[txID]++
+}
+
+// callbackCount returns the number of callbacks received for the transaction.
+func (r *latencyRecorder) callbackCount(txID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.callbacks[txID]
+}
+
+// lastStatus returns the last status received for the transaction.
+func (r *latencyRecorder) lastStatus(txID string) (metamorph_api.Status, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status, found := r.last[txID]
+
+	return status, found
+}
+
+// discard removes a transaction which could not be submitted.
+func (r *latencyRecorder) discard(txID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.submitted, txID)
+}
+
+// pending returns the ids of the submitted transactions which have neither been mined nor rejected yet.
+func (r *latencyRecorder) pending() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	txIDs := make([]string, 0)
+	for txID := range r.submitted {
+		if r.last[txID] == metamorph_api.Status_REJECTED {
+			continue
+		}
+
+		if _, found := r.reached[LatencySubmitMined][txID]; !found {
+			txIDs = append(txIDs, txID)
+		}
+	}
+
+	return txIDs
+}
+
+func (r *latencyRecorder) summaries() map[LatencyStage]*LatencySummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summaries := make(map[LatencyStage]*LatencySummary, len(latencyStages))
+	for _, stage := range latencyStages {
+		summaries[stage] = newLatencySummary(r.samples[stage])
+	}
+
+	return summaries
+}
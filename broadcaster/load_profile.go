@@ -0,0 +1,145 @@
+package broadcaster
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type LoadStageKind string
+
+const (
+	// LoadStageRamp increases or decreases the rate linearly from the start rate to the end rate.
+	LoadStageRamp LoadStageKind = "ramp"
+	// LoadStageSteady sends at a constant rate.
+	LoadStageSteady LoadStageKind = "steady"
+	// LoadStageSpike sends at a constant, usually much higher, rate for a short time.
+	LoadStageSpike LoadStageKind = "spike"
+	// LoadStageSoak sends at a constant rate for a long time.
+	LoadStageSoak LoadStageKind = "soak"
+)
+
+var ErrInvalidLoadProfile = errors.New("invalid load profile")
+
+// LoadStage is a part of a load profile with a rate in transactions per second which changes linearly from StartRate
+// to EndRate over the duration of the stage.
+type LoadStage struct {
+	Kind      LoadStageKind
+	StartRate float64
+	EndRate   float64
+	Duration  time.Duration
+}
+
+// LoadProfile is a sequence of load stages which are run one after another.
+type LoadProfile struct {
+	Stages []LoadStage
+}
+
+// ParseLoadProfile parses a load profile from a comma separated list of stages. A stage is either
+// ramp:<start rate>-<end rate>:<duration> or <steady|spike|soak>:<rate>:<duration> with rates in transactions per
+// second, e.g. ramp:0-100:1m,steady:100:5m,spike:500:30s,soak:50:1h.
+func ParseLoadProfile(profile string) (*LoadProfile, error) {
+	if strings.TrimSpace(profile) == "" {
+		return nil, fmt.Errorf("%w: no stages", ErrInvalidLoadProfile)
+	}
+
+	p := &LoadProfile{}
+
+	for _, stageStr := range strings.Split(profile, ",") {
+		parts := strings.Split(strings.TrimSpace(stageStr), ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%w: stage %q is not of the form kind:rate:duration", ErrInvalidLoadProfile, stageStr)
+		}
+
+		stage := LoadStage{Kind: LoadStageKind(parts[0])}
+
+		var err error
+		switch stage.Kind {
+		case LoadStageRamp:
+			rates := strings.Split(parts[1], "-")
+			if len(rates) != 2 {
+				return nil, fmt.Errorf("%w: ramp %q needs a start and an end rate", ErrInvalidLoadProfile, stageStr)
+			}
+
+			if stage.StartRate, err = parseRate(rates[0]); err != nil {
+				return nil, err
+			}
+			if stage.EndRate, err = parseRate(rates[1]); err != nil {
+				return nil, err
+			}
+		case LoadStageSteady, LoadStageSpike, LoadStageSoak:
+			if stage.StartRate, err = parseRate(parts[1]); err != nil {
+				return nil, err
+			}
+			stage.EndRate = stage.StartRate
+		default:
+			return nil, fmt.Errorf("%w: unknown stage kind %q", ErrInvalidLoadProfile, parts[0])
+		}
+
+		stage.Duration, err = time.ParseDuration(parts[2])
+		if err != nil || stage.Duration <= 0 {
+			return nil, fmt.Errorf("%w: invalid duration %q", ErrInvalidLoadProfile, parts[2])
+		}
+
+		p.Stages = append(p.Stages, stage)
+	}
+
+	return p, nil
+}
+
+func parseRate(rateStr string) (float64, error) {
+	rate, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || rate < 0 {
+		return 0, fmt.Errorf("%w: invalid rate %q", ErrInvalidLoadProfile, rateStr)
+	}
+
+	return rate, nil
+}
+
+// Duration returns the total duration of all stages.
+func (p *LoadProfile) Duration() time.Duration {
+	var duration time.Duration
+	for _, stage := range p.Stages {
+		duration += stage.Duration
+	}
+
+	return duration
+}
+
+// Transactions returns the number of transactions which have to be sent after the given time since the start of the
+// profile, i.e. the integral of the rate up to the given time.
+func (p *LoadProfile) Transactions(elapsed time.Duration) float64 {
+	total := 0.0
+
+	for _, stage := range p.Stages {
+		if elapsed <= 0 {
+			break
+		}
+
+		t := min(elapsed, stage.Duration).Seconds()
+		slope := (stage.EndRate - stage.StartRate) / stage.Duration.Seconds()
+		total += stage.StartRate*t + slope*t*t/2
+
+		elapsed -= stage.Duration
+	}
+
+	return total
+}
+
+// String returns the profile in the format parsed by ParseLoadProfile.
+func (p *LoadProfile) String() string {
+	stages := make([]string, len(p.Stages))
+
+	for i, stage := range p.Stages {
+		rate := strconv.FormatFloat(stage.StartRate, 'f', -1, 64)
+		if stage.Kind == LoadStageRamp {
+			rate += "-" + strconv.FormatFloat(stage.EndRate, 'f', -1, 64)
+		}
+
+		stages[i] = fmt.Sprintf("%s:%s:%s", stage.Kind, rate, stage.Duration)
+	}
+
+	return strings.Join(stages, ",")
+}
@@ -0,0 +1,104 @@
+package broadcaster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLoadProfile(t *testing.T) {
+	tt := []struct {
+		name    string
+		profile string
+
+		expectedStages   []LoadStage
+		expectedErrorStr string
+	}{
+		{
+			name:    "all stage kinds",
+			profile: "ramp:0-100:1m,steady:100:5m, spike:500:30s,soak:50:1h",
+
+			expectedStages: []LoadStage{
+				{Kind: LoadStageRamp, StartRate: 0, EndRate: 100, Duration: time.Minute},
+				{Kind: LoadStageSteady, StartRate: 100, EndRate: 100, Duration: 5 * time.Minute},
+				{Kind: LoadStageSpike, StartRate: 500, EndRate: 500, Duration: 30 * time.Second},
+				{Kind: LoadStageSoak, StartRate: 50, EndRate: 50, Duration: time.Hour},
+			},
+		},
+		{
+			name:    "empty",
+			profile: "",
+
+			expectedErrorStr: "no stages",
+		},
+		{
+			name:    "unknown kind",
+			profile: "burst:100:1m",
+
+			expectedErrorStr: "unknown stage kind",
+		},
+		{
+			name:    "ramp without end rate",
+			profile: "ramp:100:1m",
+
+			expectedErrorStr: "needs a start and an end rate",
+		},
+		{
+			name:    "negative rate",
+			profile: "steady:-1:1m",
+
+			expectedErrorStr: "invalid rate",
+		},
+		{
+			name:    "invalid duration",
+			profile: "steady:100:0s",
+
+			expectedErrorStr: "invalid duration",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			profile, err := ParseLoadProfile(tc.profile)
+
+			if tc.expectedErrorStr != "" {
+				require.ErrorIs(t, err, ErrInvalidLoadProfile)
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStages, profile.Stages)
+
+			// the profile is printed in the parsed format
+			reparsed, err := ParseLoadProfile(profile.String())
+			require.NoError(t, err)
+			require.Equal(t, profile, reparsed)
+		})
+	}
+}
+
+func TestLoadProfileTransactions(t *testing.T) {
+	profile, err := ParseLoadProfile("ramp:0-100:10s,steady:100:10s,spike:1000:1s")
+	require.NoError(t, err)
+
+	require.Equal(t, 21*time.Second, profile.Duration())
+
+	tt := []struct {
+		elapsed  time.Duration
+		expected float64
+	}{
+		{elapsed: 0, expected: 0},
+		{elapsed: 5 * time.Second, expected: 125},
+		{elapsed: 10 * time.Second, expected: 500},
+		{elapsed: 15 * time.Second, expected: 1000},
+		{elapsed: 20 * time.Second, expected: 1500},
+		{elapsed: 21 * time.Second, expected: 2500},
+		{elapsed: time.Minute, expected: 2500},
+	}
+
+	for _, tc := range tt {
+		require.InDelta(t, tc.expected, profile.Transactions(tc.elapsed), 1e-9, tc.elapsed.String())
+	}
+}
@@ -69,5 +69,7 @@ func (m *MetamorphBroadcaster) BroadcastTransaction(ctx context.Context, tx *bt.
 }
 
 func (m *MetamorphBroadcaster) GetTransactionStatus(ctx context.Context, txID string) (*metamorph_api.TransactionStatus, error) {
-	return nil, nil
+	return m.client.GetTransactionStatus(ctx, &metamorph_api.TransactionStatusRequest{
+		Txid: txID,
+	})
 }
@@ -0,0 +1,112 @@
+package broadcaster
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bitcoin-sv/arc/lib/fees"
+)
+
+const (
+	opReturnMessage = "ARC is here ... https://bitcoin-sv.github.io/arc/"
+	// opReturnOverhead is the maximum number of bytes of an OP_FALSE OP_RETURN script in addition to the payload, i.e.
+	// the two opcodes and the longest push data prefix.
+	opReturnOverhead = 2 + 5
+)
+
+var ErrInvalidOpReturnMix = errors.New("invalid OP_RETURN mix")
+
+// OpReturnSize is a payload size of the OP_RETURN output with the weight by which it is chosen.
+type OpReturnSize struct {
+	Size   int
+	Weight int
+}
+
+// OpReturnMix is the mix of the payload sizes of the OP_RETURN outputs of the generated transactions.
+type OpReturnMix struct {
+	Sizes       []OpReturnSize
+	totalWeight int
+}
+
+// ParseOpReturnMix parses a comma separated list of payload sizes in bytes, each with an optional weight, e.g.
+// 100:70,1000:20,100000:10. A size without weight has the weight 1.
+func ParseOpReturnMix(mix string) (*OpReturnMix, error) {
+	if strings.TrimSpace(mix) == "" {
+		return nil, fmt.Errorf("%w: no sizes", ErrInvalidOpReturnMix)
+	}
+
+	m := &OpReturnMix{}
+
+	for _, sizeStr := range strings.Split(mix, ",") {
+		parts := strings.Split(strings.TrimSpace(sizeStr), ":")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("%w: size %q is not of the form size:weight", ErrInvalidOpReturnMix, sizeStr)
+		}
+
+		size, err := strconv.Atoi(parts[0])
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("%w: invalid size %q", ErrInvalidOpReturnMix, parts[0])
+		}
+
+		weight := 1
+		if len(parts) == 2 {
+			weight, err = strconv.Atoi(parts[1])
+			if err != nil || weight <= 0 {
+				return nil, fmt.Errorf("%w: invalid weight %q", ErrInvalidOpReturnMix, parts[1])
+			}
+		}
+
+		m.Sizes = append(m.Sizes, OpReturnSize{Size: size, Weight: weight})
+		m.totalWeight += weight
+	}
+
+	return m, nil
+}
+
+// size returns the payload size of the output with the given index. The size is chosen by the weights, but depends
+// only on the index, so that the funding output and the transaction spending it agree on the size.
+func (m *OpReturnMix) size(index uint32) int {
+	// fibonacci hashing spreads consecutive indices over the weights
+	pick := int((uint64(index)*0x9E3779B97F4A7C15)>>32) % m.totalWeight
+
+	for _, size := range m.Sizes {
+		if pick < size.Weight {
+			return size.Size
+		}
+		pick -= size.Weight
+	}
+
+	return m.Sizes[len(m.Sizes)-1].Size
+}
+
+// opReturnPayload returns a payload of the given size which starts with the ARC message.
+func opReturnPayload(size int) []byte {
+	payload := make([]byte, size)
+	for i := 0; i < size; i += len(opReturnMessage) {
+		copy(payload[i:], opReturnMessage)
+	}
+
+	return payload
+}
+
+// opReturnSize returns the payload size of the OP_RETURN output of the transaction which spends the funding output
+// with the given index. Without OP_RETURN mix the transactions carry the ARC message.
+func (b *Broadcaster) opReturnSize(vout uint32) int {
+	if b.OpReturnMix == nil {
+		return len(opReturnMessage)
+	}
+
+	return b.OpReturnMix.size(vout)
+}
+
+// opReturnFee returns the data fee of the OP_RETURN output of the transaction which spends the funding output with the
+// given index.
+func (b *Broadcaster) opReturnFee(vout uint32) uint64 {
+	if b.OpReturnMix == nil {
+		return 0
+	}
+
+	return fees.EstimateDataFee(uint64(dataFee.MiningFee.Satoshis), uint64(b.opReturnSize(vout)+opReturnOverhead))
+}
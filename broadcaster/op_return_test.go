@@ -0,0 +1,142 @@
+package broadcaster
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bitcoin-sv/arc/lib/fees"
+	"github.com/bitcoin-sv/arc/lib/keyset"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOpReturnMix(t *testing.T) {
+	tt := []struct {
+		name string
+		mix  string
+
+		expectedSizes    []OpReturnSize
+		expectedErrorStr string
+	}{
+		{
+			name: "sizes with weights",
+			mix:  "100:70, 1000:20,100000:10",
+
+			expectedSizes: []OpReturnSize{{Size: 100, Weight: 70}, {Size: 1000, Weight: 20}, {Size: 100000, Weight: 10}},
+		},
+		{
+			name: "size without weight",
+			mix:  "1,500",
+
+			expectedSizes: []OpReturnSize{{Size: 1, Weight: 1}, {Size: 500, Weight: 1}},
+		},
+		{
+			name: "empty",
+			mix:  " ",
+
+			expectedErrorStr: "no sizes",
+		},
+		{
+			name: "invalid size",
+			mix:  "0",
+
+			expectedErrorStr: "invalid size",
+		},
+		{
+			name: "invalid weight",
+			mix:  "100:0",
+
+			expectedErrorStr: "invalid weight",
+		},
+		{
+			name: "too many parts",
+			mix:  "100:1:1",
+
+			expectedErrorStr: "not of the form size:weight",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			mix, err := ParseOpReturnMix(tc.mix)
+
+			if tc.expectedErrorStr != "" {
+				require.ErrorIs(t, err, ErrInvalidOpReturnMix)
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSizes, mix.Sizes)
+		})
+	}
+}
+
+func TestOpReturnMixSize(t *testing.T) {
+	mix, err := ParseOpReturnMix("100:70,1000:20,100000:10")
+	require.NoError(t, err)
+
+	counts := make(map[int]int)
+	for i := uint32(0); i < 10000; i++ {
+		counts[mix.size(i)]++
+		// the size depends only on the index
+		require.Equal(t, mix.size(i), mix.size(i))
+	}
+
+	require.InDelta(t, 7000, counts[100], 300)
+	require.InDelta(t, 2000, counts[1000], 300)
+	require.InDelta(t, 1000, counts[100000], 300)
+}
+
+func TestNewTransactionOpReturn(t *testing.T) {
+	key, err := keyset.New()
+	require.NoError(t, err)
+
+	mix, err := ParseOpReturnMix("1,100,1000,100000")
+	require.NoError(t, err)
+
+	for _, consolidate := range []bool{false, true} {
+		b := New(nil, nil, key, key, 4)
+		b.OpReturnMix = mix
+		b.Consolidate = consolidate
+
+		estimateFee := fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), 1, 1)
+
+		for vout := uint32(0); vout < 4; vout++ {
+			// the satoshis of the funding output as calculated by NewFundingTransaction
+			satoshis := estimateFee + 1 + b.opReturnFee(vout)
+			if consolidate {
+				satoshis = estimateFee*3 + b.opReturnFee(vout)
+			}
+
+			tx := b.NewTransaction(key, &bt.UTXO{
+				TxID:          bytes.Repeat([]byte{byte(vout + 1)}, 32),
+				Vout:          vout,
+				LockingScript: key.Script,
+				Satoshis:      satoshis,
+			})
+
+			var dataOutput *bt.Output
+			for _, output := range tx.Outputs {
+				if output.LockingScript.IsData() {
+					dataOutput = output
+				}
+			}
+			require.NotNil(t, dataOutput)
+
+			parts, err := bscript.DecodeParts((*dataOutput.LockingScript)[2:])
+			require.NoError(t, err)
+			payloadSize := 0
+			for _, part := range parts {
+				payloadSize += len(part)
+			}
+			require.Equal(t, mix.size(vout), payloadSize)
+
+			// the fee is paid by the standard and the data fee rate
+			feePaidEnough, err := tx.IsFeePaidEnough(b.FeeQuote)
+			require.NoError(t, err)
+			require.True(t, feePaidEnough, "vout %d, consolidate %t", vout, consolidate)
+		}
+	}
+}
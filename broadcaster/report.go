@@ -0,0 +1,134 @@
+package broadcaster
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// latencyBucketsMs are the upper bounds of the buckets of the latency histograms in milliseconds.
+var latencyBucketsMs = []float64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 300000, 600000, 1800000}
+
+// Report is the result of a run of the broadcaster.
+type Report struct {
+	Profile         string                           `json:"profile,omitempty"`
+	Start           time.Time                        `json:"start"`
+	DurationSeconds float64                          `json:"durationSeconds"`
+	Sent            uint64                           `json:"sent"`
+	Errors          uint64                           `json:"errors"`
+	RatePerSecond   float64                          `json:"ratePerSecond"`
+	Statuses        map[string]uint64                `json:"statuses"`
+	Latencies       map[LatencyStage]*LatencySummary `json:"latencies"`
+	Chain           *ChainReport                     `json:"chain,omitempty"`
+	DoubleSpend     *DoubleSpendReport               `json:"doubleSpend,omitempty"`
+}
+
+type HistogramBucket struct {
+	// UpperBoundMs is the inclusive upper bound of the bucket in milliseconds. It is omitted for the last bucket,
+	// which has no upper bound.
+	UpperBoundMs float64 `json:"upperBoundMs,omitempty"`
+	Count        uint64  `json:"count"`
+}
+
+// LatencySummary summarizes the latencies of a stage in milliseconds.
+type LatencySummary struct {
+	Count     uint64            `json:"count"`
+	MinMs     float64           `json:"minMs"`
+	MeanMs    float64           `json:"meanMs"`
+	P50Ms     float64           `json:"p50Ms"`
+	P95Ms     float64           `json:"p95Ms"`
+	P99Ms     float64           `json:"p99Ms"`
+	MaxMs     float64           `json:"maxMs"`
+	Histogram []HistogramBucket `json:"histogram"`
+}
+
+func newLatencySummary(samples []time.Duration) *LatencySummary {
+	summary := &LatencySummary{Count: uint64(len(samples))}
+
+	summary.Histogram = make([]HistogramBucket, len(latencyBucketsMs)+1)
+	for i, upperBound := range latencyBucketsMs {
+		summary.Histogram[i].UpperBoundMs = upperBound
+	}
+
+	if len(samples) == 0 {
+		return summary
+	}
+
+	ms := make([]float64, len(samples))
+	total := 0.0
+	for i, sample := range samples {
+		ms[i] = float64(sample.Microseconds()) / 1000
+		total += ms[i]
+
+		bucket := sort.SearchFloat64s(latencyBucketsMs, ms[i])
+		summary.Histogram[bucket].Count++
+	}
+	sort.Float64s(ms)
+
+	summary.MinMs = ms[0]
+	summary.MaxMs = ms[len(ms)-1]
+	summary.MeanMs = total / float64(len(ms))
+	summary.P50Ms = percentile(ms, 50)
+	summary.P95Ms = percentile(ms, 95)
+	summary.P99Ms = percentile(ms, 99)
+
+	return summary
+}
+
+// percentile returns the percentile of the sorted values by the nearest-rank method.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+
+	return sorted[max(rank, 1)-1]
+}
+
+// WriteJSON writes the report as JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes the latency summary of each stage as a row.
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"stage", "count", "min_ms", "mean_ms", "p50_ms", "p95_ms", "p99_ms", "max_ms"})
+	if err != nil {
+		return err
+	}
+
+	formatMs := func(ms float64) string {
+		return strconv.FormatFloat(ms, 'f', 3, 64)
+	}
+
+	for _, stage := range latencyStages {
+		summary, found := r.Latencies[stage]
+		if !found {
+			continue
+		}
+
+		err = writer.Write([]string{
+			string(stage),
+			strconv.FormatUint(summary.Count, 10),
+			formatMs(summary.MinMs),
+			formatMs(summary.MeanMs),
+			formatMs(summary.P50Ms),
+			formatMs(summary.P95Ms),
+			formatMs(summary.P99Ms),
+			formatMs(summary.MaxMs),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,114 @@
+package broadcaster
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencySummary(t *testing.T) {
+	samples := make([]time.Duration, 100)
+	for i := range samples {
+		samples[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	summary := newLatencySummary(samples)
+
+	require.Equal(t, uint64(100), summary.Count)
+	require.Equal(t, 1.0, summary.MinMs)
+	require.Equal(t, 100.0, summary.MaxMs)
+	require.Equal(t, 50.5, summary.MeanMs)
+	require.Equal(t, 50.0, summary.P50Ms)
+	require.Equal(t, 95.0, summary.P95Ms)
+	require.Equal(t, 99.0, summary.P99Ms)
+
+	// 1-10ms, 11-50ms and 51-100ms
+	require.Equal(t, uint64(10), summary.Histogram[0].Count)
+	require.Equal(t, uint64(40), summary.Histogram[1].Count)
+	require.Equal(t, uint64(50), summary.Histogram[2].Count)
+
+	empty := newLatencySummary(nil)
+	require.Equal(t, uint64(0), empty.Count)
+	require.Len(t, empty.Histogram, len(latencyBucketsMs)+1)
+}
+
+func TestLatencyRecorder(t *testing.T) {
+	recorder := newLatencyRecorder()
+	submittedAt := time.Now()
+
+	recorder.submit("tx1", submittedAt)
+	recorder.submit("tx2", submittedAt)
+
+	recorder.response("tx1", metamorph_api.Status_STORED, submittedAt.Add(10*time.Millisecond))
+	recorder.response("tx2", metamorph_api.Status_SEEN_ON_NETWORK, submittedAt.Add(20*time.Millisecond))
+	recorder.status("tx1", metamorph_api.Status_MINED, submittedAt.Add(time.Second))
+	// a status is only recorded once per transaction and only for submitted transactions
+	recorder.status("tx2", metamorph_api.Status_SEEN_ON_NETWORK, submittedAt.Add(time.Second))
+	recorder.status("tx3", metamorph_api.Status_MINED, submittedAt.Add(time.Second))
+
+	require.Equal(t, []string{"tx2"}, recorder.pending())
+
+	summaries := recorder.summaries()
+	require.Equal(t, uint64(2), summaries[LatencySubmitResponse].Count)
+	require.Equal(t, 20.0, summaries[LatencySubmitResponse].MaxMs)
+	require.Equal(t, uint64(2), summaries[LatencySubmitSeen].Count)
+	require.Equal(t, 1000.0, summaries[LatencySubmitSeen].MaxMs)
+	require.Equal(t, uint64(1), summaries[LatencySubmitMined].Count)
+}
+
+func TestCallbackHandler(t *testing.T) {
+	b := &Broadcaster{latency: newLatencyRecorder()}
+	b.latency.submit("tx1", time.Now())
+
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"txid":"tx1","txStatus":"MINED","timestamp":"2024-01-01T00:00:00Z"}`))
+	response := httptest.NewRecorder()
+	b.CallbackHandler().ServeHTTP(response, request)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Empty(t, b.latency.pending())
+
+	request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`invalid`))
+	response = httptest.NewRecorder()
+	b.CallbackHandler().ServeHTTP(response, request)
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestReportExport(t *testing.T) {
+	recorder := newLatencyRecorder()
+	submittedAt := time.Now()
+	recorder.submit("tx1", submittedAt)
+	recorder.response("tx1", metamorph_api.Status_SEEN_ON_NETWORK, submittedAt.Add(15*time.Millisecond))
+
+	report := &Report{
+		Profile:   "steady:10:1m",
+		Sent:      1,
+		Statuses:  map[string]uint64{"SEEN_ON_NETWORK": 1},
+		Latencies: recorder.summaries(),
+	}
+
+	jsonBuffer := &bytes.Buffer{}
+	require.NoError(t, report.WriteJSON(jsonBuffer))
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal(jsonBuffer.Bytes(), &decoded))
+	require.Equal(t, report.Profile, decoded.Profile)
+	require.Equal(t, 15.0, decoded.Latencies[LatencySubmitSeen].P99Ms)
+
+	csvBuffer := &bytes.Buffer{}
+	require.NoError(t, report.WriteCSV(csvBuffer))
+	require.Equal(t, strings.Join([]string{
+		"stage,count,min_ms,mean_ms,p50_ms,p95_ms,p99_ms,max_ms",
+		"submit_response,1,15.000,15.000,15.000,15.000,15.000,15.000",
+		"submit_seen,1,15.000,15.000,15.000,15.000,15.000,15.000",
+		"submit_mined,0,0.000,0.000,0.000,0.000,0.000,0.000",
+		"",
+	}, "\n"), csvBuffer.String())
+}
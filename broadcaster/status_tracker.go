@@ -0,0 +1,115 @@
+package broadcaster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/bitcoin-sv/arc/api"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+)
+
+// CallbackHandler records the statuses of the callbacks for the submitted transactions.
+func (b *Broadcaster) CallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var callback api.TransactionCallback
+		if err := json.NewDecoder(r.Body).Decode(&callback); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		b.latency.callback(callback.Txid)
+
+		if callback.TxStatus != nil {
+			status := metamorph_api.Status(metamorph_api.Status_value[*callback.TxStatus])
+			b.latency.status(callback.Txid, status, time.Now())
+			b.reconcileUtxos(r.Context(), callback.Txid, status)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// startCallbackServer listens for callbacks on the given address until the returned function is called.
+func (b *Broadcaster) startCallbackServer(address string) (func(), error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{Handler: b.CallbackHandler(), ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			b.logger.Errorf("callback server failed: %v", err)
+		}
+	}()
+
+	b.logger.Infof("listening for callbacks on %s", listener.Addr().String())
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = server.Shutdown(ctx)
+	}, nil
+}
+
+// waitForStatuses waits until all submitted transactions have been mined or the status timeout has passed. The
+// statuses are received by callbacks, and polled in the poll interval if it is set.
+func (b *Broadcaster) waitForStatuses(ctx context.Context) {
+	if b.StatusTimeout <= 0 || (b.PollInterval <= 0 && b.CallbackAddress == "") {
+		return
+	}
+
+	interval := b.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	timeout := time.NewTimer(b.StatusTimeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		pending := b.latency.pending()
+		if len(pending) == 0 {
+			return
+		}
+
+		b.logger.Infof("waiting for %d transactions to be mined", len(pending))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timeout.C:
+			b.logger.Warnf("%d transactions have not been mined within %s", len(pending), b.StatusTimeout)
+			return
+		case <-ticker.C:
+		}
+
+		if b.PollInterval > 0 {
+			b.pollStatuses(ctx, pending)
+		}
+	}
+}
+
+func (b *Broadcaster) pollStatuses(ctx context.Context, txIDs []string) {
+	for _, txID := range txIDs {
+		res, err := b.Client.GetTransactionStatus(ctx, txID)
+		if err != nil {
+			b.logger.Errorf("failed to get status of tx %s: %v", txID, err)
+			continue
+		}
+
+		if res != nil {
+			b.latency.status(txID, res.GetStatus(), time.Now())
+			b.reconcileUtxos(ctx, txID, res.GetStatus())
+		}
+	}
+}
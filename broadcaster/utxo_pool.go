@@ -0,0 +1,116 @@
+package broadcaster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bitcoin-sv/arc/lib/fees"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/unlocker"
+)
+
+// addPoolFundingInputs adds unspent outputs of the funding key from the UTXO pool with at least the given satoshis, or
+// all unspent outputs if satoshis is 0. It returns false if the pool does not have enough satoshis.
+func (b *Broadcaster) addPoolFundingInputs(tx *bt.Tx, satoshis uint64) (bool, error) {
+	utxos, err := b.UtxoPool.GetUnspent(context.Background(), b.FromKeySet.Script, satoshis)
+	if err != nil {
+		return false, fmt.Errorf("failed to get unspent outputs from UTXO pool: %v", err)
+	}
+
+	total := uint64(0)
+	for _, utxo := range utxos {
+		total += utxo.Satoshis
+	}
+
+	if len(utxos) == 0 || total < satoshis {
+		return false, nil
+	}
+
+	return true, tx.FromUTXOs(utxos...)
+}
+
+// spendUtxos records the transactions in the UTXO pool before they are sent.
+func (b *Broadcaster) spendUtxos(ctx context.Context, txs ...*bt.Tx) {
+	if b.UtxoPool == nil {
+		return
+	}
+
+	for _, tx := range txs {
+		if err := b.UtxoPool.Spend(ctx, tx); err != nil {
+			b.logger.Errorf("failed to record tx %s in UTXO pool: %v", tx.TxID(), err)
+		}
+	}
+}
+
+// rejectUtxos reverts the transactions in the UTXO pool which could not be sent or have been rejected.
+func (b *Broadcaster) rejectUtxos(ctx context.Context, txIDs ...string) {
+	if b.UtxoPool == nil {
+		return
+	}
+
+	for _, txID := range txIDs {
+		if err := b.UtxoPool.Reject(ctx, txID); err != nil {
+			b.logger.Errorf("failed to revert tx %s in UTXO pool: %v", txID, err)
+		}
+	}
+}
+
+// reconcileUtxos reverts the transaction in the UTXO pool if it has been rejected.
+func (b *Broadcaster) reconcileUtxos(ctx context.Context, txID string, status metamorph_api.Status) {
+	if status == metamorph_api.Status_REJECTED {
+		b.rejectUtxos(ctx, txID)
+	}
+}
+
+// Prepare fans out the funding UTXOs into the given number of outputs of equal value to the funding key and records
+// them in the UTXO pool, so that later runs are funded from the pool.
+func (b *Broadcaster) Prepare(ctx context.Context, outputs int64) (*bt.Tx, error) {
+	if b.UtxoPool == nil {
+		return nil, fmt.Errorf("UTXO pool is required to prepare outputs")
+	}
+
+	if outputs < 1 {
+		return nil, fmt.Errorf("number of outputs has to be at least 1")
+	}
+
+	tx := bt.NewTx()
+
+	if err := b.addFundingInputs(tx, 1, 0); err != nil {
+		return nil, err
+	}
+
+	fee := fees.EstimateFee(uint64(stdFee.MiningFee.Satoshis), uint64(len(tx.Inputs)), uint64(outputs))
+	if tx.TotalInputSatoshis() < fee+uint64(outputs)*bt.DustLimit {
+		return nil, fmt.Errorf("funding inputs have %d satoshis, which is not enough for %d outputs", tx.TotalInputSatoshis(), outputs)
+	}
+
+	satoshis := (tx.TotalInputSatoshis() - fee) / uint64(outputs)
+	for i := int64(0); i < outputs; i++ {
+		if err := tx.PayTo(b.FromKeySet.Script, satoshis); err != nil {
+			return nil, err
+		}
+	}
+
+	unlockerGetter := unlocker.Getter{PrivateKey: b.FromKeySet.PrivateKey}
+	if err := tx.FillAllInputs(context.Background(), &unlockerGetter); err != nil {
+		return nil, err
+	}
+
+	b.logger.Infof("preparing %d outputs of %d satoshis in tx %s", outputs, satoshis, tx.TxID())
+
+	b.spendUtxos(ctx, tx)
+
+	res, err := b.Client.BroadcastTransaction(ctx, tx, metamorph_api.Status(b.WaitForStatus))
+	if err != nil {
+		b.rejectUtxos(ctx, tx.TxID())
+		return nil, fmt.Errorf("error broadcasting prepare tx: %v", err)
+	}
+
+	if res.GetStatus() == metamorph_api.Status_REJECTED {
+		b.rejectUtxos(ctx, tx.TxID())
+		return nil, fmt.Errorf("prepare tx %s has been rejected: %s", tx.TxID(), res.GetRejectReason())
+	}
+
+	return tx, nil
+}
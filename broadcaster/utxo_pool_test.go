@@ -0,0 +1,95 @@
+package broadcaster
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bitcoin-sv/arc/broadcaster/utxopool"
+	"github.com/bitcoin-sv/arc/lib/keyset"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/libsv/go-bt/v2"
+	"github.com/ordishs/gocore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepare(t *testing.T) {
+	tt := []struct {
+		name   string
+		status metamorph_api.Status
+
+		expectedErrorStr string
+		expectedUnspent  int
+	}{
+		{
+			name:   "prepared",
+			status: metamorph_api.Status_SEEN_ON_NETWORK,
+
+			expectedUnspent: 10,
+		},
+		{
+			name:   "rejected",
+			status: metamorph_api.Status_REJECTED,
+
+			expectedErrorStr: "has been rejected",
+			expectedUnspent:  1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			key, err := keyset.New()
+			require.NoError(t, err)
+
+			pool, err := utxopool.New("")
+			require.NoError(t, err)
+			defer pool.Close()
+
+			// seed the pool with one output of the funding key
+			seedTx := bt.NewTx()
+			require.NoError(t, seedTx.FromUTXOs(&bt.UTXO{
+				TxID:          bytes.Repeat([]byte{1}, 32),
+				LockingScript: key.Script,
+				Satoshis:      100_001_000,
+			}))
+			require.NoError(t, seedTx.PayTo(key.Script, 100_000_000))
+			require.NoError(t, pool.Spend(ctx, seedTx))
+
+			client := &chainClient{
+				statuses: func(_ int) (metamorph_api.Status, error) { return tc.status, nil },
+				depths:   make(map[string]int),
+			}
+
+			b := New(gocore.Log("test"), client, key, key, 0)
+			b.IsRegtest = false
+			b.UtxoPool = pool
+
+			tx, err := b.Prepare(ctx, 10)
+
+			if tc.expectedErrorStr != "" {
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, tx.Outputs, 10)
+				require.Equal(t, seedTx.TxIDBytes(), tx.Inputs[0].PreviousTxID())
+
+				feePaidEnough, err := tx.IsFeePaidEnough(b.FeeQuote)
+				require.NoError(t, err)
+				require.True(t, feePaidEnough)
+			}
+
+			utxos, err := pool.GetUnspent(ctx, key.Script, 0)
+			require.NoError(t, err)
+			require.Len(t, utxos, tc.expectedUnspent)
+
+			// the funding transactions of later runs are funded from the pool
+			if tc.expectedErrorStr == "" {
+				fundingTx := b.NewFundingTransaction(2, 1)
+				require.Len(t, fundingTx.Inputs, 1)
+				require.Equal(t, tx.TxIDBytes(), fundingTx.Inputs[0].PreviousTxID())
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package utxopool
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/labstack/gommon/random"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/ordishs/gocore"
+	_ "modernc.org/sqlite"
+)
+
+// SQLite is a local pool of the outputs of the transactions sent by the broadcaster. The outputs of each transaction
+// are recorded and the outputs which it spends are marked as spent before it is sent. If the transaction is rejected,
+// the outputs which it spends are unspent again and its own outputs are removed.
+type SQLite struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func WithNow(nowFunc func() time.Time) func(*SQLite) {
+	return func(s *SQLite) {
+		s.now = nowFunc
+	}
+}
+
+// New opens the pool in the given file. If the file name is empty, the pool is kept in memory.
+func New(fileName string, opts ...func(*SQLite)) (*SQLite, error) {
+	var dataSourceName string
+	if fileName == "" {
+		dataSourceName = fmt.Sprintf("file:%s?mode=memory&cache=shared", random.String(16))
+	} else {
+		absFileName, err := filepath.Abs(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absolute path for UTXO pool: %v", err)
+		}
+		dataSourceName = fmt.Sprintf("%s?_pragma=busy_timeout=10000&_pragma=journal_mode=WAL", absFileName)
+	}
+
+	db, err := sql.Open("sqlite", dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open UTXO pool: %v", err)
+	}
+
+	if _, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS utxos (
+		tx_id BLOB NOT NULL,
+		vout INTEGER NOT NULL,
+		locking_script BLOB NOT NULL,
+		satoshis BIGINT NOT NULL,
+		spent_by BLOB,
+		created_at TEXT NOT NULL,
+		PRIMARY KEY (tx_id, vout)
+		);
+	`); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("could not create utxos table: %v", err)
+	}
+
+	if _, err = db.Exec(`CREATE INDEX IF NOT EXISTS ix_utxos_spent_by ON utxos (spent_by);`); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("could not create index ix_utxos_spent_by: %v", err)
+	}
+
+	s := &SQLite{
+		db:  db,
+		now: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// GetUnspent returns the unspent outputs locked by the script, the largest first, until their total reaches the given
+// satoshis. If satoshis is 0, all unspent outputs are returned.
+func (s *SQLite) GetUnspent(ctx context.Context, script *bscript.Script, satoshis uint64) ([]*bt.UTXO, error) {
+	startNanos := s.now().UnixNano()
+	defer func() {
+		gocore.NewStat("brdcst_utxo_pool").NewStat("GetUnspent").AddTime(startNanos)
+	}()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT tx_id, vout, satoshis FROM utxos
+		WHERE locking_script = $1 AND spent_by IS NULL
+		ORDER BY satoshis DESC;
+	`, []byte(*script))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	utxos := make([]*bt.UTXO, 0)
+	total := uint64(0)
+	for rows.Next() {
+		if satoshis > 0 && total >= satoshis {
+			break
+		}
+
+		utxo := &bt.UTXO{LockingScript: script}
+		if err = rows.Scan(&utxo.TxID, &utxo.Vout, &utxo.Satoshis); err != nil {
+			return nil, err
+		}
+
+		utxos = append(utxos, utxo)
+		total += utxo.Satoshis
+	}
+
+	return utxos, rows.Err()
+}
+
+// Spend marks the outputs spent by the transaction as spent and records its outputs, except for the OP_RETURN outputs.
+func (s *SQLite) Spend(ctx context.Context, tx *bt.Tx) error {
+	startNanos := s.now().UnixNano()
+	defer func() {
+		gocore.NewStat("brdcst_utxo_pool").NewStat("Spend").AddTime(startNanos)
+	}()
+
+	dbTx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = dbTx.Rollback()
+	}()
+
+	txID := tx.TxIDBytes()
+
+	for _, input := range tx.Inputs {
+		_, err = dbTx.ExecContext(ctx, `UPDATE utxos SET spent_by = $1 WHERE tx_id = $2 AND vout = $3;`, txID, input.PreviousTxID(), input.PreviousTxOutIndex)
+		if err != nil {
+			return fmt.Errorf("failed to mark output %s:%d as spent: %v", hex.EncodeToString(input.PreviousTxID()), input.PreviousTxOutIndex, err)
+		}
+	}
+
+	createdAt := s.now().UTC().Format(time.RFC3339)
+	for vout, output := range tx.Outputs {
+		if output.LockingScript.IsData() {
+			continue
+		}
+
+		_, err = dbTx.ExecContext(ctx, `
+			INSERT INTO utxos (tx_id, vout, locking_script, satoshis, created_at) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT DO NOTHING;
+		`, txID, vout, []byte(*output.LockingScript), output.Satoshis, createdAt)
+		if err != nil {
+			return fmt.Errorf("failed to record output %s:%d: %v", tx.TxID(), vout, err)
+		}
+	}
+
+	return dbTx.Commit()
+}
+
+// Reject unspends the outputs spent by the rejected transaction and removes its outputs.
+func (s *SQLite) Reject(ctx context.Context, txID string) error {
+	startNanos := s.now().UnixNano()
+	defer func() {
+		gocore.NewStat("brdcst_utxo_pool").NewStat("Reject").AddTime(startNanos)
+	}()
+
+	txIDBytes, err := hex.DecodeString(txID)
+	if err != nil {
+		return fmt.Errorf("invalid tx id %s: %v", txID, err)
+	}
+
+	dbTx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = dbTx.Rollback()
+	}()
+
+	if _, err = dbTx.ExecContext(ctx, `UPDATE utxos SET spent_by = NULL WHERE spent_by = $1;`, txIDBytes); err != nil {
+		return fmt.Errorf("failed to unspend outputs spent by %s: %v", txID, err)
+	}
+
+	if _, err = dbTx.ExecContext(ctx, `DELETE FROM utxos WHERE tx_id = $1;`, txIDBytes); err != nil {
+		return fmt.Errorf("failed to remove outputs of %s: %v", txID, err)
+	}
+
+	return dbTx.Commit()
+}
+
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,86 @@
+package utxopool
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bitcoin-sv/arc/lib/keyset"
+	"github.com/libsv/go-bt/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := keyset.New()
+	require.NoError(t, err)
+	otherKey, err := keyset.New()
+	require.NoError(t, err)
+
+	pool, err := New("")
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// the funding transaction fans out one external UTXO to the key
+	fundingTx := bt.NewTx()
+	require.NoError(t, fundingTx.From("2cb4e0a9ee1cb72bbc4f1c0e8e4ca41e1b0a7b5c3f9f1d13e4e2d5c8f1c1e0a1", 0, key.Script.String(), 10_000))
+	require.NoError(t, fundingTx.PayTo(key.Script, 1000))
+	require.NoError(t, fundingTx.PayTo(key.Script, 3000))
+	require.NoError(t, fundingTx.PayTo(otherKey.Script, 2000))
+	require.NoError(t, fundingTx.AddOpReturnOutput([]byte("data")))
+
+	require.NoError(t, pool.Spend(ctx, fundingTx))
+
+	utxos, err := pool.GetUnspent(ctx, key.Script, 0)
+	require.NoError(t, err)
+	require.Len(t, utxos, 2)
+	require.Equal(t, uint64(3000), utxos[0].Satoshis)
+	require.Equal(t, uint32(1), utxos[0].Vout)
+	require.Equal(t, fundingTx.TxIDBytes(), utxos[0].TxID)
+	require.Equal(t, uint64(1000), utxos[1].Satoshis)
+
+	// the largest outputs are returned until the satoshis are reached
+	utxos, err = pool.GetUnspent(ctx, key.Script, 2500)
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+
+	utxos, err = pool.GetUnspent(ctx, otherKey.Script, 0)
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+
+	// spending an output marks it as spent and records the new output
+	tx := bt.NewTx()
+	require.NoError(t, tx.FromUTXOs(&bt.UTXO{
+		TxID:          fundingTx.TxIDBytes(),
+		Vout:          1,
+		LockingScript: key.Script,
+		Satoshis:      3000,
+	}))
+	require.NoError(t, tx.PayTo(otherKey.Script, 2900))
+
+	require.NoError(t, pool.Spend(ctx, tx))
+
+	utxos, err = pool.GetUnspent(ctx, key.Script, 0)
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	require.Equal(t, uint64(1000), utxos[0].Satoshis)
+
+	utxos, err = pool.GetUnspent(ctx, otherKey.Script, 0)
+	require.NoError(t, err)
+	require.Len(t, utxos, 2)
+
+	// a rejected transaction unspends its inputs and removes its outputs
+	require.NoError(t, pool.Reject(ctx, tx.TxID()))
+
+	utxos, err = pool.GetUnspent(ctx, key.Script, 0)
+	require.NoError(t, err)
+	require.Len(t, utxos, 2)
+
+	utxos, err = pool.GetUnspent(ctx, otherKey.Script, 0)
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	require.False(t, bytes.Equal(tx.TxIDBytes(), utxos[0].TxID))
+
+	require.ErrorContains(t, pool.Reject(ctx, "xyz"), "invalid tx id")
+}
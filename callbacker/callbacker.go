@@ -9,11 +9,13 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/bitcoin-sv/arc/api"
 	"github.com/bitcoin-sv/arc/callbacker/callbacker_api"
 	"github.com/bitcoin-sv/arc/callbacker/store"
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
 	"github.com/ordishs/go-utils"
 )
@@ -28,6 +30,7 @@ type Callbacker struct {
 	store                 store.Store
 	ticker                *time.Ticker
 	sendCallbacksInterval time.Duration
+	callbackPolicy        atomic.Pointer[callbackpolicy.Policy]
 	shutdownCompleteStart chan struct{}
 	shutdown              chan struct{}
 }
@@ -44,6 +47,13 @@ func WithSendCallbacksInterval(d time.Duration) func(callbacker *Callbacker) {
 	}
 }
 
+// WithCallbackPolicy enforces the policy when sending callbacks. Callbacks to URLs which are not allowed are discarded.
+func WithCallbackPolicy(policy *callbackpolicy.Policy) func(callbacker *Callbacker) {
+	return func(p *Callbacker) {
+		p.callbackPolicy.Store(policy)
+	}
+}
+
 type Option func(f *Callbacker)
 
 // New creates a new callback worker.
@@ -95,6 +105,16 @@ func (c *Callbacker) Stop() {
 	<-c.shutdownCompleteStart
 }
 
+// SetSendCallbacksInterval changes the interval in which the stored callbacks are sent. It has to be called after Start.
+func (c *Callbacker) SetSendCallbacksInterval(d time.Duration) {
+	c.ticker.Reset(d)
+}
+
+// SetCallbackPolicy replaces the policy enforced when sending callbacks.
+func (c *Callbacker) SetCallbackPolicy(policy *callbackpolicy.Policy) {
+	c.callbackPolicy.Store(policy)
+}
+
 func (c *Callbacker) AddCallback(ctx context.Context, callback *callbacker_api.Callback) (string, error) {
 	key, err := c.store.Set(ctx, callback)
 	if err != nil {
@@ -138,6 +158,19 @@ func (c *Callbacker) sendCallbacks() error {
 func (c *Callbacker) sendCallback(key string, callback *callbacker_api.Callback) error {
 	txId := utils.ReverseAndHexEncodeSlice(callback.GetHash())
 
+	callbackPolicy := c.callbackPolicy.Load()
+
+	err := callbackPolicy.Validate(callback.GetUrl())
+	if err != nil {
+		// the callback will never be allowed, therefore it is removed
+		errDel := c.store.Del(context.Background(), key)
+		if errDel != nil {
+			return errors.Join(err, fmt.Errorf("failed to delete callback with key %s: %v", key, errDel))
+		}
+
+		return fmt.Errorf("callback for transaction id %s not sent: %w", txId, err)
+	}
+
 	statusString := metamorph_api.Status(callback.GetStatus()).String()
 	blockHash := ""
 	if callback.BlockHash != nil {
@@ -146,13 +179,23 @@ func (c *Callbacker) sendCallback(key string, callback *callbacker_api.Callback)
 
 	c.logger.Info("sending callback for transaction", slog.String("token", callback.GetToken()), slog.String("hash", txId), slog.String("url", callback.GetUrl()), slog.Uint64("block height", callback.GetBlockHeight()), slog.String("block hash", blockHash))
 
-	status := &api.TransactionStatus{
+	status := &api.TransactionCallback{
+		Version:     api.CallbackPayloadVersion,
 		BlockHash:   &blockHash,
 		BlockHeight: &callback.BlockHeight,
 		TxStatus:    &statusString,
 		Txid:        txId,
 		Timestamp:   time.Now(),
 	}
+	if callback.GetMerklePath() != "" {
+		status.MerklePath = &callback.MerklePath
+	}
+	if callback.GetExtraInfo() != "" {
+		status.ExtraInfo = &callback.ExtraInfo
+	}
+	if len(callback.GetCompetingTxs()) > 0 {
+		status.CompetingTxs = &callback.CompetingTxs
+	}
 	statusBytes, err := json.Marshal(status)
 	if err != nil {
 		return err
@@ -169,9 +212,8 @@ func (c *Callbacker) sendCallback(key string, callback *callbacker_api.Callback)
 		request.Header.Set("Authorization", "Bearer "+callback.GetToken())
 	}
 
-	// default http client
-	httpClient := http.Client{}
-	httpClient.Timeout = 5 * time.Second
+	// the client checks the IP addresses the callback URL resolves to and redirects against the policy
+	httpClient := callbackPolicy.HTTPClient(5 * time.Second)
 
 	var response *http.Response
 	response, err = httpClient.Do(request)
@@ -2,18 +2,22 @@ package callbacker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/bitcoin-sv/arc/api"
 	"github.com/bitcoin-sv/arc/callbacker/callbacker_api"
 	"github.com/bitcoin-sv/arc/callbacker/store"
 	"github.com/bitcoin-sv/arc/callbacker/store/badgerhold"
 	"github.com/bitcoin-sv/arc/callbacker/store/mock"
 	"github.com/bitcoin-sv/arc/callbacker/store/mock_gen"
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/jarcoal/httpmock"
 	"github.com/ordishs/go-utils"
 	"github.com/stretchr/testify/assert"
@@ -145,6 +149,103 @@ func TestCallbacker_AddCallback(t *testing.T) {
 	}
 }
 
+func TestCallbacker_sendCallbackNotAllowed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", testURL, httpmock.NewStringResponder(200, "OK"))
+
+	mockStore, err := mock.New()
+	require.NoError(t, err)
+
+	key, err := mockStore.Set(context.Background(), testCallback)
+	require.NoError(t, err)
+
+	policy, err := callbackpolicy.New(callbackpolicy.WithBlockPrivateIPs(true))
+	require.NoError(t, err)
+
+	cb, err := New(mockStore, WithCallbackPolicy(policy))
+	require.NoError(t, err)
+
+	err = cb.sendCallback(key, testCallback)
+	require.ErrorIs(t, err, callbackpolicy.ErrHostNotAllowed)
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 0, info[fmt.Sprintf("POST %s", testURL)])
+
+	// callback is removed from the store as it will never be allowed
+	_, err = mockStore.Get(context.Background(), key)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestCallbacker_sendCallbackPayload(t *testing.T) {
+	merklePath := "fe54251800020400028d97f9ebeddd9f9aa8e0e953b3a76f316298ab05e9834aa811716e9d397564e501"
+	competingTx := "c0d6fce714e4225614f000c6a5addaaa1341acbb9c87115114dcf84f37b945a6"
+	extraInfo := "double spend attempted"
+
+	tt := []struct {
+		name     string
+		callback *callbacker_api.Callback
+
+		expectedMerklePath   *string
+		expectedExtraInfo    *string
+		expectedCompetingTxs *[]string
+	}{
+		{
+			name:     "without optional fields",
+			callback: testCallback,
+		},
+		{
+			name: "with merkle path, extra info and competing txs",
+			callback: &callbacker_api.Callback{
+				Hash:         tx1Bytes,
+				Url:          testURL,
+				Status:       10,
+				MerklePath:   merklePath,
+				ExtraInfo:    extraInfo,
+				CompetingTxs: []string{competingTx},
+			},
+
+			expectedMerklePath:   &merklePath,
+			expectedExtraInfo:    &extraInfo,
+			expectedCompetingTxs: &[]string{competingTx},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			var payload api.TransactionCallback
+			httpmock.RegisterResponder("POST", testURL, func(req *http.Request) (*http.Response, error) {
+				err := json.NewDecoder(req.Body).Decode(&payload)
+				require.NoError(t, err)
+
+				return httpmock.NewStringResponse(200, "OK"), nil
+			})
+
+			mockStore := &mock_gen.StoreMock{
+				DelFunc: func(ctx context.Context, key string) error {
+					return nil
+				},
+			}
+
+			cb, err := New(mockStore)
+			require.NoError(t, err)
+
+			err = cb.sendCallback("ffdK2n44BwsyCrz9jTH12fxuEGoLYhDh", tc.callback)
+			require.NoError(t, err)
+
+			require.Equal(t, api.CallbackPayloadVersion, payload.Version)
+			require.Equal(t, tx1, payload.Txid)
+			require.Equal(t, tc.expectedMerklePath, payload.MerklePath)
+			require.Equal(t, tc.expectedExtraInfo, payload.ExtraInfo)
+			require.Equal(t, tc.expectedCompetingTxs, payload.CompetingTxs)
+		})
+	}
+}
+
 func TestCallbacker_Start(t *testing.T) {
 	tt := []struct {
 		name            string
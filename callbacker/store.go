@@ -6,11 +6,10 @@ import (
 	"path/filepath"
 	"time"
 
-	"github.com/bitcoin-sv/arc/callbacker/store"
 	callbackerBadgerhold "github.com/bitcoin-sv/arc/callbacker/store/badgerhold"
 )
 
-func NewStore(folder string, interval time.Duration) (store.Store, error) {
+func NewStore(folder string, interval time.Duration) (*callbackerBadgerhold.BadgerHold, error) {
 	f, err := filepath.Abs(path.Join(folder, "callbacker"))
 	if err != nil {
 		return nil, fmt.Errorf("could not get absolute path: %v", err)
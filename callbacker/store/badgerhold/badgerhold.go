@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bitcoin-sv/arc/callbacker/callbacker_api"
@@ -27,13 +28,16 @@ type BadgerData struct {
 	Status        int32
 	BlockHash     []byte
 	BlockHeight   uint64
+	MerklePath    string
+	ExtraInfo     string
+	CompetingTxs  []string
 }
 
 type BadgerHold struct {
 	store              *badgerhold.Store
 	mu                 sync.RWMutex
 	maxCallbackRetries int
-	interval           time.Duration
+	interval           atomic.Int64
 }
 
 type loggerWrapper struct {
@@ -70,11 +74,19 @@ func New(dir string, interval time.Duration) (*BadgerHold, error) {
 		return nil, err
 	}
 
-	return &BadgerHold{
+	bh := &BadgerHold{
 		store:              s,
 		maxCallbackRetries: 32,
-		interval:           interval,
-	}, nil
+	}
+	bh.SetInterval(interval)
+
+	return bh, nil
+}
+
+// SetInterval changes the interval after which callbacks are sent for the first time and which is increased with
+// every retry.
+func (bh *BadgerHold) SetInterval(interval time.Duration) {
+	bh.interval.Store(int64(interval))
 }
 
 func (bh *BadgerHold) Get(_ context.Context, key string) (*callbacker_api.Callback, error) {
@@ -88,12 +100,15 @@ func (bh *BadgerHold) Get(_ context.Context, key string) (*callbacker_api.Callba
 	}
 
 	return &callbacker_api.Callback{
-		Hash:        result.Hash,
-		Url:         result.Url,
-		Token:       result.Token,
-		Status:      result.Status,
-		BlockHash:   result.BlockHash,
-		BlockHeight: result.BlockHeight,
+		Hash:         result.Hash,
+		Url:          result.Url,
+		Token:        result.Token,
+		Status:       result.Status,
+		BlockHash:    result.BlockHash,
+		BlockHeight:  result.BlockHeight,
+		MerklePath:   result.MerklePath,
+		ExtraInfo:    result.ExtraInfo,
+		CompetingTxs: result.CompetingTxs,
 	}, nil
 }
 
@@ -107,12 +122,15 @@ func (bh *BadgerHold) GetExpired(_ context.Context) (map[string]*callbacker_api.
 	callbacks := make(map[string]*callbacker_api.Callback)
 	for _, callback := range result {
 		callbacks[callback.Key] = &callbacker_api.Callback{
-			Hash:        callback.Hash,
-			Url:         callback.Url,
-			Token:       callback.Token,
-			Status:      callback.Status,
-			BlockHash:   callback.BlockHash,
-			BlockHeight: callback.BlockHeight,
+			Hash:         callback.Hash,
+			Url:          callback.Url,
+			Token:        callback.Token,
+			Status:       callback.Status,
+			BlockHash:    callback.BlockHash,
+			BlockHeight:  callback.BlockHeight,
+			MerklePath:   callback.MerklePath,
+			ExtraInfo:    callback.ExtraInfo,
+			CompetingTxs: callback.CompetingTxs,
 		}
 	}
 
@@ -125,7 +143,7 @@ func (bh *BadgerHold) Set(_ context.Context, callback *callbacker_api.Callback)
 	}
 
 	key := random.String(32)
-	callbackAfter := time.Now().Add(bh.interval)
+	callbackAfter := time.Now().Add(time.Duration(bh.interval.Load()))
 	value := BadgerData{
 		Key:           key,
 		CallbackAfter: callbackAfter,
@@ -136,6 +154,9 @@ func (bh *BadgerHold) Set(_ context.Context, callback *callbacker_api.Callback)
 		Status:        callback.GetStatus(),
 		BlockHash:     callback.GetBlockHash(),
 		BlockHeight:   callback.GetBlockHeight(),
+		MerklePath:    callback.GetMerklePath(),
+		ExtraInfo:     callback.GetExtraInfo(),
+		CompetingTxs:  callback.GetCompetingTxs(),
 	}
 	if err := bh.store.Upsert(key, value); err != nil {
 		return "", fmt.Errorf("failed to insert data: %w", err)
@@ -163,7 +184,7 @@ func (bh *BadgerHold) UpdateExpiry(_ context.Context, key string) error {
 		return store.ErrMaxRetries
 	}
 
-	nextTry := bh.incrementInterval(bh.interval, data.CallbackCount)
+	nextTry := bh.incrementInterval(time.Duration(bh.interval.Load()), data.CallbackCount)
 	data.CallbackAfter = time.Now().Add(nextTry)
 
 	if err := bh.store.Update(key, data); err != nil {
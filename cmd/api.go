@@ -11,6 +11,9 @@ import (
 	"github.com/bitcoin-sv/arc/api"
 	"github.com/bitcoin-sv/arc/api/handler"
 	"github.com/bitcoin-sv/arc/api/transaction_handler"
+	"github.com/bitcoin-sv/arc/blocktx"
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/config"
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
 	apmecho "github.com/opentracing-contrib/echo"
@@ -20,7 +23,9 @@ import (
 	"github.com/spf13/viper"
 )
 
-func StartAPIServer(logger *slog.Logger) (func(), error) {
+// StartAPIServer starts the API server. If the reloader is not nil, the policies of the API handler are replaced when
+// the configuration is reloaded.
+func StartAPIServer(logger *slog.Logger, reloader *config.Reloader) (func(), error) {
 	// Set up a basic Echo router
 	e := echo.New()
 	e.HideBanner = true
@@ -44,7 +49,7 @@ func StartAPIServer(logger *slog.Logger) (func(), error) {
 
 	// load the ARC handler from config
 	// If you want to customize this for your own server, see examples dir
-	if err := LoadArcHandler(e, logger); err != nil {
+	if err := LoadArcHandler(e, logger, reloader); err != nil {
 		panic(err)
 	}
 
@@ -77,7 +82,7 @@ func StartAPIServer(logger *slog.Logger) (func(), error) {
 	}, nil
 }
 
-func LoadArcHandler(e *echo.Echo, logger *slog.Logger) error {
+func LoadArcHandler(e *echo.Echo, logger *slog.Logger, reloader *config.Reloader) error {
 	// check the swagger definition against our requests
 	handler.CheckSwagger(e)
 
@@ -98,27 +103,73 @@ func LoadArcHandler(e *echo.Echo, logger *slog.Logger) error {
 		return err
 	}
 
-	var policy *bitcoin.Settings
-	policy, err = getPolicyFromNode()
+	policy, err := getPolicy()
 	if err != nil {
-		policy, err = handler.GetDefaultPolicy()
+		return err
+	}
+
+	callbackPolicy, err := config.GetCallbackPolicy()
+	if err != nil {
+		return err
+	}
+
+	opts := []handler.Option{handler.WithCallbackPolicy(callbackPolicy)}
+
+	// block queries are only available if the blocktx service can be reached
+	blocktxAddress := viper.GetString("blocktx.dialAddr")
+	if blocktxAddress != "" {
+		conn, err := blocktx.DialGRPC(blocktxAddress)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to connect to block-tx server: %v", err)
 		}
+
+		opts = append(opts, handler.WithBlockTxClient(blocktx.NewClient(blocktx_api.NewBlockTxAPIClient(conn))))
+	} else {
+		logger.Warn("blocktx.dialAddr not found in config, block queries are disabled")
 	}
 
 	// TODO WithSecurityConfig(appConfig.Security)
-	apiHandler, err := handler.NewDefault(logger, txHandler, policy)
+	apiHandler, err := handler.NewDefault(logger, txHandler, policy, opts...)
 	if err != nil {
 		return err
 	}
 
+	defaultHandler, ok := apiHandler.(*handler.ArcDefaultHandler)
+	if reloader != nil && ok {
+		reloader.OnReload(func(cfg *config.ArcConfig) error {
+			policy, err := getPolicy()
+			if err != nil {
+				return err
+			}
+
+			callbackPolicy, err := config.NewCallbackPolicy(cfg.CallbackPolicy)
+			if err != nil {
+				return err
+			}
+
+			defaultHandler.SetNodePolicy(policy)
+			defaultHandler.SetCallbackPolicy(callbackPolicy)
+
+			return nil
+		})
+	}
+
 	// Register the ARC API
 	api.RegisterHandlers(e, apiHandler)
 
 	return nil
 }
 
+// getPolicy returns the policy of the node, or the default policy of the configuration if the node cannot be reached.
+func getPolicy() (*bitcoin.Settings, error) {
+	policy, err := getPolicyFromNode()
+	if err != nil {
+		return handler.GetDefaultPolicy()
+	}
+
+	return policy, nil
+}
+
 func getPolicyFromNode() (*bitcoin.Settings, error) {
 	peerRpcPassword := viper.GetString("peerRpc.password")
 	if peerRpcPassword == "" {
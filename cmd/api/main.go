@@ -57,7 +57,7 @@ func run() error {
 		}
 	}()
 
-	shutdown, err := cmd.StartAPIServer(logger)
+	shutdown, err := cmd.StartAPIServer(logger, nil)
 	if err != nil {
 		return fmt.Errorf("failed to start API server: %v", err)
 	}
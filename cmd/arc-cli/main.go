@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/bitcoin-sv/arc/api"
+	"github.com/bitcoin-sv/arc/lib/arccli"
+)
+
+const usage = `usage: arc-cli [options] <command> [command options] [arguments]
+
+where options are:
+
+    -config=<file>
+          file with the profiles of the ARC endpoints (default=~/.arc-cli.yaml)
+
+    -profile=<name>
+          profile of the ARC endpoint to use (default=defaultProfile of the config file)
+
+    -url=<url> -authorization=<header>
+          ARC endpoint and authorization header, which override the profile
+
+and commands are:
+
+    submit [submit options] [-format=auto|hex|binary|beef] [file]
+          submit a transaction in raw or extended format or a BEEF, in hex or binary, from the file or stdin
+
+    status [-metamorph] [-config-dir=<dir>] <txid>
+          get the status of the transaction, with -metamorph directly from metamorph as configured in config.yaml
+
+    watch [-status=<status>] [-interval=<duration>] [-listen=<address> -callback-token=<token>] [-timeout=<duration>] <txid>
+          poll the status of the transaction, or with -listen receive its callbacks, until it reaches the status (default=MINED)
+
+    policy
+          get the policy of ARC
+
+    batch [submit options] [-size=<transactions>] [file]
+          submit transaction requests ({"rawTx": "<hex>"}) as newline delimited JSON from the file or stdin in batches
+          of the size (default=100) and write the result of each transaction as newline delimited JSON
+
+where submit options set the X-* headers:
+
+    -callback-url=<url> -callback-token=<token> -full-status-updates -max-timeout=<seconds> -skip-fee-validation
+    -skip-script-validation -skip-tx-validation -merkle-proof -wait-for-status=<status number>
+`
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "arc-cli: %v\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func run() error {
+	configFile := flag.String("config", "", "file with the profiles of the ARC endpoints")
+	profileName := flag.String("profile", "", "profile of the ARC endpoint to use")
+	url := flag.String("url", "", "ARC endpoint which overrides the profile")
+	authorization := flag.String("authorization", "", "authorization header which overrides the profile")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		return errors.New("missing command")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	command, commandArgs := args[0], args[1:]
+
+	// the status from metamorph does not need a profile
+	if command == "status" && hasFlag(commandArgs, "metamorph") {
+		return metamorphStatus(ctx, commandArgs)
+	}
+
+	profile, err := loadProfile(*configFile, *profileName, *url, *authorization)
+	if err != nil {
+		return err
+	}
+
+	client, err := arccli.NewClient(profile)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "submit":
+		return submit(ctx, client, commandArgs)
+	case "status":
+		return status(ctx, client, commandArgs)
+	case "watch":
+		return watch(ctx, client, commandArgs)
+	case "policy":
+		return policy(ctx, client, commandArgs)
+	case "batch":
+		return batch(ctx, client, commandArgs)
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// loadProfile loads the profile from the config file, if it exists. The url and authorization override the profile.
+func loadProfile(configFile string, profileName string, url string, authorization string) (*arccli.Profile, error) {
+	explicitConfig := configFile != ""
+	if !explicitConfig {
+		home, err := os.UserHomeDir()
+		if err == nil {
+			configFile = filepath.Join(home, ".arc-cli.yaml")
+		}
+	}
+
+	profile := &arccli.Profile{}
+
+	_, statErr := os.Stat(configFile)
+	if explicitConfig || profileName != "" || (configFile != "" && statErr == nil) {
+		profiles, err := arccli.LoadProfiles(configFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if url == "" || profileName != "" {
+			profile, err = profiles.Get(profileName)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if url != "" {
+		profile.URL = url
+	}
+
+	if authorization != "" {
+		profile.Authorization = authorization
+	}
+
+	if profile.URL == "" {
+		return nil, errors.New("no ARC endpoint, set -url or a profile in ~/.arc-cli.yaml")
+	}
+
+	return profile, nil
+}
+
+// hasFlag returns whether the boolean flag is set in the arguments.
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == "-"+name || arg == "--"+name || arg == "-"+name+"=true" || arg == "--"+name+"=true" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// submitFlags adds the flags of the X-* headers to the flag set. The returned function returns the options of the flags
+// which have been set.
+func submitFlags(fs *flag.FlagSet) func() *arccli.SubmitOptions {
+	callbackURL := fs.String("callback-url", "", "callback URL (X-CallbackUrl)")
+	callbackToken := fs.String("callback-token", "", "callback token (X-CallbackToken)")
+	fullStatusUpdates := fs.Bool("full-status-updates", false, "send callbacks for all statuses (X-FullStatusUpdates)")
+	maxTimeout := fs.Int("max-timeout", 0, "seconds to wait for the status (X-MaxTimeout)")
+	skipFeeValidation := fs.Bool("skip-fee-validation", false, "skip the fee validation (X-SkipFeeValidation)")
+	skipScriptValidation := fs.Bool("skip-script-validation", false, "skip the script validation (X-SkipScriptValidation)")
+	skipTxValidation := fs.Bool("skip-tx-validation", false, "skip the transaction validation (X-SkipTxValidation)")
+	merkleProof := fs.Bool("merkle-proof", false, "include the merkle proof in the callbacks (X-MerkleProof)")
+	waitForStatus := fs.Int("wait-for-status", 0, "status to wait for before responding (X-WaitForStatus)")
+
+	return func() *arccli.SubmitOptions {
+		opts := &arccli.SubmitOptions{}
+
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "callback-url":
+				opts.CallbackURL = callbackURL
+			case "callback-token":
+				opts.CallbackToken = callbackToken
+			case "full-status-updates":
+				opts.FullStatusUpdates = fullStatusUpdates
+			case "max-timeout":
+				opts.MaxTimeout = maxTimeout
+			case "skip-fee-validation":
+				opts.SkipFeeValidation = skipFeeValidation
+			case "skip-script-validation":
+				opts.SkipScriptValidation = skipScriptValidation
+			case "skip-tx-validation":
+				opts.SkipTxValidation = skipTxValidation
+			case "merkle-proof":
+				merkleProofStr := fmt.Sprintf("%t", *merkleProof)
+				opts.MerkleProof = &merkleProofStr
+			case "wait-for-status":
+				opts.WaitForStatus = waitForStatus
+			}
+		})
+
+		return opts
+	}
+}
+
+// openInput opens the file, or stdin if the file name is empty or -.
+func openInput(args []string) (io.ReadCloser, error) {
+	if len(args) == 0 || args[0] == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	return os.Open(args[0])
+}
+
+// printResponse prints the indented body of the response and returns an error if the request failed.
+func printResponse(res *arccli.Response) error {
+	printJSON(res.Body)
+
+	if !res.OK() {
+		return fmt.Errorf("request failed with status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func printJSON(body []byte) {
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, body, "", "  "); err != nil {
+		fmt.Println(string(body))
+		return
+	}
+
+	fmt.Println(indented.String())
+}
+
+func submit(ctx context.Context, client *arccli.Client, args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	format := fs.String("format", string(arccli.FormatAuto), "format of the transaction: auto, hex, binary or beef")
+	submitOptions := submitFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	input, err := openInput(fs.Args())
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	txs, err := arccli.ReadTransactions(input, arccli.Format(*format))
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Submit(ctx, txs, submitOptions())
+	if err != nil {
+		return err
+	}
+
+	return printResponse(res)
+}
+
+func status(ctx context.Context, client *arccli.Client, args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: arc-cli status <txid>")
+	}
+
+	res, err := client.Status(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	return printResponse(res)
+}
+
+func watch(ctx context.Context, client *arccli.Client, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	targetStatus := fs.String("status", "MINED", "status until which the transaction is watched")
+	interval := fs.Duration("interval", 5*time.Second, "interval in which the status is polled")
+	listen := fs.String("listen", "", "address on which the callbacks are received instead of polling")
+	callbackToken := fs.String("callback-token", "", "token which the callbacks have to be sent with")
+	timeout := fs.Duration("timeout", 0, "time after which watching is given up (default=0, no timeout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: arc-cli watch [-status=<status>] <txid>")
+	}
+
+	target, err := arccli.ParseStatus(*targetStatus)
+	if err != nil {
+		return err
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+
+	if *listen != "" {
+		_, err = arccli.WatchCallbacks(ctx, *listen, *callbackToken, fs.Arg(0), target, func(callback *api.TransactionCallback) {
+			_ = encoder.Encode(callback)
+		})
+		return err
+	}
+
+	_, err = client.Watch(ctx, fs.Arg(0), target, *interval, func(txStatus *api.TransactionStatus) {
+		_ = encoder.Encode(txStatus)
+	})
+
+	return err
+}
+
+func policy(ctx context.Context, client *arccli.Client, args []string) error {
+	fs := flag.NewFlagSet("policy", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	res, err := client.Policy(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printResponse(res)
+}
+
+func batch(ctx context.Context, client *arccli.Client, args []string) error {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	size := fs.Int("size", 100, "number of transactions which are submitted at once")
+	submitOptions := submitFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	input, err := openInput(fs.Args())
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	submitted, err := client.Batch(ctx, input, os.Stdout, *size, submitOptions())
+	if err != nil {
+		return fmt.Errorf("failed after %d transactions: %v", submitted, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/bitcoin-sv/arc/api/transaction_handler"
+	"github.com/spf13/viper"
+)
+
+// metamorphStatus prints the status and the bytes of the transaction directly from metamorph, as configured in the
+// config.yaml in the config directory.
+func metamorphStatus(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	fs.Bool("metamorph", false, "get the status directly from metamorph")
+	configDir := fs.String("config-dir", ".", "directory of config.yaml")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: arc-cli status -metamorph [-config-dir=<dir>] <txid>")
+	}
+	txID := fs.Arg(0)
+
+	config := viper.New()
+	config.SetConfigName("config")
+	config.SetConfigType("yaml")
+	config.AddConfigPath(*configDir)
+	if err := config.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file config.yaml: %v", err)
+	}
+
+	addresses := config.GetString("metamorph.dialAddr")
+	if addresses == "" {
+		return errors.New("missing metamorph.dialAddr")
+	}
+
+	grpcMessageSize := config.GetInt("grpcMessageSize")
+	if grpcMessageSize == 0 {
+		return errors.New("missing grpcMessageSize")
+	}
+
+	txHandler, err := transaction_handler.NewMetamorph(addresses, grpcMessageSize)
+	if err != nil {
+		return err
+	}
+
+	txStatus, err := txHandler.GetTransactionStatus(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	transactionBytes, err := txHandler.GetTransaction(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	type response struct {
+		*transaction_handler.TransactionStatus
+		TransactionBytes string    `json:"transactionBytes"`
+		Timestamp        time.Time `json:"timestamp"`
+	}
+
+	b, err := json.Marshal(&response{
+		TransactionStatus: txStatus,
+		TransactionBytes:  hex.EncodeToString(transactionBytes),
+		Timestamp:         time.Unix(txStatus.Timestamp, 0).UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	printJSON(b)
+
+	return nil
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bitcoin-sv/arc/cmd"
+	"github.com/bitcoin-sv/arc/lib/arccli"
+	"github.com/bitcoin-sv/arc/lib/arcstore"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/bitcoin-sv/arc/metamorph/store"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/spf13/viper"
+)
+
+const usage = `usage: arc-store [options] <command> [command options] [arguments]
+
+where options are:
+
+    -config=<dir>
+          directory of config.yaml with the settings of the stores (default=.)
+
+    -store=<badger|sqlite|postgres|dynamodb>
+          metamorph store to use (default=metamorph.db.mode of config.yaml)
+
+and commands are:
+
+    get <txid>
+          print the transaction as JSON
+
+    dump [filter options]
+          print the transactions as newline delimited JSON
+
+    count [filter options]
+          print the number of transactions for each status
+
+    copy [filter options] -to=<badger|sqlite|postgres|dynamodb> [-checkpoint=<file>] [-checkpoint-interval=<transactions>]
+          copy the transactions to another store. Transactions which exist in the target store already are skipped. The
+          progress is written to the checkpoint file every checkpoint interval (default=1000), and a copy which has been
+          interrupted is continued after the checkpoint
+
+where filter options select the transactions:
+
+    -status=<status>[,<status>...] -since=<time> -until=<time>
+          times are given as RFC 3339, e.g. 2023-12-01T12:00:00Z, or as date, e.g. 2023-12-01
+`
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "arc-store: %v\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func run() error {
+	configDir := flag.String("config", ".", "directory of config.yaml")
+	storeMode := flag.String("store", "", "metamorph store to use")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		return errors.New("missing command")
+	}
+
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath(*configDir)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file config.yaml: %v", err)
+	}
+
+	if *storeMode == "" {
+		*storeMode = viper.GetString("metamorph.db.mode")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	s, err := openStore(*storeMode)
+	if err != nil {
+		return err
+	}
+	defer s.Close(context.Background())
+
+	command, commandArgs := args[0], args[1:]
+
+	switch command {
+	case "get":
+		return get(ctx, s, commandArgs)
+	case "dump":
+		return dump(ctx, s, commandArgs)
+	case "count":
+		return count(ctx, s, commandArgs)
+	case "copy":
+		return copyStore(ctx, s, commandArgs)
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+type iterableStore interface {
+	store.MetamorphStore
+	store.Iterator
+}
+
+func openStore(mode string) (iterableStore, error) {
+	if mode == "" {
+		return nil, errors.New("missing store, neither -store nor metamorph.db.mode is set")
+	}
+
+	s, err := cmd.NewStore(mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s store: %v", mode, err)
+	}
+
+	iterable, ok := s.(iterableStore)
+	if !ok {
+		_ = s.Close(context.Background())
+		return nil, fmt.Errorf("transactions of the %s store cannot be iterated", mode)
+	}
+
+	return iterable, nil
+}
+
+func get(ctx context.Context, s store.MetamorphStore, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: arc-store get <txid>")
+	}
+
+	hash, err := chainhash.NewHashFromStr(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid txid: %v", err)
+	}
+
+	data, err := s.Get(ctx, hash[:])
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(arcstore.NewRecord(data), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func dump(ctx context.Context, s store.Iterator, args []string) error {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	filter := addFilterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := filter()
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	enc := json.NewEncoder(w)
+
+	return arcstore.Walk(ctx, s, f, func(data *store.StoreData) error {
+		return enc.Encode(arcstore.NewRecord(data))
+	})
+}
+
+func count(ctx context.Context, s store.Iterator, args []string) error {
+	fs := flag.NewFlagSet("count", flag.ContinueOnError)
+	filter := addFilterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := filter()
+	if err != nil {
+		return err
+	}
+
+	counts, err := arcstore.CountByStatus(ctx, s, f)
+	if err != nil {
+		return err
+	}
+
+	statuses := make([]metamorph_api.Status, 0, len(counts))
+	total := int64(0)
+	for status, n := range counts {
+		statuses = append(statuses, status)
+		total += n
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+
+	for _, status := range statuses {
+		fmt.Printf("%-24s %d\n", status, counts[status])
+	}
+	fmt.Printf("%-24s %d\n", "TOTAL", total)
+
+	return nil
+}
+
+func copyStore(ctx context.Context, from store.Iterator, args []string) error {
+	fs := flag.NewFlagSet("copy", flag.ContinueOnError)
+	filter := addFilterFlags(fs)
+	toMode := fs.String("to", "", "metamorph store to copy the transactions to")
+	checkpointFile := fs.String("checkpoint", "", "file to which the progress is written")
+	checkpointInterval := fs.Int("checkpoint-interval", 1000, "number of transactions after which the progress is written")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := filter()
+	if err != nil {
+		return err
+	}
+
+	if *toMode == "" {
+		return errors.New("usage: arc-store copy [filter options] -to=<store> [-checkpoint=<file>] [-checkpoint-interval=<transactions>]")
+	}
+
+	if *checkpointInterval <= 0 {
+		return errors.New("checkpoint interval has to be positive")
+	}
+
+	to, err := cmd.NewStore(*toMode)
+	if err != nil {
+		return fmt.Errorf("failed to open %s store: %v", *toMode, err)
+	}
+	defer to.Close(context.Background())
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	start := time.Now()
+
+	checkpoint, err := arcstore.Copy(ctx, from, to,
+		arcstore.WithLogger(logger),
+		arcstore.WithFilter(f),
+		arcstore.WithCheckpointFile(*checkpointFile),
+		arcstore.WithCheckpointInterval(*checkpointInterval),
+	)
+
+	logger.Info("copy finished", slog.Int64("copied", checkpoint.Copied), slog.Int64("skipped", checkpoint.Skipped), slog.String("after", checkpoint.After), slog.Duration("duration", time.Since(start)))
+
+	return err
+}
+
+// addFilterFlags adds the filter options to the flag set. The returned function returns the filter once the flags
+// have been parsed.
+func addFilterFlags(fs *flag.FlagSet) func() (arcstore.Filter, error) {
+	statuses := fs.String("status", "", "comma separated statuses of the transactions")
+	since := fs.String("since", "", "transactions stored at or after the time")
+	until := fs.String("until", "", "transactions stored before the time")
+
+	return func() (arcstore.Filter, error) {
+		var filter arcstore.Filter
+		var err error
+
+		if *statuses != "" {
+			for _, name := range strings.Split(*statuses, ",") {
+				status, err := arccli.ParseStatus(strings.TrimSpace(name))
+				if err != nil {
+					return filter, err
+				}
+
+				filter.Statuses = append(filter.Statuses, status)
+			}
+		}
+
+		if filter.Since, err = parseTime(*since); err != nil {
+			return filter, fmt.Errorf("invalid since: %v", err)
+		}
+
+		if filter.Until, err = parseTime(*until); err != nil {
+			return filter, fmt.Errorf("invalid until: %v", err)
+		}
+
+		return filter, nil
+	}
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.DateOnly, value)
+}
@@ -138,6 +138,9 @@ func startBlocktxScheduler(logger *slog.Logger) (func(), error) {
 	scheduler.RunJob("clear blocktx blocks", "blocks", clearJob.ClearBlocktxTable)
 	scheduler.RunJob("clear blocktx transactions", "transactions", clearJob.ClearBlocktxTable)
 	scheduler.RunJob("clear blocktx block transactions map", "block_transactions_map", clearJob.ClearBlocktxTable)
+	scheduler.RunJob("clear blocktx block merkle trees", "block_merkle_trees", clearJob.ClearBlocktxTable)
+	scheduler.RunJob("clear blocktx block merkle subtrees", "block_merkle_subtrees", clearJob.ClearBlocktxTable)
+	scheduler.RunJob("clear blocktx block stats", "block_stats", clearJob.ClearBlocktxTable)
 
 	scheduler.Start()
 
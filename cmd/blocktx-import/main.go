@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx"
+	cfg "github.com/bitcoin-sv/arc/config"
+	_ "github.com/lib/pq"
+	"github.com/spf13/viper"
+)
+
+func main() {
+	err := run()
+	if err != nil {
+		log.Fatalf("failed to import blocks: %v", err)
+	}
+
+	os.Exit(0)
+}
+
+func run() error {
+	configDir := flag.String("config", "../../", "directory of the config.yaml file")
+	blocksDir := flag.String("dir", "", "directory of the bitcoind block files (blk*.dat)")
+	startHeight := flag.Int("start", -1, "height of the first block to import, defaults to blocktx.startingBlockHeight")
+	endHeight := flag.Uint64("end", 0, "height of the last block to import, defaults to the tip of the block files")
+	batchSize := flag.Int("batch", 0, "number of transactions which are stored at once")
+	fullIndex := flag.Bool("full-index", false, "import all transactions instead of only the registered transactions")
+	flag.Parse()
+
+	if *blocksDir == "" {
+		fmt.Println("usage: blocktx-import -dir <bitcoind blocks directory> [-config <config directory>] [-start <height>] [-end <height>] [-batch <size>]")
+		return errors.New("missing block files directory")
+	}
+
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath(*configDir)
+	err := viper.ReadInConfig()
+	if err != nil {
+		return fmt.Errorf("failed to read config file config.yaml: %v", err)
+	}
+
+	logger, err := cfg.NewLogger()
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %v", err)
+	}
+
+	dbMode := viper.GetString("blocktx.db.mode")
+	if dbMode == "" {
+		return errors.New("blocktx.db.mode not found in config")
+	}
+
+	blockStore, err := blocktx.NewStore(dbMode)
+	if err != nil {
+		return fmt.Errorf("failed to create blocktx store: %v", err)
+	}
+	defer blockStore.Close()
+
+	network, err := cfg.GetNetwork()
+	if err != nil {
+		return err
+	}
+
+	if *startHeight < 0 {
+		*startHeight = viper.GetInt("blocktx.startingBlockHeight")
+	}
+
+	opts := []func(*blocktx.BlockImporter){
+		blocktx.WithImportStartHeight(uint64(*startHeight)),
+		blocktx.WithImportEndHeight(*endHeight),
+	}
+
+	if *batchSize > 0 {
+		opts = append(opts, blocktx.WithImportTransactionBatchSize(*batchSize))
+	}
+
+	if *fullIndex {
+		opts = append(opts, blocktx.WithImportFullIndex())
+	}
+
+	hostName, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to get hostname: %v", err)
+	}
+
+	// the import holds the lease of the primary, so that no blocktx instance processes blocks at the same time
+	var electionOpts []func(*blocktx.PrimaryElection)
+	if leaseDuration := viper.GetDuration("blocktx.primaryLease.duration"); leaseDuration > 0 {
+		electionOpts = append(electionOpts, blocktx.WithLeaseDuration(leaseDuration))
+	}
+	if renewInterval := viper.GetDuration("blocktx.primaryLease.renewInterval"); renewInterval > 0 {
+		electionOpts = append(electionOpts, blocktx.WithLeaseRenewInterval(renewInterval))
+	}
+
+	election := blocktx.NewPrimaryElection(logger, blockStore, fmt.Sprintf("%s/%d/import", hostName, os.Getpid()), electionOpts...)
+	election.Start()
+	defer election.Shutdown()
+
+	if !election.IsPrimary() {
+		lease, err := blockStore.GetLease(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to acquire lease of the primary: %v", err)
+		}
+
+		return fmt.Errorf("lease of the primary is held by %s until %s, the import can only run while no blocktx instance is primary", lease.HostName, lease.ExpiresAt.Format(time.RFC3339))
+	}
+
+	opts = append(opts, blocktx.WithImportPrimaryElection(election))
+
+	importer, err := blocktx.NewBlockImporter(logger, blockStore, network, opts...)
+	if err != nil {
+		return err
+	}
+
+	// the import stops after the current block and can be resumed later
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return importer.Import(ctx, *blocksDir)
+}
@@ -6,15 +6,12 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"time"
 
 	"github.com/bitcoin-sv/arc/blocktx"
 	"github.com/bitcoin-sv/arc/config"
 	"github.com/spf13/viper"
 )
 
-const BecomePrimaryintervalSecs = 30
-
 func StartBlockTx(logger *slog.Logger) (func(), error) {
 	dbMode := viper.GetString("blocktx.db.mode")
 	if dbMode == "" {
@@ -55,12 +52,75 @@ func StartBlockTx(logger *slog.Logger) (func(), error) {
 		return nil, err
 	}
 
-	peerHandler, err := blocktx.NewPeerHandler(logger, blockStore, startingBlockHeight, peerURLs, network, blocktx.WithRetentionDays(recordRetentionDays))
+	peerHandlerOpts := []func(*blocktx.PeerHandler){blocktx.WithRetentionDays(recordRetentionDays)}
+
+	if viper.GetBool("blocktx.headersFirstSync") {
+		headerChain, err := blocktx.NewHeaderChain(context.Background(), blockStore, network)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create header chain: %v", err)
+		}
+
+		peerHandlerOpts = append(peerHandlerOpts, blocktx.WithHeaderChain(headerChain))
+	}
+
+	spoolMemoryLimitMB := viper.GetInt("blocktx.blockSpool.memoryLimitMB")
+	if spoolMemoryLimitMB > 0 {
+		peerHandlerOpts = append(peerHandlerOpts, blocktx.WithBlockSpool(viper.GetString("blocktx.blockSpool.dir"), spoolMemoryLimitMB*1024*1024))
+	}
+
+	if pipelineDepth := viper.GetInt("blocktx.blockPipelineDepth"); pipelineDepth > 0 {
+		peerHandlerOpts = append(peerHandlerOpts, blocktx.WithBlockPipelineDepth(pipelineDepth))
+	}
+
+	if viper.IsSet("blocktx.peerReputation") {
+		peerHandlerOpts = append(peerHandlerOpts, blocktx.WithPeerReputation(
+			viper.GetInt("blocktx.peerReputation.banScore"),
+			viper.GetDuration("blocktx.peerReputation.banDuration"),
+			viper.GetDuration("blocktx.peerReputation.blockResponseTimeout"),
+		))
+	}
+
+	var serverOpts []func(*blocktx.Server)
+
+	if viper.GetBool("blocktx.fullIndex") {
+		peerHandlerOpts = append(peerHandlerOpts, blocktx.WithFullIndex())
+	} else {
+		// with the full index all transactions are stored, therefore the registered transactions are not filtered
+		registeredTransactions := blocktx.NewRegisteredTransactions(logger, blockStore)
+		if err = registeredTransactions.Load(context.Background()); err != nil {
+			return nil, err
+		}
+
+		peerHandlerOpts = append(peerHandlerOpts, blocktx.WithRegisteredTransactions(registeredTransactions))
+		serverOpts = append(serverOpts, blocktx.WithServerRegisteredTransactions(registeredTransactions))
+	}
+
+	hostName, err := os.Hostname()
 	if err != nil {
+		return nil, fmt.Errorf("failed to get hostname: %v", err)
+	}
+
+	var electionOpts []func(*blocktx.PrimaryElection)
+	if leaseDuration := viper.GetDuration("blocktx.primaryLease.duration"); leaseDuration > 0 {
+		electionOpts = append(electionOpts, blocktx.WithLeaseDuration(leaseDuration))
+	}
+	if renewInterval := viper.GetDuration("blocktx.primaryLease.renewInterval"); renewInterval > 0 {
+		electionOpts = append(electionOpts, blocktx.WithLeaseRenewInterval(renewInterval))
+	}
+
+	// the process id distinguishes instances on the same host
+	election := blocktx.NewPrimaryElection(logger, blockStore, fmt.Sprintf("%s/%d", hostName, os.Getpid()), electionOpts...)
+	election.Start()
+
+	peerHandlerOpts = append(peerHandlerOpts, blocktx.WithPrimaryElection(election))
+
+	peerHandler, err := blocktx.NewPeerHandler(logger, blockStore, startingBlockHeight, peerURLs, network, peerHandlerOpts...)
+	if err != nil {
+		election.Shutdown()
 		return nil, err
 	}
 
-	blockTxServer := blocktx.NewServer(blockStore, logger)
+	blockTxServer := blocktx.NewServer(blockStore, logger, serverOpts...)
 
 	address, err := config.GetString("blocktx.listenAddr")
 	if err != nil {
@@ -72,26 +132,15 @@ func StartBlockTx(logger *slog.Logger) (func(), error) {
 		}
 	}()
 
-	primaryTicker := time.NewTicker(time.Second * BecomePrimaryintervalSecs)
-	hostName, err := os.Hostname()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get hostname: %v", err)
-	}
-	go func() {
-		for range primaryTicker.C {
-			if err := blockStore.TryToBecomePrimary(context.Background(), hostName); err != nil {
-				logger.Error("failed to try to become primary", slog.String("err", err.Error()))
-			}
-		}
-	}()
-
 	return func() {
+		// the lease is released first, so that another instance takes over the processing of blocks
+		election.Shutdown()
+
 		logger.Info("Shutting down blocktx store")
 		err = blockStore.Close()
 		if err != nil {
 			logger.Error("Error closing blocktx store", slog.String("err", err.Error()))
 		}
-		primaryTicker.Stop()
 		peerHandler.Shutdown()
 	}, nil
 }
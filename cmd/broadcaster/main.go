@@ -6,13 +6,16 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/bitcoin-sv/arc/broadcaster"
+	"github.com/bitcoin-sv/arc/broadcaster/utxopool"
 	"github.com/bitcoin-sv/arc/lib/keyset"
+	"github.com/libsv/go-bt/v2"
 	"github.com/ordishs/gocore"
 	"github.com/spf13/viper"
 )
@@ -38,12 +41,36 @@ func main() {
 	concurrency := flag.Int("concurrency", 0, "How many transactions to send concurrently")
 	batch := flag.Int("batch", 0, "send transactions in batches of this size")
 	isTestnet := flag.Bool("testnet", false, "send transactions to testnet")
+	opReturn := flag.String("opreturn", "", "payload sizes in bytes of the OP_RETURN outputs with optional weights")
+	profile := flag.String("profile", "", "load profile of stages at which rates the transactions are sent")
+	reportJSON := flag.String("report-json", "", "file to which the report is written as JSON")
+	reportCSV := flag.String("report-csv", "", "file to which the latency percentiles are written as CSV")
+	callbackListen := flag.String("callback-listen", "", "address on which callbacks are received")
+	callbackURL := flag.String("callback-url", "", "callback URL which is sent with the transactions")
+	callbackToken := flag.String("callback-token", "", "callback token which is sent with the transactions")
+	pollInterval := flag.Duration("poll-interval", 0, "interval in which the statuses of the transactions are polled")
+	statusTimeout := flag.Duration("status-timeout", 0, "time to wait for the transactions to be mined")
+	doubleSpendGroups := flag.Int("double-spend-groups", 0, "number of groups of conflicting transactions which spend the same UTXO")
+	doubleSpendConflicts := flag.Int("double-spend-conflicts", 2, "number of conflicting transactions in each group")
+	doubleSpendDelay := flag.Duration("double-spend-delay", 0, "delay between the submissions of the conflicting transactions")
+	doubleSpendEndpoints := flag.String("double-spend-endpoints", "", "comma separated ARC URLs to which the conflicting transactions are submitted in turn")
+	utxoDB := flag.String("utxo-db", "", "file of the local UTXO pool which records the outputs of the sent transactions")
+	chainDepth := flag.Int("chain-depth", 0, "depth of the chain of unconfirmed transactions built from one funding UTXO")
+	chainFanOut := flag.Int("chain-fanout", 1, "number of outputs of each transaction of the chain which are spent by the next depth")
+	chainOrder := flag.String("chain-order", string(broadcaster.ChainOrderInOrder), "order in which the transactions of the chain are submitted")
+	chainBatch := flag.Int("chain-batch", 0, "submit the transactions of the chain in batches of this size")
 	flag.Parse()
 
 	args := flag.Args()
 
+	isPrepare := len(args) == 2 && args[0] == "prepare"
+	if isPrepare {
+		args = args[1:]
+	}
+
 	if len(args) != 1 {
 		fmt.Println("usage: broadcaster [options] <number of transactions to send>")
+		fmt.Println("       broadcaster [options] -utxo-db=<file> prepare <number of outputs>")
 		fmt.Println("where options are:")
 		fmt.Println("")
 		fmt.Println("    -dryrun")
@@ -79,6 +106,47 @@ func main() {
 		fmt.Println("    -testnet=<true|false>")
 		fmt.Println("          whether to send testnet or mainnet transactions, default=false")
 		fmt.Println("")
+		fmt.Println("    -opreturn=<size[:weight],...>")
+		fmt.Println("          generate transactions with OP_RETURN outputs of these payload sizes in bytes chosen by the weights, e.g.")
+		fmt.Println("          100:70,1000:20,100000:10, the data fee is added to the funding outputs")
+		fmt.Println("")
+		fmt.Println("    -profile=<stages>")
+		fmt.Println("          send the transactions at the rates of a load profile instead of as fast as possible, e.g.")
+		fmt.Println("          ramp:0-100:1m,steady:100:5m,spike:500:30s,soak:50:1h with rates in transactions per second")
+		fmt.Println("")
+		fmt.Println("    -report-json=<file>")
+		fmt.Println("          write the report with the statuses and latency histograms to a JSON file")
+		fmt.Println("")
+		fmt.Println("    -report-csv=<file>")
+		fmt.Println("          write the latency percentiles p50, p95 and p99 of each stage to a CSV file")
+		fmt.Println("")
+		fmt.Println("    -callback-listen=<address> -callback-url=<url> -callback-token=<token>")
+		fmt.Println("          receive callbacks on the address to measure the latency until SEEN_ON_NETWORK and MINED, only in api mode")
+		fmt.Println("")
+		fmt.Println("    -poll-interval=<duration>")
+		fmt.Println("          poll the statuses of the transactions in this interval to measure the latency until SEEN_ON_NETWORK and MINED")
+		fmt.Println("")
+		fmt.Println("    -status-timeout=<duration>")
+		fmt.Println("          time to wait for the transactions to be mined after they have been sent (default=0, no waiting)")
+		fmt.Println("")
+		fmt.Println("    -double-spend-groups=<groups> -double-spend-conflicts=<transactions> -double-spend-delay=<duration>")
+		fmt.Println("          instead of the number of transactions send groups of conflicting transactions, each group spends the same UTXO")
+		fmt.Println("          (default=2 transactions per group), the transactions are submitted simultaneously or each with the delay after")
+		fmt.Println("          the previous one, the report shows which transaction won and the statuses and callbacks of the others")
+		fmt.Println("")
+		fmt.Println("    -double-spend-endpoints=<url,...>")
+		fmt.Println("          submit the conflicting transactions in turn to these ARC endpoints instead of broadcaster.apiURL, only in api mode")
+		fmt.Println("")
+		fmt.Println("    -utxo-db=<file>")
+		fmt.Println("          record the outputs of the sent transactions in a local UTXO pool and fund the transactions from it instead of")
+		fmt.Println("          WhatsOnChain, outputs are marked as spent when they are used and unspent again if the transaction is rejected")
+		fmt.Println("          with the command prepare the funding UTXOs are fanned out into the given number of outputs for later runs")
+		fmt.Println("")
+		fmt.Println("    -chain-depth=<depth> -chain-fanout=<outputs> -chain-order=<in-order|reverse|shuffled> -chain-batch=<batch size>")
+		fmt.Println("          instead of the number of transactions send a chain of unconfirmed transactions of this depth built from one")
+		fmt.Println("          funding UTXO, each transaction has fan-out outputs spent at the next depth (default=1), the transactions are")
+		fmt.Println("          submitted in order, reverse or shuffled, by themselves or in batches (default=0, no batching)")
+		fmt.Println("")
 		return
 	}
 
@@ -126,7 +194,7 @@ func main() {
 	var client broadcaster.ClientI
 	client, err = createClient(&broadcaster.Auth{
 		Authorization: *authorization,
-	})
+	}, *callbackURL, *callbackToken)
 	if err != nil {
 		panic(err)
 	}
@@ -146,14 +214,113 @@ func main() {
 	bCaster.BatchSend = *batch
 	bCaster.Consolidate = *consolidate
 	bCaster.IsTestnet = *isTestnet
+	bCaster.CallbackAddress = *callbackListen
+	bCaster.PollInterval = *pollInterval
+	bCaster.StatusTimeout = *statusTimeout
+
+	if *opReturn != "" {
+		bCaster.OpReturnMix, err = broadcaster.ParseOpReturnMix(*opReturn)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if *profile != "" {
+		bCaster.LoadProfile, err = broadcaster.ParseLoadProfile(*profile)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if *utxoDB != "" {
+		var pool *utxopool.SQLite
+		pool, err = utxopool.New(*utxoDB)
+		if err != nil {
+			panic(err)
+		}
+		defer pool.Close()
+
+		bCaster.UtxoPool = pool
+	}
+
+	if isPrepare {
+		var tx *bt.Tx
+		tx, err = bCaster.Prepare(ctx, sendNrOfTransactions)
+		if err != nil {
+			panic(err)
+		}
+
+		logger.Infof("prepared %d outputs in tx %s", len(tx.Outputs), tx.TxID())
+		return
+	}
+
+	if *chainDepth > 0 {
+		bCaster.Chain = &broadcaster.ChainConfig{
+			Depth:     *chainDepth,
+			FanOut:    *chainFanOut,
+			Order:     broadcaster.ChainOrder(*chainOrder),
+			BatchSize: *chainBatch,
+		}
+
+		if _, err = bCaster.Chain.Validate(); err != nil {
+			panic(err)
+		}
+	}
+
+	if *doubleSpendGroups > 0 {
+		bCaster.DoubleSpend = &broadcaster.DoubleSpendConfig{
+			Groups:    *doubleSpendGroups,
+			Conflicts: *doubleSpendConflicts,
+			Delay:     *doubleSpendDelay,
+		}
+
+		if *doubleSpendEndpoints != "" {
+			bCaster.DoubleSpend.Clients, err = createAPIClients(strings.Split(*doubleSpendEndpoints, ","), &broadcaster.Auth{
+				Authorization: *authorization,
+			}, *callbackURL, *callbackToken)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		if err = bCaster.DoubleSpend.Validate(); err != nil {
+			panic(err)
+		}
+	}
 
 	err = bCaster.Run(ctx, *concurrency)
 	if err != nil {
 		panic(err)
 	}
+
+	if err = writeReport(*reportJSON, bCaster.Report().WriteJSON); err != nil {
+		panic(err)
+	}
+
+	if err = writeReport(*reportCSV, bCaster.Report().WriteCSV); err != nil {
+		panic(err)
+	}
 }
 
-func createClient(auth *broadcaster.Auth) (broadcaster.ClientI, error) {
+func writeReport(fileName string, write func(w io.Writer) error) error {
+	if fileName == "" {
+		return nil
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	if err = write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func createClient(auth *broadcaster.Auth, callbackURL string, callbackToken string) (broadcaster.ClientI, error) {
 	var client broadcaster.ClientI
 	if isDryRun {
 		client = broadcaster.NewDryRunClient()
@@ -169,7 +336,12 @@ func createClient(auth *broadcaster.Auth) (broadcaster.ClientI, error) {
 		}
 
 		// create a http connection to the arc node
-		client = broadcaster.NewHTTPBroadcaster(arcServerUrl.String(), auth)
+		var opts []func(*broadcaster.APIBroadcaster)
+		if callbackURL != "" {
+			opts = append(opts, broadcaster.WithCallback(callbackURL, callbackToken))
+		}
+
+		client = broadcaster.NewHTTPBroadcaster(arcServerUrl.String(), auth, opts...)
 	} else {
 		addresses := viper.GetString("metamorph.dialAddr")
 		if addresses == "" {
@@ -182,6 +354,29 @@ func createClient(auth *broadcaster.Auth) (broadcaster.ClientI, error) {
 	return client, nil
 }
 
+func createAPIClients(endpoints []string, auth *broadcaster.Auth, callbackURL string, callbackToken string) ([]broadcaster.ClientI, error) {
+	if !isAPIClient || isDryRun {
+		return nil, errors.New("endpoints can only be used in api mode")
+	}
+
+	var opts []func(*broadcaster.APIBroadcaster)
+	if callbackURL != "" {
+		opts = append(opts, broadcaster.WithCallback(callbackURL, callbackToken))
+	}
+
+	clients := make([]broadcaster.ClientI, len(endpoints))
+	for i, endpoint := range endpoints {
+		endpointUrl, err := url.Parse(strings.TrimSpace(endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s is not a valid url", endpoint)
+		}
+
+		clients[i] = broadcaster.NewHTTPBroadcaster(endpointUrl.String(), auth, opts...)
+	}
+
+	return clients, nil
+}
+
 func getKeySets(xpriv string, keyFile *string) (fundingKeySet *keyset.KeySet, receivingKeySet *keyset.KeySet, err error) {
 	if xpriv != "" || *keyFile != "" {
 		if xpriv == "" {
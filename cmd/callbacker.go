@@ -7,10 +7,13 @@ import (
 	"log/slog"
 
 	"github.com/bitcoin-sv/arc/callbacker"
+	"github.com/bitcoin-sv/arc/config"
 	"github.com/spf13/viper"
 )
 
-func StartCallbacker(logger *slog.Logger) (func(), error) {
+// StartCallbacker starts the callbacker. If the reloader is not nil, the intervals and the callback policy are changed
+// when the configuration is reloaded.
+func StartCallbacker(logger *slog.Logger, reloader *config.Reloader) (func(), error) {
 	logger.With(slog.String("service", "clb"))
 	folder := viper.GetString("dataFolder")
 	if folder == "" {
@@ -26,13 +29,41 @@ func StartCallbacker(logger *slog.Logger) (func(), error) {
 
 	callbackerInterval := viper.GetDuration("callbacker.interval")
 
+	callbackPolicy, err := config.GetCallbackPolicy()
+	if err != nil {
+		return nil, err
+	}
+
 	var callbackWorker *callbacker.Callbacker
-	callbackWorker, err = callbacker.New(callbackStore, callbacker.WithLogger(logger), callbacker.WithSendCallbacksInterval(callbackerInterval))
+	callbackWorker, err = callbacker.New(callbackStore,
+		callbacker.WithLogger(logger),
+		callbacker.WithSendCallbacksInterval(callbackerInterval),
+		callbacker.WithCallbackPolicy(callbackPolicy),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create callbacker: %v", err)
 	}
 	callbackWorker.Start()
 
+	if reloader != nil {
+		reloader.OnReload(func(cfg *config.ArcConfig) error {
+			if cfg.Callbacker.Interval <= 0 {
+				return errors.New("setting callbacker.interval not found")
+			}
+
+			callbackPolicy, err := config.NewCallbackPolicy(cfg.CallbackPolicy)
+			if err != nil {
+				return err
+			}
+
+			callbackWorker.SetCallbackPolicy(callbackPolicy)
+			callbackWorker.SetSendCallbacksInterval(cfg.Callbacker.Interval)
+			callbackStore.SetInterval(cfg.Callbacker.ExpiryInterval)
+
+			return nil
+		})
+	}
+
 	srv := callbacker.NewServer(logger, callbackWorker)
 
 	address := viper.GetString("callbacker.listenAddr")
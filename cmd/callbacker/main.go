@@ -50,7 +50,7 @@ func main() {
 		}
 	}()
 
-	shutdown, err := cmd.StartCallbacker(logger)
+	shutdown, err := cmd.StartCallbacker(logger, nil)
 	if err != nil {
 		logger.Error("Failed to start callbacker", slog.String("err", err.Error()))
 	}
@@ -41,7 +41,9 @@ const (
 	DbModeSQLite   = "sqlite"
 )
 
-func StartMetamorph(logger *slog.Logger) (func(), error) {
+// StartMetamorph starts metamorph. If the reloader is not nil, the reloadable settings of the processor and the server
+// are changed when the configuration is reloaded.
+func StartMetamorph(logger *slog.Logger, reloader *config.Reloader) (func(), error) {
 	logger = logger.With(slog.String("service", "mtm"))
 
 	dbMode, err := config.GetString("metamorph.db.mode")
@@ -99,6 +101,15 @@ func StartMetamorph(logger *slog.Logger) (func(), error) {
 	if err != nil {
 		return nil, err
 	}
+	rebroadcastInterval, err := config.GetDuration("metamorph.rebroadcastInterval")
+	if err != nil {
+		return nil, err
+	}
+
+	callbackPolicy, err := config.GetCallbackPolicy()
+	if err != nil {
+		return nil, err
+	}
 
 	metamorphProcessor, err := metamorph.NewProcessor(
 		s,
@@ -109,13 +120,15 @@ func StartMetamorph(logger *slog.Logger) (func(), error) {
 		metamorph.WithDataRetentionPeriod(time.Duration(dataRetentionDays)*24*time.Hour),
 		metamorph.WithProcessCheckIfMinedInterval(checkIfMinedInterval),
 		metamorph.WithMaxMonitoredTxs(maxMonitoredTxs),
+		metamorph.WithRebroadcastInterval(rebroadcastInterval),
+		metamorph.WithCallbackPolicy(callbackPolicy),
 	)
 
 	http.HandleFunc("/pstats", metamorphProcessor.HandleStats)
 
 	go func() {
 		for message := range statusMessageCh {
-			_, err = metamorphProcessor.SendStatusForTransaction(message.Hash, message.Status, message.Peer, message.Err)
+			_, err = metamorphProcessor.SendStatusForTransaction(message.Hash, message.Status, message.Peer, message.Err, message.CompetingTxs)
 			if err != nil {
 				logger.Error("Could not send status for transaction", slog.String("hash", message.Hash.String()), slog.String("err", err.Error()))
 			}
@@ -185,6 +198,8 @@ func StartMetamorph(logger *slog.Logger) (func(), error) {
 		optsServer = append(optsServer, metamorph.WithForceCheckUtxos(node))
 	}
 
+	optsServer = append(optsServer, metamorph.WithServerCallbackPolicy(callbackPolicy))
+
 	btxTimeout := viper.GetDuration("metamorph.blocktxTimeout")
 	if btxTimeout > 0 {
 		optsServer = append(optsServer, metamorph.WithBlocktxTimeout(btxTimeout))
@@ -192,6 +207,35 @@ func StartMetamorph(logger *slog.Logger) (func(), error) {
 
 	serv := metamorph.NewServer(s, metamorphProcessor, btx, optsServer...)
 
+	if reloader != nil {
+		reloader.OnReload(func(cfg *config.ArcConfig) error {
+			if cfg.Metamorph.CheckIfMinedInterval <= 0 {
+				return errors.New("setting metamorph.checkIfMinedInterval not found")
+			}
+
+			if cfg.Metamorph.MaxMonitoredTxs <= 0 {
+				return errors.New("setting metamorph.maxMonitoredTxs not found")
+			}
+
+			if cfg.Metamorph.RebroadcastInterval <= 0 {
+				return errors.New("setting metamorph.rebroadcastInterval not found")
+			}
+
+			callbackPolicy, err := config.NewCallbackPolicy(cfg.CallbackPolicy)
+			if err != nil {
+				return err
+			}
+
+			metamorphProcessor.SetProcessCheckIfMinedInterval(cfg.Metamorph.CheckIfMinedInterval)
+			metamorphProcessor.SetMaxMonitoredTxs(cfg.Metamorph.MaxMonitoredTxs)
+			metamorphProcessor.SetRebroadcastInterval(cfg.Metamorph.RebroadcastInterval)
+			metamorphProcessor.SetCallbackPolicy(callbackPolicy)
+			serv.SetCallbackPolicy(callbackPolicy)
+
+			return nil
+		})
+	}
+
 	go func() {
 		grpcMessageSize := viper.GetInt("grpcMessageSize")
 		if grpcMessageSize == 0 {
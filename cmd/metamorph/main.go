@@ -58,7 +58,7 @@ func run() error {
 		}
 	}()
 
-	shutdown, err := cmd.StartMetamorph(logger)
+	shutdown, err := cmd.StartMetamorph(logger, nil)
 	if err != nil {
 		return fmt.Errorf("failed to start metamorph: %v", err)
 	}
@@ -0,0 +1,514 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the prefix of the environment variables which override settings of the configuration.
+const EnvPrefix = "ARC"
+
+var (
+	logLevels          = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+	logFormats         = []string{"text", "json", "tint"}
+	networks           = []string{"mainnet", "testnet", "regtest"}
+	metamorphDBModes   = []string{"badger", "postgres", "dynamodb", "sqlite", "sqlite_memory"}
+	blocktxDBModes     = []string{"sqlite", "sqlite_memory", "postgres"}
+	metamorphDataModes = []string{"badger", "sqlite"}
+)
+
+// Problem is a setting of the configuration which is invalid.
+type Problem struct {
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Key, p.Message)
+}
+
+// EnvOverride is a setting whose value is taken from an environment variable.
+type EnvOverride struct {
+	Key      string
+	Variable string
+}
+
+// CheckResult is the result of checking the configuration.
+type CheckResult struct {
+	Config       *ArcConfig
+	Problems     []Problem
+	EnvOverrides []EnvOverride
+}
+
+// EnvVariable returns the name of the environment variable which overrides the setting with the key, e.g.
+// ARC_METAMORPH_DB_MODE for metamorph.db.mode.
+func EnvVariable(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// Check decodes the configuration into ArcConfig and validates it. All problems are reported at once. These are
+// settings which cannot be decoded, unknown keys and settings with invalid values. Settings which are required by a
+// service are only required if the section of the service is set. Environment variables which override settings are
+// taken into account like they are by the services.
+func Check(v *viper.Viper) CheckResult {
+	c := &checker{v: v, problemKeys: make(map[string]bool)}
+	result := CheckResult{Config: &ArcConfig{}}
+
+	keys, prefixes := knownKeys(reflect.TypeOf(ArcConfig{}), "")
+
+	for _, key := range keys {
+		variable := EnvVariable(key)
+		if _, found := os.LookupEnv(variable); !found {
+			continue
+		}
+
+		// bound explicitly, because settings which are only set by environment variables are not decoded otherwise
+		_ = v.BindEnv(key, variable)
+		result.EnvOverrides = append(result.EnvOverrides, EnvOverride{Key: key, Variable: variable})
+	}
+
+	c.envOverrides = result.EnvOverrides
+	c.unknownKeys(v.AllKeys(), keys, prefixes)
+
+	err := v.Unmarshal(result.Config)
+	if err != nil {
+		var decodeErr *mapstructure.Error
+		if !errors.As(err, &decodeErr) {
+			c.add("", "%s", err)
+		} else {
+			for _, msg := range decodeErr.Errors {
+				c.addDecodeError(msg)
+			}
+		}
+	}
+
+	c.validate(result.Config)
+
+	sort.SliceStable(c.problems, func(i, j int) bool { return c.problems[i].Key < c.problems[j].Key })
+	result.Problems = c.problems
+
+	return result
+}
+
+// knownKeys returns the keys of all settings of the type and the keys of maps whose entries are not known in advance.
+func knownKeys(t reflect.Type, prefix string) (keys []string, prefixes []string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		key := settingName(field)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			structKeys, structPrefixes := knownKeys(fieldType, key)
+			keys = append(keys, structKeys...)
+			prefixes = append(prefixes, structPrefixes...)
+		case reflect.Map:
+			keys = append(keys, key)
+			prefixes = append(prefixes, key+".")
+		default:
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, prefixes
+}
+
+// settingName returns the name of the setting of the struct field as it is decoded by viper.
+func settingName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+type checker struct {
+	v            *viper.Viper
+	envOverrides []EnvOverride
+	problems     []Problem
+	problemKeys  map[string]bool
+}
+
+// isSet returns whether the setting or section is set in the configuration file or by an environment variable.
+func (c *checker) isSet(key string) bool {
+	if c.v.IsSet(key) {
+		return true
+	}
+
+	for _, override := range c.envOverrides {
+		if strings.HasPrefix(override.Key, key+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *checker) add(key string, format string, args ...any) {
+	c.problems = append(c.problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+	c.problemKeys[key] = true
+}
+
+// addDecodeError adds an error of mapstructure, which contains the quoted key of the setting.
+func (c *checker) addDecodeError(msg string) {
+	_, rest, found := strings.Cut(msg, "'")
+	if !found {
+		c.add("", "%s", msg)
+		return
+	}
+
+	key, _, found := strings.Cut(rest, "'")
+	if !found {
+		c.add("", "%s", msg)
+		return
+	}
+
+	c.add(key, "%s", strings.Replace(msg, "'"+key+"'", "value", 1))
+}
+
+func (c *checker) unknownKeys(keys []string, known []string, knownPrefixes []string) {
+	// viper returns the keys in lower case
+	knownSet := make(map[string]bool, len(known))
+	for _, key := range known {
+		knownSet[strings.ToLower(key)] = true
+	}
+
+	for _, key := range keys {
+		if knownSet[key] || hasAnyPrefix(key, knownPrefixes) {
+			continue
+		}
+
+		c.add(key, "unknown key")
+	}
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *checker) validate(cfg *ArcConfig) {
+	c.oneOf("logLevel", cfg.LogLevel, logLevels, true)
+	c.oneOf("logFormat", cfg.LogFormat, logFormats, true)
+	c.oneOf("network", cfg.Network, networks, true)
+	c.address("profilerAddr", cfg.ProfilerAddr, false)
+	c.address("statisticsServerAddress", cfg.StatisticsServerAddress, false)
+
+	if cfg.PrometheusEndpoint != "" && !strings.HasPrefix(cfg.PrometheusEndpoint, "/") {
+		c.add("prometheusEndpoint", "has to start with /")
+	}
+
+	usesGrpc := c.isSet("metamorph") || c.isSet("blocktx") || c.isSet("api") || c.isSet("k8sWatcher")
+	c.positive("grpcMessageSize", int64(cfg.GrpcMessageSize), usesGrpc)
+
+	if (c.isSet("metamorph") || c.isSet("blocktx")) && len(cfg.Peers) == 0 {
+		c.required("peers")
+	}
+
+	for i, peer := range cfg.Peers {
+		key := fmt.Sprintf("peers[%d]", i)
+		if peer.Host == "" {
+			c.required(key + ".host")
+		}
+		c.port(key+".port.p2p", peer.Port.P2P, true)
+		c.port(key+".port.zmq", peer.Port.ZMQ, false)
+	}
+
+	if c.isSet("peerRpc") {
+		if cfg.PeerRpc.Host == "" {
+			c.required("peerRpc.host")
+		}
+		c.port("peerRpc.port", cfg.PeerRpc.Port, true)
+	}
+
+	if cfg.CallbackPolicy != nil && !c.problemKeys["callbackPolicy"] {
+		if _, err := callbackpolicy.NewFromConfig(*cfg.CallbackPolicy); err != nil {
+			c.add("callbackPolicy", "%v", err)
+		}
+	}
+
+	c.validateCallbacker(cfg)
+	c.validateMetamorph(cfg)
+	c.validateBlockTx(cfg)
+
+	if cfg.Broadcaster.ApiURL != "" {
+		apiURL, err := url.Parse(cfg.Broadcaster.ApiURL)
+		if err != nil || (apiURL.Scheme != "http" && apiURL.Scheme != "https") || apiURL.Host == "" {
+			c.add("broadcaster.apiURL", "invalid URL %q, has to be an http or https URL", cfg.Broadcaster.ApiURL)
+		}
+	}
+
+	if c.isSet("api") {
+		c.address("api.address", cfg.API.Address, true)
+	}
+
+	if c.isSet("k8sWatcher") && cfg.K8sWatcher.Namespace == "" {
+		c.required("k8sWatcher.namespace")
+	}
+}
+
+func (c *checker) validateCallbacker(cfg *ArcConfig) {
+	if !c.isSet("callbacker") {
+		return
+	}
+
+	callbacker := cfg.Callbacker
+	c.address("callbacker.listenAddr", callbacker.ListenAddr, true)
+	c.dialAddress("callbacker.dialAddr", callbacker.DialAddr, false)
+	c.address("callbacker.profilerAddr", callbacker.ProfilerAddr, false)
+	c.duration("callbacker.interval", callbacker.Interval, false)
+	c.duration("callbacker.expiryInterval", callbacker.ExpiryInterval, false)
+
+	if cfg.DataFolder == "" {
+		c.required("dataFolder")
+	}
+}
+
+func (c *checker) validateMetamorph(cfg *ArcConfig) {
+	if !c.isSet("metamorph") {
+		return
+	}
+
+	metamorph := cfg.Metamorph
+	c.address("metamorph.listenAddr", metamorph.ListenAddr, true)
+	c.dialAddress("metamorph.dialAddr", metamorph.DialAddr, true)
+	c.address("metamorph.profilerAddr", metamorph.ProfilerAddr, false)
+	c.dialAddress("metamorph.healthServerDialAddr", metamorph.HealthServerDialAddr, false)
+	c.duration("metamorph.processorCacheExpiryTime", metamorph.ProcessorCacheExpiryTime, false)
+	c.duration("metamorph.blocktxTimeout", metamorph.BlocktxTimeout, false)
+	c.duration("metamorph.checkIfMinedInterval", metamorph.CheckIfMinedInterval, false)
+	c.duration("metamorph.loadUnminedPeriod", metamorph.LoadUnminedPeriod, false)
+	c.positive("metamorph.maxMonitoredTxs", metamorph.MaxMonitoredTxs, false)
+	c.duration("metamorph.rebroadcastInterval", metamorph.RebroadcastInterval, false)
+
+	db := metamorph.DB
+	c.oneOf("metamorph.db.mode", db.Mode, metamorphDBModes, true)
+	c.cleanData("metamorph.db.cleanData", db.CleanData, db.Mode == "dynamodb")
+
+	switch db.Mode {
+	case "postgres":
+		c.postgres("metamorph.db.postgres", db.Postgres)
+	case "dynamodb":
+		if db.DynamoDB.TableNameSuffix == "" {
+			c.required("metamorph.db.dynamoDB.tableNameSuffix")
+		}
+	}
+
+	if contains(metamorphDataModes, db.Mode) && cfg.DataFolder == "" {
+		c.required("dataFolder")
+	}
+}
+
+func (c *checker) validateBlockTx(cfg *ArcConfig) {
+	if !c.isSet("blocktx") {
+		return
+	}
+
+	blocktx := cfg.BlockTx
+	c.address("blocktx.listenAddr", blocktx.ListenAddr, true)
+	c.dialAddress("blocktx.dialAddr", blocktx.DialAddr, true)
+	c.address("blocktx.profilerAddr", blocktx.ProfilerAddr, false)
+	c.positive("blocktx.startingBlockHeight", int64(blocktx.StartingBlockHeight), true)
+	c.positive("blocktx.blockPipelineDepth", int64(blocktx.BlockPipelineDepth), false)
+	c.positive("blocktx.blockSpool.memoryLimitMB", int64(blocktx.BlockSpool.MemoryLimitMB), false)
+
+	lease := blocktx.PrimaryLease
+	c.duration("blocktx.primaryLease.duration", lease.Duration, false)
+	c.duration("blocktx.primaryLease.renewInterval", lease.RenewInterval, false)
+	if lease.Duration > 0 && lease.RenewInterval >= lease.Duration {
+		c.add("blocktx.primaryLease.renewInterval", "has to be shorter than blocktx.primaryLease.duration")
+	}
+
+	if c.isSet("blocktx.peerReputation") {
+		reputation := blocktx.PeerReputation
+		c.positive("blocktx.peerReputation.banScore", int64(reputation.BanScore), true)
+		c.duration("blocktx.peerReputation.banDuration", reputation.BanDuration, true)
+		c.duration("blocktx.peerReputation.blockResponseTimeout", reputation.BlockResponseTimeout, true)
+	}
+
+	db := blocktx.DB
+	c.oneOf("blocktx.db.mode", db.Mode, blocktxDBModes, true)
+	c.cleanData("blocktx.db.cleanData", db.CleanData, true)
+
+	switch db.Mode {
+	case "postgres":
+		c.postgres("blocktx.db.postgres", db.Postgres)
+	case "sqlite":
+		if cfg.DataFolder == "" {
+			c.required("dataFolder")
+		}
+	}
+}
+
+func (c *checker) postgres(key string, postgres PostgresConfig) {
+	for setting, value := range map[string]string{
+		"host":     postgres.Host,
+		"name":     postgres.Name,
+		"user":     postgres.User,
+		"password": postgres.Password,
+		"sslMode":  postgres.SslMode,
+	} {
+		if value == "" {
+			c.required(key + "." + setting)
+		}
+	}
+
+	c.port(key+".port", postgres.Port, true)
+	c.positive(key+".maxIdleConns", int64(postgres.MaxIdleConns), true)
+	c.positive(key+".maxOpenConns", int64(postgres.MaxOpenConns), true)
+}
+
+func (c *checker) cleanData(key string, cleanData CleanDataConfig, retentionRequired bool) {
+	c.positive(key+".recordRetentionDays", int64(cleanData.RecordRetentionDays), retentionRequired)
+	c.positive(key+".executionIntervalHours", int64(cleanData.ExecutionIntervalHours), false)
+}
+
+func (c *checker) required(key string) {
+	if c.problemKeys[key] {
+		return
+	}
+
+	c.add(key, "is required")
+}
+
+func (c *checker) oneOf(key string, value string, allowed []string, required bool) {
+	if c.problemKeys[key] {
+		return
+	}
+
+	if value == "" {
+		if required {
+			c.add(key, "is required, has to be one of %s", strings.Join(allowed, " | "))
+		}
+		return
+	}
+
+	if !contains(allowed, value) {
+		c.add(key, "invalid value %q, has to be one of %s", value, strings.Join(allowed, " | "))
+	}
+}
+
+// address checks an address to listen on like localhost:8011 or :8011.
+func (c *checker) address(key string, value string, required bool) {
+	if c.problemKeys[key] {
+		return
+	}
+
+	if value == "" {
+		if required {
+			c.required(key)
+		}
+		return
+	}
+
+	_, port, err := net.SplitHostPort(value)
+	if err != nil {
+		c.add(key, "invalid address %q, has to be host:port", value)
+		return
+	}
+
+	if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+		c.add(key, "invalid port %q", port)
+	}
+}
+
+// dialAddress checks an address to dial. Besides host:port it can be a gRPC target with a scheme like
+// dns:///metamorph:8001.
+func (c *checker) dialAddress(key string, value string, required bool) {
+	if _, target, found := strings.Cut(value, ":///"); found {
+		value = target
+	}
+
+	c.address(key, value, required)
+}
+
+func (c *checker) port(key string, port int, required bool) {
+	if c.problemKeys[key] {
+		return
+	}
+
+	if port == 0 {
+		if required {
+			c.required(key)
+		}
+		return
+	}
+
+	if port < 0 || port > 65535 {
+		c.add(key, "invalid port %d", port)
+	}
+}
+
+func (c *checker) positive(key string, value int64, required bool) {
+	if c.problemKeys[key] {
+		return
+	}
+
+	if value == 0 {
+		if required {
+			c.required(key)
+		}
+		return
+	}
+
+	if value < 0 {
+		c.add(key, "has to be positive, got %d", value)
+	}
+}
+
+func (c *checker) duration(key string, value time.Duration, required bool) {
+	if c.problemKeys[key] {
+		return
+	}
+
+	if value == 0 {
+		if required {
+			c.required(key)
+		}
+		return
+	}
+
+	if value < 0 {
+		c.add(key, "has to be a positive duration, got %s", value)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const minimalConfig = `
+logLevel: INFO
+logFormat: json
+network: regtest
+`
+
+func readConfig(t *testing.T, content string) *viper.Viper {
+	t.Helper()
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(content)))
+
+	return v
+}
+
+func problemKeys(problems []Problem) []string {
+	keys := make([]string, len(problems))
+	for i, problem := range problems {
+		keys[i] = problem.Key
+	}
+
+	return keys
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("config.yaml of the repository is valid", func(t *testing.T) {
+		v := viper.New()
+		v.SetConfigFile("../config.yaml")
+		require.NoError(t, v.ReadInConfig())
+
+		result := Check(v)
+
+		require.Empty(t, result.Problems)
+		require.Equal(t, "localhost:8001", result.Config.Metamorph.ListenAddr)
+		require.Equal(t, 2*time.Minute, result.Config.BlockTx.PrimaryLease.Duration)
+		require.Len(t, result.Config.Peers, 3)
+	})
+
+	tt := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name:   "minimal configuration",
+			config: minimalConfig,
+		},
+		{
+			name: "invalid enum values",
+			config: `
+logLevel: TRACE
+logFormat: xml
+network: signet
+`,
+			expected: []string{"logFormat", "logLevel", "network"},
+		},
+		{
+			name:     "missing required settings",
+			config:   "tracing: true",
+			expected: []string{"logFormat", "logLevel", "network"},
+		},
+		{
+			name: "unknown keys",
+			config: minimalConfig + `
+loglevels: DEBUG
+metamorph:
+  listenAddr: localhost:8001
+  dialAddr: localhost:8001
+  db:
+    mode: badger
+    postgress:
+      host: localhost
+dataFolder: data
+grpcMessageSize: 1000
+peers:
+  - host: localhost
+    port:
+      p2p: 18333
+api:
+  address: localhost:9090
+  defaultPolicy:
+    maxtxsizepolicy: 100000000
+`,
+			expected: []string{"loglevels", "metamorph.db.postgress.host"},
+		},
+		{
+			name: "values which cannot be decoded",
+			config: minimalConfig + `
+callbacker:
+  listenAddr: localhost:8021
+  interval: soon
+dataFolder: data
+grpcMessageSize: large
+`,
+			expected: []string{"callbacker.interval", "grpcMessageSize"},
+		},
+		{
+			name: "settings required by a service",
+			config: minimalConfig + `
+blocktx:
+  db:
+    mode: postgres
+    postgres:
+      host: localhost
+      name: blocktx
+      user: arc
+      password: arc
+      sslMode: disable
+      maxIdleConns: 10
+      maxOpenConns: 80
+`,
+			expected: []string{
+				"blocktx.db.cleanData.recordRetentionDays",
+				"blocktx.db.postgres.port",
+				"blocktx.dialAddr",
+				"blocktx.listenAddr",
+				"blocktx.startingBlockHeight",
+				"grpcMessageSize",
+				"peers",
+			},
+		},
+		{
+			name: "invalid addresses, ports and durations",
+			config: minimalConfig + `
+profilerAddr: localhost
+prometheusEndpoint: metrics
+peers:
+  - host: localhost
+    port:
+      p2p: 70000
+  - port:
+      p2p: 18333
+callbacker:
+  listenAddr: localhost:8021
+  dialAddr: dns:///callbacker:8021
+  expiryInterval: -1m
+dataFolder: data
+broadcaster:
+  apiURL: arc.taal.com
+`,
+			expected: []string{
+				"broadcaster.apiURL",
+				"callbacker.expiryInterval",
+				"peers[0].port.p2p",
+				"peers[1].host",
+				"profilerAddr",
+				"prometheusEndpoint",
+			},
+		},
+		{
+			name: "renew interval of the primary lease longer than its duration",
+			config: minimalConfig + `
+grpcMessageSize: 1000
+dataFolder: data
+peers:
+  - host: localhost
+    port:
+      p2p: 18333
+blocktx:
+  listenAddr: localhost:8011
+  dialAddr: localhost:8011
+  startingBlockHeight: 100
+  db:
+    mode: sqlite
+    cleanData:
+      recordRetentionDays: 14
+  primaryLease:
+    duration: 30s
+    renewInterval: 1m
+`,
+			expected: []string{"blocktx.primaryLease.renewInterval"},
+		},
+		{
+			name: "invalid callback policy",
+			config: minimalConfig + `
+callbackPolicy:
+  maxRedirects: -1
+`,
+			expected: []string{"callbackPolicy"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Check(readConfig(t, tc.config))
+
+			require.Equal(t, tc.expected, nonEmpty(problemKeys(result.Problems)), result.Problems)
+		})
+	}
+
+	t.Run("environment overrides", func(t *testing.T) {
+		t.Setenv("ARC_LOGFORMAT", "xml")
+		t.Setenv("ARC_METAMORPH_DB_MODE", "postgres")
+
+		v := readConfig(t, minimalConfig)
+		v.SetEnvPrefix(EnvPrefix)
+		v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+		v.AutomaticEnv()
+
+		result := Check(v)
+
+		require.ElementsMatch(t, []EnvOverride{
+			{Key: "logFormat", Variable: "ARC_LOGFORMAT"},
+			{Key: "metamorph.db.mode", Variable: "ARC_METAMORPH_DB_MODE"},
+		}, result.EnvOverrides)
+		require.Equal(t, "postgres", result.Config.Metamorph.DB.Mode)
+		require.Contains(t, problemKeys(result.Problems), "logFormat")
+		require.Contains(t, problemKeys(result.Problems), "metamorph.db.postgres.host")
+	})
+}
+
+// nonEmpty returns nil instead of an empty slice, so that it can be compared to an expectation which is not set.
+func nonEmpty(keys []string) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return keys
+}
+
+func TestEnvVariable(t *testing.T) {
+	require.Equal(t, "ARC_METAMORPH_DB_MODE", EnvVariable("metamorph.db.mode"))
+	require.Equal(t, "ARC_BLOCKTX_PRIMARYLEASE_RENEWINTERVAL", EnvVariable("blocktx.primaryLease.renewInterval"))
+}
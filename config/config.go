@@ -0,0 +1,144 @@
+package config
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
+)
+
+// ArcConfig is the configuration of all services of ARC as read from config.yaml. The keys of the settings are the
+// mapstructure tags of the fields.
+type ArcConfig struct {
+	LogLevel                string                 `mapstructure:"logLevel"`
+	LogFormat               string                 `mapstructure:"logFormat"`
+	ProfilerAddr            string                 `mapstructure:"profilerAddr"`
+	StatisticsServerAddress string                 `mapstructure:"statisticsServerAddress"`
+	PrometheusEndpoint      string                 `mapstructure:"prometheusEndpoint"`
+	Tracing                 bool                   `mapstructure:"tracing"`
+	DataFolder              string                 `mapstructure:"dataFolder"`
+	GrpcMessageSize         int                    `mapstructure:"grpcMessageSize"`
+	Network                 string                 `mapstructure:"network"`
+	PeerRpc                 PeerRpcConfig          `mapstructure:"peerRpc"`
+	Peers                   []Peer                 `mapstructure:"peers"`
+	CallbackPolicy          *callbackpolicy.Config `mapstructure:"callbackPolicy"`
+	Callbacker              CallbackerConfig       `mapstructure:"callbacker"`
+	Metamorph               MetamorphConfig        `mapstructure:"metamorph"`
+	BlockTx                 BlockTxConfig          `mapstructure:"blocktx"`
+	Broadcaster             BroadcasterConfig      `mapstructure:"broadcaster"`
+	API                     APIConfig              `mapstructure:"api"`
+	K8sWatcher              K8sWatcherConfig       `mapstructure:"k8sWatcher"`
+}
+
+type PeerRpcConfig struct {
+	Password string `mapstructure:"password"`
+	User     string `mapstructure:"user"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+}
+
+type CallbackerConfig struct {
+	ListenAddr     string        `mapstructure:"listenAddr"`
+	DialAddr       string        `mapstructure:"dialAddr"`
+	ProfilerAddr   string        `mapstructure:"profilerAddr"`
+	Interval       time.Duration `mapstructure:"interval"`
+	ExpiryInterval time.Duration `mapstructure:"expiryInterval"`
+}
+
+type MetamorphConfig struct {
+	ListenAddr               string             `mapstructure:"listenAddr"`
+	DialAddr                 string             `mapstructure:"dialAddr"`
+	DB                       MetamorphDBConfig  `mapstructure:"db"`
+	ProcessorCacheExpiryTime time.Duration      `mapstructure:"processorCacheExpiryTime"`
+	CheckUtxos               bool               `mapstructure:"checkUtxos"`
+	StatsKeypress            bool               `mapstructure:"statsKeypress"`
+	ProfilerAddr             string             `mapstructure:"profilerAddr"`
+	BlocktxTimeout           time.Duration      `mapstructure:"blocktxTimeout"`
+	CheckIfMinedInterval     time.Duration      `mapstructure:"checkIfMinedInterval"`
+	HealthServerDialAddr     string             `mapstructure:"healthServerDialAddr"`
+	LoadUnminedPeriod        time.Duration      `mapstructure:"loadUnminedPeriod"`
+	MaxMonitoredTxs          int64              `mapstructure:"maxMonitoredTxs"`
+	RebroadcastInterval      time.Duration      `mapstructure:"rebroadcastInterval"`
+	Log                      MetamorphLogConfig `mapstructure:"log"`
+}
+
+type MetamorphDBConfig struct {
+	Mode      string          `mapstructure:"mode"`
+	Postgres  PostgresConfig  `mapstructure:"postgres"`
+	DynamoDB  DynamoDBConfig  `mapstructure:"dynamoDB"`
+	CleanData CleanDataConfig `mapstructure:"cleanData"`
+}
+
+type MetamorphLogConfig struct {
+	File string `mapstructure:"file"`
+}
+
+type DynamoDBConfig struct {
+	TableNameSuffix string `mapstructure:"tableNameSuffix"`
+}
+
+type BlockTxConfig struct {
+	ListenAddr          string               `mapstructure:"listenAddr"`
+	DialAddr            string               `mapstructure:"dialAddr"`
+	DB                  BlockTxDBConfig      `mapstructure:"db"`
+	ProfilerAddr        string               `mapstructure:"profilerAddr"`
+	StartingBlockHeight int                  `mapstructure:"startingBlockHeight"`
+	HeadersFirstSync    bool                 `mapstructure:"headersFirstSync"`
+	FullIndex           bool                 `mapstructure:"fullIndex"`
+	BlockPipelineDepth  int                  `mapstructure:"blockPipelineDepth"`
+	PrimaryLease        PrimaryLeaseConfig   `mapstructure:"primaryLease"`
+	PeerReputation      PeerReputationConfig `mapstructure:"peerReputation"`
+	BlockSpool          BlockSpoolConfig     `mapstructure:"blockSpool"`
+}
+
+type BlockTxDBConfig struct {
+	Mode      string          `mapstructure:"mode"`
+	Postgres  PostgresConfig  `mapstructure:"postgres"`
+	CleanData CleanDataConfig `mapstructure:"cleanData"`
+}
+
+type PrimaryLeaseConfig struct {
+	Duration      time.Duration `mapstructure:"duration"`
+	RenewInterval time.Duration `mapstructure:"renewInterval"`
+}
+
+type PeerReputationConfig struct {
+	BanScore             int           `mapstructure:"banScore"`
+	BanDuration          time.Duration `mapstructure:"banDuration"`
+	BlockResponseTimeout time.Duration `mapstructure:"blockResponseTimeout"`
+}
+
+type BlockSpoolConfig struct {
+	Dir           string `mapstructure:"dir"`
+	MemoryLimitMB int    `mapstructure:"memoryLimitMB"`
+}
+
+type PostgresConfig struct {
+	Host         string `mapstructure:"host"`
+	Port         int    `mapstructure:"port"`
+	Name         string `mapstructure:"name"`
+	User         string `mapstructure:"user"`
+	Password     string `mapstructure:"password"`
+	MaxIdleConns int    `mapstructure:"maxIdleConns"`
+	MaxOpenConns int    `mapstructure:"maxOpenConns"`
+	SslMode      string `mapstructure:"sslMode"`
+}
+
+type CleanDataConfig struct {
+	RecordRetentionDays    int `mapstructure:"recordRetentionDays"`
+	ExecutionIntervalHours int `mapstructure:"executionIntervalHours"`
+}
+
+type BroadcasterConfig struct {
+	ApiURL string `mapstructure:"apiURL"`
+}
+
+type APIConfig struct {
+	Address   string `mapstructure:"address"`
+	WocApiKey string `mapstructure:"wocApiKey"`
+	// DefaultPolicy holds the policy settings of the node, which are passed on as they are.
+	DefaultPolicy map[string]any `mapstructure:"defaultPolicy"`
+}
+
+type K8sWatcherConfig struct {
+	Namespace string `mapstructure:"namespace"`
+}
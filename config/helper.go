@@ -7,15 +7,20 @@ import (
 	"os"
 	"time"
 
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/libsv/go-p2p/wire"
 	"github.com/lmittmann/tint"
 	"github.com/spf13/viper"
 )
 
-func GetSlogLevel() (slog.Level, error) {
+// logLevel is the level of all loggers created by NewLogger, so that it can be changed while the services are running.
+var logLevel = new(slog.LevelVar)
 
-	logLevelString := viper.GetString("logLevel")
+func GetSlogLevel() (slog.Level, error) {
+	return ParseLogLevel(viper.GetString("logLevel"))
+}
 
+func ParseLogLevel(logLevelString string) (slog.Level, error) {
 	switch logLevelString {
 	case "INFO":
 		return slog.LevelInfo, nil
@@ -30,13 +35,20 @@ func GetSlogLevel() (slog.Level, error) {
 	return slog.LevelInfo, fmt.Errorf("invalid log level: %s", logLevelString)
 }
 
+// SetLogLevel changes the level of all loggers created by NewLogger.
+func SetLogLevel(level slog.Level) {
+	logLevel.Set(level)
+}
+
 func NewLogger() (*slog.Logger, error) {
 
-	logLevel, err := GetSlogLevel()
+	level, err := GetSlogLevel()
 	if err != nil {
 		return nil, err
 	}
 
+	logLevel.Set(level)
+
 	logFormat := viper.GetString("logFormat")
 	switch logFormat {
 	case "json":
@@ -155,3 +167,33 @@ func GetNetwork() (wire.BitcoinNet, error) {
 
 	return network, nil
 }
+
+// GetCallbackPolicy returns the policy for callback URLs. If no policy is configured, the default policy is returned.
+func GetCallbackPolicy() (*callbackpolicy.Policy, error) {
+	if !viper.IsSet("callbackPolicy") {
+		return NewCallbackPolicy(nil)
+	}
+
+	var cfg callbackpolicy.Config
+	err := viper.UnmarshalKey("callbackPolicy", &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCallbackPolicy(&cfg)
+}
+
+// NewCallbackPolicy returns the policy for callback URLs with the configuration. If the configuration is nil, the
+// default policy is returned, which allows http and https, blocks private IP addresses and limits redirects.
+func NewCallbackPolicy(cfg *callbackpolicy.Config) (*callbackpolicy.Policy, error) {
+	if cfg == nil {
+		cfg = &callbackpolicy.Config{}
+	}
+
+	policy, err := callbackpolicy.NewFromConfig(*cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid callback policy: %v", err)
+	}
+
+	return policy, nil
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
@@ -36,3 +38,63 @@ func TestGetPeerSettings(t *testing.T) {
 		require.Equal(t, expectedPeerSettings, peerSettings)
 	})
 }
+
+func TestGetCallbackPolicy(t *testing.T) {
+	t.Run("get callback policy from config", func(t *testing.T) {
+		viper.SetConfigName("config")
+		viper.SetConfigType("yaml")
+		viper.AddConfigPath("./testdata")
+		err := viper.ReadInConfig()
+		require.NoError(t, err)
+
+		policy, err := GetCallbackPolicy()
+		require.NoError(t, err)
+		require.NotNil(t, policy)
+
+		require.NoError(t, policy.Validate("https://api.callback.com"))
+		require.ErrorIs(t, policy.Validate("http://api.callback.com"), callbackpolicy.ErrSchemeNotAllowed)
+		require.ErrorIs(t, policy.Validate("https://203.0.113.10"), callbackpolicy.ErrHostNotAllowed)
+		require.ErrorIs(t, policy.Validate("https://192.168.1.1"), callbackpolicy.ErrIPNotAllowed)
+	})
+
+	t.Run("no callback policy", func(t *testing.T) {
+		viper.Reset()
+
+		policy, err := GetCallbackPolicy()
+		require.NoError(t, err)
+		require.NotNil(t, policy)
+
+		require.NoError(t, policy.Validate("http://api.callback.com"))
+		require.NoError(t, policy.Validate("https://api.callback.com"))
+		require.ErrorIs(t, policy.Validate("ftp://api.callback.com"), callbackpolicy.ErrSchemeNotAllowed)
+		require.ErrorIs(t, policy.Validate("http://127.0.0.1:8080"), callbackpolicy.ErrIPNotAllowed)
+	})
+
+	t.Run("block private IPs not set", func(t *testing.T) {
+		viper.Reset()
+		viper.SetConfigType("yaml")
+		err := viper.ReadConfig(strings.NewReader(`
+callbackPolicy:
+  maxRedirects: 1
+`))
+		require.NoError(t, err)
+
+		policy, err := GetCallbackPolicy()
+		require.NoError(t, err)
+		require.ErrorIs(t, policy.Validate("http://192.168.1.1"), callbackpolicy.ErrIPNotAllowed)
+	})
+
+	t.Run("private IPs allowed", func(t *testing.T) {
+		viper.Reset()
+		viper.SetConfigType("yaml")
+		err := viper.ReadConfig(strings.NewReader(`
+callbackPolicy:
+  blockPrivateIPs: false
+`))
+		require.NoError(t, err)
+
+		policy, err := GetCallbackPolicy()
+		require.NoError(t, err)
+		require.NoError(t, policy.Validate("http://192.168.1.1"))
+	})
+}
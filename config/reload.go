@@ -0,0 +1,195 @@
+package config
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// reloadableKeys are the settings which are applied while the services are running. A key denotes all settings of
+// its section as well. Changes of all other settings require a restart.
+var reloadableKeys = []string{
+	"logLevel",
+	"callbackPolicy",
+	"metamorph.maxMonitoredTxs",
+	"metamorph.checkIfMinedInterval",
+	"metamorph.rebroadcastInterval",
+	"api.defaultPolicy",
+	"callbacker.interval",
+	"callbacker.expiryInterval",
+}
+
+// ReloadFunc applies the reloadable settings of the configuration to a running service.
+type ReloadFunc func(cfg *ArcConfig) error
+
+// Reloader reloads the configuration while the services are running. Changes of the reloadable settings are applied
+// by the functions registered with OnReload, changes of other settings are logged and rejected. The configuration is
+// reloaded if the configuration file changes or on ReloadFile, e.g. on SIGHUP.
+type Reloader struct {
+	logger *slog.Logger
+	v      *viper.Viper
+
+	mu        sync.Mutex
+	current   *ArcConfig
+	reloadFns []ReloadFunc
+}
+
+// NewReloader returns a reloader of the configuration which has been read by viper. Problems of the configuration are
+// logged, because the configuration is only reloaded once it has no problems.
+func NewReloader(logger *slog.Logger, v *viper.Viper) *Reloader {
+	r := &Reloader{
+		logger: logger.With(slog.String("module", "config-reloader")),
+		v:      v,
+	}
+
+	result := Check(v)
+	for _, problem := range result.Problems {
+		r.logger.Warn("invalid setting", slog.String("key", problem.Key), slog.String("problem", problem.Message))
+	}
+
+	r.current = result.Config
+
+	return r
+}
+
+// OnReload registers the function which applies the reloadable settings to a service.
+func (r *Reloader) OnReload(fn ReloadFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reloadFns = append(r.reloadFns, fn)
+}
+
+// Watch reloads the configuration whenever the configuration file changes.
+func (r *Reloader) Watch() {
+	r.v.OnConfigChange(func(_ fsnotify.Event) {
+		r.Reload()
+	})
+	r.v.WatchConfig()
+}
+
+// ReloadFile reads the configuration file again and reloads the configuration.
+func (r *Reloader) ReloadFile() {
+	err := r.v.ReadInConfig()
+	if err != nil {
+		r.logger.Error("failed to read config file, configuration not reloaded", slog.String("err", err.Error()))
+		return
+	}
+
+	r.Reload()
+}
+
+// Reload applies the changes of the reloadable settings since the last reload. The configuration is not reloaded at
+// all if it has problems.
+func (r *Reloader) Reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := Check(r.v)
+	if len(result.Problems) > 0 {
+		for _, problem := range result.Problems {
+			r.logger.Error("invalid setting", slog.String("key", problem.Key), slog.String("problem", problem.Message))
+		}
+		r.logger.Error("configuration has problems and is not reloaded", slog.Int("problems", len(result.Problems)))
+		return
+	}
+
+	var reloaded []string
+	for _, key := range changedKeys(reflect.ValueOf(*r.current), reflect.ValueOf(*result.Config), "") {
+		if !isReloadable(key) {
+			r.logger.Warn("change of setting requires a restart and is rejected", slog.String("key", key))
+			continue
+		}
+
+		reloaded = append(reloaded, key)
+	}
+
+	if len(reloaded) == 0 {
+		return
+	}
+
+	applied := applyReloadable(r.current, result.Config)
+
+	// the log level is valid, otherwise the configuration would have problems
+	level, _ := ParseLogLevel(applied.LogLevel)
+	SetLogLevel(level)
+
+	for _, fn := range r.reloadFns {
+		err := fn(applied)
+		if err != nil {
+			r.logger.Error("failed to apply reloaded configuration", slog.String("err", err.Error()))
+		}
+	}
+
+	r.current = applied
+	r.logger.Info("configuration reloaded", slog.String("keys", strings.Join(reloaded, ", ")))
+}
+
+func isReloadable(key string) bool {
+	for _, reloadableKey := range reloadableKeys {
+		if key == reloadableKey || strings.HasPrefix(key, reloadableKey+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyReloadable returns the current configuration with the reloadable settings of the updated configuration.
+func applyReloadable(current *ArcConfig, updated *ArcConfig) *ArcConfig {
+	applied := *current
+
+	applied.LogLevel = updated.LogLevel
+	applied.CallbackPolicy = updated.CallbackPolicy
+	applied.Metamorph.MaxMonitoredTxs = updated.Metamorph.MaxMonitoredTxs
+	applied.Metamorph.CheckIfMinedInterval = updated.Metamorph.CheckIfMinedInterval
+	applied.Metamorph.RebroadcastInterval = updated.Metamorph.RebroadcastInterval
+	applied.API.DefaultPolicy = updated.API.DefaultPolicy
+	applied.Callbacker.Interval = updated.Callbacker.Interval
+	applied.Callbacker.ExpiryInterval = updated.Callbacker.ExpiryInterval
+
+	return &applied
+}
+
+// changedKeys returns the keys of the settings whose values differ between the two structs of the same type. Structs
+// are compared setting by setting, all other values as a whole.
+func changedKeys(a reflect.Value, b reflect.Value, prefix string) []string {
+	var keys []string
+
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+
+		key := settingName(field)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		valueA, valueB := a.Field(i), b.Field(i)
+
+		if field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct {
+			if valueA.IsNil() || valueB.IsNil() {
+				if valueA.IsNil() != valueB.IsNil() {
+					keys = append(keys, key)
+				}
+				continue
+			}
+
+			valueA, valueB = valueA.Elem(), valueB.Elem()
+		}
+
+		if valueA.Kind() == reflect.Struct {
+			keys = append(keys, changedKeys(valueA, valueB, key)...)
+			continue
+		}
+
+		if !reflect.DeepEqual(valueA.Interface(), valueB.Interface()) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
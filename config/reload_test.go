@@ -0,0 +1,209 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const reloadConfig = minimalConfig + `
+callbacker:
+  listenAddr: localhost:8021
+  interval: 30s
+  expiryInterval: 3m
+dataFolder: data
+`
+
+func TestReloader(t *testing.T) {
+	tt := []struct {
+		name    string
+		updated string
+
+		expectedReloads  int
+		expectedLogLevel slog.Level
+		expectedInterval time.Duration
+	}{
+		{
+			name:    "reloadable settings",
+			updated: strings.Replace(strings.Replace(reloadConfig, "INFO", "DEBUG", 1), "interval: 30s", "interval: 10s", 1),
+
+			expectedReloads:  1,
+			expectedLogLevel: slog.LevelDebug,
+			expectedInterval: 10 * time.Second,
+		},
+		{
+			name:    "setting which requires a restart",
+			updated: strings.Replace(reloadConfig, "localhost:8021", "localhost:8022", 1),
+
+			expectedReloads:  0,
+			expectedLogLevel: slog.LevelInfo,
+			expectedInterval: 30 * time.Second,
+		},
+		{
+			name:    "reloadable setting and setting which requires a restart",
+			updated: strings.Replace(strings.Replace(reloadConfig, "localhost:8021", "localhost:8022", 1), "interval: 30s", "interval: 10s", 1),
+
+			expectedReloads:  1,
+			expectedLogLevel: slog.LevelInfo,
+			expectedInterval: 10 * time.Second,
+		},
+		{
+			name:    "configuration with problems",
+			updated: strings.Replace(strings.Replace(reloadConfig, "INFO", "TRACE", 1), "interval: 30s", "interval: 10s", 1),
+
+			expectedReloads:  0,
+			expectedLogLevel: slog.LevelInfo,
+			expectedInterval: 30 * time.Second,
+		},
+		{
+			name:    "no changes",
+			updated: reloadConfig,
+
+			expectedReloads:  0,
+			expectedLogLevel: slog.LevelInfo,
+			expectedInterval: 30 * time.Second,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			SetLogLevel(slog.LevelInfo)
+			t.Cleanup(func() { SetLogLevel(slog.LevelInfo) })
+
+			v := readConfig(t, reloadConfig)
+			reloader := NewReloader(slog.New(slog.NewTextHandler(io.Discard, nil)), v)
+
+			reloads := 0
+			var reloaded *ArcConfig
+			reloader.OnReload(func(cfg *ArcConfig) error {
+				reloads++
+				reloaded = cfg
+				return nil
+			})
+
+			require.NoError(t, v.ReadConfig(strings.NewReader(tc.updated)))
+			reloader.Reload()
+
+			require.Equal(t, tc.expectedReloads, reloads)
+			require.Equal(t, tc.expectedLogLevel, logLevel.Level())
+			require.Equal(t, tc.expectedInterval, reloader.current.Callbacker.Interval)
+			require.Equal(t, "localhost:8021", reloader.current.Callbacker.ListenAddr)
+			if reloaded != nil {
+				require.Equal(t, reloader.current, reloaded)
+			}
+		})
+	}
+
+	t.Run("failing reload function", func(t *testing.T) {
+		v := readConfig(t, reloadConfig)
+		reloader := NewReloader(slog.New(slog.NewTextHandler(io.Discard, nil)), v)
+
+		reloads := 0
+		reloader.OnReload(func(_ *ArcConfig) error {
+			return errors.New("failed to apply")
+		})
+		reloader.OnReload(func(_ *ArcConfig) error {
+			reloads++
+			return nil
+		})
+
+		require.NoError(t, v.ReadConfig(strings.NewReader(strings.Replace(reloadConfig, "interval: 30s", "interval: 10s", 1))))
+		reloader.Reload()
+
+		require.Equal(t, 1, reloads)
+		require.Equal(t, 10*time.Second, reloader.current.Callbacker.Interval)
+	})
+
+	t.Run("rebroadcast interval", func(t *testing.T) {
+		config := reloadConfig + `
+grpcMessageSize: 100000000
+peers:
+  - host: localhost
+    port:
+      p2p: 18333
+metamorph:
+  listenAddr: localhost:8001
+  dialAddr: localhost:8001
+  db:
+    mode: badger
+  rebroadcastInterval: 1m
+`
+		v := readConfig(t, config)
+		reloader := NewReloader(slog.New(slog.NewTextHandler(io.Discard, nil)), v)
+
+		var reloaded *ArcConfig
+		reloader.OnReload(func(cfg *ArcConfig) error {
+			reloaded = cfg
+			return nil
+		})
+
+		require.NoError(t, v.ReadConfig(strings.NewReader(strings.Replace(config, "rebroadcastInterval: 1m", "rebroadcastInterval: 10s", 1))))
+		reloader.Reload()
+
+		require.NotNil(t, reloaded)
+		require.Equal(t, 10*time.Second, reloaded.Metamorph.RebroadcastInterval)
+	})
+}
+
+func TestChangedKeys(t *testing.T) {
+	current := ArcConfig{
+		LogLevel: "INFO",
+		Peers:    []Peer{{Host: "localhost"}},
+		Metamorph: MetamorphConfig{
+			DB: MetamorphDBConfig{Mode: "badger"},
+		},
+	}
+
+	updated := current
+	updated.LogLevel = "DEBUG"
+	updated.Peers = []Peer{{Host: "node"}}
+	updated.Metamorph.DB.Mode = "postgres"
+
+	require.Equal(t, []string{"logLevel", "peers", "metamorph.db.mode"}, changedKeys(reflect.ValueOf(current), reflect.ValueOf(updated), ""))
+	require.Empty(t, changedKeys(reflect.ValueOf(current), reflect.ValueOf(current), ""))
+}
+
+// TestApplyReloadable makes sure that the reloadable keys and the settings applied by applyReloadable do not diverge.
+func TestApplyReloadable(t *testing.T) {
+	current := Check(readConfig(t, "")).Config
+	updated := Check(readConfig(t, `
+logLevel: DEBUG
+callbackPolicy:
+  maxRedirects: 3
+metamorph:
+  maxMonitoredTxs: 10
+  checkIfMinedInterval: 1m
+  rebroadcastInterval: 2m
+api:
+  defaultPolicy:
+    maxtxsizepolicy: 100
+callbacker:
+  interval: 10s
+  expiryInterval: 1m
+`)).Config
+
+	applied := applyReloadable(current, updated)
+	changed := changedKeys(reflect.ValueOf(*current), reflect.ValueOf(*applied), "")
+
+	require.Len(t, changed, len(reloadableKeys))
+	for _, key := range changed {
+		require.True(t, isReloadable(key), key)
+	}
+}
+
+func TestIsReloadable(t *testing.T) {
+	require.True(t, isReloadable("logLevel"))
+	require.True(t, isReloadable("callbackPolicy"))
+	require.True(t, isReloadable("callbackPolicy.maxRedirects"))
+	require.True(t, isReloadable("metamorph.maxMonitoredTxs"))
+	require.True(t, isReloadable("metamorph.rebroadcastInterval"))
+	require.False(t, isReloadable("logFormat"))
+	require.False(t, isReloadable("metamorph.listenAddr"))
+	require.False(t, isReloadable("callbacker.intervals"))
+}
@@ -0,0 +1,5 @@
+DROP INDEX ix_block_merkle_subtrees_inserted_at;
+DROP INDEX ix_block_merkle_trees_inserted_at;
+
+DROP TABLE block_merkle_subtrees;
+DROP TABLE block_merkle_trees;
@@ -0,0 +1,18 @@
+CREATE TABLE block_merkle_trees (
+    blockid BIGINT PRIMARY KEY,
+    tx_count BIGINT NOT NULL,
+    subtree_size BIGINT NOT NULL,
+    subtree_roots BYTEA NOT NULL,
+    inserted_at_num INTEGER DEFAULT TO_NUMBER(TO_CHAR((NOW()) AT TIME ZONE 'UTC', 'yyyymmddhh24'), '9999999999') NOT NULL
+);
+
+CREATE TABLE block_merkle_subtrees (
+    blockid BIGINT NOT NULL,
+    subtree_index BIGINT NOT NULL,
+    leaves BYTEA NOT NULL,
+    inserted_at_num INTEGER DEFAULT TO_NUMBER(TO_CHAR((NOW()) AT TIME ZONE 'UTC', 'yyyymmddhh24'), '9999999999') NOT NULL,
+    PRIMARY KEY (blockid, subtree_index)
+);
+
+CREATE INDEX ix_block_merkle_trees_inserted_at ON block_merkle_trees (inserted_at_num);
+CREATE INDEX ix_block_merkle_subtrees_inserted_at ON block_merkle_subtrees (inserted_at_num);
@@ -0,0 +1,2 @@
+DROP INDEX ix_block_headers_height;
+DROP TABLE block_headers;
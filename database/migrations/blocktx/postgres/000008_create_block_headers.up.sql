@@ -0,0 +1,9 @@
+CREATE TABLE block_headers (
+    hash BYTEA PRIMARY KEY,
+    prevhash BYTEA NOT NULL,
+    height BIGINT NOT NULL,
+    header BYTEA NOT NULL,
+    inserted_at_num INTEGER DEFAULT TO_NUMBER(TO_CHAR((NOW()) AT TIME ZONE 'UTC', 'yyyymmddhh24'), '9999999999') NOT NULL
+);
+
+CREATE INDEX ix_block_headers_height ON block_headers (height);
@@ -0,0 +1,8 @@
+ALTER TABLE blocks DROP COLUMN fencing_epoch;
+
+DROP TABLE primary_blocktx;
+
+CREATE TABLE primary_blocktx (
+    host_name TEXT PRIMARY KEY,
+    primary_until TIMESTAMP
+);
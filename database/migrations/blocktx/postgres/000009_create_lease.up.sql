@@ -0,0 +1,10 @@
+DROP TABLE primary_blocktx;
+
+CREATE TABLE primary_blocktx (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    host_name TEXT NOT NULL,
+    primary_until TIMESTAMPTZ NOT NULL,
+    epoch BIGINT NOT NULL
+);
+
+ALTER TABLE blocks ADD COLUMN fencing_epoch BIGINT;
@@ -0,0 +1,3 @@
+DROP INDEX ix_block_stats_inserted_at;
+
+DROP TABLE block_stats;
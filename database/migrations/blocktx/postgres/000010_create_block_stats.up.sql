@@ -0,0 +1,12 @@
+CREATE TABLE block_stats (
+    blockid BIGINT PRIMARY KEY,
+    block_time BIGINT NOT NULL,
+    coinbase_value BIGINT NOT NULL,
+    subsidy BIGINT NOT NULL,
+    total_fees BIGINT NOT NULL,
+    tx_per_second DOUBLE PRECISION NOT NULL,
+    registered_tx_count BIGINT NOT NULL,
+    inserted_at_num INTEGER DEFAULT TO_NUMBER(TO_CHAR((NOW()) AT TIME ZONE 'UTC', 'yyyymmddhh24'), '9999999999') NOT NULL
+);
+
+CREATE INDEX ix_block_stats_inserted_at ON block_stats (inserted_at_num);
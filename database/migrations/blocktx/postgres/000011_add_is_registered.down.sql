@@ -0,0 +1,3 @@
+DROP INDEX ix_transactions_is_registered;
+
+ALTER TABLE transactions DROP COLUMN is_registered;
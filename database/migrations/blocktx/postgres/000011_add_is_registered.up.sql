@@ -0,0 +1,7 @@
+ALTER TABLE transactions
+ADD COLUMN is_registered BOOLEAN DEFAULT FALSE NOT NULL;
+
+-- only registered transactions have a source, transactions inserted for the full index do not
+UPDATE transactions SET is_registered = TRUE WHERE source IS NOT NULL;
+
+CREATE INDEX ix_transactions_is_registered ON transactions (id) WHERE is_registered;
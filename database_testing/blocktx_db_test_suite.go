@@ -77,6 +77,11 @@ type BlockTXDBTestSuite struct {
 }
 
 func (s *BlockTXDBTestSuite) SetupSuite() {
+	MigrateUpBlockTX(s.T())
+}
+
+// MigrateUpBlockTX applies the migrations of the blocktx database
+func MigrateUpBlockTX(t require.TestingT) {
 	_, callerFilePath, _, _ := runtime.Caller(0)
 
 	testDir := filepath.Dir(callerFilePath)
@@ -84,11 +89,11 @@ func (s *BlockTXDBTestSuite) SetupSuite() {
 	path := "file://" + testDir + "/../database/migrations/blocktx/postgres"
 	m, err := migrate.New(path, DefaultParams.String())
 
-	require.NoError(s.T(), err)
+	require.NoError(t, err)
 
 	if err := m.Up(); err != nil {
 		if !errors.Is(err, migrate.ErrNoChange) {
-			require.NoError(s.T(), err)
+			require.NoError(t, err)
 		}
 	}
 }
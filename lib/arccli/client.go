@@ -0,0 +1,284 @@
+package arccli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bitcoin-sv/arc/api"
+	"github.com/libsv/go-bt/v2"
+)
+
+const maxBatchLineSize = 64 * 1024 * 1024
+
+var ErrNoTransactions = errors.New("no transactions")
+
+// SubmitOptions are the X-* headers which are sent with submitted transactions. Options which are nil are not sent.
+type SubmitOptions struct {
+	CallbackURL          *string
+	CallbackToken        *string
+	FullStatusUpdates    *bool
+	MaxTimeout           *int
+	SkipFeeValidation    *bool
+	SkipScriptValidation *bool
+	SkipTxValidation     *bool
+	MerkleProof          *string
+	WaitForStatus        *int
+}
+
+func (o *SubmitOptions) transactionParams() *api.POSTTransactionParams {
+	return &api.POSTTransactionParams{
+		XCallbackUrl:          o.CallbackURL,
+		XCallbackToken:        o.CallbackToken,
+		XFullStatusUpdates:    o.FullStatusUpdates,
+		XMaxTimeout:           o.MaxTimeout,
+		XSkipFeeValidation:    o.SkipFeeValidation,
+		XSkipScriptValidation: o.SkipScriptValidation,
+		XSkipTxValidation:     o.SkipTxValidation,
+		XMerkleProof:          o.MerkleProof,
+		XWaitForStatus:        o.WaitForStatus,
+	}
+}
+
+func (o *SubmitOptions) transactionsParams() *api.POSTTransactionsParams {
+	return &api.POSTTransactionsParams{
+		XCallbackUrl:          o.CallbackURL,
+		XCallbackToken:        o.CallbackToken,
+		XFullStatusUpdates:    o.FullStatusUpdates,
+		XMaxTimeout:           o.MaxTimeout,
+		XSkipFeeValidation:    o.SkipFeeValidation,
+		XSkipScriptValidation: o.SkipScriptValidation,
+		XSkipTxValidation:     o.SkipTxValidation,
+		XMerkleProof:          o.MerkleProof,
+		XWaitForStatus:        o.WaitForStatus,
+	}
+}
+
+// Response is the status code and the JSON body of a response of ARC.
+type Response struct {
+	StatusCode int
+	Body       json.RawMessage
+}
+
+// OK returns whether the request succeeded.
+func (r *Response) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Client is a client of the ARC API for the endpoint of a profile.
+type Client struct {
+	arc        *api.ClientWithResponses
+	profile    *Profile
+	httpClient api.HttpRequestDoer
+}
+
+func WithHTTPClient(httpClient api.HttpRequestDoer) func(*Client) {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient returns a client of the endpoint of the profile, which sends the authorization of the profile with each
+// request.
+func NewClient(profile *Profile, opts ...func(*Client)) (*Client, error) {
+	if profile.URL == "" {
+		return nil, errors.New("url of ARC is missing")
+	}
+
+	c := &Client{profile: profile}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	clientOpts := make([]api.ClientOption, 0)
+	if profile.Authorization != "" {
+		clientOpts = append(clientOpts, api.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", profile.Authorization)
+			return nil
+		}))
+	}
+
+	if c.httpClient != nil {
+		clientOpts = append(clientOpts, api.WithHTTPClient(c.httpClient))
+	}
+
+	var err error
+	c.arc, err = api.NewClientWithResponses(strings.TrimSuffix(profile.URL, "/"), clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// submitOptions returns the options with the callback of the profile, if the options do not set a callback.
+func (c *Client) submitOptions(opts *SubmitOptions) *SubmitOptions {
+	withProfile := SubmitOptions{}
+	if opts != nil {
+		withProfile = *opts
+	}
+
+	if withProfile.CallbackURL == nil && c.profile.CallbackURL != "" {
+		withProfile.CallbackURL = &c.profile.CallbackURL
+	}
+
+	if withProfile.CallbackToken == nil && c.profile.CallbackToken != "" {
+		withProfile.CallbackToken = &c.profile.CallbackToken
+	}
+
+	return &withProfile
+}
+
+// Submit submits the transactions. A single transaction is submitted by itself, several transactions, e.g. a
+// transaction with its unmined ancestors, are submitted as batch in the given order.
+func (c *Client) Submit(ctx context.Context, txs []*bt.Tx, opts *SubmitOptions) (*Response, error) {
+	if len(txs) == 0 {
+		return nil, ErrNoTransactions
+	}
+
+	opts = c.submitOptions(opts)
+
+	if len(txs) == 1 {
+		res, err := c.arc.POSTTransactionWithBodyWithResponse(ctx, opts.transactionParams(), "application/octet-stream", bytes.NewReader(transactionBytes(txs[0])))
+		if err != nil {
+			return nil, err
+		}
+
+		return &Response{StatusCode: res.StatusCode(), Body: res.Body}, nil
+	}
+
+	var body []byte
+	for _, tx := range txs {
+		body = append(body, transactionBytes(tx)...)
+	}
+
+	res, err := c.arc.POSTTransactionsWithBodyWithResponse(ctx, opts.transactionsParams(), "application/octet-stream", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{StatusCode: res.StatusCode(), Body: res.Body}, nil
+}
+
+// Status returns the status of the transaction.
+func (c *Client) Status(ctx context.Context, txID string) (*Response, error) {
+	res, err := c.arc.GETTransactionStatusWithResponse(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{StatusCode: res.StatusCode(), Body: res.Body}, nil
+}
+
+// Policy returns the policy of ARC.
+func (c *Client) Policy(ctx context.Context) (*Response, error) {
+	res, err := c.arc.GETPolicyWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{StatusCode: res.StatusCode(), Body: res.Body}, nil
+}
+
+// Batch reads transaction requests ({"rawTx": "<hex>"}) as newline delimited JSON and submits them in batches of the
+// given size. For each request one line with the result of the transaction is written. If a batch fails as a whole,
+// the error is written for each of its transactions. It returns the number of transactions which have been submitted.
+func (c *Client) Batch(ctx context.Context, r io.Reader, w io.Writer, size int, opts *SubmitOptions) (int, error) {
+	if size < 1 {
+		return 0, errors.New("batch size has to be at least 1")
+	}
+
+	opts = c.submitOptions(opts)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBatchLineSize)
+
+	submitted := 0
+	batch := make([]api.TransactionRequest, 0, size)
+	line := 0
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		err := c.submitBatch(ctx, batch, w, opts)
+		if err != nil {
+			return err
+		}
+
+		submitted += len(batch)
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for scanner.Scan() {
+		line++
+
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var request api.TransactionRequest
+		if err := json.Unmarshal(text, &request); err != nil {
+			return submitted, fmt.Errorf("invalid transaction request in line %d: %v", line, err)
+		}
+
+		batch = append(batch, request)
+		if len(batch) == size {
+			if err := flush(); err != nil {
+				return submitted, err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return submitted, err
+	}
+
+	return submitted, flush()
+}
+
+func (c *Client) submitBatch(ctx context.Context, batch []api.TransactionRequest, w io.Writer, opts *SubmitOptions) error {
+	res, err := c.arc.POSTTransactionsWithResponse(ctx, opts.transactionsParams(), batch)
+	if err != nil {
+		return err
+	}
+
+	if res.JSON200 == nil || res.JSON200.Transactions == nil {
+		body := res.Body
+		compacted := &bytes.Buffer{}
+		if err = json.Compact(compacted, body); err == nil {
+			body = compacted.Bytes()
+		}
+
+		for range batch {
+			if _, err = fmt.Fprintf(w, "%s\n", body); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, item := range *res.JSON200.Transactions {
+		b, err := item.MarshalJSON()
+		if err != nil {
+			return err
+		}
+
+		if _, err = fmt.Fprintf(w, "%s\n", b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
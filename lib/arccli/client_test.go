@@ -0,0 +1,325 @@
+package arccli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/api"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/libsv/go-bt/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type arcRequest struct {
+	method      string
+	path        string
+	header      http.Header
+	contentType string
+	body        []byte
+}
+
+// newARCServer returns a server which records the requests and responds with the given status code and body.
+func newARCServer(t *testing.T, respond func(r *http.Request, body []byte) (int, string)) (*httptest.Server, *[]arcRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	requests := make([]arcRequest, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		requests = append(requests, arcRequest{method: r.Method, path: r.URL.Path, header: r.Header.Clone(), contentType: r.Header.Get("Content-Type"), body: body})
+		mu.Unlock()
+
+		statusCode, response := respond(r, body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestLoadProfiles(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "arc-cli.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(`
+defaultProfile: testnet
+profiles:
+  testnet:
+    url: https://arc-test.example.com
+    authorization: Bearer secret
+    callbackUrl: https://callbacks.example.com
+    callbackToken: token
+  Local:
+    url: http://localhost:9090
+`), 0o600))
+
+	profiles, err := LoadProfiles(fileName)
+	require.NoError(t, err)
+	require.Equal(t, []string{"local", "testnet"}, profiles.Names())
+
+	profile, err := profiles.Get("")
+	require.NoError(t, err)
+	require.Equal(t, &Profile{URL: "https://arc-test.example.com", Authorization: "Bearer secret", CallbackURL: "https://callbacks.example.com", CallbackToken: "token"}, profile)
+
+	profile, err = profiles.Get("Local")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:9090", profile.URL)
+
+	_, err = profiles.Get("mainnet")
+	require.ErrorIs(t, err, ErrProfileNotFound)
+
+	_, err = LoadProfiles(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestSubmit(t *testing.T) {
+	_, _, parent, tx := newBEEF(t)
+
+	server, requests := newARCServer(t, func(_ *http.Request, _ []byte) (int, string) {
+		return http.StatusOK, `{"txid":"` + tx.TxID() + `","txStatus":"SEEN_ON_NETWORK"}`
+	})
+
+	client, err := NewClient(&Profile{URL: server.URL + "/", Authorization: "Bearer secret", CallbackURL: "https://callbacks.example.com", CallbackToken: "token"})
+	require.NoError(t, err)
+
+	waitForStatus := 7
+	skipFeeValidation := true
+	callbackURL := "https://other.example.com"
+	res, err := client.Submit(context.Background(), []*bt.Tx{tx}, &SubmitOptions{
+		CallbackURL:       &callbackURL,
+		WaitForStatus:     &waitForStatus,
+		SkipFeeValidation: &skipFeeValidation,
+	})
+	require.NoError(t, err)
+	require.True(t, res.OK())
+	require.Contains(t, string(res.Body), "SEEN_ON_NETWORK")
+
+	// a transaction with its unmined ancestor is submitted as batch
+	_, err = client.Submit(context.Background(), []*bt.Tx{parent, tx}, nil)
+	require.NoError(t, err)
+
+	_, err = client.Submit(context.Background(), nil, nil)
+	require.ErrorIs(t, err, ErrNoTransactions)
+
+	require.Len(t, *requests, 2)
+
+	single := (*requests)[0]
+	require.Equal(t, "/v1/tx", single.path)
+	require.Equal(t, "application/octet-stream", single.contentType)
+	require.Equal(t, tx.Bytes(), single.body)
+	require.Equal(t, "Bearer secret", single.header.Get("Authorization"))
+	require.Equal(t, "https://other.example.com", single.header.Get("X-CallbackUrl"))
+	require.Equal(t, "token", single.header.Get("X-CallbackToken"))
+	require.Equal(t, "7", single.header.Get("X-WaitForStatus"))
+	require.Equal(t, "true", single.header.Get("X-SkipFeeValidation"))
+	require.Empty(t, single.header.Get("X-SkipScriptValidation"))
+
+	batch := (*requests)[1]
+	require.Equal(t, "/v1/txs", batch.path)
+	require.Equal(t, append(parent.Bytes(), tx.Bytes()...), batch.body)
+	require.Equal(t, "https://callbacks.example.com", batch.header.Get("X-CallbackUrl"))
+}
+
+func TestStatusAndPolicy(t *testing.T) {
+	server, requests := newARCServer(t, func(r *http.Request, _ []byte) (int, string) {
+		if r.URL.Path == "/v1/policy" {
+			return http.StatusOK, `{"policy":{"maxscriptsizepolicy":100000000}}`
+		}
+		return http.StatusNotFound, `{"status":404,"title":"Not found"}`
+	})
+
+	client, err := NewClient(&Profile{URL: server.URL})
+	require.NoError(t, err)
+
+	res, err := client.Status(context.Background(), "abcd")
+	require.NoError(t, err)
+	require.False(t, res.OK())
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res, err = client.Policy(context.Background())
+	require.NoError(t, err)
+	require.True(t, res.OK())
+	require.Contains(t, string(res.Body), "maxscriptsizepolicy")
+
+	require.Equal(t, "/v1/tx/abcd", (*requests)[0].path)
+	require.Empty(t, (*requests)[0].header.Get("Authorization"))
+
+	_, err = NewClient(&Profile{})
+	require.ErrorContains(t, err, "url of ARC is missing")
+}
+
+func TestBatch(t *testing.T) {
+	server, requests := newARCServer(t, func(_ *http.Request, body []byte) (int, string) {
+		var batch []api.TransactionRequest
+		require.NoError(t, json.Unmarshal(body, &batch))
+
+		if batch[0].RawTx == "fail" {
+			return http.StatusBadRequest, "{\n  \"status\": 400,\n  \"title\": \"Bad request\"\n}"
+		}
+
+		items := make([]string, len(batch))
+		for i, request := range batch {
+			items[i] = fmt.Sprintf(`{"txid":"%s","txStatus":"STORED"}`, request.RawTx)
+		}
+		return http.StatusOK, `{"transactions":[` + strings.Join(items, ",") + `]}`
+	})
+
+	client, err := NewClient(&Profile{URL: server.URL})
+	require.NoError(t, err)
+
+	input := strings.Join([]string{
+		`{"rawTx":"01"}`,
+		`{"rawTx":"02"}`,
+		``,
+		`{"rawTx":"03"}`,
+		`{"rawTx":"fail"}`,
+	}, "\n")
+
+	out := &bytes.Buffer{}
+	submitted, err := client.Batch(context.Background(), strings.NewReader(input), out, 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, submitted)
+	require.Len(t, *requests, 2)
+
+	require.Equal(t, strings.Join([]string{
+		`{"txid":"01","txStatus":"STORED"}`,
+		`{"txid":"02","txStatus":"STORED"}`,
+		`{"txid":"03","txStatus":"STORED"}`,
+		`{"status":400,"title":"Bad request"}`,
+	}, "\n")+"\n", out.String())
+
+	_, err = client.Batch(context.Background(), strings.NewReader("no json"), out, 3, nil)
+	require.ErrorContains(t, err, "invalid transaction request in line 1")
+}
+
+func TestWatch(t *testing.T) {
+	tt := []struct {
+		name     string
+		statuses []string
+		target   metamorph_api.Status
+
+		expectedStatuses []string
+		expectedErr      error
+	}{
+		{
+			name:     "until mined",
+			statuses: []string{"", "STORED", "STORED", "SEEN_IN_ORPHAN_MEMPOOL", "SEEN_ON_NETWORK", "MINED"},
+			target:   metamorph_api.Status_MINED,
+
+			expectedStatuses: []string{"STORED", "SEEN_IN_ORPHAN_MEMPOOL", "SEEN_ON_NETWORK", "MINED"},
+		},
+		{
+			name:     "past target",
+			statuses: []string{"MINED"},
+			target:   metamorph_api.Status_SEEN_ON_NETWORK,
+
+			expectedStatuses: []string{"MINED"},
+		},
+		{
+			name:     "orphan is not seen",
+			statuses: []string{"SEEN_IN_ORPHAN_MEMPOOL", "REJECTED"},
+			target:   metamorph_api.Status_SEEN_ON_NETWORK,
+
+			expectedStatuses: []string{"SEEN_IN_ORPHAN_MEMPOOL", "REJECTED"},
+			expectedErr:      ErrRejected,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			polls := 0
+			server, _ := newARCServer(t, func(_ *http.Request, _ []byte) (int, string) {
+				status := tc.statuses[min(polls, len(tc.statuses)-1)]
+				polls++
+
+				if status == "" {
+					return http.StatusNotFound, `{"status":404,"title":"Not found"}`
+				}
+				return http.StatusOK, `{"txid":"abcd","txStatus":"` + status + `"}`
+			})
+
+			client, err := NewClient(&Profile{URL: server.URL})
+			require.NoError(t, err)
+
+			statuses := make([]string, 0)
+			txStatus, err := client.Watch(context.Background(), "abcd", tc.target, time.Millisecond, func(txStatus *api.TransactionStatus) {
+				statuses = append(statuses, *txStatus.TxStatus)
+			})
+
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Equal(t, tc.expectedStatuses, statuses)
+			require.Equal(t, tc.expectedStatuses[len(tc.expectedStatuses)-1], *txStatus.TxStatus)
+		})
+	}
+}
+
+func TestWatchCallbacks(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		send := func(callback string, token string) int {
+			for {
+				req, err := http.NewRequest(http.MethodPost, "http://"+address+"/callback", strings.NewReader(callback))
+				if err != nil {
+					return 0
+				}
+				req.Header.Set("Authorization", "Bearer "+token)
+
+				res, err := http.DefaultClient.Do(req)
+				if err == nil {
+					_ = res.Body.Close()
+					return res.StatusCode
+				}
+
+				if ctx.Err() != nil {
+					return 0
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+
+		send(`{"txid":"abcd","txStatus":"MINED"}`, "wrong")
+		send(`{"txid":"other","txStatus":"MINED"}`, "token")
+		send(`{"txid":"abcd","txStatus":"SEEN_ON_NETWORK"}`, "token")
+		send(`{"txid":"abcd","txStatus":"MINED"}`, "token")
+	}()
+
+	statuses := make([]string, 0)
+	callback, err := WatchCallbacks(ctx, address, "token", "abcd", metamorph_api.Status_MINED, func(callback *api.TransactionCallback) {
+		statuses = append(statuses, *callback.TxStatus)
+	})
+	require.NoError(t, err)
+	require.Equal(t, "MINED", *callback.TxStatus)
+	require.Equal(t, []string{"SEEN_ON_NETWORK", "MINED"}, statuses)
+}
+
+func TestParseStatus(t *testing.T) {
+	status, err := ParseStatus("seen_on_network")
+	require.NoError(t, err)
+	require.Equal(t, metamorph_api.Status_SEEN_ON_NETWORK, status)
+
+	_, err = ParseStatus("DONE")
+	require.ErrorContains(t, err, "unknown status")
+}
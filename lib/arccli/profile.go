@@ -0,0 +1,79 @@
+package arccli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile is an ARC endpoint together with the authorization and the callback which are sent with each request.
+type Profile struct {
+	URL           string `mapstructure:"url"`
+	Authorization string `mapstructure:"authorization"`
+	CallbackURL   string `mapstructure:"callbackUrl"`
+	CallbackToken string `mapstructure:"callbackToken"`
+}
+
+// Profiles are the named profiles of a config file, e.g.
+//
+//	defaultProfile: testnet
+//	profiles:
+//	  testnet:
+//	    url: https://arc-test.example.com
+//	    authorization: Bearer <token>
+//	  local:
+//	    url: http://localhost:9090
+type Profiles struct {
+	DefaultProfile string              `mapstructure:"defaultProfile"`
+	Profiles       map[string]*Profile `mapstructure:"profiles"`
+}
+
+// LoadProfiles loads the profiles from the YAML file.
+func LoadProfiles(fileName string) (*Profiles, error) {
+	v := viper.New()
+	v.SetConfigFile(fileName)
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read profiles from %s: %v", fileName, err)
+	}
+
+	profiles := &Profiles{}
+	if err := v.Unmarshal(profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles from %s: %v", fileName, err)
+	}
+
+	return profiles, nil
+}
+
+// Get returns the profile with the given name, or the default profile if the name is empty. Profile names are case
+// insensitive.
+func (p *Profiles) Get(name string) (*Profile, error) {
+	if name == "" {
+		name = p.DefaultProfile
+	}
+	name = strings.ToLower(name)
+
+	profile, found := p.Profiles[name]
+	if !found || profile == nil {
+		return nil, fmt.Errorf("%w: %q, available profiles: %v", ErrProfileNotFound, name, p.Names())
+	}
+
+	return profile, nil
+}
+
+// Names returns the sorted names of the profiles.
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
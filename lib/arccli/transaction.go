@@ -0,0 +1,132 @@
+package arccli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bitcoin-sv/arc/lib/spv"
+	"github.com/libsv/go-bt/v2"
+)
+
+type Format string
+
+const (
+	// FormatAuto detects the format of the input.
+	FormatAuto Format = "auto"
+	// FormatHex is a transaction in raw or extended format or a BEEF as hex string.
+	FormatHex Format = "hex"
+	// FormatBinary is a transaction in raw or extended format.
+	FormatBinary Format = "binary"
+	// FormatBEEF is a BEEF in binary or hex format.
+	FormatBEEF Format = "beef"
+)
+
+var ErrInvalidTransaction = errors.New("invalid transaction")
+
+// ReadTransactions reads a transaction in the given format. A transaction in raw or extended format is returned by
+// itself. Of a BEEF the subject transaction is returned together with its unmined ancestors in the order in which they
+// have to be submitted, each in extended format if the outputs it spends are part of the BEEF.
+func ReadTransactions(r io.Reader, format Format) ([]*bt.Tx, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatAuto, FormatHex, FormatBEEF:
+		if decoded, ok := decodeHex(b); ok {
+			b = decoded
+		} else if format == FormatHex {
+			return nil, fmt.Errorf("%w: not a hex string", ErrInvalidTransaction)
+		}
+	case FormatBinary:
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidTransaction, format)
+	}
+
+	if spv.IsBEEF(b) {
+		return beefTransactions(b)
+	}
+
+	if format == FormatBEEF {
+		return nil, fmt.Errorf("%w: not a BEEF", ErrInvalidTransaction)
+	}
+
+	tx, err := bt.NewTxFromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	return []*bt.Tx{tx}, nil
+}
+
+// decodeHex decodes the bytes if they are a hex string, leading and trailing white space is ignored.
+func decodeHex(b []byte) ([]byte, bool) {
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) == 0 {
+		return nil, false
+	}
+
+	decoded, err := hex.DecodeString(string(trimmed))
+	if err != nil {
+		return nil, false
+	}
+
+	return decoded, true
+}
+
+func beefTransactions(b []byte) ([]*bt.Tx, error) {
+	beef, err := spv.NewBEEFFromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	outputs := make(map[string]*bt.Tx, len(beef.Transactions))
+	for _, beefTx := range beef.Transactions {
+		outputs[beefTx.Tx.TxID()] = beefTx.Tx
+	}
+
+	txs := make([]*bt.Tx, 0)
+	for _, beefTx := range beef.Transactions {
+		if beefTx.HasMerklePath {
+			continue
+		}
+
+		for _, input := range beefTx.Tx.Inputs {
+			parent, found := outputs[input.PreviousTxIDStr()]
+			if !found || int(input.PreviousTxOutIndex) >= len(parent.Outputs) {
+				continue
+			}
+
+			input.PreviousTxSatoshis = parent.Outputs[input.PreviousTxOutIndex].Satoshis
+			input.PreviousTxScript = parent.Outputs[input.PreviousTxOutIndex].LockingScript
+		}
+
+		txs = append(txs, beefTx.Tx)
+	}
+
+	return txs, nil
+}
+
+// isExtended returns whether the outputs spent by all inputs of the transaction are known.
+func isExtended(tx *bt.Tx) bool {
+	for _, input := range tx.Inputs {
+		if input.PreviousTxScript == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// transactionBytes returns the transaction in extended format if possible, otherwise in raw format.
+func transactionBytes(tx *bt.Tx) []byte {
+	if isExtended(tx) {
+		return tx.ExtendedBytes()
+	}
+
+	return tx.Bytes()
+}
@@ -0,0 +1,170 @@
+package arccli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/bitcoin-sv/arc/lib/spv"
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func spendingTx(t *testing.T, satoshis uint64, parents ...*bt.Tx) *bt.Tx {
+	t.Helper()
+
+	tx := bt.NewTx()
+	for _, parent := range parents {
+		unlockingScript := bscript.Script([]byte{0x51})
+		input := &bt.Input{PreviousTxOutIndex: 0, SequenceNumber: 0xffffffff, UnlockingScript: &unlockingScript}
+		require.NoError(t, input.PreviousTxIDAdd(parent.TxIDBytes()))
+		tx.Inputs = append(tx.Inputs, input)
+	}
+
+	lockingScript := bscript.Script([]byte{0x51})
+	tx.Outputs = append(tx.Outputs, &bt.Output{Satoshis: satoshis, LockingScript: &lockingScript})
+
+	return tx
+}
+
+// newBEEF returns the BEEF of a transaction which spends an unmined parent, which spends a mined grandparent.
+func newBEEF(t *testing.T) (*spv.BEEF, *bt.Tx, *bt.Tx, *bt.Tx) {
+	t.Helper()
+
+	coinbase := spendingTx(t, 5000)
+	grandparent := spendingTx(t, 3000, coinbase)
+	parent := spendingTx(t, 2000, grandparent)
+	tx := spendingTx(t, 1000, parent)
+
+	grandparentHash, err := chainhash.NewHash(bt.ReverseBytes(grandparent.TxIDBytes()))
+	require.NoError(t, err)
+	coinbaseHash, err := chainhash.NewHash(bt.ReverseBytes(coinbase.TxIDBytes()))
+	require.NoError(t, err)
+
+	tree := bc.BuildMerkleTreeStoreChainHash([]*chainhash.Hash{coinbaseHash, grandparentHash})
+	bump, err := bc.NewBUMPFromMerkleTreeAndIndex(100, tree, 1)
+	require.NoError(t, err)
+	bumpHex, err := bump.String()
+	require.NoError(t, err)
+	merklePath, err := spv.NewMerklePathFromHex(bumpHex)
+	require.NoError(t, err)
+
+	beef, err := spv.NewBEEF(tx, []*bt.Tx{parent, grandparent}, []*spv.MerklePath{merklePath})
+	require.NoError(t, err)
+
+	return beef, grandparent, parent, tx
+}
+
+func TestReadTransactions(t *testing.T) {
+	beef, grandparent, parent, tx := newBEEF(t)
+
+	extendedTx := tx.Clone()
+	extendedTx.Inputs[0].PreviousTxSatoshis = 2000
+	extendedTx.Inputs[0].PreviousTxScript = parent.Outputs[0].LockingScript
+
+	tt := []struct {
+		name   string
+		input  []byte
+		format Format
+
+		expectedTxIDs    []string
+		expectedExtended bool
+		expectedErrorStr string
+	}{
+		{
+			name:   "hex",
+			input:  []byte(tx.String() + "\n"),
+			format: FormatAuto,
+
+			expectedTxIDs: []string{tx.TxID()},
+		},
+		{
+			name:   "binary",
+			input:  tx.Bytes(),
+			format: FormatBinary,
+
+			expectedTxIDs: []string{tx.TxID()},
+		},
+		{
+			name:   "extended format",
+			input:  extendedTx.ExtendedBytes(),
+			format: FormatAuto,
+
+			expectedTxIDs:    []string{tx.TxID()},
+			expectedExtended: true,
+		},
+		{
+			name:   "BEEF binary",
+			input:  beef.Bytes(),
+			format: FormatBEEF,
+
+			expectedTxIDs:    []string{parent.TxID(), tx.TxID()},
+			expectedExtended: true,
+		},
+		{
+			name:   "BEEF hex",
+			input:  []byte(beef.Hex()),
+			format: FormatAuto,
+
+			expectedTxIDs:    []string{parent.TxID(), tx.TxID()},
+			expectedExtended: true,
+		},
+		{
+			name:   "not a BEEF",
+			input:  []byte(tx.String()),
+			format: FormatBEEF,
+
+			expectedErrorStr: "not a BEEF",
+		},
+		{
+			name:   "not hex",
+			input:  tx.Bytes(),
+			format: FormatHex,
+
+			expectedErrorStr: "not a hex string",
+		},
+		{
+			name:   "invalid transaction",
+			input:  []byte("0102"),
+			format: FormatAuto,
+
+			expectedErrorStr: "invalid transaction",
+		},
+		{
+			name:   "unknown format",
+			input:  []byte(tx.String()),
+			format: "json",
+
+			expectedErrorStr: "unknown format",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			txs, err := ReadTransactions(bytes.NewReader(tc.input), tc.format)
+
+			if tc.expectedErrorStr != "" {
+				require.ErrorIs(t, err, ErrInvalidTransaction)
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, txs, len(tc.expectedTxIDs))
+			for i, txID := range tc.expectedTxIDs {
+				require.Equal(t, txID, txs[i].TxID())
+				require.Equal(t, tc.expectedExtended, isExtended(txs[i]))
+			}
+
+			if len(txs) == 2 {
+				// the unmined parent spends the output of the mined grandparent
+				require.Equal(t, grandparent.Outputs[0].Satoshis, txs[0].Inputs[0].PreviousTxSatoshis)
+				require.True(t, strings.HasPrefix(hex.EncodeToString(transactionBytes(txs[1])), hex.EncodeToString(tx.Bytes()[:4])+"0000000000ef"))
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package arccli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/arc/api"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+)
+
+var ErrRejected = errors.New("transaction has been rejected")
+
+// statusOrder is the order in which a transaction reaches the statuses. A transaction in the orphan mempool has been
+// accepted by the network, but not seen on the network yet.
+var statusOrder = []metamorph_api.Status{
+	metamorph_api.Status_UNKNOWN,
+	metamorph_api.Status_QUEUED,
+	metamorph_api.Status_RECEIVED,
+	metamorph_api.Status_STORED,
+	metamorph_api.Status_ANNOUNCED_TO_NETWORK,
+	metamorph_api.Status_REQUESTED_BY_NETWORK,
+	metamorph_api.Status_SENT_TO_NETWORK,
+	metamorph_api.Status_ACCEPTED_BY_NETWORK,
+	metamorph_api.Status_SEEN_IN_ORPHAN_MEMPOOL,
+	metamorph_api.Status_SEEN_ON_NETWORK,
+	metamorph_api.Status_MINED,
+	metamorph_api.Status_CONFIRMED,
+}
+
+// ParseStatus returns the status with the given name, e.g. SEEN_ON_NETWORK or mined.
+func ParseStatus(name string) (metamorph_api.Status, error) {
+	status, found := metamorph_api.Status_value[strings.ToUpper(name)]
+	if !found {
+		return 0, fmt.Errorf("unknown status %q", name)
+	}
+
+	return metamorph_api.Status(status), nil
+}
+
+// statusReached returns whether a transaction with the status has reached the target status.
+func statusReached(status metamorph_api.Status, target metamorph_api.Status) bool {
+	if status == target {
+		return true
+	}
+
+	statusIndex, targetIndex := -1, -1
+	for i, s := range statusOrder {
+		if s == status {
+			statusIndex = i
+		}
+		if s == target {
+			targetIndex = i
+		}
+	}
+
+	return statusIndex >= 0 && targetIndex >= 0 && statusIndex >= targetIndex
+}
+
+// Watch polls the status of the transaction in the interval until it has reached the target status or has been
+// rejected. Each status which differs from the previous one is passed to onStatus. A transaction which is not known to
+// ARC yet is polled again.
+func (c *Client) Watch(ctx context.Context, txID string, target metamorph_api.Status, interval time.Duration, onStatus func(*api.TransactionStatus)) (*api.TransactionStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastStatus := ""
+	for {
+		res, err := c.arc.GETTransactionStatusWithResponse(ctx, txID)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.JSON200 != nil && res.JSON200.TxStatus != nil {
+			txStatus := res.JSON200
+			if *txStatus.TxStatus != lastStatus {
+				lastStatus = *txStatus.TxStatus
+				onStatus(txStatus)
+			}
+
+			status := metamorph_api.Status(metamorph_api.Status_value[*txStatus.TxStatus])
+			if status == metamorph_api.Status_REJECTED && target != metamorph_api.Status_REJECTED {
+				return txStatus, ErrRejected
+			}
+
+			if statusReached(status, target) {
+				return txStatus, nil
+			}
+		} else if res.StatusCode() != http.StatusNotFound {
+			return nil, fmt.Errorf("failed to get status of transaction %s: %s", txID, res.Status())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WatchCallbacks receives the callbacks for the transaction on the address until it has reached the target status or
+// has been rejected. Each callback is passed to onCallback. The transaction has to be submitted with a callback URL
+// which points to the address.
+func WatchCallbacks(ctx context.Context, address string, token string, txID string, target metamorph_api.Status, onCallback func(*api.TransactionCallback)) (*api.TransactionCallback, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks := make(chan *api.TransactionCallback)
+
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			var callback api.TransactionCallback
+			if err := json.NewDecoder(r.Body).Decode(&callback); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+
+			if callback.Txid != txID {
+				return
+			}
+
+			select {
+			case callbacks <- &callback:
+			case <-ctx.Done():
+			}
+		}),
+	}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case callback := <-callbacks:
+			onCallback(callback)
+
+			if callback.TxStatus == nil {
+				continue
+			}
+
+			status := metamorph_api.Status(metamorph_api.Status_value[*callback.TxStatus])
+			if status == metamorph_api.Status_REJECTED && target != metamorph_api.Status_REJECTED {
+				return callback, ErrRejected
+			}
+
+			if statusReached(status, target) {
+				return callback, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package arcstore
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/bitcoin-sv/arc/metamorph/store"
+)
+
+// Filter selects transactions by their status and by the time they have been stored. The zero value selects all
+// transactions.
+type Filter struct {
+	// Statuses selects the transactions with one of the statuses. All statuses are selected if it is empty.
+	Statuses []metamorph_api.Status
+	// Since selects the transactions stored at or after the time, if it is not zero.
+	Since time.Time
+	// Until selects the transactions stored before the time, if it is not zero.
+	Until time.Time
+}
+
+// Matches returns whether the transaction is selected by the filter.
+func (f Filter) Matches(data *store.StoreData) bool {
+	if len(f.Statuses) > 0 {
+		found := false
+		for _, status := range f.Statuses {
+			if data.Status == status {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if !f.Since.IsZero() && data.StoredAt.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && !data.StoredAt.Before(f.Until) {
+		return false
+	}
+
+	return true
+}
+
+// Walk calls fn for all transactions of the store which are selected by the filter.
+func Walk(ctx context.Context, s store.Iterator, filter Filter, fn store.IterateFunc) error {
+	return s.Iterate(ctx, nil, func(data *store.StoreData) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !filter.Matches(data) {
+			return nil
+		}
+
+		return fn(data)
+	})
+}
+
+// CountByStatus returns the number of transactions selected by the filter for each status.
+func CountByStatus(ctx context.Context, s store.Iterator, filter Filter) (map[metamorph_api.Status]int64, error) {
+	counts := make(map[metamorph_api.Status]int64)
+
+	err := Walk(ctx, s, filter, func(data *store.StoreData) error {
+		counts[data.Status]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// Record is the representation of a transaction of the store as JSON. Hashes are given as transaction and block ids,
+// times which are not set are omitted.
+type Record struct {
+	Hash              string     `json:"hash"`
+	Status            string     `json:"status"`
+	StoredAt          *time.Time `json:"storedAt,omitempty"`
+	AnnouncedAt       *time.Time `json:"announcedAt,omitempty"`
+	MinedAt           *time.Time `json:"minedAt,omitempty"`
+	BlockHeight       uint64     `json:"blockHeight,omitempty"`
+	BlockHash         string     `json:"blockHash,omitempty"`
+	MerkleProof       bool       `json:"merkleProof,omitempty"`
+	CallbackUrl       string     `json:"callbackUrl,omitempty"`
+	CallbackToken     string     `json:"callbackToken,omitempty"`
+	FullStatusUpdates bool       `json:"fullStatusUpdates,omitempty"`
+	RejectReason      string     `json:"rejectReason,omitempty"`
+	LockedBy          string     `json:"lockedBy,omitempty"`
+	RawTx             string     `json:"rawTx"`
+}
+
+// NewRecord returns the record of the transaction.
+func NewRecord(data *store.StoreData) Record {
+	record := Record{
+		Status:            data.Status.String(),
+		StoredAt:          timeOrNil(data.StoredAt),
+		AnnouncedAt:       timeOrNil(data.AnnouncedAt),
+		MinedAt:           timeOrNil(data.MinedAt),
+		BlockHeight:       data.BlockHeight,
+		MerkleProof:       data.MerkleProof,
+		CallbackUrl:       data.CallbackUrl,
+		CallbackToken:     data.CallbackToken,
+		FullStatusUpdates: data.FullStatusUpdates,
+		RejectReason:      data.RejectReason,
+		LockedBy:          data.LockedBy,
+		RawTx:             hex.EncodeToString(data.RawTx),
+	}
+
+	if data.Hash != nil {
+		record.Hash = data.Hash.String()
+	}
+
+	if data.BlockHash != nil {
+		record.BlockHash = data.BlockHash.String()
+	}
+
+	return record
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	t = t.UTC()
+	return &t
+}
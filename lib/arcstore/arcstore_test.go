@@ -0,0 +1,225 @@
+package arcstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/bitcoin-sv/arc/metamorph/store"
+	"github.com/bitcoin-sv/arc/metamorph/store/badger"
+	"github.com/bitcoin-sv/arc/metamorph/store/sqlite"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+var storedAt = time.Date(2023, 12, 1, 12, 0, 0, 0, time.UTC)
+
+type iterableStore interface {
+	store.MetamorphStore
+	store.Iterator
+}
+
+// newSQLiteStore returns an in-memory store with transactions, one stored every hour, alternately with status
+// SEEN_ON_NETWORK and MINED.
+func newSQLiteStore(t *testing.T, transactions int) iterableStore {
+	t.Helper()
+
+	s, err := sqlite.New(true, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close(context.Background()) })
+
+	for i := 0; i < transactions; i++ {
+		hash := chainhash.DoubleHashH([]byte{byte(i)})
+
+		status := metamorph_api.Status_SEEN_ON_NETWORK
+		if i%2 == 1 {
+			status = metamorph_api.Status_MINED
+		}
+
+		err = s.Set(context.Background(), hash[:], &store.StoreData{
+			Hash:     &hash,
+			Status:   status,
+			StoredAt: storedAt.Add(time.Duration(i) * time.Hour),
+			RawTx:    []byte{byte(i)},
+		})
+		require.NoError(t, err)
+	}
+
+	return s.(iterableStore)
+}
+
+func TestFilter(t *testing.T) {
+	data := &store.StoreData{
+		Status:   metamorph_api.Status_MINED,
+		StoredAt: storedAt,
+	}
+
+	tt := []struct {
+		name     string
+		filter   Filter
+		expected bool
+	}{
+		{
+			name:     "zero value",
+			expected: true,
+		},
+		{
+			name:     "status",
+			filter:   Filter{Statuses: []metamorph_api.Status{metamorph_api.Status_SEEN_ON_NETWORK, metamorph_api.Status_MINED}},
+			expected: true,
+		},
+		{
+			name:     "other status",
+			filter:   Filter{Statuses: []metamorph_api.Status{metamorph_api.Status_SEEN_ON_NETWORK}},
+			expected: false,
+		},
+		{
+			name:     "since is inclusive",
+			filter:   Filter{Since: storedAt},
+			expected: true,
+		},
+		{
+			name:     "until is exclusive",
+			filter:   Filter{Until: storedAt},
+			expected: false,
+		},
+		{
+			name:     "time range",
+			filter:   Filter{Since: storedAt.Add(-time.Hour), Until: storedAt.Add(time.Hour)},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.filter.Matches(data))
+		})
+	}
+}
+
+func TestCountByStatus(t *testing.T) {
+	s := newSQLiteStore(t, 10)
+
+	counts, err := CountByStatus(context.Background(), s, Filter{})
+	require.NoError(t, err)
+	require.Equal(t, map[metamorph_api.Status]int64{
+		metamorph_api.Status_SEEN_ON_NETWORK: 5,
+		metamorph_api.Status_MINED:           5,
+	}, counts)
+
+	counts, err = CountByStatus(context.Background(), s, Filter{Since: storedAt.Add(6 * time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, map[metamorph_api.Status]int64{
+		metamorph_api.Status_SEEN_ON_NETWORK: 2,
+		metamorph_api.Status_MINED:           2,
+	}, counts)
+}
+
+func TestNewRecord(t *testing.T) {
+	hash := chainhash.DoubleHashH([]byte{1})
+
+	b, err := json.Marshal(NewRecord(&store.StoreData{
+		Hash:     &hash,
+		Status:   metamorph_api.Status_SEEN_ON_NETWORK,
+		StoredAt: storedAt,
+		RawTx:    []byte{0x01, 0x02},
+	}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"hash": "`+hash.String()+`",
+		"status": "SEEN_ON_NETWORK",
+		"storedAt": "2023-12-01T12:00:00Z",
+		"rawTx": "0102"
+	}`, string(b))
+}
+
+// failingIterator fails after the given number of transactions.
+type failingIterator struct {
+	store.Iterator
+	failAfter int
+}
+
+var errIteration = errors.New("iteration failed")
+
+func (f failingIterator) Iterate(ctx context.Context, after *chainhash.Hash, fn store.IterateFunc) error {
+	count := 0
+	return f.Iterator.Iterate(ctx, after, func(data *store.StoreData) error {
+		if count == f.failAfter {
+			return errIteration
+		}
+		count++
+		return fn(data)
+	})
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("from sqlite to badger with filter", func(t *testing.T) {
+		from := newSQLiteStore(t, 10)
+
+		to, err := badger.New(t.TempDir())
+		require.NoError(t, err)
+		defer to.Close(ctx)
+
+		filter := Filter{Statuses: []metamorph_api.Status{metamorph_api.Status_SEEN_ON_NETWORK}}
+		checkpoint, err := Copy(ctx, from, to, WithFilter(filter), WithCheckpointInterval(3))
+		require.NoError(t, err)
+		require.Equal(t, int64(5), checkpoint.Copied)
+		require.Equal(t, int64(0), checkpoint.Skipped)
+
+		counts, err := CountByStatus(ctx, to, Filter{})
+		require.NoError(t, err)
+		require.Equal(t, map[metamorph_api.Status]int64{metamorph_api.Status_SEEN_ON_NETWORK: 5}, counts)
+
+		hash := chainhash.DoubleHashH([]byte{2})
+		data, err := to.Get(ctx, hash[:])
+		require.NoError(t, err)
+		require.Equal(t, []byte{2}, data.RawTx)
+		require.True(t, storedAt.Add(2*time.Hour).Equal(data.StoredAt))
+	})
+
+	t.Run("continue after checkpoint", func(t *testing.T) {
+		from := newSQLiteStore(t, 10)
+		to := newSQLiteStore(t, 0)
+		checkpointFile := filepath.Join(t.TempDir(), "checkpoint.json")
+
+		checkpoint, err := Copy(ctx, failingIterator{Iterator: from, failAfter: 4}, to, WithCheckpointFile(checkpointFile), WithCheckpointInterval(3))
+		require.ErrorIs(t, err, errIteration)
+		require.Equal(t, int64(4), checkpoint.Copied)
+
+		b, err := os.ReadFile(checkpointFile)
+		require.NoError(t, err)
+
+		var written Checkpoint
+		require.NoError(t, json.Unmarshal(b, &written))
+		require.Equal(t, checkpoint, written)
+
+		checkpoint, err = Copy(ctx, from, to, WithCheckpointFile(checkpointFile), WithCheckpointInterval(3))
+		require.NoError(t, err)
+		require.Equal(t, int64(10), checkpoint.Copied)
+		require.Equal(t, int64(0), checkpoint.Skipped)
+
+		counts, err := CountByStatus(ctx, to, Filter{})
+		require.NoError(t, err)
+		require.Equal(t, map[metamorph_api.Status]int64{
+			metamorph_api.Status_SEEN_ON_NETWORK: 5,
+			metamorph_api.Status_MINED:           5,
+		}, counts)
+	})
+
+	t.Run("existing transactions are skipped", func(t *testing.T) {
+		from := newSQLiteStore(t, 10)
+		to := newSQLiteStore(t, 4)
+
+		checkpoint, err := Copy(ctx, from, to)
+		require.NoError(t, err)
+		require.Equal(t, int64(6), checkpoint.Copied)
+		require.Equal(t, int64(4), checkpoint.Skipped)
+	})
+}
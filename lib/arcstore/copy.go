@@ -0,0 +1,223 @@
+package arcstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/bitcoin-sv/arc/metamorph/store"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+)
+
+const checkpointIntervalDefault = 1000
+
+// Checkpoint is the progress of a copy. It is written to the checkpoint file, so that an interrupted copy can be
+// continued after the last transaction which has been processed.
+type Checkpoint struct {
+	// After is the id of the last transaction which has been processed, whether it has been copied or not.
+	After   string `json:"after"`
+	Copied  int64  `json:"copied"`
+	Skipped int64  `json:"skipped"`
+}
+
+type copier struct {
+	logger             *slog.Logger
+	filter             Filter
+	checkpointFile     string
+	checkpointInterval int
+}
+
+type CopyOption func(*copier)
+
+func WithLogger(logger *slog.Logger) func(*copier) {
+	return func(c *copier) {
+		c.logger = logger
+	}
+}
+
+// WithFilter copies only the transactions which are selected by the filter.
+func WithFilter(filter Filter) func(*copier) {
+	return func(c *copier) {
+		c.filter = filter
+	}
+}
+
+// WithCheckpointFile writes the progress of the copy to the file. If the file exists, the copy is continued after the
+// checkpoint.
+func WithCheckpointFile(file string) func(*copier) {
+	return func(c *copier) {
+		c.checkpointFile = file
+	}
+}
+
+// WithCheckpointInterval writes a checkpoint after the number of processed transactions.
+func WithCheckpointInterval(n int) func(*copier) {
+	return func(c *copier) {
+		c.checkpointInterval = n
+	}
+}
+
+// Copy copies the transactions from one store to another. Transactions which already exist in the target store are
+// skipped, so that a copy can be repeated. The copied transactions are unlocked in the target store, in order to be
+// processed by any metamorph instance. The returned checkpoint holds the progress of the copy also in case of an error.
+func Copy(ctx context.Context, from store.Iterator, to store.MetamorphStore, opts ...CopyOption) (Checkpoint, error) {
+	c := &copier{
+		logger:             slog.Default(),
+		checkpointInterval: checkpointIntervalDefault,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	checkpoint, err := c.readCheckpoint()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	var after *chainhash.Hash
+	if checkpoint.After != "" {
+		after, err = chainhash.NewHashFromStr(checkpoint.After)
+		if err != nil {
+			return checkpoint, fmt.Errorf("invalid checkpoint %s: %v", c.checkpointFile, err)
+		}
+
+		c.logger.Info("continuing copy after checkpoint", slog.String("after", checkpoint.After), slog.Int64("copied", checkpoint.Copied), slog.Int64("skipped", checkpoint.Skipped))
+	}
+
+	unlock := make([]*chainhash.Hash, 0, c.checkpointInterval)
+	processed := 0
+
+	// flush unlocks the copied transactions and writes the checkpoint
+	flush := func(ctx context.Context) error {
+		if len(unlock) > 0 {
+			if err := to.SetUnlocked(ctx, unlock); err != nil {
+				return fmt.Errorf("failed to unlock copied transactions: %v", err)
+			}
+			unlock = unlock[:0]
+		}
+
+		return c.writeCheckpoint(checkpoint)
+	}
+
+	err = from.Iterate(ctx, after, func(data *store.StoreData) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if c.filter.Matches(data) {
+			copied, err := c.copy(ctx, to, data)
+			if err != nil {
+				return err
+			}
+
+			if copied {
+				checkpoint.Copied++
+			} else {
+				checkpoint.Skipped++
+			}
+
+			unlock = append(unlock, data.Hash)
+		}
+
+		checkpoint.After = data.Hash.String()
+
+		processed++
+		if processed%c.checkpointInterval == 0 {
+			if err := flush(ctx); err != nil {
+				return err
+			}
+
+			c.logger.Info("copy in progress", slog.String("after", checkpoint.After), slog.Int64("copied", checkpoint.Copied), slog.Int64("skipped", checkpoint.Skipped))
+		}
+
+		return nil
+	})
+
+	// the progress is kept also if the copy has failed or has been interrupted
+	if flushErr := flush(context.WithoutCancel(ctx)); flushErr != nil && err == nil {
+		err = flushErr
+	}
+
+	return checkpoint, err
+}
+
+// copy stores the transaction in the target store. It returns false if the transaction exists already.
+func (c *copier) copy(ctx context.Context, to store.MetamorphStore, data *store.StoreData) (bool, error) {
+	_, err := to.Get(ctx, data.Hash[:])
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.Is(err, store.ErrNotFound) {
+		return false, fmt.Errorf("failed to get transaction %s from target store: %v", data.Hash, err)
+	}
+
+	err = to.Set(ctx, data.Hash[:], data)
+	if err != nil {
+		return false, fmt.Errorf("failed to copy transaction %s: %v", data.Hash, err)
+	}
+
+	return true, nil
+}
+
+func (c *copier) readCheckpoint() (Checkpoint, error) {
+	var checkpoint Checkpoint
+
+	if c.checkpointFile == "" {
+		return checkpoint, nil
+	}
+
+	b, err := os.ReadFile(c.checkpointFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return checkpoint, nil
+		}
+
+		return checkpoint, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+
+	err = json.Unmarshal(b, &checkpoint)
+	if err != nil {
+		return checkpoint, fmt.Errorf("invalid checkpoint %s: %v", c.checkpointFile, err)
+	}
+
+	return checkpoint, nil
+}
+
+// writeCheckpoint replaces the checkpoint file, so that it is never left partially written.
+func (c *copier) writeCheckpoint(checkpoint Checkpoint) error {
+	if c.checkpointFile == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.checkpointFile), filepath.Base(c.checkpointFile)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+
+	if err = os.Rename(tmp.Name(), c.checkpointFile); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package callbackpolicy
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	maxRedirectsDefault = 3
+	dialTimeout         = 5 * time.Second
+)
+
+var (
+	ErrInvalidURL        = errors.New("invalid callback URL")
+	ErrSchemeNotAllowed  = errors.New("callback URL scheme is not allowed")
+	ErrHostNotAllowed    = errors.New("callback URL host is not allowed")
+	ErrIPNotAllowed      = errors.New("callback URL resolves to an IP address which is not allowed")
+	ErrTooManyRedirects  = errors.New("callback exceeded maximum number of redirects")
+	schemesDefault       = []string{"http", "https"}
+	internalHostSuffixes = []string{".localhost", ".local", ".internal", ".svc"}
+
+	// sharedAddressSpace is the carrier-grade NAT range (RFC 6598) which is not covered by net.IP.IsPrivate.
+	sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+)
+
+// Policy decides to which URLs callbacks may be sent. A nil Policy allows every valid URL.
+type Policy struct {
+	allowedSchemes  []string
+	allowedHosts    []string
+	allowedNets     []*net.IPNet
+	deniedHosts     []string
+	deniedNets      []*net.IPNet
+	blockPrivateIPs bool
+	maxRedirects    int
+}
+
+// Config is the configuration of a policy. Private IP addresses are blocked unless BlockPrivateIPs is set to false.
+type Config struct {
+	AllowedSchemes  []string `mapstructure:"allowedSchemes"`
+	AllowedHosts    []string `mapstructure:"allowedHosts"`
+	DeniedHosts     []string `mapstructure:"deniedHosts"`
+	BlockPrivateIPs *bool    `mapstructure:"blockPrivateIPs"`
+	MaxRedirects    *int     `mapstructure:"maxRedirects"`
+}
+
+// WithAllowedSchemes sets the URL schemes which are allowed. Default is http and https.
+func WithAllowedSchemes(schemes ...string) func(*Policy) {
+	return func(p *Policy) {
+		p.allowedSchemes = make([]string, len(schemes))
+		for i, scheme := range schemes {
+			p.allowedSchemes[i] = strings.ToLower(scheme)
+		}
+	}
+}
+
+// WithAllowedHosts restricts callbacks to the given hosts. A host can be a hostname, a wildcard hostname
+// like *.example.com, an IP address or a CIDR range. Allowed IP ranges take precedence over blocked private IPs.
+func WithAllowedHosts(hosts ...string) func(*Policy) {
+	return func(p *Policy) {
+		p.allowedHosts = append(p.allowedHosts, hosts...)
+	}
+}
+
+// WithDeniedHosts denies callbacks to the given hosts. The format is the same as for WithAllowedHosts.
+func WithDeniedHosts(hosts ...string) func(*Policy) {
+	return func(p *Policy) {
+		p.deniedHosts = append(p.deniedHosts, hosts...)
+	}
+}
+
+// WithBlockPrivateIPs denies callbacks to loopback, link-local, private and other non-public IP addresses.
+// The resolved IP address is checked at dial time, so that DNS rebinding cannot be used to circumvent the check.
+func WithBlockPrivateIPs(block bool) func(*Policy) {
+	return func(p *Policy) {
+		p.blockPrivateIPs = block
+	}
+}
+
+// WithMaxRedirects sets the maximum number of redirects which are followed when sending a callback.
+func WithMaxRedirects(maxRedirects int) func(*Policy) {
+	return func(p *Policy) {
+		p.maxRedirects = maxRedirects
+	}
+}
+
+type Option func(p *Policy)
+
+func New(opts ...Option) (*Policy, error) {
+	p := &Policy{
+		allowedSchemes: schemesDefault,
+		maxRedirects:   maxRedirectsDefault,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	var err error
+	p.allowedHosts, p.allowedNets, err = parseHosts(p.allowedHosts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse allowed hosts: %v", err)
+	}
+
+	p.deniedHosts, p.deniedNets, err = parseHosts(p.deniedHosts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse denied hosts: %v", err)
+	}
+
+	if p.maxRedirects < 0 {
+		return nil, fmt.Errorf("max redirects must not be negative: %d", p.maxRedirects)
+	}
+
+	return p, nil
+}
+
+// NewFromConfig creates a policy from the given configuration. The zero value of the configuration is the default
+// policy, which allows http and https, blocks private IP addresses and follows at most 3 redirects.
+func NewFromConfig(cfg Config) (*Policy, error) {
+	blockPrivateIPs := true
+	if cfg.BlockPrivateIPs != nil {
+		blockPrivateIPs = *cfg.BlockPrivateIPs
+	}
+
+	opts := []Option{
+		WithAllowedHosts(cfg.AllowedHosts...),
+		WithDeniedHosts(cfg.DeniedHosts...),
+		WithBlockPrivateIPs(blockPrivateIPs),
+	}
+
+	if len(cfg.AllowedSchemes) > 0 {
+		opts = append(opts, WithAllowedSchemes(cfg.AllowedSchemes...))
+	}
+
+	if cfg.MaxRedirects != nil {
+		opts = append(opts, WithMaxRedirects(*cfg.MaxRedirects))
+	}
+
+	return New(opts...)
+}
+
+// Validate checks whether callbacks may be sent to the given URL. IP addresses which hostnames resolve to are
+// not checked here, but when the connection is established by the client returned by HTTPClient.
+func (p *Policy) Validate(callbackURL string) error {
+	u, err := url.ParseRequestURI(callbackURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+
+	if p == nil {
+		return nil
+	}
+
+	return p.validateURL(u)
+}
+
+func (p *Policy) validateURL(u *url.URL) error {
+	if !contains(p.allowedSchemes, strings.ToLower(u.Scheme)) {
+		return fmt.Errorf("%w: %s", ErrSchemeNotAllowed, u.Scheme)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "" {
+		return fmt.Errorf("%w: host is empty", ErrInvalidURL)
+	}
+
+	ip := net.ParseIP(host)
+
+	if matchHost(host, ip, p.deniedHosts, p.deniedNets) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+	}
+
+	if len(p.allowedHosts) > 0 || len(p.allowedNets) > 0 {
+		if !matchHost(host, ip, p.allowedHosts, p.allowedNets) {
+			return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+		}
+
+		return nil
+	}
+
+	if !p.blockPrivateIPs {
+		return nil
+	}
+
+	if ip != nil {
+		if !p.ipAllowed(ip) {
+			return fmt.Errorf("%w: %s", ErrIPNotAllowed, ip.String())
+		}
+
+		return nil
+	}
+
+	if isInternalHostname(host) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+	}
+
+	return nil
+}
+
+// HTTPClient returns a client which enforces the policy on every connection and redirect.
+func (p *Policy) HTTPClient(timeout time.Duration) *http.Client {
+	if p == nil {
+		return &http.Client{Timeout: timeout}
+	}
+
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: p.dialControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would establish the connection on our behalf and bypass the IP check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > p.maxRedirects {
+				return fmt.Errorf("%w: %d", ErrTooManyRedirects, p.maxRedirects)
+			}
+
+			return p.validateURL(req.URL)
+		},
+	}
+}
+
+// dialControl is called after the address was resolved and before the connection is established.
+func (p *Policy) dialControl(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: %s", ErrIPNotAllowed, host)
+	}
+
+	if !p.ipAllowed(ip) {
+		return fmt.Errorf("%w: %s", ErrIPNotAllowed, ip.String())
+	}
+
+	return nil
+}
+
+func (p *Policy) ipAllowed(ip net.IP) bool {
+	if containsIP(p.deniedNets, ip) {
+		return false
+	}
+
+	if containsIP(p.allowedNets, ip) {
+		return true
+	}
+
+	if p.blockPrivateIPs && !isPublicIP(ip) {
+		return false
+	}
+
+	return true
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	if ip4 := ip.To4(); ip4 != nil && (ip4[0] == 0 || sharedAddressSpace.Contains(ip4)) {
+		return false
+	}
+
+	return true
+}
+
+func isInternalHostname(host string) bool {
+	if host == "localhost" || !strings.Contains(host, ".") {
+		return true
+	}
+
+	for _, suffix := range internalHostSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseHosts(entries []string) ([]string, []*net.IPNet, error) {
+	var hosts []string
+	var nets []*net.IPNet
+
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		if strings.Contains(entry, "/") {
+			_, ipNet, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			nets = append(nets, ipNet)
+			continue
+		}
+
+		if ip := net.ParseIP(entry); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		hosts = append(hosts, entry)
+	}
+
+	return hosts, nets, nil
+}
+
+func matchHost(host string, ip net.IP, hosts []string, nets []*net.IPNet) bool {
+	if ip != nil {
+		return containsIP(nets, ip)
+	}
+
+	for _, pattern := range hosts {
+		if pattern == host {
+			return true
+		}
+
+		if strings.HasPrefix(pattern, "*.") && strings.HasSuffix(host, pattern[1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,272 @@
+package callbackpolicy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tt := []struct {
+		name string
+		opts []Option
+
+		expectedErrorStr string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "valid hosts",
+			opts: []Option{
+				WithAllowedHosts("example.com", "*.example.com", "203.0.113.0/24", "198.51.100.7"),
+				WithDeniedHosts("internal.example.com", "fd00::/8"),
+			},
+		},
+		{
+			name: "invalid CIDR",
+			opts: []Option{WithDeniedHosts("10.0.0.0/33")},
+
+			expectedErrorStr: "failed to parse denied hosts",
+		},
+		{
+			name: "negative max redirects",
+			opts: []Option{WithMaxRedirects(-1)},
+
+			expectedErrorStr: "max redirects must not be negative",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.opts...)
+
+			if tc.expectedErrorStr != "" || err != nil {
+				require.ErrorContains(t, err, tc.expectedErrorStr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	tt := []struct {
+		name        string
+		opts        []Option
+		callbackURL string
+
+		expectedErr error
+	}{
+		{
+			name:        "invalid URL",
+			callbackURL: "api.callback.com",
+
+			expectedErr: ErrInvalidURL,
+		},
+		{
+			name:        "valid URL",
+			callbackURL: "https://api.callback.com/callback",
+		},
+		{
+			name:        "scheme not allowed",
+			callbackURL: "ftp://api.callback.com",
+
+			expectedErr: ErrSchemeNotAllowed,
+		},
+		{
+			name:        "scheme not in custom allowlist",
+			opts:        []Option{WithAllowedSchemes("https")},
+			callbackURL: "http://api.callback.com",
+
+			expectedErr: ErrSchemeNotAllowed,
+		},
+		{
+			name:        "private IP allowed if not blocked",
+			callbackURL: "http://127.0.0.1:8000",
+		},
+		{
+			name:        "loopback IP blocked",
+			opts:        []Option{WithBlockPrivateIPs(true)},
+			callbackURL: "http://127.0.0.1:8000",
+
+			expectedErr: ErrIPNotAllowed,
+		},
+		{
+			name:        "RFC1918 IP blocked",
+			opts:        []Option{WithBlockPrivateIPs(true)},
+			callbackURL: "http://10.1.2.3/callback",
+
+			expectedErr: ErrIPNotAllowed,
+		},
+		{
+			name:        "link-local IP blocked",
+			opts:        []Option{WithBlockPrivateIPs(true)},
+			callbackURL: "http://169.254.169.254/latest/meta-data",
+
+			expectedErr: ErrIPNotAllowed,
+		},
+		{
+			name:        "IPv6 loopback blocked",
+			opts:        []Option{WithBlockPrivateIPs(true)},
+			callbackURL: "http://[::1]:8000",
+
+			expectedErr: ErrIPNotAllowed,
+		},
+		{
+			name:        "localhost blocked",
+			opts:        []Option{WithBlockPrivateIPs(true)},
+			callbackURL: "http://localhost:8000",
+
+			expectedErr: ErrHostNotAllowed,
+		},
+		{
+			name:        "kubernetes service blocked",
+			opts:        []Option{WithBlockPrivateIPs(true)},
+			callbackURL: "http://metamorph.arc.svc.cluster.local:8001",
+
+			expectedErr: ErrHostNotAllowed,
+		},
+		{
+			name:        "single label hostname blocked",
+			opts:        []Option{WithBlockPrivateIPs(true)},
+			callbackURL: "http://metamorph:8001",
+
+			expectedErr: ErrHostNotAllowed,
+		},
+		{
+			name:        "public IP allowed",
+			opts:        []Option{WithBlockPrivateIPs(true)},
+			callbackURL: "http://8.8.8.8/callback",
+		},
+		{
+			name:        "denied host",
+			opts:        []Option{WithDeniedHosts("*.evil.com")},
+			callbackURL: "https://api.evil.com/callback",
+
+			expectedErr: ErrHostNotAllowed,
+		},
+		{
+			name:        "denied CIDR",
+			opts:        []Option{WithDeniedHosts("8.8.8.0/24")},
+			callbackURL: "https://8.8.8.8/callback",
+
+			expectedErr: ErrHostNotAllowed,
+		},
+		{
+			name:        "host not in allowlist",
+			opts:        []Option{WithAllowedHosts("*.example.com")},
+			callbackURL: "https://api.callback.com/callback",
+
+			expectedErr: ErrHostNotAllowed,
+		},
+		{
+			name:        "host in allowlist",
+			opts:        []Option{WithAllowedHosts("*.example.com")},
+			callbackURL: "https://api.example.com/callback",
+		},
+		{
+			name:        "allowed CIDR takes precedence over blocked private IPs",
+			opts:        []Option{WithBlockPrivateIPs(true), WithAllowedHosts("10.0.0.0/8")},
+			callbackURL: "http://10.1.2.3/callback",
+		},
+		{
+			name:        "denylist takes precedence over allowlist",
+			opts:        []Option{WithAllowedHosts("*.example.com"), WithDeniedHosts("internal.example.com")},
+			callbackURL: "https://internal.example.com/callback",
+
+			expectedErr: ErrHostNotAllowed,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := New(tc.opts...)
+			require.NoError(t, err)
+
+			err = policy.Validate(tc.callbackURL)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolicy_HTTPClient(t *testing.T) {
+	redirectCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" && redirectCount < 5 {
+			redirectCount++
+			http.Redirect(w, r, "/redirect", http.StatusFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name string
+		opts []Option
+		path string
+
+		expectedErr error
+	}{
+		{
+			name: "loopback allowed",
+		},
+		{
+			name: "loopback blocked at dial time",
+			opts: []Option{WithBlockPrivateIPs(true)},
+
+			expectedErr: ErrIPNotAllowed,
+		},
+		{
+			name: "loopback explicitly allowed",
+			opts: []Option{WithBlockPrivateIPs(true), WithAllowedHosts("127.0.0.0/8")},
+		},
+		{
+			name: "denied at dial time",
+			opts: []Option{WithDeniedHosts("127.0.0.1")},
+
+			expectedErr: ErrIPNotAllowed,
+		},
+		{
+			name: "too many redirects",
+			opts: []Option{WithMaxRedirects(2)},
+			path: "/redirect",
+
+			expectedErr: ErrTooManyRedirects,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			redirectCount = 0
+
+			policy, err := New(tc.opts...)
+			require.NoError(t, err)
+
+			client := policy.HTTPClient(time.Second)
+
+			// the URL is not validated on purpose, only the checks of the client are tested
+			response, err := client.Get(server.URL + tc.path)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+			require.Equal(t, http.StatusOK, response.StatusCode)
+		})
+	}
+}
@@ -21,3 +21,12 @@ func EstimateFee(satsPerKB, numberOfInputs, numberOfPayingOutputs uint64) uint64
 	fee = math.Ceil(fee)
 	return uint64(fee)
 }
+
+// EstimateDataFee returns the fee for the given number of data bytes, i.e. the bytes of OP_RETURN outputs which are
+// charged by the data fee rate.
+func EstimateDataFee(satsPerKB, dataBytes uint64) uint64 {
+	feeRate := float64(satsPerKB) / 1000
+	fee := float64(dataBytes) * feeRate
+	fee = math.Ceil(fee)
+	return uint64(fee)
+}
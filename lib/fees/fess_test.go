@@ -52,3 +52,37 @@ func TestEstimateFee(t *testing.T) {
 		})
 	}
 }
+
+func TestEstimateDataFee(t *testing.T) {
+	tests := []struct {
+		name      string
+		satsPerKB uint64
+		dataBytes uint64
+		want      uint64
+	}{
+		{
+			name:      "no data",
+			satsPerKB: 3,
+			dataBytes: 0,
+			want:      0,
+		},
+		{
+			name:      "rounded up",
+			satsPerKB: 3,
+			dataBytes: 100,
+			want:      1,
+		},
+		{
+			name:      "100 kB",
+			satsPerKB: 3,
+			dataBytes: 100_000,
+			want:      300,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee := EstimateDataFee(tt.satsPerKB, tt.dataBytes)
+			assert.Equalf(t, tt.want, fee, "EstimateDataFee(%d, %d) => %d", tt.satsPerKB, tt.dataBytes, fee)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package p2psim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+)
+
+// maxPayloadSize is the maximum size of the payload of a message which is read from a peer.
+const maxPayloadSize = 32 * 1024 * 1024
+
+var errInvalidMessage = errors.New("invalid message")
+
+// The messages are read and written by the nodes themselves instead of by wire.ReadMessage and wire.WriteMessage,
+// because these use the global message handlers and limits of the wire package, which are overridden by BlockTx and
+// go-p2p while the nodes are running.
+
+// readMessage reads the next message from the peer. Messages which the nodes do not handle are skipped and returned as
+// nil together with their command.
+func readMessage(r io.Reader, network wire.BitcoinNet) (wire.Message, string, error) {
+	var header [wire.MessageHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, "", err
+	}
+
+	magic := wire.BitcoinNet(binary.LittleEndian.Uint32(header[0:4]))
+	command := string(bytes.TrimRight(header[4:4+wire.CommandSize], "\x00"))
+	length := binary.LittleEndian.Uint32(header[16:20])
+	checksum := header[20:24]
+
+	if magic != network {
+		return nil, command, fmt.Errorf("%w: message from other network %s", errInvalidMessage, magic)
+	}
+
+	if length > maxPayloadSize {
+		return nil, command, fmt.Errorf("%w: payload of %d bytes is too large", errInvalidMessage, length)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, command, err
+	}
+
+	if !bytes.Equal(chainhash.DoubleHashB(payload)[:4], checksum) {
+		return nil, command, fmt.Errorf("%w: checksum of %s message does not match", errInvalidMessage, command)
+	}
+
+	var msg wire.Message
+	switch command {
+	case wire.CmdVersion:
+		msg = &wire.MsgVersion{}
+	case wire.CmdVerAck:
+		msg = &wire.MsgVerAck{}
+	case wire.CmdPing:
+		msg = &wire.MsgPing{}
+	case wire.CmdInv:
+		msg = &wire.MsgInv{}
+	case wire.CmdGetData:
+		msg = &wire.MsgGetData{}
+	case wire.CmdTx:
+		msg = &wire.MsgTx{}
+	case wire.CmdGetHeaders:
+		msg = &wire.MsgGetHeaders{}
+	default:
+		return nil, command, nil
+	}
+
+	if err := msg.Bsvdecode(bytes.NewBuffer(payload), wire.ProtocolVersion, wire.BaseEncoding); err != nil {
+		return nil, command, fmt.Errorf("%w: %v", errInvalidMessage, err)
+	}
+
+	return msg, command, nil
+}
+
+// writeMessage writes the message to the peer.
+func writeMessage(w io.Writer, msg wire.Message, network wire.BitcoinNet) error {
+	payload := &bytes.Buffer{}
+	if err := msg.BsvEncode(payload, wire.ProtocolVersion, wire.BaseEncoding); err != nil {
+		return err
+	}
+
+	header := make([]byte, wire.MessageHeaderSize)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(network))
+	copy(header[4:4+wire.CommandSize], msg.Command())
+	binary.LittleEndian.PutUint32(header[16:20], uint32(payload.Len()))
+	copy(header[20:24], chainhash.DoubleHashB(payload.Bytes())[:4])
+
+	_, err := w.Write(append(header, payload.Bytes()...))
+	return err
+}
@@ -0,0 +1,498 @@
+// Package p2psim simulates a network of Bitcoin nodes in process. The nodes speak the wire protocol of go-p2p over
+// in-memory connections, so that peers of Metamorph and BlockTx can be connected to them by dialing with Dial.
+package p2psim
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/headers"
+	"github.com/libsv/go-p2p/blockchain"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+)
+
+const (
+	// maxHeadersPerMsg is the maximum number of headers sent in response to a getheaders message.
+	maxHeadersPerMsg = 2000
+
+	// RejectReasonMempoolConflict is the reason with which a transaction is rejected that spends an output which is
+	// spent by a transaction in the mempool already.
+	RejectReasonMempoolConflict = "txn-mempool-conflict"
+	// RejectReasonInputsSpent is the reason with which a transaction is rejected that spends an output which is spent
+	// by a mined transaction already.
+	RejectReasonInputsSpent = "bad-txns-inputs-spent"
+	// DiscardReasonCollisionInBlock is the reason with which a transaction is discarded from the mempool when a mined
+	// transaction spends one of its inputs.
+	DiscardReasonCollisionInBlock = "collision-in-block-tx"
+)
+
+var (
+	ErrNodeNotFound      = errors.New("no simulated node at address")
+	ErrUnsupportedNet    = errors.New("network is not supported by the simulation")
+	ErrNodeAlreadyExists = errors.New("simulated node exists already")
+	ErrNetworkClosed     = errors.New("simulated network is closed")
+)
+
+// Rejection is the reason for which a transaction is rejected. It is sent to the peer in a reject message.
+type Rejection struct {
+	Code   wire.RejectCode
+	Reason string
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("transaction rejected: %s (%s)", r.Reason, r.Code)
+}
+
+// Network is a simulated network of nodes. The nodes share the mempool and the chain, a transaction received by one of
+// them is announced by all of them and a mined block is announced by all of them.
+type Network struct {
+	logger   *slog.Logger
+	net      wire.BitcoinNet
+	params   *headers.Params
+	validate func(tx *wire.MsgTx) *Rejection
+	now      func() time.Time
+
+	mu         sync.Mutex
+	closed     bool
+	nodes      map[string]*Node
+	mempool    map[chainhash.Hash]*wire.MsgTx
+	spentBy    map[wire.OutPoint]chainhash.Hash
+	mined      map[chainhash.Hash]struct{}
+	rejections map[chainhash.Hash]*Rejection
+	headers    []wire.BlockHeader
+	blocks     map[chainhash.Hash]*wire.MsgBlock
+	heights    map[chainhash.Hash]uint64
+}
+
+func WithLogger(logger *slog.Logger) func(*Network) {
+	return func(n *Network) {
+		n.logger = logger.With(slog.String("service", "p2psim"))
+	}
+}
+
+// WithValidator validates each transaction which is received. A transaction for which the validator returns a
+// rejection is rejected.
+func WithValidator(validate func(tx *wire.MsgTx) *Rejection) func(*Network) {
+	return func(n *Network) {
+		n.validate = validate
+	}
+}
+
+// WithNow sets the clock from which the timestamps of mined blocks are taken.
+func WithNow(now func() time.Time) func(*Network) {
+	return func(n *Network) {
+		n.now = now
+	}
+}
+
+// NewNetwork returns a simulated network whose chain consists of the genesis block of the given network. Only the
+// regression test network (wire.TestNet) is supported, because blocks are mined with its minimal difficulty.
+func NewNetwork(network wire.BitcoinNet, opts ...func(*Network)) (*Network, error) {
+	if network != wire.TestNet {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedNet, network)
+	}
+
+	params, err := headers.NetworkParams(network)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Network{
+		logger:     slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn})).With(slog.String("service", "p2psim")),
+		net:        network,
+		params:     params,
+		now:        time.Now,
+		nodes:      make(map[string]*Node),
+		mempool:    make(map[chainhash.Hash]*wire.MsgTx),
+		spentBy:    make(map[wire.OutPoint]chainhash.Hash),
+		mined:      make(map[chainhash.Hash]struct{}),
+		rejections: make(map[chainhash.Hash]*Rejection),
+		headers:    []wire.BlockHeader{params.GenesisHeader},
+		blocks:     make(map[chainhash.Hash]*wire.MsgBlock),
+		heights:    map[chainhash.Hash]uint64{params.GenesisHeader.BlockHash(): 0},
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n, nil
+}
+
+// AddNode adds a node which accepts connections dialed to the address, e.g. "node1:18333".
+func (n *Network) AddNode(address string) (*Node, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, found := n.nodes[address]; found {
+		return nil, fmt.Errorf("%w: %s", ErrNodeAlreadyExists, address)
+	}
+
+	node := newNode(n, address)
+	n.nodes[address] = node
+
+	return node, nil
+}
+
+// Node returns the node with the address or nil if there is none.
+func (n *Network) Node(address string) *Node {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.nodes[address]
+}
+
+// Dial connects to the node with the address over an in-memory connection. It can be passed to peers with
+// p2p.WithDialer.
+func (n *Network) Dial(_ string, address string) (net.Conn, error) {
+	n.mu.Lock()
+	closed := n.closed
+	node := n.nodes[address]
+	n.mu.Unlock()
+
+	if closed {
+		return nil, ErrNetworkClosed
+	}
+
+	if node == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, address)
+	}
+
+	return node.dial()
+}
+
+// Close closes the connections of all nodes. Peers cannot connect to the nodes anymore, so that they stop reading and
+// writing messages.
+func (n *Network) Close() {
+	n.mu.Lock()
+	n.closed = true
+	nodes := n.nodeList()
+	n.mu.Unlock()
+
+	for _, node := range nodes {
+		node.Close()
+	}
+}
+
+// RejectTransaction rejects the transaction with the hash with the given code and reason when it is received.
+func (n *Network) RejectTransaction(hash chainhash.Hash, code wire.RejectCode, reason string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.rejections[hash] = &Rejection{Code: code, Reason: reason}
+}
+
+// SubmitTransaction adds the transaction to the mempool as if it was received from outside the connected peers. It is
+// announced to all peers. If it is rejected, the rejection is returned.
+func (n *Network) SubmitTransaction(tx *wire.MsgTx) error {
+	rejection := n.receiveTransaction(tx, nil, nil)
+	if rejection != nil {
+		return rejection
+	}
+
+	return nil
+}
+
+// Transaction returns the transaction with the hash from the mempool.
+func (n *Network) Transaction(hash chainhash.Hash) (*wire.MsgTx, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	tx, found := n.mempool[hash]
+	return tx, found
+}
+
+// Mempool returns the hashes of the transactions in the mempool.
+func (n *Network) Mempool() []chainhash.Hash {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	hashes := make([]chainhash.Hash, 0, len(n.mempool))
+	for hash := range n.mempool {
+		hashes = append(hashes, hash)
+	}
+
+	return hashes
+}
+
+// Height returns the height of the tip of the chain.
+func (n *Network) Height() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return uint64(len(n.headers) - 1)
+}
+
+// Block returns the mined block with the hash. The genesis block is not returned.
+func (n *Network) Block(hash chainhash.Hash) (*wire.MsgBlock, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	block, found := n.blocks[hash]
+	return block, found
+}
+
+// receiveTransaction validates the transaction received by the node from the connection. An accepted transaction is
+// added to the mempool and announced to all peers except the one it was received from. Both node and origin are nil
+// for transactions submitted by SubmitTransaction.
+func (n *Network) receiveTransaction(tx *wire.MsgTx, node *Node, origin *conn) *Rejection {
+	hash := tx.TxHash()
+
+	n.mu.Lock()
+
+	if _, found := n.mempool[hash]; found {
+		n.mu.Unlock()
+		return nil
+	}
+
+	if _, found := n.mined[hash]; found {
+		n.mu.Unlock()
+		return nil
+	}
+
+	rejection, collidedWith := n.checkTransaction(tx)
+	if rejection != nil {
+		n.mu.Unlock()
+
+		n.logger.Debug("rejected transaction", slog.String("hash", hash.String()), slog.String("reason", rejection.Reason))
+
+		if origin != nil {
+			msg := wire.NewMsgReject(wire.CmdTx, rejection.Code, rejection.Reason)
+			msg.Hash = hash
+			origin.send(msg)
+		}
+
+		if node != nil {
+			node.publish(topicInvalidTx, invalidTxPayload(tx, node.address, rejection, collidedWith))
+		}
+
+		return rejection
+	}
+
+	n.mempool[hash] = tx
+	for _, input := range tx.TxIn {
+		n.spentBy[input.PreviousOutPoint] = hash
+	}
+
+	nodes := n.nodeList()
+	n.mu.Unlock()
+
+	n.logger.Debug("accepted transaction", slog.String("hash", hash.String()))
+
+	inv := wire.NewMsgInv()
+	_ = inv.AddInvVect(wire.NewInvVect(wire.InvTypeTx, &hash))
+
+	for _, other := range nodes {
+		other.publish(topicHashTx, hash.String())
+		other.broadcast(inv, origin)
+	}
+
+	return nil
+}
+
+// checkTransaction returns the rejection of the transaction and the transaction it collided with, if any.
+func (n *Network) checkTransaction(tx *wire.MsgTx) (*Rejection, *wire.MsgTx) {
+	if rejection, found := n.rejections[tx.TxHash()]; found {
+		return rejection, nil
+	}
+
+	if n.validate != nil {
+		if rejection := n.validate(tx); rejection != nil {
+			return rejection, nil
+		}
+	}
+
+	for _, input := range tx.TxIn {
+		spendingHash, found := n.spentBy[input.PreviousOutPoint]
+		if !found {
+			continue
+		}
+
+		if spendingTx, inMempool := n.mempool[spendingHash]; inMempool {
+			return &Rejection{Code: wire.RejectDuplicate, Reason: RejectReasonMempoolConflict}, spendingTx
+		}
+
+		return &Rejection{Code: wire.RejectDuplicate, Reason: RejectReasonInputsSpent}, nil
+	}
+
+	return nil, nil
+}
+
+// MineBlock mines a block on the tip of the chain which contains a coinbase transaction followed by the transactions.
+// The transactions do not need to be in the mempool. Transactions in the mempool which conflict with the transactions
+// of the block are discarded. The block is announced to all peers.
+func (n *Network) MineBlock(txs ...*wire.MsgTx) (*wire.MsgBlock, error) {
+	n.mu.Lock()
+
+	height := uint64(len(n.headers))
+	previous := n.headers[len(n.headers)-1]
+
+	timestamp := n.now().Truncate(time.Second)
+	if !timestamp.After(previous.Timestamp) {
+		timestamp = previous.Timestamp.Add(time.Second)
+	}
+
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   0x20000000,
+			PrevBlock: previous.BlockHash(),
+			Timestamp: timestamp,
+			Bits:      n.params.PowLimitBits,
+		},
+		Transactions: append([]*wire.MsgTx{n.coinbaseTransaction(height)}, txs...),
+	}
+
+	txHashes := make([][]byte, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		hash := tx.TxHash()
+		txHashes[i] = hash[:]
+	}
+
+	merkleTree := blockchain.BuildMerkleTreeStore(txHashes)
+	merkleRoot, err := chainhash.NewHash(merkleTree[len(merkleTree)-1])
+	if err != nil {
+		n.mu.Unlock()
+		return nil, err
+	}
+	block.Header.MerkleRoot = *merkleRoot
+
+	for headers.CheckProofOfWork(&block.Header, n.params.PowLimit) != nil {
+		block.Header.Nonce++
+	}
+
+	blockHash := block.Header.BlockHash()
+	discarded := n.confirmTransactions(block)
+
+	n.headers = append(n.headers, block.Header)
+	n.blocks[blockHash] = block
+	n.heights[blockHash] = height
+
+	nodes := n.nodeList()
+	n.mu.Unlock()
+
+	n.logger.Debug("mined block", slog.String("hash", blockHash.String()), slog.Uint64("height", height), slog.Int("txs", len(block.Transactions)))
+
+	inv := wire.NewMsgInv()
+	_ = inv.AddInvVect(wire.NewInvVect(wire.InvTypeBlock, &blockHash))
+
+	for _, node := range nodes {
+		for _, d := range discarded {
+			node.publish(topicDiscardedFromMempool, discardedPayload(d.tx, d.collidedWith, blockHash))
+		}
+
+		node.publish(topicHashBlock, blockHash.String())
+		node.broadcast(inv, nil)
+	}
+
+	return block, nil
+}
+
+type discardedTx struct {
+	tx           *wire.MsgTx
+	collidedWith *wire.MsgTx
+}
+
+// confirmTransactions removes the transactions of the block from the mempool and discards the transactions of the
+// mempool which spend the same outputs.
+func (n *Network) confirmTransactions(block *wire.MsgBlock) []discardedTx {
+	var discarded []discardedTx
+
+	for _, tx := range block.Transactions[1:] {
+		hash := tx.TxHash()
+		delete(n.mempool, hash)
+		n.mined[hash] = struct{}{}
+
+		for _, input := range tx.TxIn {
+			spendingHash, found := n.spentBy[input.PreviousOutPoint]
+			n.spentBy[input.PreviousOutPoint] = hash
+
+			if !found || spendingHash == hash {
+				continue
+			}
+
+			conflicting, inMempool := n.mempool[spendingHash]
+			if !inMempool {
+				continue
+			}
+
+			delete(n.mempool, spendingHash)
+			discarded = append(discarded, discardedTx{tx: conflicting, collidedWith: tx})
+		}
+	}
+
+	return discarded
+}
+
+// coinbaseTransaction returns a coinbase transaction which pays the block subsidy to an anyone-can-spend output. The
+// height is encoded at the beginning of the unlocking script as in BIP34.
+func (n *Network) coinbaseTransaction(height uint64) *wire.MsgTx {
+	heightBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(heightBytes, height)
+
+	length := 8
+	for length > 1 && heightBytes[length-1] == 0 {
+		length--
+	}
+
+	unlockingScript := append([]byte{byte(length)}, heightBytes[:length]...)
+	unlockingScript = append(unlockingScript, []byte("/p2psim/")...)
+
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), unlockingScript))
+	tx.AddTxOut(wire.NewTxOut(int64(n.params.BlockSubsidy(height)), []byte{0x51})) // OP_TRUE
+
+	return tx
+}
+
+// headersAfter returns the headers following the first hash of the locator which is in the chain, up to the stop hash.
+// If none of the hashes is in the chain, the headers following the genesis block are returned.
+func (n *Network) headersAfter(locator []*chainhash.Hash, stop chainhash.Hash) []wire.BlockHeader {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	start := uint64(1)
+	for _, hash := range locator {
+		if height, found := n.heights[*hash]; found {
+			start = height + 1
+			break
+		}
+	}
+
+	var result []wire.BlockHeader
+	for height := start; height < uint64(len(n.headers)) && len(result) < maxHeadersPerMsg; height++ {
+		result = append(result, n.headers[height])
+
+		if n.headers[height].BlockHash() == stop {
+			break
+		}
+	}
+
+	return result
+}
+
+// known returns whether the transaction is in the mempool or has been mined.
+func (n *Network) known(hash chainhash.Hash) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, inMempool := n.mempool[hash]
+	_, mined := n.mined[hash]
+
+	return inMempool || mined
+}
+
+func (n *Network) nodeList() []*Node {
+	nodes := make([]*Node, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
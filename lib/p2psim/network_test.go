@@ -0,0 +1,431 @@
+package p2psim
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx/headers"
+	"github.com/bitcoin-sv/arc/metamorph"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+)
+
+const waitTimeout = 5 * time.Second
+
+var _ metamorph.ZMQI = (*Node)(nil)
+
+// peerHandler records the messages received by a peer and serves the transactions it announces.
+type peerHandler struct {
+	mu            sync.Mutex
+	txs           map[chainhash.Hash][]byte
+	sent          []chainhash.Hash
+	announcements []chainhash.Hash
+	rejections    []*wire.MsgReject
+	blocks        []*wire.MsgBlock
+}
+
+func newPeerHandler() *peerHandler {
+	return &peerHandler{txs: make(map[chainhash.Hash][]byte)}
+}
+
+func (h *peerHandler) add(tx *wire.MsgTx) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buf := &bytes.Buffer{}
+	_ = tx.Serialize(buf)
+	h.txs[tx.TxHash()] = buf.Bytes()
+}
+
+func (h *peerHandler) HandleTransactionGet(msg *wire.InvVect, _ p2p.PeerI) ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.txs[msg.Hash], nil
+}
+
+func (h *peerHandler) HandleTransactionSent(msg *wire.MsgTx, _ p2p.PeerI) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sent = append(h.sent, msg.TxHash())
+	return nil
+}
+
+func (h *peerHandler) HandleTransactionAnnouncement(msg *wire.InvVect, _ p2p.PeerI) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.announcements = append(h.announcements, msg.Hash)
+	return nil
+}
+
+func (h *peerHandler) HandleTransactionRejection(rejMsg *wire.MsgReject, _ p2p.PeerI) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.rejections = append(h.rejections, rejMsg)
+	return nil
+}
+
+func (h *peerHandler) HandleTransaction(_ *wire.MsgTx, _ p2p.PeerI) error {
+	return nil
+}
+
+func (h *peerHandler) HandleBlockAnnouncement(msg *wire.InvVect, peer p2p.PeerI) error {
+	peer.RequestBlock(&msg.Hash)
+	return nil
+}
+
+func (h *peerHandler) HandleBlock(msg wire.Message, _ p2p.PeerI) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	block, ok := msg.(*wire.MsgBlock)
+	if ok {
+		h.blocks = append(h.blocks, block)
+	}
+	return nil
+}
+
+func (h *peerHandler) hasAnnouncement(hash chainhash.Hash) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, announced := range h.announcements {
+		if announced == hash {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *peerHandler) sentTransactions() []chainhash.Hash {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]chainhash.Hash(nil), h.sent...)
+}
+
+func (h *peerHandler) rejection(hash chainhash.Hash) *wire.MsgReject {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, rejection := range h.rejections {
+		if rejection.Hash == hash {
+			return rejection
+		}
+	}
+	return nil
+}
+
+func (h *peerHandler) block() *wire.MsgBlock {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.blocks) == 0 {
+		return nil
+	}
+	return h.blocks[len(h.blocks)-1]
+}
+
+// spendingTx returns a transaction which spends the output of the previous transaction with the hash.
+func spendingTx(previousHash chainhash.Hash, index uint32, satoshis int64) *wire.MsgTx {
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&previousHash, index), []byte{0x51}))
+	tx.AddTxOut(wire.NewTxOut(satoshis, []byte{0x51}))
+
+	return tx
+}
+
+func connectPeer(t *testing.T, network *Network, node *Node, handler p2p.PeerHandlerI) *p2p.Peer {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	peers := node.Peers()
+	peer, err := p2p.NewPeer(logger, node.Address(), handler, wire.TestNet, p2p.WithDialer(network.Dial), p2p.WithBatchDelay(10*time.Millisecond))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return peer.Connected() && node.Peers() == peers+1
+	}, waitTimeout, 10*time.Millisecond)
+
+	return peer
+}
+
+// receiveEvent returns the payload of the next event of the topic.
+func receiveEvent(t *testing.T, ch chan []string, topic string) string {
+	t.Helper()
+
+	for {
+		select {
+		case event := <-ch:
+			if event[0] == topic {
+				return event[1]
+			}
+		case <-time.After(waitTimeout):
+			t.Fatalf("no %s event received", topic)
+		}
+	}
+}
+
+func decodeEvent(t *testing.T, payload string, v any) {
+	t.Helper()
+
+	b, err := hex.DecodeString(payload)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, v))
+}
+
+func TestNetwork(t *testing.T) {
+	// the network is not closed, because peers of go-p2p race when they are disconnected
+	network, err := NewNetwork(wire.TestNet)
+	require.NoError(t, err)
+
+	node1, err := network.AddNode("node1:18333")
+	require.NoError(t, err)
+	node2, err := network.AddNode("node2:18333")
+	require.NoError(t, err)
+
+	events1 := make(chan []string, 100)
+	require.NoError(t, node1.Subscribe(topicHashTx, events1))
+	require.NoError(t, node1.Subscribe(topicInvalidTx, events1))
+	events2 := make(chan []string, 100)
+	require.NoError(t, node2.Subscribe(topicDiscardedFromMempool, events2))
+	require.NoError(t, node2.Subscribe(topicHashBlock, events2))
+
+	sender := newPeerHandler()
+	senderPeer := connectPeer(t, network, node1, sender)
+	receiver := newPeerHandler()
+	_ = connectPeer(t, network, node2, receiver)
+
+	fundingHash := chainhash.DoubleHashH([]byte("funding"))
+
+	t.Run("announced transaction is requested, accepted and announced by the other node", func(t *testing.T) {
+		tx := spendingTx(fundingHash, 0, 1000)
+		hash := tx.TxHash()
+		sender.add(tx)
+
+		senderPeer.AnnounceTransaction(&hash)
+
+		require.Eventually(t, func() bool { return receiver.hasAnnouncement(hash) }, waitTimeout, 10*time.Millisecond)
+		require.Eventually(t, func() bool { return len(sender.sentTransactions()) == 1 }, waitTimeout, 10*time.Millisecond)
+		require.False(t, sender.hasAnnouncement(hash))
+		require.Equal(t, []chainhash.Hash{hash}, sender.sentTransactions())
+		require.Equal(t, hash.String(), receiveEvent(t, events1, topicHashTx))
+
+		_, found := network.Transaction(hash)
+		require.True(t, found)
+	})
+
+	t.Run("double spend is rejected", func(t *testing.T) {
+		tx := spendingTx(fundingHash, 0, 900)
+		hash := tx.TxHash()
+		sender.add(tx)
+
+		senderPeer.AnnounceTransaction(&hash)
+
+		require.Eventually(t, func() bool { return sender.rejection(hash) != nil }, waitTimeout, 10*time.Millisecond)
+		rejection := sender.rejection(hash)
+		require.Equal(t, wire.RejectDuplicate, rejection.Code)
+		require.Equal(t, RejectReasonMempoolConflict, rejection.Reason)
+
+		var event invalidTx
+		decodeEvent(t, receiveEvent(t, events1, topicInvalidTx), &event)
+		require.Equal(t, hash.String(), event.TxID)
+		require.True(t, event.IsMempoolConflictDetected)
+		require.Equal(t, spendingTx(fundingHash, 0, 1000).TxHash().String(), event.CollidedWith[0].TxID)
+		require.False(t, receiver.hasAnnouncement(hash))
+	})
+
+	t.Run("transaction is rejected as configured", func(t *testing.T) {
+		tx := spendingTx(fundingHash, 1, 1000)
+		hash := tx.TxHash()
+		sender.add(tx)
+		network.RejectTransaction(hash, wire.RejectNonstandard, "dust")
+
+		senderPeer.AnnounceTransaction(&hash)
+
+		require.Eventually(t, func() bool { return sender.rejection(hash) != nil }, waitTimeout, 10*time.Millisecond)
+		require.Equal(t, "dust", sender.rejection(hash).Reason)
+
+		err := network.SubmitTransaction(tx)
+		require.ErrorContains(t, err, "dust")
+	})
+
+	t.Run("mined block contains the chosen transactions and discards conflicts", func(t *testing.T) {
+		submitted := spendingTx(fundingHash, 2, 1000)
+		require.NoError(t, network.SubmitTransaction(submitted))
+		require.Eventually(t, func() bool { return receiver.hasAnnouncement(submitted.TxHash()) }, waitTimeout, 10*time.Millisecond)
+
+		minedTx, found := network.Transaction(spendingTx(fundingHash, 0, 1000).TxHash())
+		require.True(t, found)
+		conflicting := spendingTx(fundingHash, 2, 500)
+
+		block, err := network.MineBlock(minedTx, conflicting)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), network.Height())
+		require.Len(t, block.Transactions, 3)
+		require.NoError(t, headers.CheckProofOfWork(&block.Header, headers.RegressionNetParams.PowLimit))
+		require.Equal(t, headers.RegressionNetParams.GenesisHeader.BlockHash(), block.Header.PrevBlock)
+		require.Equal(t, []byte{0x01, 0x01}, block.Transactions[0].TxIn[0].SignatureScript[:2])
+
+		require.Empty(t, network.Mempool())
+
+		var event discardedFromMempool
+		decodeEvent(t, receiveEvent(t, events2, topicDiscardedFromMempool), &event)
+		require.Equal(t, submitted.TxHash().String(), event.TxID)
+		require.Equal(t, DiscardReasonCollisionInBlock, event.Reason)
+		require.Equal(t, conflicting.TxHash().String(), event.CollidedWith.TxID)
+		require.Equal(t, block.BlockHash().String(), receiveEvent(t, events2, topicHashBlock))
+
+		require.Eventually(t, func() bool { return receiver.block() != nil }, waitTimeout, 10*time.Millisecond)
+		received := receiver.block()
+		require.Equal(t, block.BlockHash(), received.BlockHash())
+		require.Len(t, received.Transactions, 3)
+
+		// an output spent by a mined transaction cannot be spent again
+		err = network.SubmitTransaction(spendingTx(fundingHash, 2, 100))
+		require.ErrorContains(t, err, RejectReasonInputsSpent)
+	})
+}
+
+// handshake connects to the node with a raw connection and completes the version handshake.
+func handshake(t *testing.T, network *Network, address string) func(msg wire.Message) wire.Message {
+	t.Helper()
+
+	c, err := network.Dial("tcp", address)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	write := func(msg wire.Message) {
+		require.NoError(t, wire.WriteMessage(c, msg, wire.ProtocolVersion, wire.TestNet))
+	}
+	read := func() wire.Message {
+		msg, _, err := wire.ReadMessage(c, wire.ProtocolVersion, wire.TestNet)
+		require.NoError(t, err)
+		return msg
+	}
+
+	me := wire.NewNetAddressIPPort(nil, 0, 0)
+	write(wire.NewMsgVersion(me, me, 1, 0))
+	require.IsType(t, &wire.MsgVersion{}, read())
+	require.IsType(t, &wire.MsgVerAck{}, read())
+	write(wire.NewMsgVerAck())
+
+	return func(msg wire.Message) wire.Message {
+		write(msg)
+		return read()
+	}
+}
+
+func TestGetHeaders(t *testing.T) {
+	network, err := NewNetwork(wire.TestNet, WithNow(func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }))
+	require.NoError(t, err)
+
+	_, err = network.AddNode("node1:18333")
+	require.NoError(t, err)
+
+	hashes := make([]chainhash.Hash, 3)
+	for i := range hashes {
+		block, err := network.MineBlock()
+		require.NoError(t, err)
+		hashes[i] = block.BlockHash()
+	}
+
+	request := handshake(t, network, "node1:18333")
+
+	tt := []struct {
+		name    string
+		locator []chainhash.Hash
+		stop    chainhash.Hash
+
+		expectedHashes []chainhash.Hash
+	}{
+		{
+			name:    "from genesis",
+			locator: []chainhash.Hash{headers.RegressionNetParams.GenesisHeader.BlockHash()},
+
+			expectedHashes: hashes,
+		},
+		{
+			name:    "from first known locator hash",
+			locator: []chainhash.Hash{chainhash.DoubleHashH([]byte("unknown")), hashes[0]},
+
+			expectedHashes: hashes[1:],
+		},
+		{
+			name:    "until stop hash",
+			locator: []chainhash.Hash{headers.RegressionNetParams.GenesisHeader.BlockHash()},
+			stop:    hashes[1],
+
+			expectedHashes: hashes[:2],
+		},
+		{
+			name:    "from tip",
+			locator: []chainhash.Hash{hashes[2]},
+
+			expectedHashes: []chainhash.Hash{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := wire.NewMsgGetHeaders()
+			for _, hash := range tc.locator {
+				hash := hash
+				require.NoError(t, msg.AddBlockLocatorHash(&hash))
+			}
+			msg.HashStop = tc.stop
+
+			headersMsg, ok := request(msg).(*wire.MsgHeaders)
+			require.True(t, ok)
+
+			actualHashes := make([]chainhash.Hash, len(headersMsg.Headers))
+			for i, header := range headersMsg.Headers {
+				actualHashes[i] = header.BlockHash()
+			}
+			require.Equal(t, tc.expectedHashes, actualHashes)
+		})
+	}
+
+	// the timestamps increase even if the clock does not
+	block1, _ := network.Block(hashes[0])
+	block2, _ := network.Block(hashes[1])
+	require.True(t, block2.Header.Timestamp.After(block1.Header.Timestamp))
+
+	notFound, ok := request(&wire.MsgGetData{InvList: []*wire.InvVect{wire.NewInvVect(wire.InvTypeTx, &hashes[0])}}).(*wire.MsgNotFound)
+	require.True(t, ok)
+	require.Len(t, notFound.InvList, 1)
+
+	network.Close()
+	require.Zero(t, network.Node("node1:18333").Peers())
+
+	_, err = network.Dial("tcp", "node1:18333")
+	require.ErrorIs(t, err, ErrNetworkClosed)
+}
+
+func TestNewNetwork(t *testing.T) {
+	_, err := NewNetwork(wire.MainNet)
+	require.ErrorIs(t, err, ErrUnsupportedNet)
+
+	network, err := NewNetwork(wire.TestNet)
+	require.NoError(t, err)
+
+	_, err = network.AddNode("node1:18333")
+	require.NoError(t, err)
+	_, err = network.AddNode("node1:18333")
+	require.ErrorIs(t, err, ErrNodeAlreadyExists)
+
+	_, err = network.Dial("tcp", "node2:18333")
+	require.ErrorIs(t, err, ErrNodeNotFound)
+}
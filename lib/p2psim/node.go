@@ -0,0 +1,291 @@
+package p2psim
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/libsv/go-p2p/wire"
+)
+
+// connWriteBufferSize is the number of messages which are buffered for a connection. Messages are written
+// asynchronously, because the in-memory connections block until the peer reads.
+const connWriteBufferSize = 10000
+
+// Node is a simulated node of the network. It accepts in-memory connections from peers.
+type Node struct {
+	network *Network
+	address string
+	logger  *slog.Logger
+
+	mu          sync.Mutex
+	conns       map[*conn]struct{}
+	subscribers map[string][]chan []string
+	wg          sync.WaitGroup
+}
+
+func newNode(network *Network, address string) *Node {
+	return &Node{
+		network:     network,
+		address:     address,
+		logger:      network.logger.With(slog.String("node", address)),
+		conns:       make(map[*conn]struct{}),
+		subscribers: make(map[string][]chan []string),
+	}
+}
+
+// Address returns the address to which peers dial to connect to the node.
+func (n *Node) Address() string {
+	return n.address
+}
+
+// Peers returns the number of connected peers which have completed the handshake.
+func (n *Node) Peers() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	peers := 0
+	for c := range n.conns {
+		if c.isReady() {
+			peers++
+		}
+	}
+
+	return peers
+}
+
+// Close closes the connections of all peers of the node and waits until they are done.
+func (n *Node) Close() {
+	n.mu.Lock()
+	conns := make([]*conn, 0, len(n.conns))
+	for c := range n.conns {
+		conns = append(conns, c)
+	}
+	n.mu.Unlock()
+
+	for _, c := range conns {
+		c.close()
+	}
+
+	n.wg.Wait()
+}
+
+func (n *Node) dial() (net.Conn, error) {
+	nodeEnd, peerEnd := net.Pipe()
+
+	c := &conn{
+		node:    n,
+		netConn: nodeEnd,
+		writeCh: make(chan wire.Message, connWriteBufferSize),
+		quit:    make(chan struct{}),
+	}
+
+	n.mu.Lock()
+	n.conns[c] = struct{}{}
+	n.mu.Unlock()
+
+	n.wg.Add(2)
+	go c.writeHandler()
+	go c.readHandler()
+
+	return peerEnd, nil
+}
+
+func (n *Node) removeConn(c *conn) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.conns, c)
+}
+
+// broadcast sends the message to all connected peers except the excluded one.
+func (n *Node) broadcast(msg wire.Message, exclude *conn) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for c := range n.conns {
+		if c == exclude || !c.isReady() {
+			continue
+		}
+
+		c.send(msg)
+	}
+}
+
+// conn is the connection of a peer to a node.
+type conn struct {
+	node    *Node
+	netConn net.Conn
+	writeCh chan wire.Message
+
+	mu        sync.Mutex
+	ready     bool
+	quit      chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *conn) isReady() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ready
+}
+
+func (c *conn) setReady() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ready = true
+}
+
+// send queues the message to be written to the peer. Messages to closed connections are dropped.
+func (c *conn) send(msg wire.Message) {
+	select {
+	case <-c.quit:
+	case c.writeCh <- msg:
+	}
+}
+
+func (c *conn) close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+		_ = c.netConn.Close()
+		c.node.removeConn(c)
+	})
+}
+
+func (c *conn) writeHandler() {
+	defer c.node.wg.Done()
+
+	for {
+		select {
+		case <-c.quit:
+			return
+		case msg := <-c.writeCh:
+			if err := writeMessage(c.netConn, msg, c.node.network.net); err != nil {
+				c.node.logger.Debug("failed to write message", slog.String("command", msg.Command()), slog.String("err", err.Error()))
+				c.close()
+				return
+			}
+		}
+	}
+}
+
+func (c *conn) readHandler() {
+	defer c.node.wg.Done()
+	defer c.close()
+
+	for {
+		msg, command, err := readMessage(c.netConn, c.node.network.net)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrClosedPipe) {
+				c.node.logger.Debug("failed to read message", slog.String("err", err.Error()))
+			}
+			return
+		}
+
+		if msg == nil {
+			c.node.logger.Debug("ignored message", slog.String("command", command))
+			continue
+		}
+
+		c.handleMessage(msg)
+	}
+}
+
+func (c *conn) handleMessage(msg wire.Message) {
+	switch m := msg.(type) {
+	case *wire.MsgVersion:
+		c.send(c.versionMessage())
+		c.send(wire.NewMsgVerAck())
+
+	case *wire.MsgVerAck:
+		c.setReady()
+
+	case *wire.MsgPing:
+		c.send(wire.NewMsgPong(m.Nonce))
+
+	case *wire.MsgInv:
+		c.handleInv(m)
+
+	case *wire.MsgGetData:
+		c.handleGetData(m)
+
+	case *wire.MsgTx:
+		c.node.network.receiveTransaction(m, c.node, c)
+
+	case *wire.MsgGetHeaders:
+		headersMsg := wire.NewMsgHeaders()
+		for _, header := range c.node.network.headersAfter(m.BlockLocatorHashes, m.HashStop) {
+			header := header
+			_ = headersMsg.AddBlockHeader(&header)
+		}
+		c.send(headersMsg)
+	}
+}
+
+// handleInv requests the announced transactions which are not known yet. Announced blocks are ignored.
+func (c *conn) handleInv(msg *wire.MsgInv) {
+	getData := wire.NewMsgGetData()
+	for _, inv := range msg.InvList {
+		if inv.Type != wire.InvTypeTx || c.node.network.known(inv.Hash) {
+			continue
+		}
+
+		_ = getData.AddInvVect(wire.NewInvVect(wire.InvTypeTx, &inv.Hash))
+	}
+
+	if len(getData.InvList) > 0 {
+		c.send(getData)
+	}
+}
+
+// handleGetData sends the requested transactions from the mempool and the requested blocks. Items which are not found
+// are listed in a notfound message.
+func (c *conn) handleGetData(msg *wire.MsgGetData) {
+	notFound := wire.NewMsgNotFound()
+
+	for _, inv := range msg.InvList {
+		switch inv.Type {
+		case wire.InvTypeTx:
+			if tx, found := c.node.network.Transaction(inv.Hash); found {
+				c.send(tx)
+				continue
+			}
+		case wire.InvTypeBlock:
+			if block, found := c.node.network.Block(inv.Hash); found {
+				c.send(block)
+				continue
+			}
+		}
+
+		_ = notFound.AddInvVect(inv)
+	}
+
+	if len(notFound.InvList) > 0 {
+		c.send(notFound)
+	}
+}
+
+func (c *conn) versionMessage() *wire.MsgVersion {
+	host, portStr, _ := net.SplitHostPort(c.node.address)
+	port, _ := strconv.ParseUint(portStr, 10, 16)
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		ip = net.IPv4(127, 0, 0, 1)
+	}
+
+	me := wire.NewNetAddressIPPort(ip, uint16(port), wire.SFNodeNetwork)
+	you := wire.NewNetAddressIPPort(net.IPv4(127, 0, 0, 1), 0, 0)
+
+	msg := wire.NewMsgVersion(me, you, rand.Uint64(), int32(c.node.network.Height()))
+	msg.Services = wire.SFNodeNetwork
+	msg.UserAgent = "/p2psim/"
+
+	return msg
+}
@@ -0,0 +1,122 @@
+package p2psim
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+)
+
+// The topics of the events which nodes publish like the ZMQ interface of a node.
+const (
+	topicHashTx               = "hashtx2"
+	topicHashBlock            = "hashblock"
+	topicInvalidTx            = "invalidtx"
+	topicDiscardedFromMempool = "discardedfrommempool"
+)
+
+// Subscribe subscribes the channel to the events of the topic. Each event is sent as the topic followed by its
+// payload, as the ZMQ events of a node are. Transaction and block hashes are published as hex strings, invalid and
+// discarded transactions as JSON encoded in hex. It implements metamorph.ZMQI.
+func (n *Node) Subscribe(topic string, ch chan []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.subscribers[topic] = append(n.subscribers[topic], ch)
+
+	return nil
+}
+
+// publish sends the event to the subscribers of the topic.
+func (n *Node) publish(topic string, payload string) {
+	n.mu.Lock()
+	subscribers := append([]chan []string(nil), n.subscribers[topic]...)
+	n.mu.Unlock()
+
+	for _, ch := range subscribers {
+		ch <- []string{topic, payload}
+	}
+}
+
+type collidedTx struct {
+	TxID string `json:"txid"`
+	Size int    `json:"size"`
+	Hex  string `json:"hex"`
+}
+
+func newCollidedTx(tx *wire.MsgTx) collidedTx {
+	buf := &bytes.Buffer{}
+	_ = tx.Serialize(buf)
+
+	return collidedTx{
+		TxID: tx.TxHash().String(),
+		Size: buf.Len(),
+		Hex:  hex.EncodeToString(buf.Bytes()),
+	}
+}
+
+type invalidTx struct {
+	TxID                      string       `json:"txid"`
+	FromBlock                 bool         `json:"fromBlock"`
+	Source                    string       `json:"source"`
+	Address                   string       `json:"address"`
+	Size                      int          `json:"size"`
+	Hex                       string       `json:"hex"`
+	IsInvalid                 bool         `json:"isInvalid"`
+	IsMempoolConflictDetected bool         `json:"isMempoolConflictDetected"`
+	RejectionCode             int          `json:"rejectionCode"`
+	RejectionReason           string       `json:"rejectionReason"`
+	CollidedWith              []collidedTx `json:"collidedWith"`
+	RejectionTime             string       `json:"rejectionTime"`
+}
+
+// invalidTxPayload returns the payload of the invalidtx event of the rejected transaction.
+func invalidTxPayload(tx *wire.MsgTx, address string, rejection *Rejection, collidedWith *wire.MsgTx) string {
+	rejected := newCollidedTx(tx)
+
+	event := invalidTx{
+		TxID:            rejected.TxID,
+		Source:          "p2p",
+		Address:         address,
+		Size:            rejected.Size,
+		Hex:             rejected.Hex,
+		IsInvalid:       true,
+		RejectionCode:   int(rejection.Code),
+		RejectionReason: rejection.Reason,
+		CollidedWith:    []collidedTx{},
+		RejectionTime:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if collidedWith != nil {
+		event.IsMempoolConflictDetected = true
+		event.CollidedWith = append(event.CollidedWith, newCollidedTx(collidedWith))
+	}
+
+	return encodePayload(event)
+}
+
+type discardedFromMempool struct {
+	TxID         string     `json:"txid"`
+	Reason       string     `json:"reason"`
+	CollidedWith collidedTx `json:"collidedWith"`
+	BlockHash    string     `json:"blockhash"`
+}
+
+// discardedPayload returns the payload of the discardedfrommempool event of a transaction which has been discarded
+// because the transaction of the block spends one of its inputs.
+func discardedPayload(tx *wire.MsgTx, collidedWith *wire.MsgTx, blockHash chainhash.Hash) string {
+	return encodePayload(discardedFromMempool{
+		TxID:         tx.TxHash().String(),
+		Reason:       DiscardReasonCollisionInBlock,
+		CollidedWith: newCollidedTx(collidedWith),
+		BlockHash:    blockHash.String(),
+	})
+}
+
+func encodePayload(event any) string {
+	b, _ := json.Marshal(event)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,298 @@
+package spv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+)
+
+// BEEFVersion is the version of the Background Evaluation Extended Format (BEEF) specified in BRC-62.
+const BEEFVersion uint32 = 0xEFBE0001
+
+var (
+	ErrInvalidBEEF     = errors.New("invalid BEEF")
+	ErrMissingAncestor = errors.New("transaction without merkle path spends an output of an unknown transaction")
+	ErrInvalidProof    = errors.New("merkle path is not valid for the chain")
+)
+
+// BEEFTx is a transaction of a BEEF together with the index of its merkle path if it is mined.
+type BEEFTx struct {
+	Tx              *bt.Tx
+	HasMerklePath   bool
+	MerklePathIndex uint64
+}
+
+// BEEF is a transaction together with its unmined ancestors and the merkle paths of the mined ancestors in the
+// Background Evaluation Extended Format (BEEF) specified in BRC-62. The transactions are ordered such that each
+// transaction comes after the transactions it spends outputs of, the last transaction is the subject transaction.
+type BEEF struct {
+	MerklePaths  []*MerklePath
+	Transactions []*BEEFTx
+}
+
+// NewBEEF returns the BEEF of the transaction. The ancestors have to contain all transactions which are required to
+// reach a mined transaction from the transaction, and the merkle paths have to contain the mined transactions.
+// Ancestors which are not required are left out.
+func NewBEEF(tx *bt.Tx, ancestors []*bt.Tx, merklePaths []*MerklePath) (*BEEF, error) {
+	txs := make(map[chainhash.Hash]*bt.Tx, len(ancestors)+1)
+	for _, ancestor := range ancestors {
+		txs[txHash(ancestor)] = ancestor
+	}
+	txs[txHash(tx)] = tx
+
+	beef := &BEEF{}
+	merklePathIndexes := make(map[*MerklePath]uint64)
+	visited := make(map[chainhash.Hash]bool)
+
+	var visit func(hash chainhash.Hash) error
+	visit = func(hash chainhash.Hash) error {
+		if visited[hash] {
+			return nil
+		}
+		visited[hash] = true
+
+		beefTx := &BEEFTx{Tx: txs[hash]}
+
+		merklePath := findMerklePath(merklePaths, &hash)
+		if merklePath != nil {
+			index, found := merklePathIndexes[merklePath]
+			if !found {
+				index = uint64(len(beef.MerklePaths))
+				merklePathIndexes[merklePath] = index
+				beef.MerklePaths = append(beef.MerklePaths, merklePath)
+			}
+
+			beefTx.HasMerklePath = true
+			beefTx.MerklePathIndex = index
+		} else {
+			// the ancestors of a transaction which is not mined have to be included
+			for _, input := range beefTx.Tx.Inputs {
+				parentHash, err := chainhash.NewHash(bt.ReverseBytes(input.PreviousTxID()))
+				if err != nil {
+					return err
+				}
+
+				if _, found := txs[*parentHash]; !found {
+					return fmt.Errorf("%w: %s", ErrMissingAncestor, parentHash.String())
+				}
+
+				if err = visit(*parentHash); err != nil {
+					return err
+				}
+			}
+		}
+
+		beef.Transactions = append(beef.Transactions, beefTx)
+
+		return nil
+	}
+
+	if err := visit(txHash(tx)); err != nil {
+		return nil, err
+	}
+
+	return beef, nil
+}
+
+// NewBEEFFromHex parses a BEEF in hex format.
+func NewBEEFFromHex(s string) (*BEEF, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBEEF, err)
+	}
+
+	return NewBEEFFromBytes(b)
+}
+
+// NewBEEFFromBytes parses a BEEF in binary format.
+func NewBEEFFromBytes(b []byte) (*BEEF, error) {
+	if !IsBEEF(b) {
+		return nil, fmt.Errorf("%w: unknown version", ErrInvalidBEEF)
+	}
+
+	reader := bytes.NewReader(b[4:])
+
+	var nMerklePaths bt.VarInt
+	if _, err := nMerklePaths.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("%w: failed to read number of merkle paths: %v", ErrInvalidBEEF, err)
+	}
+
+	beef := &BEEF{}
+	for i := uint64(0); i < uint64(nMerklePaths); i++ {
+		merklePath, err := readMerklePath(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read merkle path %d: %v", ErrInvalidBEEF, i, err)
+		}
+
+		beef.MerklePaths = append(beef.MerklePaths, merklePath)
+	}
+
+	var nTransactions bt.VarInt
+	if _, err := nTransactions.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("%w: failed to read number of transactions: %v", ErrInvalidBEEF, err)
+	}
+
+	if nTransactions == 0 {
+		return nil, fmt.Errorf("%w: no transactions", ErrInvalidBEEF)
+	}
+
+	for i := uint64(0); i < uint64(nTransactions); i++ {
+		beefTx, err := readBEEFTx(reader, uint64(len(beef.MerklePaths)))
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read transaction %d: %v", ErrInvalidBEEF, i, err)
+		}
+
+		beef.Transactions = append(beef.Transactions, beefTx)
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d unexpected trailing bytes", ErrInvalidBEEF, reader.Len())
+	}
+
+	return beef, nil
+}
+
+func readBEEFTx(reader io.Reader, nMerklePaths uint64) (*BEEFTx, error) {
+	tx := bt.NewTx()
+	if _, err := tx.ReadFrom(reader); err != nil {
+		return nil, err
+	}
+
+	beefTx := &BEEFTx{Tx: tx}
+
+	hasMerklePath := make([]byte, 1)
+	if _, err := io.ReadFull(reader, hasMerklePath); err != nil {
+		return nil, err
+	}
+
+	switch hasMerklePath[0] {
+	case 0x00:
+		return beefTx, nil
+	case 0x01:
+	default:
+		return nil, fmt.Errorf("invalid merkle path flag %d", hasMerklePath[0])
+	}
+
+	var index bt.VarInt
+	if _, err := index.ReadFrom(reader); err != nil {
+		return nil, err
+	}
+
+	if uint64(index) >= nMerklePaths {
+		return nil, fmt.Errorf("merkle path index %d out of range", index)
+	}
+
+	beefTx.HasMerklePath = true
+	beefTx.MerklePathIndex = uint64(index)
+
+	return beefTx, nil
+}
+
+// IsBEEF returns whether the bytes start with the BEEF version.
+func IsBEEF(b []byte) bool {
+	return len(b) >= 4 && binary.LittleEndian.Uint32(b[:4]) == BEEFVersion
+}
+
+// Bytes returns the BEEF in binary format.
+func (beef *BEEF) Bytes() []byte {
+	b := binary.LittleEndian.AppendUint32(nil, BEEFVersion)
+
+	b = append(b, bt.VarInt(len(beef.MerklePaths)).Bytes()...)
+	for _, merklePath := range beef.MerklePaths {
+		b = append(b, merklePath.Bytes()...)
+	}
+
+	b = append(b, bt.VarInt(len(beef.Transactions)).Bytes()...)
+	for _, beefTx := range beef.Transactions {
+		b = append(b, beefTx.Tx.Bytes()...)
+
+		if !beefTx.HasMerklePath {
+			b = append(b, 0x00)
+			continue
+		}
+
+		b = append(b, 0x01)
+		b = append(b, bt.VarInt(beefTx.MerklePathIndex).Bytes()...)
+	}
+
+	return b
+}
+
+// Hex returns the BEEF in hex format.
+func (beef *BEEF) Hex() string {
+	return hex.EncodeToString(beef.Bytes())
+}
+
+// Tx returns the subject transaction of the BEEF.
+func (beef *BEEF) Tx() *bt.Tx {
+	if len(beef.Transactions) == 0 {
+		return nil
+	}
+
+	return beef.Transactions[len(beef.Transactions)-1].Tx
+}
+
+// Verify verifies that the merkle paths of the mined transactions are valid for the chain and that the transactions
+// which are not mined only spend outputs of preceding transactions. The scripts of the transactions are not verified.
+func (beef *BEEF) Verify(ctx context.Context, chainTracker ChainTracker) error {
+	if len(beef.Transactions) == 0 {
+		return fmt.Errorf("%w: no transactions", ErrInvalidBEEF)
+	}
+
+	preceding := make(map[chainhash.Hash]bool, len(beef.Transactions))
+
+	for _, beefTx := range beef.Transactions {
+		hash := txHash(beefTx.Tx)
+
+		if beefTx.HasMerklePath {
+			if beefTx.MerklePathIndex >= uint64(len(beef.MerklePaths)) {
+				return fmt.Errorf("%w: merkle path index %d out of range", ErrInvalidBEEF, beefTx.MerklePathIndex)
+			}
+
+			valid, err := beef.MerklePaths[beefTx.MerklePathIndex].Verify(ctx, &hash, chainTracker)
+			if err != nil {
+				return err
+			}
+
+			if !valid {
+				return fmt.Errorf("%w: transaction %s", ErrInvalidProof, hash.String())
+			}
+		} else {
+			for _, input := range beefTx.Tx.Inputs {
+				parentHash, err := chainhash.NewHash(bt.ReverseBytes(input.PreviousTxID()))
+				if err != nil {
+					return err
+				}
+
+				if !preceding[*parentHash] {
+					return fmt.Errorf("%w: %s", ErrMissingAncestor, parentHash.String())
+				}
+			}
+		}
+
+		preceding[hash] = true
+	}
+
+	return nil
+}
+
+func findMerklePath(merklePaths []*MerklePath, hash *chainhash.Hash) *MerklePath {
+	for _, merklePath := range merklePaths {
+		if merklePath.Contains(hash) {
+			return merklePath
+		}
+	}
+
+	return nil
+}
+
+func txHash(tx *bt.Tx) chainhash.Hash {
+	return chainhash.Hash(bt.ReverseBytes(tx.TxIDBytes()))
+}
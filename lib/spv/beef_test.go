@@ -0,0 +1,141 @@
+package spv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+type rootsChainTracker map[uint64]chainhash.Hash
+
+func (c rootsChainTracker) IsValidRootForHeight(_ context.Context, root *chainhash.Hash, height uint64) (bool, error) {
+	expected, found := c[height]
+	return found && expected.IsEqual(root), nil
+}
+
+func spendingTx(t *testing.T, parents ...*bt.Tx) *bt.Tx {
+	t.Helper()
+
+	tx := bt.NewTx()
+	for _, parent := range parents {
+		unlockingScript := bscript.Script([]byte{0x51})
+		input := &bt.Input{PreviousTxOutIndex: 0, SequenceNumber: 0xffffffff, UnlockingScript: &unlockingScript}
+		require.NoError(t, input.PreviousTxIDAdd(parent.TxIDBytes()))
+		tx.Inputs = append(tx.Inputs, input)
+	}
+
+	lockingScript := bscript.Script([]byte{0x51})
+	tx.Outputs = append(tx.Outputs, &bt.Output{Satoshis: uint64(len(parents)) * 1000, LockingScript: &lockingScript})
+
+	return tx
+}
+
+// minedTxs returns the merkle path of the transactions mined in a block at the height and the merkle root.
+func minedTxs(t *testing.T, height uint64, txs ...*bt.Tx) (*MerklePath, chainhash.Hash) {
+	t.Helper()
+
+	txIDs := syntheticTxIDs(5)
+	for i, tx := range txs {
+		hash := txHash(tx)
+		txIDs[i+1] = &hash
+	}
+
+	fullTree := bc.BuildMerkleTreeStoreChainHash(txIDs)
+	bump, err := bc.NewBUMPFromMerkleTreeAndIndex(height, fullTree, 1)
+	require.NoError(t, err)
+	bumpHex, err := bump.String()
+	require.NoError(t, err)
+
+	merklePath, err := NewMerklePathFromHex(bumpHex)
+	require.NoError(t, err)
+
+	return merklePath, *fullTree[len(fullTree)-1]
+}
+
+func TestBEEF(t *testing.T) {
+	coinbase := spendingTx(t)
+	minedParent := spendingTx(t, coinbase)
+	merklePath, merkleRoot := minedTxs(t, 100, minedParent)
+
+	unminedParent := spendingTx(t, minedParent)
+	tx := spendingTx(t, unminedParent, minedParent)
+	unrelated := spendingTx(t, coinbase)
+
+	beef, err := NewBEEF(tx, []*bt.Tx{unrelated, unminedParent, minedParent}, []*MerklePath{merklePath})
+	require.NoError(t, err)
+
+	require.Len(t, beef.MerklePaths, 1)
+	require.Len(t, beef.Transactions, 3)
+	require.Equal(t, minedParent.TxID(), beef.Transactions[0].Tx.TxID())
+	require.True(t, beef.Transactions[0].HasMerklePath)
+	require.Equal(t, unminedParent.TxID(), beef.Transactions[1].Tx.TxID())
+	require.False(t, beef.Transactions[1].HasMerklePath)
+	require.Equal(t, tx.TxID(), beef.Tx().TxID())
+
+	b := beef.Bytes()
+	require.True(t, IsBEEF(b))
+	require.Equal(t, []byte{0x01, 0x00, 0xbe, 0xef}, b[:4])
+
+	parsed, err := NewBEEFFromHex(beef.Hex())
+	require.NoError(t, err)
+	require.Equal(t, b, parsed.Bytes())
+	require.Equal(t, tx.TxID(), parsed.Tx().TxID())
+
+	chainTracker := rootsChainTracker{100: merkleRoot}
+	require.NoError(t, parsed.Verify(context.Background(), chainTracker))
+
+	// the merkle root of the block at the height is different
+	require.ErrorIs(t, parsed.Verify(context.Background(), rootsChainTracker{100: chainhash.Hash{}}), ErrInvalidProof)
+
+	// the mined parent is missing
+	parsed.Transactions = parsed.Transactions[1:]
+	require.ErrorIs(t, parsed.Verify(context.Background(), chainTracker), ErrMissingAncestor)
+}
+
+func TestNewBEEFMissingAncestor(t *testing.T) {
+	coinbase := spendingTx(t)
+	minedParent := spendingTx(t, coinbase)
+	merklePath, _ := minedTxs(t, 100, minedParent)
+
+	unminedParent := spendingTx(t, minedParent)
+	tx := spendingTx(t, unminedParent)
+
+	_, err := NewBEEF(tx, []*bt.Tx{minedParent}, []*MerklePath{merklePath})
+	require.ErrorIs(t, err, ErrMissingAncestor)
+
+	_, err = NewBEEF(tx, []*bt.Tx{unminedParent, minedParent}, nil)
+	require.ErrorIs(t, err, ErrMissingAncestor)
+}
+
+func TestNewBEEFFromBytes(t *testing.T) {
+	coinbase := spendingTx(t)
+	tx := spendingTx(t, coinbase)
+	merklePath, _ := minedTxs(t, 100, tx)
+
+	beef, err := NewBEEF(tx, nil, []*MerklePath{merklePath})
+	require.NoError(t, err)
+	valid := beef.Bytes()
+
+	tt := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "unknown version", b: append([]byte{0x01, 0x00, 0x00, 0x00}, valid[4:]...)},
+		{name: "truncated", b: valid[:len(valid)-1]},
+		{name: "trailing bytes", b: append(append([]byte{}, valid...), 0x00)},
+		{name: "no transactions", b: []byte{0x01, 0x00, 0xbe, 0xef, 0x00, 0x00}},
+		{name: "merkle path index out of range", b: append(append([]byte{}, valid[:len(valid)-1]...), 0x01)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewBEEFFromBytes(tc.b)
+			require.ErrorIs(t, err, ErrInvalidBEEF)
+		})
+	}
+}
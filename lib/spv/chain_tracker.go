@@ -0,0 +1,53 @@
+package spv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+)
+
+// ChainTracker verifies merkle roots against a view of the chain.
+type ChainTracker interface {
+	// IsValidRootForHeight returns whether the merkle root is the merkle root of the block at the height in the longest
+	// chain.
+	IsValidRootForHeight(ctx context.Context, root *chainhash.Hash, height uint64) (bool, error)
+}
+
+// BlockGetter returns blocks by height. It is implemented by the blocktx client.
+type BlockGetter interface {
+	GetBlockByHeight(ctx context.Context, height uint64) (*blocktx_api.Block, error)
+}
+
+// BlockTxChainTracker is a ChainTracker which queries the blocks known to blocktx.
+type BlockTxChainTracker struct {
+	blocks      BlockGetter
+	notFoundErr error
+}
+
+// NewBlockTxChainTracker returns a ChainTracker which queries blocktx. A block which is not found, i.e. the getter
+// returns notFoundErr, results in an invalid merkle root.
+func NewBlockTxChainTracker(blocks BlockGetter, notFoundErr error) *BlockTxChainTracker {
+	return &BlockTxChainTracker{
+		blocks:      blocks,
+		notFoundErr: notFoundErr,
+	}
+}
+
+func (c *BlockTxChainTracker) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash, height uint64) (bool, error) {
+	block, err := c.blocks.GetBlockByHeight(ctx, height)
+	if err != nil {
+		if c.notFoundErr != nil && errors.Is(err, c.notFoundErr) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	merkleRoot, err := chainhash.NewHash(block.GetMerkleRoot())
+	if err != nil {
+		return false, err
+	}
+
+	return merkleRoot.IsEqual(root), nil
+}
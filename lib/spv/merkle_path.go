@@ -0,0 +1,334 @@
+package spv
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+)
+
+const (
+	leafFlagHash      = 0x00
+	leafFlagDuplicate = 0x01
+	leafFlagTxID      = 0x02
+
+	// maxTreeHeight is the height of a merkle tree of 2^64 transactions.
+	maxTreeHeight = 64
+)
+
+var (
+	ErrInvalidMerklePath = errors.New("invalid merkle path")
+	ErrTxIDNotFound      = errors.New("merkle path does not contain the transaction")
+	ErrMissingHash       = errors.New("merkle path does not contain the hashes required to calculate the merkle root")
+)
+
+// Leaf is a hash of a level of the merkle tree at the given offset.
+type Leaf struct {
+	Offset uint64
+	// Hash is nil if the leaf is a duplicate of its sibling.
+	Hash *chainhash.Hash
+	// TxID is set if the hash is the id of a transaction which the merkle path proves.
+	TxID bool
+}
+
+// Duplicate returns whether the leaf is a duplicate of its sibling.
+func (l *Leaf) Duplicate() bool {
+	return l.Hash == nil
+}
+
+// MerklePath is a merkle path in the BSV Unified Merkle Path (BUMP) format specified in BRC-74. It can prove that one
+// or more transactions are included in the block at the given height.
+type MerklePath struct {
+	BlockHeight uint64
+	// Path contains the leaves of each level of the merkle tree starting with the transaction ids.
+	Path [][]*Leaf
+}
+
+// NewMerklePathFromHex parses a merkle path in BUMP hex format.
+func NewMerklePathFromHex(s string) (*MerklePath, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMerklePath, err)
+	}
+
+	return NewMerklePathFromBytes(b)
+}
+
+// NewMerklePathFromBytes parses a merkle path in BUMP binary format.
+func NewMerklePathFromBytes(b []byte) (*MerklePath, error) {
+	reader := bytes.NewReader(b)
+
+	mp, err := readMerklePath(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d unexpected trailing bytes", ErrInvalidMerklePath, reader.Len())
+	}
+
+	return mp, nil
+}
+
+func readMerklePath(reader io.Reader) (*MerklePath, error) {
+	var blockHeight bt.VarInt
+	if _, err := blockHeight.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("%w: failed to read block height: %v", ErrInvalidMerklePath, err)
+	}
+
+	treeHeight := make([]byte, 1)
+	if _, err := io.ReadFull(reader, treeHeight); err != nil {
+		return nil, fmt.Errorf("%w: failed to read tree height: %v", ErrInvalidMerklePath, err)
+	}
+
+	if treeHeight[0] == 0 || treeHeight[0] > maxTreeHeight {
+		return nil, fmt.Errorf("%w: tree height %d out of range", ErrInvalidMerklePath, treeHeight[0])
+	}
+
+	mp := &MerklePath{
+		BlockHeight: uint64(blockHeight),
+		Path:        make([][]*Leaf, treeHeight[0]),
+	}
+
+	for level := range mp.Path {
+		var nLeaves bt.VarInt
+		if _, err := nLeaves.ReadFrom(reader); err != nil {
+			return nil, fmt.Errorf("%w: failed to read number of leaves at level %d: %v", ErrInvalidMerklePath, level, err)
+		}
+
+		if nLeaves == 0 {
+			return nil, fmt.Errorf("%w: no leaves at level %d", ErrInvalidMerklePath, level)
+		}
+
+		// each leaf consists of at least 2 bytes, therefore the number of leaves is not used as capacity to not allocate
+		// memory for a forged number of leaves
+		for i := uint64(0); i < uint64(nLeaves); i++ {
+			leaf, err := readLeaf(reader)
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to read leaf %d at level %d: %v", ErrInvalidMerklePath, i, level, err)
+			}
+
+			mp.Path[level] = append(mp.Path[level], leaf)
+		}
+	}
+
+	return mp, nil
+}
+
+func readLeaf(reader io.Reader) (*Leaf, error) {
+	var offset bt.VarInt
+	if _, err := offset.ReadFrom(reader); err != nil {
+		return nil, err
+	}
+
+	flags := make([]byte, 1)
+	if _, err := io.ReadFull(reader, flags); err != nil {
+		return nil, err
+	}
+
+	leaf := &Leaf{Offset: uint64(offset)}
+
+	switch flags[0] {
+	case leafFlagDuplicate:
+		return leaf, nil
+	case leafFlagHash, leafFlagTxID:
+		leaf.TxID = flags[0] == leafFlagTxID
+	default:
+		return nil, fmt.Errorf("unknown flags %d", flags[0])
+	}
+
+	leaf.Hash = &chainhash.Hash{}
+	if _, err := io.ReadFull(reader, leaf.Hash[:]); err != nil {
+		return nil, err
+	}
+
+	return leaf, nil
+}
+
+// Bytes returns the merkle path in BUMP binary format.
+func (mp *MerklePath) Bytes() []byte {
+	b := bt.VarInt(mp.BlockHeight).Bytes()
+	b = append(b, byte(len(mp.Path)))
+
+	for _, leaves := range mp.Path {
+		b = append(b, bt.VarInt(len(leaves)).Bytes()...)
+
+		for _, leaf := range leaves {
+			b = append(b, bt.VarInt(leaf.Offset).Bytes()...)
+
+			switch {
+			case leaf.Duplicate():
+				b = append(b, leafFlagDuplicate)
+				continue
+			case leaf.TxID:
+				b = append(b, leafFlagTxID)
+			default:
+				b = append(b, leafFlagHash)
+			}
+
+			b = append(b, leaf.Hash[:]...)
+		}
+	}
+
+	return b
+}
+
+// Hex returns the merkle path in BUMP hex format.
+func (mp *MerklePath) Hex() string {
+	return hex.EncodeToString(mp.Bytes())
+}
+
+// TxIDs returns the ids of the transactions which are proven by the merkle path.
+func (mp *MerklePath) TxIDs() []*chainhash.Hash {
+	if len(mp.Path) == 0 {
+		return nil
+	}
+
+	txIDs := make([]*chainhash.Hash, 0)
+	for _, leaf := range mp.Path[0] {
+		if leaf.TxID {
+			txIDs = append(txIDs, leaf.Hash)
+		}
+	}
+
+	return txIDs
+}
+
+// Contains returns whether the lowest level of the merkle path contains the transaction id.
+func (mp *MerklePath) Contains(txID *chainhash.Hash) bool {
+	_, found := mp.txIndex(txID)
+	return found
+}
+
+// ComputeRoot returns the merkle root which results from the merkle path of the transaction.
+func (mp *MerklePath) ComputeRoot(txID *chainhash.Hash) (*chainhash.Hash, error) {
+	index, found := mp.txIndex(txID)
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrTxIDNotFound, txID.String())
+	}
+
+	// the merkle root of a block with a single transaction is the transaction id
+	if len(mp.Path) == 1 && len(mp.Path[0]) == 1 {
+		root := *txID
+		return &root, nil
+	}
+
+	working := *txID
+	for level := range mp.Path {
+		offset := index >> level
+
+		sibling, err := mp.hashAt(level, offset^1)
+		if err != nil {
+			return nil, err
+		}
+
+		// the last hash of a level with an odd number of hashes is paired with itself
+		if sibling == nil {
+			sibling = &working
+		}
+
+		if offset%2 == 0 {
+			working = hashPair(&working, sibling)
+		} else {
+			working = hashPair(sibling, &working)
+		}
+	}
+
+	return &working, nil
+}
+
+// Verify returns whether the merkle path proves that the transaction is included in a block of the chain.
+func (mp *MerklePath) Verify(ctx context.Context, txID *chainhash.Hash, chainTracker ChainTracker) (bool, error) {
+	root, err := mp.ComputeRoot(txID)
+	if err != nil {
+		return false, err
+	}
+
+	return chainTracker.IsValidRootForHeight(ctx, root, mp.BlockHeight)
+}
+
+func (mp *MerklePath) txIndex(txID *chainhash.Hash) (uint64, bool) {
+	if len(mp.Path) == 0 {
+		return 0, false
+	}
+
+	for _, leaf := range mp.Path[0] {
+		if leaf.Hash != nil && leaf.Hash.IsEqual(txID) {
+			return leaf.Offset, true
+		}
+	}
+
+	return 0, false
+}
+
+// hashAt returns the hash at the offset of the level. The hash is calculated from the lower levels if it is not part of
+// the level, which is the case for merkle paths of multiple transactions. It returns nil if the hash is a duplicate.
+func (mp *MerklePath) hashAt(level int, offset uint64) (*chainhash.Hash, error) {
+	for _, leaf := range mp.Path[level] {
+		if leaf.Offset == offset {
+			return leaf.Hash, nil
+		}
+	}
+
+	if level == 0 {
+		return nil, fmt.Errorf("%w: offset %d at level %d", ErrMissingHash, offset, level)
+	}
+
+	left, err := mp.hashAt(level-1, offset*2)
+	if err != nil || left == nil {
+		return nil, fmt.Errorf("%w: offset %d at level %d", ErrMissingHash, offset, level)
+	}
+
+	right, err := mp.hashAt(level-1, offset*2+1)
+	if err != nil {
+		return nil, err
+	}
+
+	if right == nil {
+		right = left
+	}
+
+	hash := hashPair(left, right)
+	return &hash, nil
+}
+
+func hashPair(left *chainhash.Hash, right *chainhash.Hash) chainhash.Hash {
+	var b [chainhash.HashSize * 2]byte
+	copy(b[:chainhash.HashSize], left[:])
+	copy(b[chainhash.HashSize:], right[:])
+
+	return chainhash.DoubleHashH(b[:])
+}
+
+// MerkleRoot returns the merkle root of the transaction ids of a block.
+func MerkleRoot(txIDs []*chainhash.Hash) (*chainhash.Hash, error) {
+	if len(txIDs) == 0 {
+		return nil, errors.New("no transaction ids")
+	}
+
+	level := make([]chainhash.Hash, len(txIDs))
+	for i, txID := range txIDs {
+		level[i] = *txID
+	}
+
+	for len(level) > 1 {
+		// the last hash of a level with an odd number of hashes is paired with itself
+		if len(level)%2 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+
+		parents := make([]chainhash.Hash, len(level)/2)
+		for i := range parents {
+			parents[i] = hashPair(&level[2*i], &level[2*i+1])
+		}
+
+		level = parents
+	}
+
+	return &level[0], nil
+}
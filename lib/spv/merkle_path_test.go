@@ -0,0 +1,194 @@
+package spv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func syntheticTxIDs(count int) []*chainhash.Hash {
+	txIDs := make([]*chainhash.Hash, count)
+	for i := range txIDs {
+		hash := chainhash.DoubleHashH([]byte{byte(i), byte(i >> 8)})
+		txIDs[i] = &hash
+	}
+
+	return txIDs
+}
+
+func TestMerkleRoot(t *testing.T) {
+	for _, count := range []int{1, 2, 3, 5, 8, 13, 100} {
+		txIDs := syntheticTxIDs(count)
+
+		fullTree := bc.BuildMerkleTreeStoreChainHash(txIDs)
+		expectedRoot := fullTree[len(fullTree)-1]
+
+		root, err := MerkleRoot(txIDs)
+		require.NoError(t, err)
+		require.Equal(t, expectedRoot.String(), root.String())
+	}
+
+	_, err := MerkleRoot(nil)
+	require.Error(t, err)
+}
+
+func TestMerklePath(t *testing.T) {
+	tt := []struct {
+		name    string
+		txCount int
+		txIndex uint64
+	}{
+		{name: "single transaction", txCount: 1, txIndex: 0},
+		{name: "first transaction", txCount: 7, txIndex: 0},
+		{name: "duplicated last transaction", txCount: 7, txIndex: 6},
+		{name: "transaction in the middle", txCount: 100, txIndex: 57},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			txIDs := syntheticTxIDs(tc.txCount)
+			fullTree := bc.BuildMerkleTreeStoreChainHash(txIDs)
+			expectedRoot := fullTree[len(fullTree)-1]
+
+			bump, err := bc.NewBUMPFromMerkleTreeAndIndex(826481, fullTree, tc.txIndex)
+			require.NoError(t, err)
+			bumpHex, err := bump.String()
+			require.NoError(t, err)
+
+			merklePath, err := NewMerklePathFromHex(bumpHex)
+			require.NoError(t, err)
+			require.Equal(t, uint64(826481), merklePath.BlockHeight)
+			require.Equal(t, bumpHex, merklePath.Hex())
+			require.True(t, merklePath.Contains(txIDs[tc.txIndex]))
+
+			root, err := merklePath.ComputeRoot(txIDs[tc.txIndex])
+			require.NoError(t, err)
+			require.Equal(t, expectedRoot.String(), root.String())
+
+			other := chainhash.DoubleHashH([]byte("other"))
+			_, err = merklePath.ComputeRoot(&other)
+			require.ErrorIs(t, err, ErrTxIDNotFound)
+		})
+	}
+}
+
+func TestMerklePathMultipleTransactions(t *testing.T) {
+	txIDs := syntheticTxIDs(11)
+	fullTree := bc.BuildMerkleTreeStoreChainHash(txIDs)
+	expectedRoot := fullTree[len(fullTree)-1]
+
+	// the merkle path of the transactions 2 and 3 contains the hash of their parent only implicitly
+	hash := func(h *chainhash.Hash) *chainhash.Hash {
+		c := *h
+		return &c
+	}
+	merklePath := &MerklePath{
+		BlockHeight: 100,
+		Path: [][]*Leaf{
+			{{Offset: 2, Hash: hash(txIDs[2]), TxID: true}, {Offset: 3, Hash: hash(txIDs[3]), TxID: true}},
+			{{Offset: 0, Hash: fullTree[16]}},
+			{{Offset: 1, Hash: fullTree[16+8+1]}},
+			{{Offset: 1, Hash: fullTree[16+8+4+1]}},
+		},
+	}
+
+	for _, txID := range txIDs[2:4] {
+		root, err := merklePath.ComputeRoot(txID)
+		require.NoError(t, err)
+		require.Equal(t, expectedRoot.String(), root.String())
+	}
+
+	require.Len(t, merklePath.TxIDs(), 2)
+
+	parsed, err := NewMerklePathFromBytes(merklePath.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, merklePath, parsed)
+
+	// the hash at offset 0 of the first level cannot be calculated from the lowest level
+	merklePath.Path[1] = nil
+	_, err = merklePath.ComputeRoot(txIDs[2])
+	require.ErrorIs(t, err, ErrMissingHash)
+}
+
+func TestNewMerklePathFromHex(t *testing.T) {
+	tt := []struct {
+		name string
+		hex  string
+	}{
+		{name: "invalid hex", hex: "xyz"},
+		{name: "empty", hex: ""},
+		{name: "zero tree height", hex: "fe716c0c0000"},
+		{name: "missing leaves", hex: "fe716c0c0001"},
+		{name: "truncated hash", hex: "fe716c0c00010100020102"},
+		{name: "unknown flags", hex: "fe716c0c0001010007"},
+		{name: "trailing bytes", hex: "fe716c0c000101000100"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewMerklePathFromHex(tc.hex)
+			require.ErrorIs(t, err, ErrInvalidMerklePath)
+		})
+	}
+}
+
+type blockGetter func(ctx context.Context, height uint64) (*blocktx_api.Block, error)
+
+func (f blockGetter) GetBlockByHeight(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+	return f(ctx, height)
+}
+
+func TestBlockTxChainTracker(t *testing.T) {
+	errNotFound := errors.New("block not found")
+	errFailed := errors.New("failed")
+	root := chainhash.DoubleHashH([]byte("root"))
+	otherRoot := chainhash.DoubleHashH([]byte("other root"))
+
+	tt := []struct {
+		name     string
+		block    *blocktx_api.Block
+		blockErr error
+
+		expectedValid bool
+		expectedErr   error
+	}{
+		{
+			name:  "valid root",
+			block: &blocktx_api.Block{MerkleRoot: root[:]},
+
+			expectedValid: true,
+		},
+		{
+			name:  "invalid root",
+			block: &blocktx_api.Block{MerkleRoot: otherRoot[:]},
+		},
+		{
+			name:     "block not found",
+			blockErr: errNotFound,
+		},
+		{
+			name:     "failed to get block",
+			blockErr: errFailed,
+
+			expectedErr: errFailed,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			chainTracker := NewBlockTxChainTracker(blockGetter(func(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+				require.Equal(t, uint64(100), height)
+				return tc.block, tc.blockErr
+			}), errNotFound)
+
+			valid, err := chainTracker.IsValidRootForHeight(context.Background(), &root, 100)
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Equal(t, tc.expectedValid, valid)
+		})
+	}
+}
@@ -52,6 +52,7 @@ func run() error {
 	useTracer := flag.Bool("tracer", false, "start tracer")
 	help := flag.Bool("help", false, "Show help")
 	config := flag.String("config", ".", "path to configuration yaml file")
+	checkConfig := flag.Bool("check-config", false, "check the configuration and exit")
 
 	flag.Parse()
 
@@ -83,12 +84,17 @@ func run() error {
 		fmt.Println("    -config=/location")
 		fmt.Println("          directory to look for config.yaml (default='')")
 		fmt.Println("")
+		fmt.Println("    -check-config")
+		fmt.Println("          check the configuration including environment overrides, report all problems and exit")
+		fmt.Println("")
+		fmt.Println("the configuration is reloaded when config.yaml changes or on SIGHUP")
+		fmt.Println("")
 		return nil
 	}
 
 	viper.AddConfigPath(*config) // optionally look for config in the working directory
 	viper.AutomaticEnv()         // read in environment variables that match
-	viper.SetEnvPrefix("ARC")
+	viper.SetEnvPrefix(cfg.EnvPrefix)
 	replacer := strings.NewReplacer(".", "_")
 	viper.SetEnvKeyReplacer(replacer)
 
@@ -97,6 +103,10 @@ func run() error {
 		return fmt.Errorf("failed to read config file: %v", err)
 	}
 
+	if checkConfig != nil && *checkConfig {
+		return checkConfiguration(viper.GetViper())
+	}
+
 	logger, err := cfg.NewLogger()
 	if err != nil {
 		return fmt.Errorf("failed to create logger: %v", err)
@@ -111,6 +121,8 @@ func run() error {
 
 	logger.Info("starting arc", slog.String("version", version), slog.String("commit", commit))
 
+	reloader := cfg.NewReloader(logger, viper.GetViper())
+
 	go func() {
 		profilerAddr := viper.GetString("profilerAddr")
 		if profilerAddr != "" {
@@ -194,7 +206,7 @@ func run() error {
 
 	if startCallbacker != nil && *startCallbacker {
 		logger.Info("Starting Callbacker")
-		shutdown, err := cmd.StartCallbacker(logger)
+		shutdown, err := cmd.StartCallbacker(logger, reloader)
 		if err != nil {
 			return fmt.Errorf("failed to start callbacker: %v", err)
 		}
@@ -203,7 +215,7 @@ func run() error {
 
 	if startMetamorph != nil && *startMetamorph {
 		logger.Info("Starting Metamorph")
-		shutdown, err := cmd.StartMetamorph(logger)
+		shutdown, err := cmd.StartMetamorph(logger, reloader)
 		if err != nil {
 			return fmt.Errorf("failed to start metamorph: %v", err)
 		}
@@ -212,7 +224,7 @@ func run() error {
 
 	if startApi != nil && *startApi {
 		logger.Info("Starting API")
-		shutdown, err := cmd.StartAPIServer(logger)
+		shutdown, err := cmd.StartAPIServer(logger, reloader)
 		if err != nil {
 			return fmt.Errorf("failed to start api: %v", err)
 		}
@@ -238,16 +250,53 @@ func run() error {
 		shutdownFns = append(shutdownFns, func() { shutdown() })
 	}
 
+	reloader.Watch()
+
 	// setup signal catching
 	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, syscall.SIGTERM)
+	signal.Notify(signalChan, syscall.SIGTERM, syscall.SIGHUP)
 
-	<-signalChan
+	for sig := range signalChan {
+		if sig == syscall.SIGHUP {
+			logger.Info("Reloading configuration")
+			reloader.ReloadFile()
+			continue
+		}
+
+		break
+	}
 	appCleanup(logger, shutdownFns)
 
 	return nil
 }
 
+// checkConfiguration prints the environment overrides and all problems of the configuration. An error is returned if
+// there are problems.
+func checkConfiguration(v *viper.Viper) error {
+	result := cfg.Check(v)
+
+	fmt.Printf("configuration file: %s\n", v.ConfigFileUsed())
+
+	if len(result.EnvOverrides) > 0 {
+		fmt.Println("environment overrides:")
+		for _, override := range result.EnvOverrides {
+			fmt.Printf("    %s from %s\n", override.Key, override.Variable)
+		}
+	}
+
+	if len(result.Problems) == 0 {
+		fmt.Println("configuration is valid")
+		return nil
+	}
+
+	fmt.Println("problems:")
+	for _, problem := range result.Problems {
+		fmt.Printf("    %s\n", problem)
+	}
+
+	return fmt.Errorf("configuration has %d problems", len(result.Problems))
+}
+
 func appCleanup(logger *slog.Logger, shutdownFns []func()) {
 	logger.Info("Shutting down")
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/bitcoin-sv/arc/api"
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/bitcoin-sv/arc/metamorph/store"
 	"github.com/ordishs/go-utils"
 )
@@ -19,7 +20,19 @@ const (
 	CallbackIntervalSeconds = 5
 )
 
-func SendCallback(logger *slog.Logger, tx *store.StoreData) {
+// SendCallback sends the current status of the transaction to its callback URL. The Merkle path and the competing
+// transactions are only included in the payload if they are not empty. Callbacks to URLs which are not allowed by
+// the policy are not sent.
+func SendCallback(logger *slog.Logger, policy *callbackpolicy.Policy, tx *store.StoreData, merklePath string, competingTxs []string) {
+	err := policy.Validate(tx.CallbackUrl)
+	if err != nil {
+		logger.Error("Callback URL not allowed", slog.String("url", tx.CallbackUrl), slog.String("hash", tx.Hash.String()), slog.String("err", err.Error()))
+		return
+	}
+
+	// the client checks the IP addresses the callback URL resolves to and redirects against the policy
+	httpClient := policy.HTTPClient(5 * time.Second)
+
 	sleepDuration := CallbackIntervalSeconds
 	for i := 0; i < CallbackTries; i++ {
 		statusString := tx.Status.String()
@@ -30,13 +43,7 @@ func SendCallback(logger *slog.Logger, tx *store.StoreData) {
 
 		logger.Info("Sending callback for transaction", slog.String("hash", tx.Hash.String()), slog.String("url", tx.CallbackUrl), slog.String("token", tx.CallbackToken), slog.String("status", statusString), slog.Uint64("block height", tx.BlockHeight), slog.String("block hash", blockHash))
 
-		status := &api.TransactionStatus{
-			BlockHash:   &blockHash,
-			BlockHeight: &tx.BlockHeight,
-			TxStatus:    &statusString,
-			Txid:        tx.Hash.String(),
-			Timestamp:   time.Now(),
-		}
+		status := newCallbackPayload(tx, statusString, blockHash, merklePath, competingTxs)
 		statusBytes, err := json.Marshal(status)
 		if err != nil {
 			logger.Error("Couldn't marshal status", slog.String("err", err.Error()))
@@ -54,11 +61,6 @@ func SendCallback(logger *slog.Logger, tx *store.StoreData) {
 			request.Header.Set("Authorization", "Bearer "+tx.CallbackToken)
 		}
 
-		// default http client
-		httpClient := http.Client{
-			Timeout: 5 * time.Second,
-		}
-
 		var response *http.Response
 		response, err = httpClient.Do(request)
 		if err != nil {
@@ -87,3 +89,25 @@ func SendCallback(logger *slog.Logger, tx *store.StoreData) {
 
 	logger.Error("Couldn't send transaction info through callback url after tries", slog.String("url", tx.CallbackUrl), slog.String("token", tx.CallbackToken), slog.String("hash", tx.Hash.String()), slog.Int("retries", CallbackTries))
 }
+
+func newCallbackPayload(tx *store.StoreData, statusString string, blockHash string, merklePath string, competingTxs []string) *api.TransactionCallback {
+	status := &api.TransactionCallback{
+		Version:     api.CallbackPayloadVersion,
+		BlockHash:   &blockHash,
+		BlockHeight: &tx.BlockHeight,
+		TxStatus:    &statusString,
+		Txid:        tx.Hash.String(),
+		Timestamp:   time.Now(),
+	}
+	if merklePath != "" {
+		status.MerklePath = &merklePath
+	}
+	if tx.RejectReason != "" {
+		status.ExtraInfo = &tx.RejectReason
+	}
+	if len(competingTxs) > 0 {
+		status.CompetingTxs = &competingTxs
+	}
+
+	return status
+}
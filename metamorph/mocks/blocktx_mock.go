@@ -20,6 +20,24 @@ var _ blocktx.ClientI = &ClientIMock{}
 //
 //		// make and configure a mocked blocktx.ClientI
 //		mockedClientI := &ClientIMock{
+//			GetBlockFunc: func(ctx context.Context, hash []byte) (*blocktx_api.Block, error) {
+//				panic("mock out the GetBlock method")
+//			},
+//			GetBlockByHeightFunc: func(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+//				panic("mock out the GetBlockByHeight method")
+//			},
+//			GetBlockStatsFunc: func(ctx context.Context, hash []byte) (*blocktx_api.BlockStats, error) {
+//				panic("mock out the GetBlockStats method")
+//			},
+//			GetBlockTransactionsFunc: func(ctx context.Context, hash []byte, offset uint64, limit uint64) (*blocktx_api.BlockTransactions, error) {
+//				panic("mock out the GetBlockTransactions method")
+//			},
+//			GetChainTipFunc: func(ctx context.Context) (*blocktx_api.Block, error) {
+//				panic("mock out the GetChainTip method")
+//			},
+//			GetConfirmationsFunc: func(ctx context.Context, hash []byte) (*blocktx_api.Confirmations, error) {
+//				panic("mock out the GetConfirmations method")
+//			},
 //			GetTransactionBlocksFunc: func(ctx context.Context, transaction *blocktx_api.Transactions) (*blocktx_api.TransactionBlocks, error) {
 //				panic("mock out the GetTransactionBlocks method")
 //			},
@@ -32,6 +50,9 @@ var _ blocktx.ClientI = &ClientIMock{}
 //			RegisterTransactionFunc: func(ctx context.Context, transaction *blocktx_api.TransactionAndSource) error {
 //				panic("mock out the RegisterTransaction method")
 //			},
+//			VerifyMerklePathFunc: func(ctx context.Context, merklePath string, hash []byte) (*blocktx_api.MerklePathVerification, error) {
+//				panic("mock out the VerifyMerklePath method")
+//			},
 //		}
 //
 //		// use mockedClientI in code that requires blocktx.ClientI
@@ -39,6 +60,24 @@ var _ blocktx.ClientI = &ClientIMock{}
 //
 //	}
 type ClientIMock struct {
+	// GetBlockFunc mocks the GetBlock method.
+	GetBlockFunc func(ctx context.Context, hash []byte) (*blocktx_api.Block, error)
+
+	// GetBlockByHeightFunc mocks the GetBlockByHeight method.
+	GetBlockByHeightFunc func(ctx context.Context, height uint64) (*blocktx_api.Block, error)
+
+	// GetBlockStatsFunc mocks the GetBlockStats method.
+	GetBlockStatsFunc func(ctx context.Context, hash []byte) (*blocktx_api.BlockStats, error)
+
+	// GetBlockTransactionsFunc mocks the GetBlockTransactions method.
+	GetBlockTransactionsFunc func(ctx context.Context, hash []byte, offset uint64, limit uint64) (*blocktx_api.BlockTransactions, error)
+
+	// GetChainTipFunc mocks the GetChainTip method.
+	GetChainTipFunc func(ctx context.Context) (*blocktx_api.Block, error)
+
+	// GetConfirmationsFunc mocks the GetConfirmations method.
+	GetConfirmationsFunc func(ctx context.Context, hash []byte) (*blocktx_api.Confirmations, error)
+
 	// GetTransactionBlocksFunc mocks the GetTransactionBlocks method.
 	GetTransactionBlocksFunc func(ctx context.Context, transaction *blocktx_api.Transactions) (*blocktx_api.TransactionBlocks, error)
 
@@ -51,8 +90,55 @@ type ClientIMock struct {
 	// RegisterTransactionFunc mocks the RegisterTransaction method.
 	RegisterTransactionFunc func(ctx context.Context, transaction *blocktx_api.TransactionAndSource) error
 
+	// VerifyMerklePathFunc mocks the VerifyMerklePath method.
+	VerifyMerklePathFunc func(ctx context.Context, merklePath string, hash []byte) (*blocktx_api.MerklePathVerification, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetBlock holds details about calls to the GetBlock method.
+		GetBlock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash []byte
+		}
+		// GetBlockByHeight holds details about calls to the GetBlockByHeight method.
+		GetBlockByHeight []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Height is the height argument value.
+			Height uint64
+		}
+		// GetBlockStats holds details about calls to the GetBlockStats method.
+		GetBlockStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash []byte
+		}
+		// GetBlockTransactions holds details about calls to the GetBlockTransactions method.
+		GetBlockTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash []byte
+			// Offset is the offset argument value.
+			Offset uint64
+			// Limit is the limit argument value.
+			Limit uint64
+		}
+		// GetChainTip holds details about calls to the GetChainTip method.
+		GetChainTip []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetConfirmations holds details about calls to the GetConfirmations method.
+		GetConfirmations []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash []byte
+		}
 		// GetTransactionBlocks holds details about calls to the GetTransactionBlocks method.
 		GetTransactionBlocks []struct {
 			// Ctx is the ctx argument value.
@@ -79,11 +165,247 @@ type ClientIMock struct {
 			// Transaction is the transaction argument value.
 			Transaction *blocktx_api.TransactionAndSource
 		}
+		// VerifyMerklePath holds details about calls to the VerifyMerklePath method.
+		VerifyMerklePath []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MerklePath is the merklePath argument value.
+			MerklePath string
+			// Hash is the hash argument value.
+			Hash []byte
+		}
 	}
+	lockGetBlock                 sync.RWMutex
+	lockGetBlockByHeight         sync.RWMutex
+	lockGetBlockStats            sync.RWMutex
+	lockGetBlockTransactions     sync.RWMutex
+	lockGetChainTip              sync.RWMutex
+	lockGetConfirmations         sync.RWMutex
 	lockGetTransactionBlocks     sync.RWMutex
 	lockGetTransactionMerklePath sync.RWMutex
 	lockHealth                   sync.RWMutex
 	lockRegisterTransaction      sync.RWMutex
+	lockVerifyMerklePath         sync.RWMutex
+}
+
+// GetBlock calls GetBlockFunc.
+func (mock *ClientIMock) GetBlock(ctx context.Context, hash []byte) (*blocktx_api.Block, error) {
+	if mock.GetBlockFunc == nil {
+		panic("ClientIMock.GetBlockFunc: method is nil but ClientI.GetBlock was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash []byte
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockGetBlock.Lock()
+	mock.calls.GetBlock = append(mock.calls.GetBlock, callInfo)
+	mock.lockGetBlock.Unlock()
+	return mock.GetBlockFunc(ctx, hash)
+}
+
+// GetBlockCalls gets all the calls that were made to GetBlock.
+// Check the length with:
+//
+//	len(mockedClientI.GetBlockCalls())
+func (mock *ClientIMock) GetBlockCalls() []struct {
+	Ctx  context.Context
+	Hash []byte
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash []byte
+	}
+	mock.lockGetBlock.RLock()
+	calls = mock.calls.GetBlock
+	mock.lockGetBlock.RUnlock()
+	return calls
+}
+
+// GetBlockByHeight calls GetBlockByHeightFunc.
+func (mock *ClientIMock) GetBlockByHeight(ctx context.Context, height uint64) (*blocktx_api.Block, error) {
+	if mock.GetBlockByHeightFunc == nil {
+		panic("ClientIMock.GetBlockByHeightFunc: method is nil but ClientI.GetBlockByHeight was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Height uint64
+	}{
+		Ctx:    ctx,
+		Height: height,
+	}
+	mock.lockGetBlockByHeight.Lock()
+	mock.calls.GetBlockByHeight = append(mock.calls.GetBlockByHeight, callInfo)
+	mock.lockGetBlockByHeight.Unlock()
+	return mock.GetBlockByHeightFunc(ctx, height)
+}
+
+// GetBlockByHeightCalls gets all the calls that were made to GetBlockByHeight.
+// Check the length with:
+//
+//	len(mockedClientI.GetBlockByHeightCalls())
+func (mock *ClientIMock) GetBlockByHeightCalls() []struct {
+	Ctx    context.Context
+	Height uint64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Height uint64
+	}
+	mock.lockGetBlockByHeight.RLock()
+	calls = mock.calls.GetBlockByHeight
+	mock.lockGetBlockByHeight.RUnlock()
+	return calls
+}
+
+// GetBlockStats calls GetBlockStatsFunc.
+func (mock *ClientIMock) GetBlockStats(ctx context.Context, hash []byte) (*blocktx_api.BlockStats, error) {
+	if mock.GetBlockStatsFunc == nil {
+		panic("ClientIMock.GetBlockStatsFunc: method is nil but ClientI.GetBlockStats was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash []byte
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockGetBlockStats.Lock()
+	mock.calls.GetBlockStats = append(mock.calls.GetBlockStats, callInfo)
+	mock.lockGetBlockStats.Unlock()
+	return mock.GetBlockStatsFunc(ctx, hash)
+}
+
+// GetBlockStatsCalls gets all the calls that were made to GetBlockStats.
+// Check the length with:
+//
+//	len(mockedClientI.GetBlockStatsCalls())
+func (mock *ClientIMock) GetBlockStatsCalls() []struct {
+	Ctx  context.Context
+	Hash []byte
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash []byte
+	}
+	mock.lockGetBlockStats.RLock()
+	calls = mock.calls.GetBlockStats
+	mock.lockGetBlockStats.RUnlock()
+	return calls
+}
+
+// GetBlockTransactions calls GetBlockTransactionsFunc.
+func (mock *ClientIMock) GetBlockTransactions(ctx context.Context, hash []byte, offset uint64, limit uint64) (*blocktx_api.BlockTransactions, error) {
+	if mock.GetBlockTransactionsFunc == nil {
+		panic("ClientIMock.GetBlockTransactionsFunc: method is nil but ClientI.GetBlockTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Hash   []byte
+		Offset uint64
+		Limit  uint64
+	}{
+		Ctx:    ctx,
+		Hash:   hash,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetBlockTransactions.Lock()
+	mock.calls.GetBlockTransactions = append(mock.calls.GetBlockTransactions, callInfo)
+	mock.lockGetBlockTransactions.Unlock()
+	return mock.GetBlockTransactionsFunc(ctx, hash, offset, limit)
+}
+
+// GetBlockTransactionsCalls gets all the calls that were made to GetBlockTransactions.
+// Check the length with:
+//
+//	len(mockedClientI.GetBlockTransactionsCalls())
+func (mock *ClientIMock) GetBlockTransactionsCalls() []struct {
+	Ctx    context.Context
+	Hash   []byte
+	Offset uint64
+	Limit  uint64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Hash   []byte
+		Offset uint64
+		Limit  uint64
+	}
+	mock.lockGetBlockTransactions.RLock()
+	calls = mock.calls.GetBlockTransactions
+	mock.lockGetBlockTransactions.RUnlock()
+	return calls
+}
+
+// GetChainTip calls GetChainTipFunc.
+func (mock *ClientIMock) GetChainTip(ctx context.Context) (*blocktx_api.Block, error) {
+	if mock.GetChainTipFunc == nil {
+		panic("ClientIMock.GetChainTipFunc: method is nil but ClientI.GetChainTip was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetChainTip.Lock()
+	mock.calls.GetChainTip = append(mock.calls.GetChainTip, callInfo)
+	mock.lockGetChainTip.Unlock()
+	return mock.GetChainTipFunc(ctx)
+}
+
+// GetChainTipCalls gets all the calls that were made to GetChainTip.
+// Check the length with:
+//
+//	len(mockedClientI.GetChainTipCalls())
+func (mock *ClientIMock) GetChainTipCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetChainTip.RLock()
+	calls = mock.calls.GetChainTip
+	mock.lockGetChainTip.RUnlock()
+	return calls
+}
+
+// GetConfirmations calls GetConfirmationsFunc.
+func (mock *ClientIMock) GetConfirmations(ctx context.Context, hash []byte) (*blocktx_api.Confirmations, error) {
+	if mock.GetConfirmationsFunc == nil {
+		panic("ClientIMock.GetConfirmationsFunc: method is nil but ClientI.GetConfirmations was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash []byte
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockGetConfirmations.Lock()
+	mock.calls.GetConfirmations = append(mock.calls.GetConfirmations, callInfo)
+	mock.lockGetConfirmations.Unlock()
+	return mock.GetConfirmationsFunc(ctx, hash)
+}
+
+// GetConfirmationsCalls gets all the calls that were made to GetConfirmations.
+// Check the length with:
+//
+//	len(mockedClientI.GetConfirmationsCalls())
+func (mock *ClientIMock) GetConfirmationsCalls() []struct {
+	Ctx  context.Context
+	Hash []byte
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash []byte
+	}
+	mock.lockGetConfirmations.RLock()
+	calls = mock.calls.GetConfirmations
+	mock.lockGetConfirmations.RUnlock()
+	return calls
 }
 
 // GetTransactionBlocks calls GetTransactionBlocksFunc.
@@ -225,3 +547,43 @@ func (mock *ClientIMock) RegisterTransactionCalls() []struct {
 	mock.lockRegisterTransaction.RUnlock()
 	return calls
 }
+
+// VerifyMerklePath calls VerifyMerklePathFunc.
+func (mock *ClientIMock) VerifyMerklePath(ctx context.Context, merklePath string, hash []byte) (*blocktx_api.MerklePathVerification, error) {
+	if mock.VerifyMerklePathFunc == nil {
+		panic("ClientIMock.VerifyMerklePathFunc: method is nil but ClientI.VerifyMerklePath was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		MerklePath string
+		Hash       []byte
+	}{
+		Ctx:        ctx,
+		MerklePath: merklePath,
+		Hash:       hash,
+	}
+	mock.lockVerifyMerklePath.Lock()
+	mock.calls.VerifyMerklePath = append(mock.calls.VerifyMerklePath, callInfo)
+	mock.lockVerifyMerklePath.Unlock()
+	return mock.VerifyMerklePathFunc(ctx, merklePath, hash)
+}
+
+// VerifyMerklePathCalls gets all the calls that were made to VerifyMerklePath.
+// Check the length with:
+//
+//	len(mockedClientI.VerifyMerklePathCalls())
+func (mock *ClientIMock) VerifyMerklePathCalls() []struct {
+	Ctx        context.Context
+	MerklePath string
+	Hash       []byte
+} {
+	var calls []struct {
+		Ctx        context.Context
+		MerklePath string
+		Hash       []byte
+	}
+	mock.lockVerifyMerklePath.RLock()
+	calls = mock.calls.VerifyMerklePath
+	mock.lockVerifyMerklePath.RUnlock()
+	return calls
+}
@@ -36,7 +36,7 @@ var _ metamorph.ProcessorI = &ProcessorIMock{}
 //			ProcessTransactionFunc: func(ctx context.Context, req *metamorph.ProcessorRequest)  {
 //				panic("mock out the ProcessTransaction method")
 //			},
-//			SendStatusForTransactionFunc: func(hash *chainhash.Hash, status metamorph_api.Status, id string, err error) (bool, error) {
+//			SendStatusForTransactionFunc: func(hash *chainhash.Hash, status metamorph_api.Status, id string, err error, competingTxs []string) (bool, error) {
 //				panic("mock out the SendStatusForTransaction method")
 //			},
 //			SendStatusMinedForTransactionFunc: func(hash *chainhash.Hash, blockHash *chainhash.Hash, blockHeight uint64) (bool, error) {
@@ -68,7 +68,7 @@ type ProcessorIMock struct {
 	ProcessTransactionFunc func(ctx context.Context, req *metamorph.ProcessorRequest)
 
 	// SendStatusForTransactionFunc mocks the SendStatusForTransaction method.
-	SendStatusForTransactionFunc func(hash *chainhash.Hash, status metamorph_api.Status, id string, err error) (bool, error)
+	SendStatusForTransactionFunc func(hash *chainhash.Hash, status metamorph_api.Status, id string, err error, competingTxs []string) (bool, error)
 
 	// SendStatusMinedForTransactionFunc mocks the SendStatusMinedForTransaction method.
 	SendStatusMinedForTransactionFunc func(hash *chainhash.Hash, blockHash *chainhash.Hash, blockHeight uint64) (bool, error)
@@ -109,6 +109,8 @@ type ProcessorIMock struct {
 			ID string
 			// Err is the err argument value.
 			Err error
+			// CompetingTxs is the competingTxs argument value.
+			CompetingTxs []string
 		}
 		// SendStatusMinedForTransaction holds details about calls to the SendStatusMinedForTransaction method.
 		SendStatusMinedForTransaction []struct {
@@ -283,25 +285,27 @@ func (mock *ProcessorIMock) ProcessTransactionCalls() []struct {
 }
 
 // SendStatusForTransaction calls SendStatusForTransactionFunc.
-func (mock *ProcessorIMock) SendStatusForTransaction(hash *chainhash.Hash, status metamorph_api.Status, id string, err error) (bool, error) {
+func (mock *ProcessorIMock) SendStatusForTransaction(hash *chainhash.Hash, status metamorph_api.Status, id string, err error, competingTxs []string) (bool, error) {
 	if mock.SendStatusForTransactionFunc == nil {
 		panic("ProcessorIMock.SendStatusForTransactionFunc: method is nil but ProcessorI.SendStatusForTransaction was just called")
 	}
 	callInfo := struct {
-		Hash   *chainhash.Hash
-		Status metamorph_api.Status
-		ID     string
-		Err    error
+		Hash         *chainhash.Hash
+		Status       metamorph_api.Status
+		ID           string
+		Err          error
+		CompetingTxs []string
 	}{
-		Hash:   hash,
-		Status: status,
-		ID:     id,
-		Err:    err,
+		Hash:         hash,
+		Status:       status,
+		ID:           id,
+		Err:          err,
+		CompetingTxs: competingTxs,
 	}
 	mock.lockSendStatusForTransaction.Lock()
 	mock.calls.SendStatusForTransaction = append(mock.calls.SendStatusForTransaction, callInfo)
 	mock.lockSendStatusForTransaction.Unlock()
-	return mock.SendStatusForTransactionFunc(hash, status, id, err)
+	return mock.SendStatusForTransactionFunc(hash, status, id, err, competingTxs)
 }
 
 // SendStatusForTransactionCalls gets all the calls that were made to SendStatusForTransaction.
@@ -309,16 +313,18 @@ func (mock *ProcessorIMock) SendStatusForTransaction(hash *chainhash.Hash, statu
 //
 //	len(mockedProcessorI.SendStatusForTransactionCalls())
 func (mock *ProcessorIMock) SendStatusForTransactionCalls() []struct {
-	Hash   *chainhash.Hash
-	Status metamorph_api.Status
-	ID     string
-	Err    error
+	Hash         *chainhash.Hash
+	Status       metamorph_api.Status
+	ID           string
+	Err          error
+	CompetingTxs []string
 } {
 	var calls []struct {
-		Hash   *chainhash.Hash
-		Status metamorph_api.Status
-		ID     string
-		Err    error
+		Hash         *chainhash.Hash
+		Status       metamorph_api.Status
+		ID           string
+		Err          error
+		CompetingTxs []string
 	}
 	mock.lockSendStatusForTransaction.RLock()
 	calls = mock.calls.SendStatusForTransaction
@@ -12,6 +12,7 @@ import (
 
 	"github.com/bitcoin-sv/arc/blocktx"
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
 	"github.com/bitcoin-sv/arc/metamorph/processor_response"
 	"github.com/bitcoin-sv/arc/metamorph/store"
@@ -29,7 +30,7 @@ const (
 	MaxRetries = 15
 	// length of interval for checking transactions if they are seen on the network
 	// if not we resend them again for a few times
-	unseenTransactionRebroadcastingInterval = 60
+	rebroadcastIntervalDefault = 1 * time.Minute
 
 	processCheckIfMinedIntervalDefault = 1 * time.Minute
 
@@ -53,6 +54,7 @@ type Processor struct {
 	ProcessorResponseMap *ProcessorResponseMap
 	pm                   p2p.PeerManagerI
 	btc                  blocktx.ClientI
+	callbackPolicy       atomic.Pointer[callbackpolicy.Policy]
 	logger               *slog.Logger
 	mapExpiryTime        time.Duration
 	dataRetentionPeriod  time.Duration
@@ -61,9 +63,10 @@ type Processor struct {
 	processCheckIfMinedInterval time.Duration
 	processCheckIfMinedTicker   *time.Ticker
 
+	rebroadcastInterval     atomic.Int64
 	processExpiredTxsTicker *time.Ticker
 
-	maxMonitoredTxs int64
+	maxMonitoredTxs atomic.Int64
 
 	startTime          time.Time
 	queueLength        atomic.Int32
@@ -91,19 +94,16 @@ func NewProcessor(s store.MetamorphStore, pm p2p.PeerManagerI, btc blocktx.Clien
 	}
 
 	p := &Processor{
-		startTime:               time.Now().UTC(),
-		store:                   s,
-		pm:                      pm,
-		btc:                     btc,
-		dataRetentionPeriod:     dataRetentionPeriodDefault,
-		mapExpiryTime:           mapExpiryTimeDefault,
-		now:                     time.Now,
-		processExpiredTxsTicker: time.NewTicker(unseenTransactionRebroadcastingInterval * time.Second),
+		startTime:           time.Now().UTC(),
+		store:               s,
+		pm:                  pm,
+		btc:                 btc,
+		dataRetentionPeriod: dataRetentionPeriodDefault,
+		mapExpiryTime:       mapExpiryTimeDefault,
+		now:                 time.Now,
 
 		processCheckIfMinedInterval: processCheckIfMinedIntervalDefault,
 
-		maxMonitoredTxs: maxMonitoriedTxs,
-
 		stored:             stat.NewAtomicStat(),
 		announcedToNetwork: stat.NewAtomicStats(),
 		requestedByNetwork: stat.NewAtomicStats(),
@@ -115,6 +115,9 @@ func NewProcessor(s store.MetamorphStore, pm p2p.PeerManagerI, btc blocktx.Clien
 		retries:            stat.NewAtomicStat(),
 	}
 
+	p.maxMonitoredTxs.Store(maxMonitoriedTxs)
+	p.rebroadcastInterval.Store(int64(rebroadcastIntervalDefault))
+
 	p.logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: LogLevelDefault})).With(slog.String("service", "mtm"))
 
 	// apply options to processor
@@ -124,6 +127,9 @@ func NewProcessor(s store.MetamorphStore, pm p2p.PeerManagerI, btc blocktx.Clien
 
 	p.ProcessorResponseMap = NewProcessorResponseMap(p.mapExpiryTime, WithNowResponseMap(p.now))
 	p.processCheckIfMinedTicker = time.NewTicker(p.processCheckIfMinedInterval)
+	if p.processExpiredTxsTicker == nil {
+		p.processExpiredTxsTicker = time.NewTicker(time.Duration(p.rebroadcastInterval.Load()))
+	}
 
 	p.logger.Info("Starting processor", slog.Duration("cacheExpiryTime", p.mapExpiryTime))
 
@@ -153,6 +159,28 @@ func (p *Processor) Shutdown() {
 	p.ProcessorResponseMap.Close()
 }
 
+// SetCallbackPolicy replaces the policy enforced when sending callbacks.
+func (p *Processor) SetCallbackPolicy(policy *callbackpolicy.Policy) {
+	p.callbackPolicy.Store(policy)
+}
+
+// SetMaxMonitoredTxs changes the maximum number of transactions which are monitored by the processor.
+func (p *Processor) SetMaxMonitoredTxs(m int64) {
+	p.maxMonitoredTxs.Store(m)
+}
+
+// SetProcessCheckIfMinedInterval changes the interval in which the processor checks whether transactions have been mined.
+func (p *Processor) SetProcessCheckIfMinedInterval(d time.Duration) {
+	p.processCheckIfMinedTicker.Reset(d)
+}
+
+// SetRebroadcastInterval changes the interval in which transactions which have not been seen on the network are
+// announced again.
+func (p *Processor) SetRebroadcastInterval(d time.Duration) {
+	p.rebroadcastInterval.Store(int64(d))
+	p.processExpiredTxsTicker.Reset(d)
+}
+
 func (p *Processor) unlockItems() error {
 	items := p.ProcessorResponseMap.Items()
 	hashes := make([]*chainhash.Hash, len(items))
@@ -237,7 +265,7 @@ func (p *Processor) checkIfMined(transactions *blocktx_api.Transactions) {
 func (p *Processor) processExpiredTransactions() {
 	// filterFunc returns true if the transaction has not been seen on the network
 	filterFunc := func(procResp *processor_response.ProcessorResponse) bool {
-		return (procResp.GetStatus() < metamorph_api.Status_SEEN_ON_NETWORK || procResp.GetStatus() == metamorph_api.Status_SEEN_IN_ORPHAN_MEMPOOL) && p.now().Sub(procResp.Start) > unseenTransactionRebroadcastingInterval*time.Second
+		return (procResp.GetStatus() < metamorph_api.Status_SEEN_ON_NETWORK || procResp.GetStatus() == metamorph_api.Status_SEEN_IN_ORPHAN_MEMPOOL) && p.now().Sub(procResp.Start) > time.Duration(p.rebroadcastInterval.Load())
 	}
 
 	// Resend transactions that have not been seen on the network
@@ -297,7 +325,7 @@ func (p *Processor) LoadUnmined() {
 	defer span.Finish()
 
 	limit := loadUnminedLimit
-	margin := p.maxMonitoredTxs - int64(len(p.ProcessorResponseMap.Items()))
+	margin := p.maxMonitoredTxs.Load() - int64(len(p.ProcessorResponseMap.Items()))
 
 	if margin < limit {
 		limit = margin
@@ -366,7 +394,7 @@ func (p *Processor) SendStatusMinedForTransaction(hash *chainhash.Hash, blockHas
 
 			data, _ := p.store.Get(spanCtx, hash[:])
 			if data.CallbackUrl != "" {
-				go SendCallback(p.logger, data)
+				go p.sendCallback(data, nil)
 			}
 		},
 	})
@@ -374,6 +402,24 @@ func (p *Processor) SendStatusMinedForTransaction(hash *chainhash.Hash, blockHas
 	return true, nil
 }
 
+// sendCallback sends the callback for the transaction. If a Merkle proof was requested for a mined transaction,
+// the Merkle path is requested from blocktx and included in the callback.
+func (p *Processor) sendCallback(data *store.StoreData, competingTxs []string) {
+	merklePath := ""
+	if data.MerkleProof && data.Status == metamorph_api.Status_MINED && p.btc != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), blocktxTimeout)
+		defer cancel()
+
+		var err error
+		merklePath, err = p.btc.GetTransactionMerklePath(ctx, &blocktx_api.Transaction{Hash: data.Hash[:]})
+		if err != nil {
+			p.logger.Error("failed to get Merkle path for callback", slog.String("hash", data.Hash.String()), slog.String("err", err.Error()))
+		}
+	}
+
+	SendCallback(p.logger, p.callbackPolicy.Load(), data, merklePath, competingTxs)
+}
+
 var statusValueMap = map[metamorph_api.Status]int{
 	metamorph_api.Status_UNKNOWN:                0,
 	metamorph_api.Status_QUEUED:                 1,
@@ -390,7 +436,7 @@ var statusValueMap = map[metamorph_api.Status]int{
 	metamorph_api.Status_CONFIRMED:              12,
 }
 
-func (p *Processor) SendStatusForTransaction(hash *chainhash.Hash, status metamorph_api.Status, source string, statusErr error) (bool, error) {
+func (p *Processor) SendStatusForTransaction(hash *chainhash.Hash, status metamorph_api.Status, source string, statusErr error, competingTxs []string) (bool, error) {
 	processorResponse, ok := p.ProcessorResponseMap.Get(hash)
 	if !ok {
 		return false, nil
@@ -441,14 +487,14 @@ func (p *Processor) SendStatusForTransaction(hash *chainhash.Hash, status metamo
 			case metamorph_api.Status_SEEN_IN_ORPHAN_MEMPOOL:
 				data, _ := p.store.Get(spanCtx, hash[:])
 				if data.CallbackUrl != "" && data.FullStatusUpdates {
-					go SendCallback(p.logger, data)
+					go p.sendCallback(data, nil)
 				}
 
 			case metamorph_api.Status_SEEN_ON_NETWORK:
 				p.seenOnNetwork.AddDuration(source, time.Since(processorResponse.Start))
 				data, _ := p.store.Get(spanCtx, hash[:])
 				if data.CallbackUrl != "" && data.FullStatusUpdates {
-					go SendCallback(p.logger, data)
+					go p.sendCallback(data, nil)
 				}
 
 			case metamorph_api.Status_MINED:
@@ -461,7 +507,7 @@ func (p *Processor) SendStatusForTransaction(hash *chainhash.Hash, status metamo
 				p.rejected.AddDuration(source, time.Since(processorResponse.Start))
 				data, _ := p.store.Get(spanCtx, hash[:])
 				if data.CallbackUrl != "" {
-					go SendCallback(p.logger, data)
+					go p.sendCallback(data, competingTxs)
 				}
 			}
 		},
@@ -3,6 +3,8 @@ package metamorph
 import (
 	"log/slog"
 	"time"
+
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 )
 
 func WithProcessCheckIfMinedInterval(d time.Duration) func(*Processor) {
@@ -35,6 +37,14 @@ func WithProcessExpiredTxsInterval(d time.Duration) func(*Processor) {
 	}
 }
 
+// WithRebroadcastInterval sets the interval in which transactions which have not been seen on the network are announced
+// again.
+func WithRebroadcastInterval(d time.Duration) func(*Processor) {
+	return func(p *Processor) {
+		p.rebroadcastInterval.Store(int64(d))
+	}
+}
+
 func WithDataRetentionPeriod(d time.Duration) func(*Processor) {
 	return func(p *Processor) {
 		p.dataRetentionPeriod = d
@@ -43,6 +53,13 @@ func WithDataRetentionPeriod(d time.Duration) func(*Processor) {
 
 func WithMaxMonitoredTxs(m int64) func(processor *Processor) {
 	return func(p *Processor) {
-		p.maxMonitoredTxs = m
+		p.maxMonitoredTxs.Store(m)
+	}
+}
+
+// WithCallbackPolicy enforces the policy when sending callbacks.
+func WithCallbackPolicy(policy *callbackpolicy.Policy) func(processor *Processor) {
+	return func(p *Processor) {
+		p.callbackPolicy.Store(policy)
 	}
 }
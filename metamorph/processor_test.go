@@ -2,14 +2,17 @@ package metamorph_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/bitcoin-sv/arc/api"
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
 	. "github.com/bitcoin-sv/arc/metamorph"
 	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
@@ -19,6 +22,7 @@ import (
 	"github.com/bitcoin-sv/arc/metamorph/store/badger"
 	"github.com/bitcoin-sv/arc/metamorph/store/sqlite"
 	"github.com/bitcoin-sv/arc/testdata"
+	"github.com/jarcoal/httpmock"
 	"github.com/labstack/gommon/random"
 	"github.com/libsv/go-bt/v2"
 	"github.com/libsv/go-p2p"
@@ -491,7 +495,7 @@ func TestSendStatusForTransaction(t *testing.T) {
 				processor.ProcessorResponseMap.Set(tc.txResponseHash, tc.txResponseHashValue)
 			}
 
-			statusUpdated, sendErr := processor.SendStatusForTransaction(testdata.TX1Hash, tc.updateStatus, "test", tc.statusErr)
+			statusUpdated, sendErr := processor.SendStatusForTransaction(testdata.TX1Hash, tc.updateStatus, "test", tc.statusErr, nil)
 			assert.NoError(t, sendErr)
 			assert.Equal(t, tc.expectedStatusUpdated, statusUpdated)
 
@@ -548,6 +552,64 @@ func TestSendStatusMinedForTransaction(t *testing.T) {
 		assert.Equal(t, metamorph_api.Status_MINED, txStored.Status)
 	})
 
+	t.Run("SendStatusMinedForTransaction known tx - callback with merkle path", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		callbackCh := make(chan *api.TransactionCallback, 1)
+		httpmock.RegisterResponder("POST", "https://test.com", func(req *http.Request) (*http.Response, error) {
+			callback := &api.TransactionCallback{}
+			err := json.NewDecoder(req.Body).Decode(callback)
+			require.NoError(t, err)
+			callbackCh <- callback
+
+			return httpmock.NewStringResponse(http.StatusOK, "OK"), nil
+		})
+
+		s, err := sqlite.New(true, "")
+		require.NoError(t, err)
+		err = s.Set(context.Background(), testdata.TX1Hash[:], &store.StoreData{
+			Hash:        testdata.TX1Hash,
+			Status:      metamorph_api.Status_SEEN_ON_NETWORK,
+			MerkleProof: true,
+			CallbackUrl: "https://test.com",
+		})
+		require.NoError(t, err)
+
+		pm := p2p.NewPeerManagerMock()
+
+		btc := &ClientIMock{
+			GetTransactionMerklePathFunc: func(ctx context.Context, transaction *blocktx_api.Transaction) (string, error) {
+				require.Equal(t, testdata.TX1Hash[:], transaction.GetHash())
+				return "fe54251800020400028d97f9ebeddd9f9aa8e0e953b3a76f316298ab05e9834aa811716e9d397564e501", nil
+			},
+		}
+
+		processor, err := NewProcessor(s, pm, btc)
+		require.NoError(t, err)
+		processor.ProcessorResponseMap.Set(testdata.TX1Hash, processor_response.NewProcessorResponseWithStatus(
+			testdata.TX1Hash,
+			metamorph_api.Status_SEEN_ON_NETWORK,
+		))
+
+		ok, sendErr := processor.SendStatusMinedForTransaction(testdata.TX1Hash, testdata.Block1Hash, 1233)
+		assert.True(t, ok)
+		assert.NoError(t, sendErr)
+
+		select {
+		case callback := <-callbackCh:
+			assert.Equal(t, api.CallbackPayloadVersion, callback.Version)
+			assert.Equal(t, testdata.TX1Hash.String(), callback.Txid)
+			assert.Equal(t, metamorph_api.Status_MINED.String(), *callback.TxStatus)
+			assert.Equal(t, uint64(1233), *callback.BlockHeight)
+			require.NotNil(t, callback.MerklePath)
+			assert.Equal(t, "fe54251800020400028d97f9ebeddd9f9aa8e0e953b3a76f316298ab05e9834aa811716e9d397564e501", *callback.MerklePath)
+			assert.Nil(t, callback.CompetingTxs)
+		case <-time.After(time.Second):
+			t.Fatal("callback was not sent")
+		}
+	})
+
 	t.Run("SendStatusForTransaction known tx - processed", func(t *testing.T) {
 		s, err := sqlite.New(true, "")
 		require.NoError(t, err)
@@ -832,6 +894,70 @@ func TestProcessExpiredTransactions(t *testing.T) {
 	}
 }
 
+func TestProcessExpiredTransactionsRebroadcastInterval(t *testing.T) {
+	tt := []struct {
+		name                   string
+		rebroadcastInterval    time.Duration
+		setRebroadcastInterval time.Duration
+
+		expectedAnnounced bool
+	}{
+		{
+			name:                "default interval",
+			rebroadcastInterval: 0,
+
+			expectedAnnounced: true,
+		},
+		{
+			name:                "not expired",
+			rebroadcastInterval: time.Hour,
+
+			expectedAnnounced: false,
+		},
+		{
+			name:                   "interval changed",
+			rebroadcastInterval:    time.Hour,
+			setRebroadcastInterval: 20 * time.Millisecond,
+
+			expectedAnnounced: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			metamorphStore := &MetamorphStoreMock{
+				SetUnlockedFunc: func(ctx context.Context, hashes []*chainhash.Hash) error { return nil },
+			}
+			pm := p2p.NewPeerManagerMock()
+
+			opts := []Option{
+				WithProcessCheckIfMinedInterval(time.Hour),
+				WithProcessExpiredTxsInterval(20 * time.Millisecond),
+				WithNow(func() time.Time {
+					return time.Now().Add(2 * time.Minute)
+				}),
+			}
+			if tc.rebroadcastInterval > 0 {
+				opts = append(opts, WithRebroadcastInterval(tc.rebroadcastInterval))
+			}
+
+			processor, err := NewProcessor(metamorphStore, pm, nil, opts...)
+			require.NoError(t, err)
+			defer processor.Shutdown()
+
+			if tc.setRebroadcastInterval > 0 {
+				processor.SetRebroadcastInterval(tc.setRebroadcastInterval)
+			}
+
+			processor.ProcessorResponseMap.Set(testdata.TX1Hash, processor_response.NewProcessorResponseWithStatus(testdata.TX1Hash, metamorph_api.Status_ANNOUNCED_TO_NETWORK))
+
+			time.Sleep(50 * time.Millisecond)
+
+			require.Equal(t, tc.expectedAnnounced, len(pm.AnnouncedTransactions) > 0)
+		})
+	}
+}
+
 func TestProcessorHealth(t *testing.T) {
 	tt := []struct {
 		name       string
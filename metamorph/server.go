@@ -10,11 +10,13 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bitcoin-sv/arc/api/handler"
 	"github.com/bitcoin-sv/arc/blocktx"
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
 	"github.com/bitcoin-sv/arc/metamorph/processor_response"
 	"github.com/bitcoin-sv/arc/metamorph/store"
@@ -49,7 +51,7 @@ type BitcoinNode interface {
 type ProcessorI interface {
 	LoadUnmined()
 	ProcessTransaction(ctx context.Context, req *ProcessorRequest)
-	SendStatusForTransaction(hash *chainhash.Hash, status metamorph_api.Status, id string, err error) (bool, error)
+	SendStatusForTransaction(hash *chainhash.Hash, status metamorph_api.Status, id string, err error, competingTxs []string) (bool, error)
 	SendStatusMinedForTransaction(hash *chainhash.Hash, blockHash *chainhash.Hash, blockHeight uint64) (bool, error)
 	GetStats(debugItems bool) *ProcessorStats
 	GetPeers() ([]string, []string)
@@ -69,6 +71,7 @@ type Server struct {
 	bitcoinNode     BitcoinNode
 	forceCheckUtxos bool
 	blocktxTimeout  time.Duration
+	callbackPolicy  atomic.Pointer[callbackpolicy.Policy]
 }
 
 func WithBlocktxTimeout(d time.Duration) func(*Server) {
@@ -77,6 +80,13 @@ func WithBlocktxTimeout(d time.Duration) func(*Server) {
 	}
 }
 
+// WithServerCallbackPolicy rejects transactions with callback URLs which are not allowed by the policy.
+func WithServerCallbackPolicy(policy *callbackpolicy.Policy) func(*Server) {
+	return func(s *Server) {
+		s.callbackPolicy.Store(policy)
+	}
+}
+
 func WithLogger(logger *slog.Logger) func(*Server) {
 	return func(s *Server) {
 		s.logger = logger
@@ -191,6 +201,25 @@ func ValidateCallbackURL(callbackURL string) error {
 	return nil
 }
 
+// SetCallbackPolicy replaces the policy which callback URLs of transactions have to comply with.
+func (s *Server) SetCallbackPolicy(policy *callbackpolicy.Policy) {
+	s.callbackPolicy.Store(policy)
+}
+
+func (s *Server) validateCallbackURL(callbackURL string) error {
+	err := ValidateCallbackURL(callbackURL)
+	if err != nil || callbackURL == "" {
+		return err
+	}
+
+	err = s.callbackPolicy.Load().Validate(callbackURL)
+	if err != nil {
+		return fmt.Errorf("callback URL not allowed [%w]", err)
+	}
+
+	return nil
+}
+
 func (s *Server) PutTransaction(ctx context.Context, req *metamorph_api.TransactionRequest) (*metamorph_api.TransactionStatus, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "Server:PutTransaction")
 	defer span.Finish()
@@ -200,7 +229,7 @@ func (s *Server) PutTransaction(ctx context.Context, req *metamorph_api.Transact
 		gocore.NewStat("PutTransaction").AddTime(start)
 	}()
 
-	err := ValidateCallbackURL(req.GetCallbackUrl())
+	err := s.validateCallbackURL(req.GetCallbackUrl())
 	if err != nil {
 		return nil, err
 	}
@@ -251,7 +280,7 @@ func (s *Server) PutTransactions(ctx context.Context, req *metamorph_api.Transac
 	var timeout int64
 
 	for ind, txReq := range req.GetTransactions() {
-		err := ValidateCallbackURL(txReq.GetCallbackUrl())
+		err := s.validateCallbackURL(txReq.GetCallbackUrl())
 		if err != nil {
 			return nil, err
 		}
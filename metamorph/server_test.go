@@ -11,6 +11,7 @@ import (
 
 	"github.com/bitcoin-sv/arc/blocktx"
 	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/lib/callbackpolicy"
 	. "github.com/bitcoin-sv/arc/metamorph"
 	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
 	. "github.com/bitcoin-sv/arc/metamorph/mocks"
@@ -151,6 +152,20 @@ func TestPutTransaction(t *testing.T) {
 		assert.ErrorContains(t, err, "invalid URL [parse \"api.callback.com\": invalid URI for request]")
 	})
 
+	t.Run("callback URL not allowed", func(t *testing.T) {
+		policy, err := callbackpolicy.New(callbackpolicy.WithBlockPrivateIPs(true))
+		require.NoError(t, err)
+
+		server := NewServer(nil, nil, nil, WithServerCallbackPolicy(policy))
+
+		txRequest := &metamorph_api.TransactionRequest{
+			CallbackUrl: "http://10.0.0.1:8080/callback",
+		}
+
+		_, err = server.PutTransaction(context.Background(), txRequest)
+		assert.ErrorIs(t, err, callbackpolicy.ErrIPNotAllowed)
+	})
+
 	t.Run("PutTransaction - SEEN to network", func(t *testing.T) {
 		s, err := sqlite.New(true, "")
 		require.NoError(t, err)
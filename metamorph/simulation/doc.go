@@ -0,0 +1,7 @@
+// Package simulation contains the tests which run Metamorph together with BlockTx against the simulated network of
+// lib/p2psim.
+//
+// The tests are kept in their own package for the same reason as the tests of blocktx/simulation: the peers of go-p2p
+// cannot be stopped and read the global message handlers and limits of the wire package, which every new peer handler
+// of BlockTx overrides.
+package simulation
@@ -0,0 +1,148 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/arc/blocktx"
+	"github.com/bitcoin-sv/arc/blocktx/blocktx_api"
+	"github.com/bitcoin-sv/arc/lib/p2psim"
+	"github.com/bitcoin-sv/arc/metamorph"
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/bitcoin-sv/arc/metamorph/store/sqlite"
+	"github.com/libsv/go-p2p"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/libsv/go-p2p/wire"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startBlockTx starts the gRPC server of BlockTx on an in-memory listener and returns a client connected to it.
+func startBlockTx(t *testing.T, server *blocktx.Server) blocktx.ClientI {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	blocktx_api.RegisterBlockTxAPIServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return blocktx.NewClient(blocktx_api.NewBlockTxAPIClient(conn))
+}
+
+func TestSimulatedNetwork(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	// the network is not closed, because peers of go-p2p race when they are disconnected
+	network, err := p2psim.NewNetwork(wire.TestNet)
+	require.NoError(t, err)
+
+	// a node does not announce a transaction to the peer it received the transaction from, therefore Metamorph sees
+	// its transactions through the second node
+	nodes := make([]*p2psim.Node, 2)
+	for i, address := range []string{"node1:18333", "node2:18333"} {
+		nodes[i], err = network.AddNode(address)
+		require.NoError(t, err)
+	}
+
+	blocktxStore, err := blocktx.NewStore("sqlite_memory")
+	require.NoError(t, err)
+	defer blocktxStore.Close()
+
+	peerHandler, err := blocktx.NewPeerHandler(logger, blocktxStore, 0, []string{nodes[0].Address()}, wire.TestNet, blocktx.WithPeerOptions(p2p.WithDialer(network.Dial)))
+	require.NoError(t, err)
+	defer peerHandler.Shutdown()
+
+	btc := startBlockTx(t, blocktx.NewServer(blocktxStore, logger))
+
+	metamorphStore, err := sqlite.New(true, "")
+	require.NoError(t, err)
+	defer metamorphStore.Close(context.Background())
+
+	messageCh := make(chan *metamorph.PeerTxMessage, 100)
+	pm := p2p.NewPeerManager(logger, wire.TestNet)
+	metamorphPeerHandler := metamorph.NewPeerHandler(metamorphStore, messageCh)
+	for _, node := range nodes {
+		peer, err := p2p.NewPeer(logger, node.Address(), metamorphPeerHandler, wire.TestNet, p2p.WithDialer(network.Dial))
+		require.NoError(t, err)
+		require.NoError(t, pm.AddPeer(peer))
+	}
+
+	processor, err := metamorph.NewProcessor(metamorphStore, pm, btc,
+		metamorph.WithProcessorLogger(logger),
+		metamorph.WithProcessCheckIfMinedInterval(50*time.Millisecond),
+	)
+	require.NoError(t, err)
+	defer processor.Shutdown()
+
+	go func() {
+		for message := range messageCh {
+			_, _ = processor.SendStatusForTransaction(message.Hash, message.Status, message.Peer, message.Err, message.CompetingTxs)
+		}
+	}()
+
+	server := metamorph.NewServer(metamorphStore, processor, btc, metamorph.WithLogger(logger))
+
+	require.Eventually(t, func() bool {
+		return nodes[0].Peers() == 2 && nodes[1].Peers() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), []byte{0x51}))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+	txHash := tx.TxHash()
+
+	var rawTx bytes.Buffer
+	require.NoError(t, tx.Serialize(&rawTx))
+
+	status, err := server.PutTransaction(context.Background(), &metamorph_api.TransactionRequest{
+		RawTx:         rawTx.Bytes(),
+		WaitForStatus: metamorph_api.Status_SEEN_ON_NETWORK,
+		MaxTimeout:    5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, metamorph_api.Status_SEEN_ON_NETWORK, status.GetStatus())
+	require.Equal(t, txHash.String(), status.GetTxid())
+
+	_, found := network.Transaction(txHash)
+	require.True(t, found)
+
+	// the transaction is registered in BlockTx asynchronously
+	require.Eventually(t, func() bool {
+		registered, err := blocktxStore.GetRegisteredTransactions(context.Background(), 0, 10)
+		return err == nil && len(registered) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	block, err := network.MineBlock(tx)
+	require.NoError(t, err)
+	blockHash := block.BlockHash()
+
+	require.Eventually(t, func() bool {
+		stored, err := metamorphStore.Get(context.Background(), txHash[:])
+		return err == nil && stored.Status == metamorph_api.Status_MINED
+	}, 10*time.Second, 50*time.Millisecond)
+
+	stored, err := metamorphStore.Get(context.Background(), txHash[:])
+	require.NoError(t, err)
+	require.Equal(t, blockHash, *stored.BlockHash)
+	require.Equal(t, uint64(1), stored.BlockHeight)
+}
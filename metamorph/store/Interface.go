@@ -222,6 +222,18 @@ type MetamorphStore interface {
 	Ping(ctx context.Context) error
 }
 
+// IterateFunc is called for each transaction of the store. Returning an error stops the iteration.
+type IterateFunc func(data *StoreData) error
+
+// Iterator is implemented by the stores whose transactions can be iterated, e.g. in order to dump them or to copy them
+// to another store.
+type Iterator interface {
+	// Iterate calls fn for all transactions in the order of the store, starting after the transaction with hash after.
+	// All transactions are iterated if after is nil. The order is the same for every iteration, so that an
+	// iteration can be continued after the last transaction which has been processed.
+	Iterate(ctx context.Context, after *chainhash.Hash, fn IterateFunc) error
+}
+
 func encodeTime(buf *bytes.Buffer, tm time.Time) error {
 	if tm.IsZero() {
 		return binary.Write(buf, binary.BigEndian, int64(0))
@@ -94,11 +94,8 @@ func (s *Badger) Close(ctx context.Context) error {
 	span, _ := opentracing.StartSpanFromContext(ctx, "badger:Close")
 	defer span.Finish()
 
-	metrics := s.store.BlockCacheMetrics()
-	fmt.Printf("metrics: %+v", metrics)
-
-	metrics2 := s.store.IndexCacheMetrics()
-	fmt.Printf("metrics2: %+v", metrics2)
+	s.logger.Debugf("block cache metrics: %+v", s.store.BlockCacheMetrics())
+	s.logger.Debugf("index cache metrics: %+v", s.store.IndexCacheMetrics())
 
 	return s.store.Close()
 }
@@ -339,6 +336,61 @@ func (s *Badger) GetUnmined(ctx context.Context, since time.Time, limit int64) (
 	return data, nil
 }
 
+// Iterate calls fn for all transactions ordered by their hash, starting after the given hash.
+func (s *Badger) Iterate(ctx context.Context, after *chainhash.Hash, fn store.IterateFunc) error {
+	start := gocore.CurrentNanos()
+	defer func() {
+		gocore.NewStat("mtm_store_badger").NewStat("Iterate").AddTime(start)
+	}()
+	span, _ := opentracing.StartSpanFromContext(ctx, "badger:Iterate")
+	defer span.Finish()
+
+	return s.store.View(func(tx *badger.Txn) error {
+		iter := tx.NewIterator(badger.DefaultIteratorOptions)
+		defer iter.Close()
+
+		iter.Rewind()
+		if after != nil {
+			iter.Seek(after[:])
+			if iter.Valid() && bytes.Equal(iter.Item().Key(), after[:]) {
+				iter.Next()
+			}
+		}
+
+		for ; iter.Valid(); iter.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			item := iter.Item()
+			if strings.HasPrefix(string(item.Key()), "block_processed_") {
+				continue
+			}
+			if item.IsDeletedOrExpired() {
+				continue
+			}
+
+			var result *store.StoreData
+			err := item.Value(func(val []byte) error {
+				var err2 error
+				result, err2 = store.DecodeFromBytes(val)
+				return err2
+			})
+			if err != nil {
+				span.SetTag(string(ext.Error), true)
+				span.LogFields(log.Error(err))
+				return fmt.Errorf("failed to decode data for %x: %w", item.Key(), err)
+			}
+
+			if err = fn(result); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 func (s *Badger) Del(ctx context.Context, hash []byte) error {
 	start := gocore.CurrentNanos()
 	defer func() {
@@ -106,6 +106,13 @@ func TestGetUnseen(t *testing.T) {
 	})
 }
 
+func TestIterate(t *testing.T) {
+	bh, tearDown := setupSuite(t)
+	defer tearDown(t)
+
+	tests.Iterate(t, bh)
+}
+
 func TestUpdateMined(t *testing.T) {
 	t.Run("update mined - not found", func(t *testing.T) {
 		bh, tearDown := setupSuite(t)
@@ -454,6 +454,58 @@ func (ddb *DynamoDB) GetUnmined(ctx context.Context, since time.Time, limit int6
 	return data, nil
 }
 
+// Iterate calls fn for all transactions in the order of a scan of the transactions table, starting after the given
+// hash.
+func (ddb *DynamoDB) Iterate(ctx context.Context, after *chainhash.Hash, fn store.IterateFunc) error {
+	// setup log and tracing
+	startNanos := ddb.now().UnixNano()
+	defer func() {
+		gocore.NewStat("mtm_store_dynamodb").NewStat("Iterate").AddTime(startNanos)
+	}()
+	span, ctx := opentracing.StartSpanFromContext(ctx, "dynamodb:Iterate")
+	defer span.Finish()
+
+	// a scan continues after the item with the given key
+	var startKey map[string]types.AttributeValue
+	if after != nil {
+		startKey = map[string]types.AttributeValue{
+			"tx_hash": &types.AttributeValueMemberB{Value: after.CloneBytes()},
+		}
+	}
+
+	for {
+		out, err := ddb.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:         aws.String(ddb.transactionsTableName),
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			span.SetTag(string(ext.Error), true)
+			span.LogFields(log.Error(err))
+			return err
+		}
+
+		for _, item := range out.Items {
+			var transaction store.StoreData
+			err = attributevalue.UnmarshalMap(item, &transaction)
+			if err != nil {
+				span.SetTag(string(ext.Error), true)
+				span.LogFields(log.Error(err))
+				return err
+			}
+
+			if err = fn(&transaction); err != nil {
+				return err
+			}
+		}
+
+		if len(out.LastEvaluatedKey) == 0 {
+			return nil
+		}
+
+		startKey = out.LastEvaluatedKey
+	}
+}
+
 func (ddb *DynamoDB) UpdateStatus(ctx context.Context, hash *chainhash.Hash, status metamorph_api.Status, rejectReason string) error {
 	// setup log and tracing
 	startNanos := ddb.now().UnixNano()
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
 	"github.com/bitcoin-sv/arc/metamorph/store"
+	"github.com/bitcoin-sv/arc/metamorph/store/tests"
 	"github.com/libsv/go-bt/v2"
 	"github.com/libsv/go-p2p/chaincfg/chainhash"
 	"github.com/ory/dockertest/v3"
@@ -259,6 +260,10 @@ func TestDynamoDBIntegration(t *testing.T) {
 		require.ErrorIs(t, err, store.ErrNotFound)
 	})
 
+	t.Run("iterate", func(t *testing.T) {
+		tests.Iterate(t, repo)
+	})
+
 	t.Run("blocks - time to live = -1 hour", func(t *testing.T) {
 		repo.ttl = time.Minute * -10
 		err := repo.SetBlockProcessed(ctx, Block1Hash)
@@ -474,6 +474,171 @@ func (p *PostgreSQL) GetUnmined(ctx context.Context, since time.Time, limit int6
 	return unminedTxs, nil
 }
 
+// iterateBatchSize is the number of transactions which are queried at once by Iterate.
+var iterateBatchSize = 1000
+
+// Iterate calls fn for all transactions ordered by their hash, starting after the given hash. The transactions are
+// queried in batches, so that no query is open while fn is called.
+func (p *PostgreSQL) Iterate(ctx context.Context, after *chainhash.Hash, fn store.IterateFunc) error {
+	startNanos := p.now().UnixNano()
+	defer func() {
+		gocore.NewStat("mtm_store_sql").NewStat("Iterate").AddTime(startNanos)
+	}()
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sql:Iterate")
+	defer span.Finish()
+
+	for {
+		batch, err := p.getBatch(ctx, after, iterateBatchSize)
+		if err != nil {
+			span.SetTag(string(ext.Error), true)
+			span.LogFields(log.Error(err))
+			return err
+		}
+
+		for _, data := range batch {
+			if err = fn(data); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) < iterateBatchSize {
+			return nil
+		}
+
+		after = batch[len(batch)-1].Hash
+	}
+}
+
+func (p *PostgreSQL) getBatch(ctx context.Context, after *chainhash.Hash, limit int) ([]*store.StoreData, error) {
+	q := `SELECT
+	     stored_at
+		,announced_at
+		,mined_at
+		,hash
+		,status
+		,block_height
+		,block_hash
+		,callback_url
+		,callback_token
+		,full_status_updates
+		,merkle_proof
+		,reject_reason
+		,raw_tx
+		,locked_by
+		FROM metamorph.transactions
+		WHERE hash > $1
+		ORDER BY hash
+		LIMIT $2;`
+
+	// all hashes are greater than the empty bytea
+	afterHash := []byte{}
+	if after != nil {
+		afterHash = after.CloneBytes()
+	}
+
+	rows, err := p.db.QueryContext(ctx, q, afterHash, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batch := make([]*store.StoreData, 0, limit)
+	for rows.Next() {
+		data := &store.StoreData{}
+
+		var storedAt sql.NullTime
+		var announcedAt sql.NullTime
+		var minedAt sql.NullTime
+		var blockHeight sql.NullInt64
+		var txHash []byte
+		var blockHash []byte
+		var callbackUrl sql.NullString
+		var callbackToken sql.NullString
+		var fullStatusUpdates sql.NullBool
+		var merkleProof sql.NullBool
+		var rejectReason sql.NullString
+		var lockedBy sql.NullString
+		var status sql.NullInt32
+
+		if err = rows.Scan(
+			&storedAt,
+			&announcedAt,
+			&minedAt,
+			&txHash,
+			&status,
+			&blockHeight,
+			&blockHash,
+			&callbackUrl,
+			&callbackToken,
+			&fullStatusUpdates,
+			&merkleProof,
+			&rejectReason,
+			&data.RawTx,
+			&lockedBy,
+		); err != nil {
+			return nil, err
+		}
+
+		if data.Hash, err = chainhash.NewHash(txHash); err != nil {
+			return nil, err
+		}
+
+		if len(blockHash) > 0 {
+			if data.BlockHash, err = chainhash.NewHash(blockHash); err != nil {
+				return nil, err
+			}
+		}
+
+		if storedAt.Valid {
+			data.StoredAt = storedAt.Time.UTC()
+		}
+
+		if announcedAt.Valid {
+			data.AnnouncedAt = announcedAt.Time.UTC()
+		}
+
+		if minedAt.Valid {
+			data.MinedAt = minedAt.Time.UTC()
+		}
+
+		if status.Valid {
+			data.Status = metamorph_api.Status(status.Int32)
+		}
+
+		if blockHeight.Valid {
+			data.BlockHeight = uint64(blockHeight.Int64)
+		}
+
+		if callbackUrl.Valid {
+			data.CallbackUrl = callbackUrl.String
+		}
+
+		if callbackToken.Valid {
+			data.CallbackToken = callbackToken.String
+		}
+
+		if fullStatusUpdates.Valid {
+			data.FullStatusUpdates = fullStatusUpdates.Bool
+		}
+
+		if merkleProof.Valid {
+			data.MerkleProof = merkleProof.Bool
+		}
+
+		if rejectReason.Valid {
+			data.RejectReason = rejectReason.String
+		}
+
+		if lockedBy.Valid {
+			data.LockedBy = lockedBy.String
+		}
+
+		batch = append(batch, data)
+	}
+
+	return batch, rows.Err()
+}
+
 func (p *PostgreSQL) UpdateStatus(ctx context.Context, hash *chainhash.Hash, status metamorph_api.Status, rejectReason string) error {
 	startNanos := p.now().UnixNano()
 	defer func() {
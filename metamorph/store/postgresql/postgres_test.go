@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -339,4 +340,31 @@ func TestPostgresDB(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 7, numberOfRemainingTxs)
 	})
+
+	t.Run("iterate", func(t *testing.T) {
+		// iterate in several batches
+		defaultBatchSize := iterateBatchSize
+		iterateBatchSize = 3
+		defer func() { iterateBatchSize = defaultBatchSize }()
+
+		var hashes []*chainhash.Hash
+		err = postgresDB.Iterate(ctx, nil, func(data *store.StoreData) error {
+			hashes = append(hashes, data.Hash)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, hashes, 7)
+
+		for i := 1; i < len(hashes); i++ {
+			require.Negative(t, bytes.Compare(hashes[i-1][:], hashes[i][:]))
+		}
+
+		var continued []*chainhash.Hash
+		err = postgresDB.Iterate(ctx, hashes[3], func(data *store.StoreData) error {
+			continued = append(continued, data.Hash)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, hashes[4:], continued)
+	})
 }
@@ -448,6 +448,134 @@ func (s *SqLite) GetUnmined(ctx context.Context, since time.Time, limit int64) (
 	return storeData, nil
 }
 
+// iterateBatchSize is the number of transactions which are queried at once by Iterate.
+var iterateBatchSize = 1000
+
+// Iterate calls fn for all transactions ordered by their hash, starting after the given hash. The transactions are
+// queried in batches, so that no query is open while fn is called.
+func (s *SqLite) Iterate(ctx context.Context, after *chainhash.Hash, fn store.IterateFunc) error {
+	startNanos := s.now().UnixNano()
+	defer func() {
+		gocore.NewStat("mtm_store_sql").NewStat("Iterate").AddTime(startNanos)
+	}()
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sql:Iterate")
+	defer span.Finish()
+
+	for {
+		batch, err := s.getBatch(ctx, after, iterateBatchSize)
+		if err != nil {
+			span.SetTag(string(ext.Error), true)
+			span.LogFields(log.Error(err))
+			return err
+		}
+
+		for _, data := range batch {
+			if err = fn(data); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) < iterateBatchSize {
+			return nil
+		}
+
+		after = batch[len(batch)-1].Hash
+	}
+}
+
+func (s *SqLite) getBatch(ctx context.Context, after *chainhash.Hash, limit int) ([]*store.StoreData, error) {
+	q := `SELECT
+	     stored_at
+		,announced_at
+		,mined_at
+		,hash
+		,status
+		,block_height
+		,block_hash
+		,callback_url
+		,callback_token
+		,merkle_proof
+		,reject_reason
+		,raw_tx
+		FROM transactions
+		WHERE hash > $1
+		ORDER BY hash
+		LIMIT $2
+		;`
+
+	// all hashes are greater than the empty blob
+	afterHash := []byte{}
+	if after != nil {
+		afterHash = after.CloneBytes()
+	}
+
+	rows, err := s.db.QueryContext(ctx, q, afterHash, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batch := make([]*store.StoreData, 0, limit)
+	for rows.Next() {
+		data := &store.StoreData{}
+
+		var txHash []byte
+		var blockHash []byte
+		var storedAt string
+		var announcedAt string
+		var minedAt string
+
+		if err = rows.Scan(
+			&storedAt,
+			&announcedAt,
+			&minedAt,
+			&txHash,
+			&data.Status,
+			&data.BlockHeight,
+			&blockHash,
+			&data.CallbackUrl,
+			&data.CallbackToken,
+			&data.MerkleProof,
+			&data.RejectReason,
+			&data.RawTx,
+		); err != nil {
+			return nil, err
+		}
+
+		if data.Hash, err = chainhash.NewHash(txHash); err != nil {
+			return nil, err
+		}
+
+		if blockHash != nil {
+			if data.BlockHash, err = chainhash.NewHash(blockHash); err != nil {
+				return nil, err
+			}
+		}
+
+		if storedAt != "" {
+			if data.StoredAt, err = time.Parse(time.RFC3339, storedAt); err != nil {
+				return nil, err
+			}
+		}
+
+		if announcedAt != "" {
+			if data.AnnouncedAt, err = time.Parse(time.RFC3339, announcedAt); err != nil {
+				return nil, err
+			}
+		}
+
+		if minedAt != "" {
+			if data.MinedAt, err = time.Parse(time.RFC3339, minedAt); err != nil {
+				return nil, err
+			}
+		}
+
+		batch = append(batch, data)
+	}
+
+	return batch, rows.Err()
+}
+
 func (s *SqLite) UpdateStatus(ctx context.Context, hash *chainhash.Hash, status metamorph_api.Status, rejectReason string) error {
 	startNanos := s.now().UnixNano()
 	defer func() {
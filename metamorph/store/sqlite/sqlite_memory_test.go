@@ -116,6 +116,20 @@ func TestGetUnmined(t *testing.T) {
 	})
 }
 
+func TestIterate(t *testing.T) {
+	// iterate in several batches
+	defaultBatchSize := iterateBatchSize
+	iterateBatchSize = 3
+	defer func() { iterateBatchSize = defaultBatchSize }()
+
+	sqliteDB, err := New(true, "")
+	require.NoError(t, err)
+
+	defer sqliteDB.Close(context.Background())
+
+	tests.Iterate(t, sqliteDB)
+}
+
 func TestUpdateMined(t *testing.T) {
 	t.Run("update mined - not found", func(t *testing.T) {
 		sqliteDB, err := New(true, "")
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bitcoin-sv/arc/metamorph/metamorph_api"
+	"github.com/bitcoin-sv/arc/metamorph/store"
+	"github.com/libsv/go-p2p/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+// Iterate stores transactions and checks that all of them are iterated, and that an iteration can be continued after
+// any transaction.
+func Iterate(t *testing.T, s store.MetamorphStore) {
+	iterator, ok := s.(store.Iterator)
+	require.True(t, ok)
+
+	ctx := context.Background()
+
+	stored := make(map[chainhash.Hash]bool)
+	for i := byte(0); i < 10; i++ {
+		hash := chainhash.DoubleHashH([]byte{i})
+		err := s.Set(ctx, hash[:], &store.StoreData{
+			Hash:   &hash,
+			Status: metamorph_api.Status_SEEN_ON_NETWORK,
+			RawTx:  []byte{i},
+		})
+		require.NoError(t, err)
+		stored[hash] = true
+	}
+
+	// processed blocks are not iterated
+	require.NoError(t, s.SetBlockProcessed(ctx, Block1Hash))
+
+	var hashes []chainhash.Hash
+	err := iterator.Iterate(ctx, nil, func(data *store.StoreData) error {
+		require.True(t, stored[*data.Hash])
+		require.Equal(t, metamorph_api.Status_SEEN_ON_NETWORK, data.Status)
+		hashes = append(hashes, *data.Hash)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, hashes, len(stored))
+
+	for i := range hashes {
+		var continued []chainhash.Hash
+		err = iterator.Iterate(ctx, &hashes[i], func(data *store.StoreData) error {
+			continued = append(continued, *data.Hash)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, hashes[i+1:], nonEmpty(continued))
+	}
+
+	errStop := errors.New("stop")
+	count := 0
+	err = iterator.Iterate(ctx, nil, func(_ *store.StoreData) error {
+		count++
+		if count == 3 {
+			return errStop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, 3, count)
+}
+
+// nonEmpty returns an empty slice instead of nil, so that it can be compared to the remainder of a slice.
+func nonEmpty(hashes []chainhash.Hash) []chainhash.Hash {
+	if hashes == nil {
+		return []chainhash.Hash{}
+	}
+
+	return hashes
+}
@@ -49,6 +49,26 @@ type ZMQTxInfo struct {
 	RejectionTime               string        `json:"rejectionTime"`
 }
 
+// competingTxs returns the IDs of the transactions the invalid transaction collided with.
+func (t *ZMQTxInfo) competingTxs() []string {
+	var txIDs []string
+	for _, collided := range t.CollidedWith {
+		collidedTx, ok := collided.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		txID, ok := collidedTx["txid"].(string)
+		if !ok || txID == "" {
+			continue
+		}
+
+		txIDs = append(txIDs, txID)
+	}
+
+	return txIDs
+}
+
 type ZMQDiscardFromMempool struct {
 	TxID         string `json:"txid"`
 	Reason       string `json:"reason"`
@@ -125,11 +145,12 @@ func (z *ZMQ) Start(zmqi ZMQI) {
 
 				hash, _ := chainhash.NewHashFromStr(txInfo.TxID)
 				z.statusMessageCh <- &PeerTxMessage{
-					Start:  time.Now(),
-					Hash:   hash,
-					Status: status,
-					Peer:   z.URL.String(),
-					Err:    fmt.Errorf(errReason),
+					Start:        time.Now(),
+					Hash:         hash,
+					Status:       status,
+					Peer:         z.URL.String(),
+					Err:          fmt.Errorf(errReason),
+					CompetingTxs: txInfo.competingTxs(),
 				}
 			case "discardedfrommempool":
 				z.Stats.discardedFromMempool.Add(1)
@@ -144,12 +165,18 @@ func (z *ZMQ) Start(zmqi ZMQI) {
 
 				hash, _ := chainhash.NewHashFromStr(txInfo.TxID)
 
+				var competingTxs []string
+				if txInfo.CollidedWith.TxID != "" {
+					competingTxs = []string{txInfo.CollidedWith.TxID}
+				}
+
 				z.statusMessageCh <- &PeerTxMessage{
-					Start:  time.Now(),
-					Hash:   hash,
-					Status: metamorph_api.Status_REJECTED,
-					Peer:   z.URL.String(),
-					Err:    fmt.Errorf("discarded from mempool: %s", txInfo.Reason),
+					Start:        time.Now(),
+					Hash:         hash,
+					Status:       metamorph_api.Status_REJECTED,
+					Peer:         z.URL.String(),
+					Err:          fmt.Errorf("discarded from mempool: %s", txInfo.Reason),
+					CompetingTxs: competingTxs,
 				}
 			default:
 				z.Logger.Info("Unhandled ZMQ message", c)
@@ -1,6 +1,7 @@
 package metamorph_test
 
 import (
+	"encoding/hex"
 	"net/url"
 	"testing"
 
@@ -60,3 +61,49 @@ func TestInvalidTxZMQI(t *testing.T) {
 
 	assert.Equal(t, status.Status, metamorph_api.Status_ACCEPTED_BY_NETWORK)
 }
+
+func TestDoubleSpendZMQI(t *testing.T) {
+	txInfo := `{"txid":"4ae1d209a1aae2a4aa703e2addaf9135f4a1b1cd0d87020037ea5619d495f717","isInvalid":true,"isDoubleSpendDetected":true,"rejectionReason":"txn-mempool-conflict","collidedWith":[{"txid":"c0d6fce714e4225614f000c6a5addaaa1341acbb9c87115114dcf84f37b945a6","size":191,"hex":""}]}`
+
+	mockedZMQI := &ZMQIMock{
+		SubscribeFunc: func(s string, stringsCh chan []string) error {
+			if s != "invalidtx" {
+				return nil
+			}
+			stringsCh <- []string{"invalidtx", hex.EncodeToString([]byte(txInfo)), "2459"}
+			return nil
+		},
+	}
+
+	statuses := make(chan *PeerTxMessage, 1)
+	url, _ := url.Parse("https://some-url.com")
+	zmq := NewZMQ(url, statuses)
+	zmq.Start(mockedZMQI)
+	status := <-statuses
+
+	assert.Equal(t, metamorph_api.Status_REJECTED, status.Status)
+	assert.Equal(t, []string{"c0d6fce714e4225614f000c6a5addaaa1341acbb9c87115114dcf84f37b945a6"}, status.CompetingTxs)
+}
+
+func TestDiscardedFromMempoolZMQI(t *testing.T) {
+	txInfo := `{"txid":"4ae1d209a1aae2a4aa703e2addaf9135f4a1b1cd0d87020037ea5619d495f717","reason":"collision-in-block-tx","collidedWith":{"txid":"c0d6fce714e4225614f000c6a5addaaa1341acbb9c87115114dcf84f37b945a6","size":191,"hex":""},"blockhash":"0000000000000aac89fbed163ed60061ba33bc0ab9de8e7fd8b34ad94c2414cd"}`
+
+	mockedZMQI := &ZMQIMock{
+		SubscribeFunc: func(s string, stringsCh chan []string) error {
+			if s != "discardedfrommempool" {
+				return nil
+			}
+			stringsCh <- []string{"discardedfrommempool", hex.EncodeToString([]byte(txInfo)), "2459"}
+			return nil
+		},
+	}
+
+	statuses := make(chan *PeerTxMessage, 1)
+	url, _ := url.Parse("https://some-url.com")
+	zmq := NewZMQ(url, statuses)
+	zmq.Start(mockedZMQI)
+	status := <-statuses
+
+	assert.Equal(t, metamorph_api.Status_REJECTED, status.Status)
+	assert.Equal(t, []string{"c0d6fce714e4225614f000c6a5addaaa1341acbb9c87115114dcf84f37b945a6"}, status.CompetingTxs)
+}
@@ -17,6 +17,10 @@ type PeerHandlerStats struct {
 	BlockAnnouncement       atomic.Uint64
 	Block                   atomic.Uint64
 	BlockProcessingMs       atomic.Uint64
+	InvalidResponse         atomic.Uint64
+	SlowResponse            atomic.Uint64
+	ReputationScore         atomic.Int64
+	Banned                  atomic.Bool
 }
 
 func NewPeerHandlerStats() *PeerHandlerStats {
@@ -29,6 +33,10 @@ func NewPeerHandlerStats() *PeerHandlerStats {
 		BlockAnnouncement:       atomic.Uint64{},
 		Block:                   atomic.Uint64{},
 		BlockProcessingMs:       atomic.Uint64{},
+		InvalidResponse:         atomic.Uint64{},
+		SlowResponse:            atomic.Uint64{},
+		ReputationScore:         atomic.Int64{},
+		Banned:                  atomic.Bool{},
 	}
 }
 
@@ -43,6 +51,10 @@ type PeerHandlerCollector struct {
 	blockAnnouncement       *prometheus.Desc
 	block                   *prometheus.Desc
 	blockProcessingMs       *prometheus.Desc
+	invalidResponse         *prometheus.Desc
+	slowResponse            *prometheus.Desc
+	reputationScore         *prometheus.Desc
+	banned                  *prometheus.Desc
 }
 
 // NewPeerHandlerCollector initializes every descriptor and returns a pointer to the prometheusCollector
@@ -82,6 +94,22 @@ func NewPeerHandlerCollector(service string, stats *safemap.Safemap[string, *Pee
 			"Shows the total time spent processing blocks by the peer handler",
 			[]string{"peer"}, nil,
 		),
+		invalidResponse: prometheus.NewDesc(fmt.Sprintf("arc_%s_peer_invalid_response_count", service),
+			"Shows the number of invalid blocks and block headers received from the peer",
+			[]string{"peer"}, nil,
+		),
+		slowResponse: prometheus.NewDesc(fmt.Sprintf("arc_%s_peer_slow_response_count", service),
+			"Shows the number of requested blocks which the peer did not send in time",
+			[]string{"peer"}, nil,
+		),
+		reputationScore: prometheus.NewDesc(fmt.Sprintf("arc_%s_peer_reputation_score", service),
+			"Shows the penalty score of the peer, the peer is banned when the score reaches the ban score",
+			[]string{"peer"}, nil,
+		),
+		banned: prometheus.NewDesc(fmt.Sprintf("arc_%s_peer_banned", service),
+			"Shows whether the peer is banned",
+			[]string{"peer"}, nil,
+		),
 	}
 
 	return c
@@ -107,6 +135,10 @@ func (c *PeerHandlerCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.blockAnnouncement
 	ch <- c.block
 	ch <- c.blockProcessingMs
+	ch <- c.invalidResponse
+	ch <- c.slowResponse
+	ch <- c.reputationScore
+	ch <- c.banned
 }
 
 // Collect implements required collect function for all prometheus collectors
@@ -130,5 +162,17 @@ func (c *PeerHandlerCollector) Collect(ch chan<- prometheus.Metric) {
 			float64(peerStats.Block.Load()), peer)
 		ch <- prometheus.MustNewConstMetric(c.blockProcessingMs, prometheus.CounterValue,
 			float64(peerStats.BlockProcessingMs.Load()), peer)
+		ch <- prometheus.MustNewConstMetric(c.invalidResponse, prometheus.CounterValue,
+			float64(peerStats.InvalidResponse.Load()), peer)
+		ch <- prometheus.MustNewConstMetric(c.slowResponse, prometheus.CounterValue,
+			float64(peerStats.SlowResponse.Load()), peer)
+		ch <- prometheus.MustNewConstMetric(c.reputationScore, prometheus.GaugeValue,
+			float64(peerStats.ReputationScore.Load()), peer)
+
+		banned := 0.0
+		if peerStats.Banned.Load() {
+			banned = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.banned, prometheus.GaugeValue, banned, peer)
 	})
 }